@@ -0,0 +1,210 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jt05610/petri/middleware"
+)
+
+var (
+	ErrDenied            = errors.New("permission denied")
+	ErrInvalidPermission = errors.New("invalid permission")
+)
+
+// Resource is the kind of thing access is controlled for.
+type Resource string
+
+const (
+	NetResource        Resource = "net"
+	PlaceResource      Resource = "place"
+	TransitionResource Resource = "transition"
+	ArcResource        Resource = "arc"
+	DeviceResource     Resource = "device"
+	SequenceResource   Resource = "sequence"
+)
+
+// Permission is what a share grant lets another user do. Owners can do
+// everything, including granting and revoking permissions.
+type Permission string
+
+const (
+	Read Permission = "read"
+	Edit Permission = "edit"
+	Run  Permission = "run"
+)
+
+func (p Permission) Valid() bool {
+	return p == Read || p == Edit || p == Run
+}
+
+// Allows reports whether holding p is enough for want. Both edit and run imply
+// read.
+func (p Permission) Allows(want Permission) bool {
+	return p == want || want == Read
+}
+
+// Grant shares a resource with a user.
+type Grant struct {
+	Resource   Resource
+	ID         string
+	User       string
+	Permission Permission
+}
+
+// Store records who owns each resource and who it has been shared with.
+type Store interface {
+	SetOwner(ctx context.Context, res Resource, id, user string) error
+	// Owner returns the owner of the resource, or an empty string if it has
+	// none.
+	Owner(ctx context.Context, res Resource, id string) (string, error)
+	Grant(ctx context.Context, g *Grant) error
+	Revoke(ctx context.Context, g *Grant) error
+	Grants(ctx context.Context, res Resource, id string) ([]*Grant, error)
+	// Forget removes the owner and every grant of the resource.
+	Forget(ctx context.Context, res Resource, id string) error
+}
+
+// Authors finds who created a resource in the system it was created in, such
+// as the author prisma records for nets, devices and sequences.
+type Authors interface {
+	// Author returns the user who created the resource, or an empty string if
+	// it is not known.
+	Author(ctx context.Context, res Resource, id string) (string, error)
+}
+
+type authored struct {
+	Store
+	authors Authors
+}
+
+// WithAuthors returns a Store that makes the author of a resource its owner
+// the first time the resource is looked up without one, so resources created
+// before access control keep their owners.
+func WithAuthors(s Store, a Authors) Store {
+	return &authored{Store: s, authors: a}
+}
+
+func (s *authored) Owner(ctx context.Context, res Resource, id string) (string, error) {
+	owner, err := s.Store.Owner(ctx, res, id)
+	if err != nil || owner != "" {
+		return owner, err
+	}
+	author, err := s.authors.Author(ctx, res, id)
+	if err != nil || author == "" {
+		return "", err
+	}
+	return author, s.Store.SetOwner(ctx, res, id, author)
+}
+
+// Checker decides what the identity on a context may do with a resource.
+// Resources without an owner, such as devices petrid finds rather than
+// creates, are only open to admins and to the users they share them with.
+type Checker struct {
+	Store Store
+}
+
+func NewChecker(store Store) *Checker {
+	return &Checker{Store: store}
+}
+
+func identity(ctx context.Context) (*middleware.Identity, error) {
+	id := middleware.IdentityFrom(ctx)
+	if id == nil {
+		return nil, middleware.ErrUnauthenticated
+	}
+	return id, nil
+}
+
+// Allowed reports whether the identity on ctx holds perm on the resource.
+func (c *Checker) Allowed(ctx context.Context, res Resource, id string, perm Permission) (bool, error) {
+	user, err := identity(ctx)
+	if err != nil {
+		return false, err
+	}
+	if user.IsAdmin() {
+		return true, nil
+	}
+	owner, err := c.Store.Owner(ctx, res, id)
+	if err != nil {
+		return false, err
+	}
+	if owner != "" && owner == user.User {
+		return true, nil
+	}
+	grants, err := c.Store.Grants(ctx, res, id)
+	if err != nil {
+		return false, err
+	}
+	for _, g := range grants {
+		if g.User == user.User && g.Permission.Allows(perm) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Check returns ErrDenied unless the identity on ctx holds perm on the
+// resource.
+func (c *Checker) Check(ctx context.Context, res Resource, id string, perm Permission) error {
+	ok, err := c.Allowed(ctx, res, id, perm)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%w: %s %s %s", ErrDenied, perm, res, id)
+	}
+	return nil
+}
+
+// CheckOwner returns ErrDenied unless the identity on ctx owns the resource or
+// is an admin.
+func (c *Checker) CheckOwner(ctx context.Context, res Resource, id string) error {
+	user, err := identity(ctx)
+	if err != nil {
+		return err
+	}
+	if user.IsAdmin() {
+		return nil
+	}
+	owner, err := c.Store.Owner(ctx, res, id)
+	if err != nil {
+		return err
+	}
+	if owner == "" {
+		return fmt.Errorf("%w: %s has no owner", ErrDenied, id)
+	}
+	if owner != user.User {
+		return fmt.Errorf("%w: %s is owned by %s", ErrDenied, id, owner)
+	}
+	return nil
+}
+
+// Claim makes the identity on ctx the owner of a newly created resource.
+func (c *Checker) Claim(ctx context.Context, res Resource, id string) error {
+	user, err := identity(ctx)
+	if err != nil {
+		return err
+	}
+	return c.Store.SetOwner(ctx, res, id, user.User)
+}
+
+// Share grants perm on a resource to another user. Only the owner or an admin
+// may share.
+func (c *Checker) Share(ctx context.Context, g *Grant) error {
+	if !g.Permission.Valid() {
+		return fmt.Errorf("%w: %s", ErrInvalidPermission, g.Permission)
+	}
+	if err := c.CheckOwner(ctx, g.Resource, g.ID); err != nil {
+		return err
+	}
+	return c.Store.Grant(ctx, g)
+}
+
+// Unshare revokes a grant. Only the owner or an admin may unshare.
+func (c *Checker) Unshare(ctx context.Context, g *Grant) error {
+	if err := c.CheckOwner(ctx, g.Resource, g.ID); err != nil {
+		return err
+	}
+	return c.Store.Revoke(ctx, g)
+}
@@ -0,0 +1,206 @@
+package access_test
+
+import (
+	"context"
+	"errors"
+	"github.com/jt05610/petri"
+	"github.com/jt05610/petri/access"
+	"github.com/jt05610/petri/datastore"
+	"github.com/jt05610/petri/middleware"
+	"github.com/jt05610/petri/sequence"
+	"github.com/jt05610/petri/sqlite"
+	"testing"
+)
+
+type nets struct {
+	petri.Service[*petri.Net, *petri.NetInput, *petri.NetFilter, *petri.NetUpdate]
+	byID map[string]*petri.Net
+}
+
+func (n *nets) Add(_ context.Context, input *petri.NetInput) (*petri.Net, error) {
+	net := input.Object().(*petri.Net)
+	n.byID[net.ID] = net
+	return net, nil
+}
+
+func (n *nets) Get(_ context.Context, id string) (*petri.Net, error) {
+	return n.byID[id], nil
+}
+
+func (n *nets) List(_ context.Context, _ *petri.NetFilter) ([]*petri.Net, error) {
+	ret := make([]*petri.Net, 0, len(n.byID))
+	for _, net := range n.byID {
+		ret = append(ret, net)
+	}
+	return ret, nil
+}
+
+func (n *nets) Update(_ context.Context, id string, _ *petri.NetUpdate) (*petri.Net, error) {
+	return n.byID[id], nil
+}
+
+func (n *nets) Remove(_ context.Context, id string) (*petri.Net, error) {
+	net := n.byID[id]
+	delete(n.byID, id)
+	return net, nil
+}
+
+type sequences map[string]*sequence.Sequence
+
+func (s sequences) Load(_ context.Context, id string) (*sequence.Sequence, error) {
+	return s[id], nil
+}
+
+func (s sequences) List(_ context.Context) ([]*sequence.ListItem, error) {
+	ret := make([]*sequence.ListItem, 0, len(s))
+	for id := range s {
+		ret = append(ret, &sequence.ListItem{ID: id})
+	}
+	return ret, nil
+}
+
+func as(user string, role datastore.Role) context.Context {
+	return middleware.WithIdentity(context.Background(), &middleware.Identity{User: user, Role: role})
+}
+
+func TestService(t *testing.T) {
+	store, err := sqlite.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+	for _, u := range []string{"alice", "bob"} {
+		if err := store.CreateUser(context.Background(), u, "pw", datastore.UserRole); err != nil {
+			t.Fatal(err)
+		}
+	}
+	checker := access.NewChecker(store)
+	s := access.Wrap[*petri.Net, *petri.NetInput, *petri.NetFilter, *petri.NetUpdate](&nets{byID: make(map[string]*petri.Net)}, checker, access.NetResource)
+	alice, bob, admin := as("alice", datastore.UserRole), as("bob", datastore.UserRole), as("root", datastore.AdminRole)
+
+	if _, err := s.Add(context.Background(), &petri.NetInput{Name: "anon"}); !errors.Is(err, middleware.ErrUnauthenticated) {
+		t.Fatalf("expected ErrUnauthenticated, got %v", err)
+	}
+	n, err := s.Add(alice, &petri.NetInput{Name: "mine"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Get(bob, n.ID); !errors.Is(err, access.ErrDenied) {
+		t.Fatalf("expected bob to be denied, got %v", err)
+	}
+	if list, err := s.List(bob, &petri.NetFilter{}); err != nil || len(list) != 0 {
+		t.Fatalf("expected bob to see no nets, got %d %v", len(list), err)
+	}
+	if err := checker.Share(bob, &access.Grant{Resource: access.NetResource, ID: n.ID, User: "bob", Permission: access.Edit}); !errors.Is(err, access.ErrDenied) {
+		t.Fatalf("expected bob to be unable to share, got %v", err)
+	}
+	if err := checker.Share(alice, &access.Grant{Resource: access.NetResource, ID: n.ID, User: "bob", Permission: access.Read}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Get(bob, n.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Update(bob, n.ID, &petri.NetUpdate{}); !errors.Is(err, access.ErrDenied) {
+		t.Fatalf("expected read grant to deny edits, got %v", err)
+	}
+	if err := checker.Share(alice, &access.Grant{Resource: access.NetResource, ID: n.ID, User: "bob", Permission: access.Edit}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Update(bob, n.ID, &petri.NetUpdate{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := checker.Check(bob, access.NetResource, n.ID, access.Run); !errors.Is(err, access.ErrDenied) {
+		t.Fatalf("expected edit grant to deny runs, got %v", err)
+	}
+	if _, err := s.Remove(bob, n.ID); !errors.Is(err, access.ErrDenied) {
+		t.Fatalf("expected only the owner to remove, got %v", err)
+	}
+	if _, err := s.Remove(admin, n.ID); err != nil {
+		t.Fatal(err)
+	}
+	grants, err := store.Grants(context.Background(), access.NetResource, n.ID)
+	if err != nil || len(grants) != 0 {
+		t.Fatalf("expected grants to be forgotten, got %d %v", len(grants), err)
+	}
+}
+
+func TestSequences(t *testing.T) {
+	store, err := sqlite.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+	if err := store.CreateUser(context.Background(), "bob", "pw", datastore.UserRole); err != nil {
+		t.Fatal(err)
+	}
+	checker := access.NewChecker(store)
+	s := &access.Sequences{
+		Service: sequences{"mine": {ID: "mine"}, "found": {ID: "found"}},
+		Checker: checker,
+	}
+	alice, bob, admin := as("alice", datastore.UserRole), as("bob", datastore.UserRole), as("root", datastore.AdminRole)
+	if err := checker.Claim(alice, access.SequenceResource, "mine"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Load(alice, "mine"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Load(bob, "mine"); !errors.Is(err, access.ErrDenied) {
+		t.Fatalf("expected bob to be refused alice's sequence, got %v", err)
+	}
+
+	// a sequence nobody owns is for admins until they share it
+	if _, err := s.Load(bob, "found"); !errors.Is(err, access.ErrDenied) {
+		t.Fatalf("expected bob to be refused an unowned sequence, got %v", err)
+	}
+	if err := checker.Share(bob, &access.Grant{Resource: access.SequenceResource, ID: "found", User: "bob", Permission: access.Run}); !errors.Is(err, access.ErrDenied) {
+		t.Fatalf("expected bob to be unable to share an unowned sequence, got %v", err)
+	}
+	if _, err := s.Load(admin, "found"); err != nil {
+		t.Fatal(err)
+	}
+	if err := checker.Share(admin, &access.Grant{Resource: access.SequenceResource, ID: "found", User: "bob", Permission: access.Run}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Load(bob, "found"); err != nil {
+		t.Fatal(err)
+	}
+	if list, err := s.List(bob); err != nil || len(list) != 1 || list[0].ID != "found" {
+		t.Fatalf("expected bob to see only the shared sequence, got %v %v", list, err)
+	}
+}
+
+type authors map[string]string
+
+func (a authors) Author(_ context.Context, _ access.Resource, id string) (string, error) {
+	return a[id], nil
+}
+
+func TestWithAuthors(t *testing.T) {
+	store, err := sqlite.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+	checker := access.NewChecker(access.WithAuthors(store, authors{"old": "alice"}))
+	alice, bob := as("alice", datastore.UserRole), as("bob", datastore.UserRole)
+	if err := checker.Check(alice, access.NetResource, "old", access.Edit); err != nil {
+		t.Fatalf("expected alice to own the net she authored, got %v", err)
+	}
+	if err := checker.Check(bob, access.NetResource, "old", access.Read); !errors.Is(err, access.ErrDenied) {
+		t.Fatalf("expected bob to be refused alice's net, got %v", err)
+	}
+	// the author is recorded, so it is kept without the lookup
+	if owner, err := store.Owner(context.Background(), access.NetResource, "old"); err != nil || owner != "alice" {
+		t.Fatalf("expected alice to be recorded as the owner, got %q %v", owner, err)
+	}
+	if err := checker.Check(alice, access.NetResource, "unknown", access.Read); !errors.Is(err, access.ErrDenied) {
+		t.Fatalf("expected a net without an author to stay unowned, got %v", err)
+	}
+}
@@ -0,0 +1,174 @@
+package access
+
+import (
+	"context"
+	"github.com/jt05610/petri"
+	"github.com/jt05610/petri/control"
+	"github.com/jt05610/petri/device"
+	"github.com/jt05610/petri/marked"
+	"github.com/jt05610/petri/sequence"
+)
+
+var (
+	_ petri.Service[*petri.Net, *petri.NetInput, *petri.NetFilter, *petri.NetUpdate] = (*Service[*petri.Net, *petri.NetInput, *petri.NetFilter, *petri.NetUpdate])(nil)
+	_ device.Service                                                                 = (*Devices)(nil)
+	_ sequence.Service                                                               = (*Sequences)(nil)
+	_ NetLoader                                                                      = (*Nets)(nil)
+)
+
+// Service wraps a storage service so that every call is checked against the
+// identity on its context. New objects are owned by whoever created them, list
+// results only contain objects the caller can read, and only owners can remove.
+type Service[T petri.Object, U petri.Input, V petri.Filter, W petri.Update] struct {
+	petri.Service[T, U, V, W]
+	*Checker
+	Resource Resource
+}
+
+func Wrap[T petri.Object, U petri.Input, V petri.Filter, W petri.Update](s petri.Service[T, U, V, W], c *Checker, res Resource) *Service[T, U, V, W] {
+	return &Service[T, U, V, W]{
+		Service:  s,
+		Checker:  c,
+		Resource: res,
+	}
+}
+
+func (s *Service[T, U, V, W]) Add(ctx context.Context, input U) (T, error) {
+	var zero T
+	if _, err := identity(ctx); err != nil {
+		return zero, err
+	}
+	o, err := s.Service.Add(ctx, input)
+	if err != nil {
+		return o, err
+	}
+	return o, s.Claim(ctx, s.Resource, o.Identifier())
+}
+
+func (s *Service[T, U, V, W]) Get(ctx context.Context, id string) (T, error) {
+	if err := s.Check(ctx, s.Resource, id, Read); err != nil {
+		var zero T
+		return zero, err
+	}
+	return s.Service.Get(ctx, id)
+}
+
+func (s *Service[T, U, V, W]) List(ctx context.Context, f V) ([]T, error) {
+	all, err := s.Service.List(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]T, 0, len(all))
+	for _, o := range all {
+		ok, err := s.Allowed(ctx, s.Resource, o.Identifier(), Read)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			ret = append(ret, o)
+		}
+	}
+	return ret, nil
+}
+
+func (s *Service[T, U, V, W]) Update(ctx context.Context, id string, update W) (T, error) {
+	if err := s.Check(ctx, s.Resource, id, Edit); err != nil {
+		var zero T
+		return zero, err
+	}
+	return s.Service.Update(ctx, id, update)
+}
+
+func (s *Service[T, U, V, W]) Remove(ctx context.Context, id string) (T, error) {
+	var zero T
+	if err := s.CheckOwner(ctx, s.Resource, id); err != nil {
+		return zero, err
+	}
+	o, err := s.Service.Remove(ctx, id)
+	if err != nil {
+		return o, err
+	}
+	return o, s.Store.Forget(ctx, s.Resource, id)
+}
+
+// Devices checks device loads against the identity on the context. Loading a
+// device to flush it to an instance drives hardware, so it needs run.
+type Devices struct {
+	device.Service
+	*Checker
+}
+
+func (d *Devices) Load(ctx context.Context, devID string, handlers control.Handlers) (*device.Device, error) {
+	if err := d.Check(ctx, DeviceResource, devID, Run); err != nil {
+		return nil, err
+	}
+	return d.Service.Load(ctx, devID, handlers)
+}
+
+func (d *Devices) List(ctx context.Context) ([]*device.ListItem, error) {
+	all, err := d.Service.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*device.ListItem, 0, len(all))
+	for _, item := range all {
+		ok, err := d.Allowed(ctx, DeviceResource, item.ID, Read)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			ret = append(ret, item)
+		}
+	}
+	return ret, nil
+}
+
+// Sequences checks sequence loads against the identity on the context. A
+// sequence is only loaded to be run, so loading needs run.
+type Sequences struct {
+	sequence.Service
+	*Checker
+}
+
+func (s *Sequences) Load(ctx context.Context, id string) (*sequence.Sequence, error) {
+	if err := s.Check(ctx, SequenceResource, id, Run); err != nil {
+		return nil, err
+	}
+	return s.Service.Load(ctx, id)
+}
+
+func (s *Sequences) List(ctx context.Context) ([]*sequence.ListItem, error) {
+	all, err := s.Service.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*sequence.ListItem, 0, len(all))
+	for _, item := range all {
+		ok, err := s.Allowed(ctx, SequenceResource, item.ID, Read)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			ret = append(ret, item)
+		}
+	}
+	return ret, nil
+}
+
+// NetLoader loads a net with its initial marking.
+type NetLoader interface {
+	Load(ctx context.Context, id string) (*marked.Net, error)
+}
+
+// Nets checks net loads against the identity on the context.
+type Nets struct {
+	NetLoader
+	*Checker
+}
+
+func (n *Nets) Load(ctx context.Context, id string) (*marked.Net, error) {
+	if err := n.Check(ctx, NetResource, id, Read); err != nil {
+		return nil, err
+	}
+	return n.NetLoader.Load(ctx, id)
+}
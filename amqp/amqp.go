@@ -2,136 +2,192 @@ package amqp
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
-	"github.com/jt05610/petri/control"
+	"github.com/google/uuid"
 	"github.com/jt05610/petri/env"
-	"github.com/jt05610/petri/labeled"
+	"github.com/jt05610/petri/transport"
 	amqp "github.com/rabbitmq/amqp091-go"
-	"strings"
+	"go.uber.org/zap"
+	"sync"
 )
 
-type CommandService struct{}
-
-func (a *CommandService) Load(_ context.Context, data amqp.Delivery) (*control.Command, error) {
-	sk := strings.Split(data.RoutingKey, ".")
-	to := sk[0]
-	topic := sk[1]
-	command := sk[2]
-	id := ""
-	if data.Headers != nil {
-		if data.Headers["x-event-id"] != nil {
-			id = data.Headers["x-event-id"].(string)
-		}
-	}
-	res := &control.Command{
-		To:    to,
-		Topic: topic,
-		Event: &labeled.Event{
-			ID:   id,
-			Name: command,
-		},
-	}
-	if len(data.Body) == 0 {
-		return res, nil
+var _ transport.Transport = (*Transport)(nil)
+
+// RoutingKeyHeader carries the topic routing key of a message sent straight to
+// a queue, where the delivery's own routing key is the queue name.
+const RoutingKeyHeader = "x-routing-key"
+
+// DeadLetterExchange is the fanout exchange poison commands are routed to.
+func DeadLetterExchange(exchange string) string {
+	return exchange + ".dlx"
+}
+
+// DeadLetterQueue is the durable queue that keeps poison commands for
+// inspection.
+func DeadLetterQueue(exchange string) string {
+	return exchange + ".dead"
+}
+
+// Transport carries messages over a Link through the topology's topic
+// exchange.
+type Transport struct {
+	link     *Link
+	topology *Topology
+}
+
+// NewTransport declares the exchange and the dead letter exchange and queue,
+// re-declaring them whenever the link reconnects.
+func NewTransport(ctx context.Context, link *Link, topology *Topology) (*Transport, error) {
+	t := &Transport{link: link, topology: topology}
+	if err := link.Setup(ctx, t.declare); err != nil {
+		return nil, err
 	}
-	return res, json.Unmarshal(data.Body, &res.Event.Data)
+	return t, nil
 }
 
-func (a *CommandService) Flush(_ context.Context, event *labeled.Event, mark ...control.Marking) (amqp.Publishing, error) {
-	bytes, err := json.Marshal(&event.Data)
-	if err != nil {
-		var zero amqp.Publishing
-		return zero, err
+func (t *Transport) declare(ch *amqp.Channel) error {
+	if err := t.topology.DeclareExchange(ch); err != nil {
+		return err
 	}
-	headers := amqp.Table{
-		"x-event-name": event.Name,
-		"x-event-id":   event.ID,
+	err := ch.ExchangeDeclare(
+		DeadLetterExchange(t.topology.Exchange), // name
+		"fanout",                                // type
+		true,                                    // durable
+		false,                                   // delete when unused
+		false,                                   // internal
+		false,                                   // no-wait
+		nil,                                     // arguments
+	)
+	if err != nil {
+		return err
 	}
-	if len(mark) > 0 {
-		m := mark[0]
-		mbytes, err := json.Marshal(&m)
-		if err != nil {
-			return amqp.Publishing{}, err
-		}
-		headers["x-marking"] = mbytes
+	dead, err := ch.QueueDeclare(
+		DeadLetterQueue(t.topology.Exchange), // name
+		true,                                 // durable
+		false,                                // delete when unused
+		false,                                // exclusive
+		false,                                // no-wait
+		nil,                                  // arguments
+	)
+	if err != nil {
+		return err
 	}
+	return ch.QueueBind(dead.Name, "", DeadLetterExchange(t.topology.Exchange), false, nil)
+}
 
+func publishing(msg *transport.Message) amqp.Publishing {
 	return amqp.Publishing{
-		Body:         bytes,
-		ContentType:  "application/json",
-		DeliveryMode: amqp.Persistent,
-		Headers:      headers,
-	}, nil
+		Headers:       amqp.Table(msg.Headers),
+		Body:          msg.Body,
+		ContentType:   "application/json",
+		DeliveryMode:  amqp.Persistent,
+		MessageId:     msg.MessageID,
+		CorrelationId: msg.CorrelationID,
+		ReplyTo:       msg.ReplyTo,
+	}
 }
 
-type EventService struct{}
+func (t *Transport) Publish(ctx context.Context, msg *transport.Message) error {
+	return t.link.Publish(ctx, t.topology.Exchange, msg.Key, publishing(msg))
+}
 
-func (a *EventService) Load(_ context.Context, data amqp.Delivery) (*control.Event, error) {
-	sk := strings.Split(data.RoutingKey, ".")
-	if len(sk) != 3 {
-		return nil, errors.New("invalid routing key")
+// Send publishes msg on the default exchange, which routes it to the queue
+// named by address. The routing key travels in a header.
+func (t *Transport) Send(ctx context.Context, address string, msg *transport.Message) error {
+	p := publishing(msg)
+	p.Headers = make(amqp.Table, len(msg.Headers)+1)
+	for k, v := range msg.Headers {
+		p.Headers[k] = v
 	}
-	from := sk[0]
-	topic := sk[1]
-	event := sk[2]
-	id := ""
-	if data.Headers != nil {
-		if data.Headers["x-event-id"] != nil {
-			id = data.Headers["x-event-id"].(string)
-		}
-		if data.Headers["x-marking"] != nil {
-			var m control.Marking
-			if err := json.Unmarshal(data.Headers["x-marking"].([]byte), &m); err != nil {
-				return nil, err
-			}
-			ret := &control.Event{
-				From:    from,
-				Topic:   topic,
-				Event:   &labeled.Event{ID: id, Name: event},
-				Marking: m,
-			}
-			return ret, json.Unmarshal(data.Body, &ret.Data)
-		}
+	p.Headers[RoutingKeyHeader] = msg.Key
+	return t.link.Publish(ctx, "", address, p)
+}
 
+type acknowledger struct {
+	d amqp.Delivery
+}
+
+func (a *acknowledger) Ack() error {
+	return a.d.Ack(false)
+}
+
+func (a *acknowledger) Nack(requeue bool) error {
+	return a.d.Nack(false, requeue)
+}
+
+func message(d amqp.Delivery) *transport.Message {
+	key := d.RoutingKey
+	if k, ok := d.Headers[RoutingKeyHeader].(string); ok {
+		key = k
 	}
-	res := &control.Event{
-		From:  from,
-		Topic: topic,
-		Event: &labeled.Event{
-			Name: event,
-			ID:   id,
-		},
-	}
-	if len(data.Body) == 0 {
-		return res, nil
+	return &transport.Message{
+		Key:           key,
+		Headers:       d.Headers,
+		Body:          d.Body,
+		MessageID:     d.MessageId,
+		CorrelationID: d.CorrelationId,
+		ReplyTo:       d.ReplyTo,
+		Acknowledger:  &acknowledger{d: d},
 	}
-	return res, json.Unmarshal(data.Body, &res.Data)
 }
 
-type Connection struct {
-	*amqp.Connection
-	*amqp.Channel
+type subscription struct {
+	mu      sync.Mutex
+	address string
+	msgs    chan *transport.Message
 }
 
-func (c *Connection) Close() error {
-	if c.Channel != nil {
-		err := c.Channel.Close()
-		if err != nil {
-			return err
-		}
-	}
-	return c.Connection.Close()
+func (s *subscription) Address() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.address
 }
 
-func Dial(environ *env.Environment) (*Connection, error) {
-	conn, err := amqp.Dial(environ.URI)
-	if err != nil {
-		return nil, err
+func (s *subscription) Messages() <-chan *transport.Message {
+	return s.msgs
+}
+
+// Subscribe consumes from the queue, declaring and resubscribing whenever the
+// link reconnects. Named queues on a durable topology are durable and called
+// exchange.name; all others are exclusive to the connection and named by the
+// broker, so their address can change after a reconnect.
+func (t *Transport) Subscribe(ctx context.Context, q *transport.Queue) (transport.Subscription, error) {
+	name := ""
+	if t.topology.Durable && q.Name != "" {
+		name = t.topology.Exchange + "." + q.Name
+	}
+	var args amqp.Table
+	if q.DeadLetter {
+		args = amqp.Table{"x-dead-letter-exchange": DeadLetterExchange(t.topology.Exchange)}
+	}
+	sub := &subscription{msgs: make(chan *transport.Message)}
+	declare := func(ch *amqp.Channel) (string, error) {
+		address, err := t.topology.DeclareQueue(ch, name, q.Keys, args)
+		if err != nil {
+			return "", err
+		}
+		sub.mu.Lock()
+		sub.address = address
+		sub.mu.Unlock()
+		return address, nil
 	}
-	ch, err := conn.Channel()
+	deliveries, err := t.link.Consume(ctx, uuid.NewString(), declare)
 	if err != nil {
 		return nil, err
 	}
-	return &Connection{conn, ch}, nil
+	go func() {
+		defer close(sub.msgs)
+		for d := range deliveries {
+			sub.msgs <- message(d)
+		}
+	}()
+	return sub, nil
+}
+
+func (t *Transport) Close() error {
+	return t.link.Close()
+}
+
+// Dial connects to the broker named in the environment.
+func Dial(environ *env.Environment, logger *zap.Logger) (*Link, error) {
+	return Connect(environ.URI, logger)
 }
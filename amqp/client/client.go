@@ -4,50 +4,94 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"github.com/jt05610/petri/amqp"
+	"github.com/google/uuid"
 	"github.com/jt05610/petri/control"
+	"github.com/jt05610/petri/datastore"
 	"github.com/jt05610/petri/labeled"
 	"github.com/jt05610/petri/sequence"
-	amqpGo "github.com/rabbitmq/amqp091-go"
+	"github.com/jt05610/petri/transport"
 	"go.uber.org/zap"
 	"log"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
 )
 
-func failOnError(err error, msg string) {
-	if err != nil {
-		log.Fatalf("%s: %s", msg, err)
-	}
-}
-
 const MaxLiveness = 3
 
+// DefaultCallTimeout bounds a Call whose context has no deadline.
+const DefaultCallTimeout = 30 * time.Second
+
+var (
+	ErrUnexpectedEvent = errors.New("unexpected event")
+	ErrUnknownInstance = errors.New("unknown instance")
+	ErrNoInstance      = errors.New("no live instance")
+)
+
 type Instance struct {
 	ID       string
 	liveness int
 	Marking  control.Marking
+	// busy counts the calls in flight to the instance.
+	busy int
+	// Manifest is what the instance reported it implements in its last
+	// beacon. It is nil for instances too old to send one.
+	Manifest *control.Manifest
 }
 
 type Controller struct {
 	logger          *zap.Logger
-	ch              *amqpGo.Channel
+	transport       transport.Transport
 	mu              sync.Mutex
 	discoveryCtx    context.Context
 	discoveryCancel context.CancelFunc
-	cmd             *amqp.CommandService
-	event           *amqp.EventService
-	dataCh          chan *control.Event
-	q               *amqpGo.Queue
+	cmd             *transport.CommandService
+	event           *transport.EventService
+	sub             transport.Subscription
 	Routes          map[string]*Instance
 	CurrentStep     *atomic.Int32
 	Sequence        *sequence.Sequence
 	Net             *labeled.Net
 	dataRelay       chan *control.Event
+	relayCtx        context.Context
 	Known           map[string]map[string]*Instance
-	exchange        string
-	stepCh          chan struct{}
+	callMu          sync.Mutex
+	calls           map[string]chan *control.Event
+	// CallTimeout is applied to calls whose context has no deadline.
+	CallTimeout time.Duration
+	// Selector picks the instance each step is sent to, unless SetSelector
+	// chose one for the device.
+	Selector  Selector
+	selectors map[string]Selector
+	// TrustUnmanifested lets steps be bound to instances that send no
+	// manifest, such as devices built before manifests, without any checks.
+	TrustUnmanifested bool
+	// Shutdown are the steps Abort sends to leave the devices safe.
+	Shutdown   []*sequence.Step
+	sessionMu  sync.Mutex
+	state      State
+	resumed    chan struct{}
+	cancelStep context.CancelFunc
+	// Journal, when set, keeps a checkpoint of the session identified by
+	// SessionID after every transition.
+	Journal datastore.Journal
+	// Data, when set, keeps each run of the session with the events its steps
+	// received and the telemetry devices raised while it ran.
+	Data       datastore.DataStore
+	SessionID  string
+	Parameters map[string]interface{}
+	// next is the index of the step in flight or the next one to send.
+	next        int
+	outstanding *datastore.Outstanding
+	markings    map[string]control.Marking
+	events      []*datastore.StepEvent
+	// runID is the run the events belong to; the first flushed of them have
+	// been appended to Data.
+	runID   string
+	flushed int
+	// problems are why the recovered session may be unsafe to resume.
+	problems []string
 }
 
 type WaitFor struct {
@@ -71,11 +115,29 @@ func (c *Controller) ActualMarking() control.Marking {
 	return ret
 }
 
-func (c *Controller) ChannelData(ch chan *control.Event) {
+// ChannelData relays the events the controller receives to ch until ctx is
+// done.
+func (c *Controller) ChannelData(ctx context.Context, ch chan *control.Event) {
+	c.relayCtx = ctx
 	c.dataRelay = ch
 }
 
+// forward passes ev to the relay set by ChannelData. It gives up once the
+// relay's context is done, so a reader that has gone away cannot stall the
+// controller.
+func (c *Controller) forward(ev *control.Event) {
+	if c.dataRelay == nil {
+		return
+	}
+	select {
+	case c.dataRelay <- ev:
+	case <-c.relayCtx.Done():
+	}
+}
+
 func (c *Controller) DeviceMarking() map[string]control.Marking {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	ret := make(map[string]control.Marking)
 	for devID, instance := range c.Routes {
 		ret[devID] = make(control.Marking)
@@ -112,18 +174,12 @@ func (c *Controller) ValidSequence() error {
 }
 
 func (c *Controller) Discover() error {
-	return c.ch.PublishWithContext(
-		c.discoveryCtx,
-		c.exchange, // exchange
-		"devices",  // routing key
-		false,      // mandatory
-		false,      // immediate
-		amqpGo.Publishing{
-			ContentType:  "application/json",
-			DeliveryMode: amqpGo.Persistent,
-			Body:         []byte{},
-		},
-	)
+	ctx, cancel := context.WithTimeout(c.discoveryCtx, time.Second)
+	defer cancel()
+	return c.transport.Publish(ctx, &transport.Message{
+		Key:  "devices",
+		Body: []byte{},
+	})
 }
 
 func (c *Controller) runDiscoverLoop(ctx context.Context) {
@@ -144,67 +200,96 @@ func (c *Controller) runDiscoverLoop(ctx context.Context) {
 	}()
 }
 
-func NewController(logger *zap.Logger, ch *amqpGo.Channel, exchange string) *Controller {
-	err := ch.Confirm(false)
-	failOnError(err, "Failed to set confirm mode")
-	err = ch.ExchangeDeclare(
-		exchange, // name
-		"topic",  // type
-		false,    // durable
-		false,    // delete when unused
-		false,    // exclusive
-		false,    // no-wait
-		nil,      // arguments
-	)
-	failOnError(err, "Failed to declare an exchange")
-	q, err := ch.QueueDeclare(
-		"",    // name
-		false, // durable
-		false, // delete when unused
-		false, // exclusive
-		false, // no-wait
-		nil,   // arguments
-	)
-	failOnError(err, "Failed to declare a queue")
-	topics := []string{"*.events.*", "*.state.current", "*.device.*"}
-	for _, t := range topics {
-		err = ch.QueueBind(
-			q.Name,   // queue name
-			t,        // routing key
-			exchange, // exchange
-			false,
-			nil)
-		failOnError(err, "Failed to bind queue")
-	}
+// NewController starts discovering the devices reachable over the transport.
+func NewController(logger *zap.Logger, t transport.Transport) *Controller {
 	c := &Controller{
 		logger:      logger,
-		ch:          ch,
-		q:           &q,
-		cmd:         &amqp.CommandService{},
-		event:       &amqp.EventService{},
-		exchange:    exchange,
+		transport:   t,
+		cmd:         &transport.CommandService{},
+		event:       &transport.EventService{},
 		CurrentStep: new(atomic.Int32),
 		Routes:      make(map[string]*Instance),
 		Known:       make(map[string]map[string]*Instance),
+		calls:       make(map[string]chan *control.Event),
+		CallTimeout: DefaultCallTimeout,
+		Selector:    FirstHealthy(),
+		selectors:   make(map[string]Selector),
+		state:       Created,
 	}
 	c.runDiscoverLoop(context.Background())
 	return c
 }
 
 func (c *Controller) Send(ctx context.Context, cmd *control.Command) error {
-	p, err := c.cmd.Flush(ctx, cmd.Event)
+	p, err := c.cmd.FlushCommand(ctx, cmd)
 	if err != nil {
 		return err
 	}
-	fmt.Printf("sending %v to %s\n", cmd, cmd.RoutingKey())
-	return c.ch.PublishWithContext(
-		ctx,
-		c.exchange,       // exchange
-		cmd.RoutingKey(), // routing key
-		false,            // mandatory
-		false,            // immediate
-		p,
-	)
+	c.logger.Debug("Sending command", zap.String("routing_key", cmd.RoutingKey()), zap.String("correlation_id", cmd.CorrelationID))
+	return c.transport.Publish(ctx, p)
+}
+
+// Call sends cmd and waits for the event that answers it. The answer is
+// matched by correlation ID, so answers from other devices or to other
+// commands are never mistaken for it. If the device reports an error the event
+// is returned along with its *control.Error. A correlation ID already set on
+// cmd is kept so the caller can record it before the command goes out.
+func (c *Controller) Call(ctx context.Context, cmd *control.Command) (*control.Event, error) {
+	if _, ok := ctx.Deadline(); !ok && c.CallTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.CallTimeout)
+		defer cancel()
+	}
+	call := *cmd
+	if call.CorrelationID == "" {
+		call.CorrelationID = uuid.NewString()
+	}
+	resp := make(chan *control.Event, 1)
+	c.callMu.Lock()
+	// the address can change if the transport reconnects, so read it fresh
+	// for every call
+	if c.sub != nil {
+		call.ReplyTo = c.sub.Address()
+	}
+	c.calls[call.CorrelationID] = resp
+	c.callMu.Unlock()
+	defer func() {
+		c.callMu.Lock()
+		delete(c.calls, call.CorrelationID)
+		c.callMu.Unlock()
+	}()
+	if err := c.Send(ctx, &call); err != nil {
+		return nil, err
+	}
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%s to %s: %w", cmd.Name, cmd.To, ctx.Err())
+	case ev := <-resp:
+		if ev.Error != nil {
+			return ev, ev.Error
+		}
+		return ev, nil
+	}
+}
+
+// answer hands an event to the Call waiting for it, reporting whether there was
+// one.
+func (c *Controller) answer(ev *control.Event) bool {
+	if ev.CorrelationID == "" {
+		return false
+	}
+	c.callMu.Lock()
+	resp, found := c.calls[ev.CorrelationID]
+	c.callMu.Unlock()
+	if !found {
+		return false
+	}
+	// a redelivered command can be answered twice; the first answer wins
+	select {
+	case resp <- ev:
+	default:
+	}
+	return true
 }
 
 func (c *Controller) CloseRelay() {
@@ -212,82 +297,150 @@ func (c *Controller) CloseRelay() {
 	c.dataRelay = nil
 }
 
-func channelSteps(stepQueue []*sequence.Step) <-chan *sequence.Step {
-	ch := make(chan *sequence.Step)
-	go func() {
-		for _, step := range stepQueue {
-			ch <- step
+// compatible checks the instance's manifest against a step. Instances without
+// a manifest are refused unless TrustUnmanifested is set. Callers hold c.mu.
+func (c *Controller) compatible(inst *Instance, step *sequence.Step) error {
+	if inst.Manifest == nil {
+		if c.TrustUnmanifested {
+			return nil
 		}
-		close(ch)
-	}()
-	return ch
+		return fmt.Errorf("instance %s for %s: %w: it sent no manifest", inst.ID, step.Event.Name, control.ErrIncompatible)
+	}
+	if err := inst.Manifest.Supports(step.Event); err != nil {
+		return fmt.Errorf("instance %s for %s: %w", inst.ID, step.Event.Name, err)
+	}
+	if err := inst.Manifest.Runs(step.Device.Nets...); err != nil {
+		return fmt.Errorf("instance %s for %s: %w", inst.ID, step.Event.Name, err)
+	}
+	return nil
 }
 
-func (c *Controller) Start(ctx context.Context) {
-	c.logger.Info("Starting sequence", zap.String("sequence", c.Sequence.Name))
-	stepCh := channelSteps(c.Sequence.Steps)
-	c.CurrentStep.Store(0)
-	go func() {
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			default:
-				step, ok := <-stepCh
-				if !ok {
-					c.logger.Info("Sequence complete", zap.String("sequence", c.Sequence.Name))
-					c.CurrentStep.Store(0)
-					return
-				}
-				c.logger.Info("Starting step", zap.String("step", step.Name))
-				inst := c.Routes[step.Device.ID]
-				if inst == nil {
-					log.Fatalf("Instance for device %s not found", step.Device.ID)
-				}
-				err := c.startStep(ctx, step)
-				if err != nil {
-					log.Println(err)
-				}
-				data := <-c.dataCh
-				c.logger.Info("Received event", zap.String("event", data.Name))
-				if c.dataRelay != nil {
-					c.dataRelay <- data
-				}
-				if step.Action.Event.ID != data.Event.ID {
-					log.Fatalf("Expected event %s (id: %s) but got %s (id: %s)", step.Action.Event.Name, step.Action.Event.ID, data.Event.Name, data.Event.ID)
-				}
-				c.CurrentStep.Add(1)
+// Bind routes the steps for the device to the instance. It refuses instances
+// whose manifest shows they cannot run one of the sequence's steps.
+func (c *Controller) Bind(deviceID, instanceID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	inst := c.Known[deviceID][instanceID]
+	if inst == nil {
+		return fmt.Errorf("%w: %s for device %s", ErrUnknownInstance, instanceID, deviceID)
+	}
+	if inst.Manifest == nil && c.TrustUnmanifested {
+		c.logger.Warn("Instance sent no manifest, assuming it is compatible", zap.String("instance", inst.ID))
+	}
+	if c.Sequence != nil {
+		for _, step := range c.Sequence.Steps {
+			if step.Device == nil || step.Device.ID != deviceID {
+				continue
+			}
+			if err := c.compatible(inst, step); err != nil {
+				return err
 			}
 		}
-	}()
+	}
+	c.Routes[deviceID] = inst
+	return nil
+}
+
+// SetSelector overrides the controller's Selector for one device.
+func (c *Controller) SetSelector(deviceID string, s Selector) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.selectors[deviceID] = s
+}
+
+// Instances lists the live instances of the device, ordered by ID.
+func (c *Controller) Instances(deviceID string) []*Instance {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.live(deviceID, nil)
+}
+
+// live lists the device's instances that are alive and, if step is set, can
+// run it. Callers hold c.mu.
+func (c *Controller) live(deviceID string, step *sequence.Step) []*Instance {
+	ret := make([]*Instance, 0, len(c.Known[deviceID]))
+	for _, inst := range c.Known[deviceID] {
+		if inst == nil || inst.liveness <= 0 {
+			continue
+		}
+		if step != nil && c.compatible(inst, step) != nil {
+			continue
+		}
+		ret = append(ret, inst)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].ID < ret[j].ID
+	})
+	return ret
 }
 
-func (c *Controller) startStep(ctx context.Context, step *sequence.Step) error {
-	to, found := c.Routes[step.Device.ID]
+// route picks the instance for a step with the device's Selector and marks it
+// busy until release is called. If the instance the device was routed to has
+// died and another is picked, a failover event is relayed to the session.
+func (c *Controller) route(step *sequence.Step) (*Instance, error) {
+	deviceID := step.Device.ID
+	c.mu.Lock()
+	current := c.Routes[deviceID]
+	candidates := c.live(deviceID, step)
+	selector, found := c.selectors[deviceID]
 	if !found {
-		return errors.New("device not found")
+		selector = c.Selector
 	}
-	cmd := step.Command(to.ID)
-	fmt.Printf("Sending %s (id: %s) to %s with data %v\n", cmd.Name, cmd.ID, cmd.To, cmd.Data)
-	done := make(chan struct{})
-	var sendErr error
-	go func() {
-		err := c.Send(ctx, cmd)
-		if err != nil {
-			sendErr = err
-		}
-		close(done)
-	}()
-	select {
-	case <-done:
-		return sendErr
-	case <-ctx.Done():
-		log.Printf("Timed out sending %s to %s", cmd.Name, cmd.To)
-		return ctx.Err()
+	inst := selector.Select(deviceID, current, candidates)
+	if inst == nil {
+		c.mu.Unlock()
+		return nil, fmt.Errorf("%w for device %s", ErrNoInstance, deviceID)
+	}
+	c.Routes[deviceID] = inst
+	inst.busy++
+	c.mu.Unlock()
+	if current != nil && current.ID != inst.ID && find(candidates, current) == nil {
+		c.logger.Warn("Failing over", zap.String("device", deviceID), zap.String("from", current.ID), zap.String("to", inst.ID))
+		c.relay(deviceID, &control.Event{
+			Topic: "failover",
+			From:  inst.ID,
+			Event: &labeled.Event{
+				Name: "failover",
+				Data: map[string]interface{}{
+					"device": deviceID,
+					"from":   current.ID,
+					"to":     inst.ID,
+					"step":   step.Event.Name,
+				},
+			},
+		})
+	}
+	return inst, nil
+}
+
+func (c *Controller) release(inst *Instance) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	inst.busy--
+}
+
+func (c *Controller) startStep(ctx context.Context, step *sequence.Step) (*control.Event, error) {
+	to, err := c.route(step)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release(to)
+	return c.callStep(ctx, step, step.Command(to.ID))
+}
+
+func (c *Controller) callStep(ctx context.Context, step *sequence.Step, cmd *control.Command) (*control.Event, error) {
+	c.logger.Info("Sending command", zap.String("command", cmd.Name), zap.String("id", cmd.ID), zap.String("to", cmd.To))
+	data, err := c.Call(ctx, cmd)
+	if err != nil {
+		return data, err
+	}
+	if step.Action.Event.ID != data.Event.ID {
+		return data, fmt.Errorf("%w: expected %s (id: %s) but got %s (id: %s)", ErrUnexpectedEvent, step.Action.Event.Name, step.Action.Event.ID, data.Event.Name, data.Event.ID)
 	}
+	return data, nil
 }
 
-func (c *Controller) registerInstance(deviceID, instanceID string, marking control.Marking) {
+func (c *Controller) registerInstance(deviceID, instanceID string, marking control.Marking, manifest *control.Manifest) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	if c.Known[deviceID] == nil {
@@ -298,12 +451,14 @@ func (c *Controller) registerInstance(deviceID, instanceID string, marking contr
 			ID:       instanceID,
 			liveness: MaxLiveness,
 			Marking:  marking,
+			Manifest: manifest,
 		}
 		return
 	}
 	if c.Known[deviceID][instanceID] != nil {
 		c.Known[deviceID][instanceID].liveness = MaxLiveness
 		c.Known[deviceID][instanceID].Marking = marking
+		c.Known[deviceID][instanceID].Manifest = manifest
 		return
 	}
 	c.logger.Debug("Registering instance", zap.String("device", deviceID), zap.String("instance", instanceID))
@@ -311,6 +466,7 @@ func (c *Controller) registerInstance(deviceID, instanceID string, marking contr
 		ID:       instanceID,
 		liveness: MaxLiveness,
 		Marking:  marking,
+		Manifest: manifest,
 	}
 	log.Printf("Registering instance %s for device %s with Marking %v", instanceID, deviceID, marking)
 }
@@ -332,45 +488,48 @@ func (c *Controller) pruneInstances() {
 	}
 }
 
-func (c *Controller) Listen(ctx context.Context) {
-	c.dataCh = make(chan *control.Event)
-	msgs, err := c.ch.Consume(
-		c.q.Name, // queue
-		"",       // consumer
-		true,     // auto-ack
-		false,    // exclusive
-		false,    // no-local
-		false,    // no-wait
-		nil,      // args
-	)
-
-	failOnError(err, "Failed to register a consumer")
-
+// Listen consumes events until ctx is cancelled. Answers to calls go to the
+// waiting Call; other events are passed to the relay set by ChannelData.
+func (c *Controller) Listen(ctx context.Context) error {
+	sub, err := c.transport.Subscribe(ctx, &transport.Queue{
+		Keys: transport.Upstream,
+	})
+	if err != nil {
+		return err
+	}
+	c.callMu.Lock()
+	c.sub = sub
+	c.callMu.Unlock()
 	go func() {
-		defer func() {
-			close(c.dataCh)
-		}()
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case d := <-msgs:
-				data, err := c.event.Load(ctx, d)
-				if err != nil {
-					log.Println(err)
-					if err.Error() == "invalid routing key" {
-						log.Println(data)
-						panic(err)
-					}
-					continue
-				}
-				if data.Topic == "device" {
-					c.registerInstance(data.Name, data.From, data.Marking)
-					continue
-				}
-				fmt.Printf("Received %s from %s\n", data.Name, data.From)
-				c.dataCh <- data
+		for d := range sub.Messages() {
+			c.receive(ctx, d)
+			if err := d.Ack(); err != nil {
+				c.logger.Debug("Failed to ack event", zap.Error(err))
 			}
 		}
 	}()
+	return nil
+}
+
+func (c *Controller) receive(ctx context.Context, d *transport.Message) {
+	data, err := c.event.Load(ctx, d)
+	if err != nil {
+		c.logger.Error("Failed to load event", zap.String("routing_key", d.Key), zap.Error(err))
+		return
+	}
+	if data.Topic == "device" {
+		manifest, err := control.ManifestFrom(data.Data)
+		if err != nil {
+			c.logger.Warn("Ignoring malformed manifest", zap.String("instance", data.From), zap.Error(err))
+			manifest = nil
+		}
+		c.registerInstance(data.Name, data.From, data.Marking, manifest)
+		return
+	}
+	if c.answer(data) {
+		return
+	}
+	c.logger.Debug("Received event", zap.String("event", data.Name), zap.String("from", data.From))
+	c.telemetry(data)
+	c.forward(data)
 }
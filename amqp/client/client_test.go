@@ -0,0 +1,305 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"github.com/jt05610/petri"
+	"github.com/jt05610/petri/amqp/client"
+	"github.com/jt05610/petri/amqp/server"
+	"github.com/jt05610/petri/control"
+	"github.com/jt05610/petri/device"
+	"github.com/jt05610/petri/labeled"
+	"github.com/jt05610/petri/marked"
+	"github.com/jt05610/petri/sequence"
+	"github.com/jt05610/petri/transport"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+type pump struct {
+	*labeled.Net
+	idle, full *petri.Place
+	eventMap   map[string]*petri.Transition
+	handlers   control.Handlers
+}
+
+// newPump is a device that fills and empties, and jams when asked to.
+func newPump() *pump {
+	idle, full := petri.NewPlace("idle", 1), petri.NewPlace("full", 1)
+	fill, empty, jam := petri.NewTransition("fill"), petri.NewTransition("empty"), petri.NewTransition("jam")
+	net := petri.NewNet("pump").
+		WithPlaces(idle, full).
+		WithTransitions(fill, empty, jam).
+		WithArcs(
+			petri.NewArc(idle, fill, "", nil),
+			petri.NewArc(fill, full, "", nil),
+			petri.NewArc(full, empty, "", nil),
+			petri.NewArc(empty, idle, "", nil),
+			petri.NewArc(idle, jam, "", nil),
+			petri.NewArc(jam, idle, "", nil),
+		)
+	ln := labeled.New(marked.New(net, marked.Marking{1, 0}))
+	ln.Events = []*labeled.Event{{ID: "fill-id", Name: "fill"}, {ID: "empty-id", Name: "empty"}, {ID: "jam-id", Name: "jam"}}
+	ok := func(_ context.Context, ev *labeled.Event) (*labeled.Event, error) {
+		return &labeled.Event{Name: ev.Name, Data: map[string]interface{}{"ok": true}}, nil
+	}
+	return &pump{
+		Net:      ln,
+		idle:     idle,
+		full:     full,
+		eventMap: map[string]*petri.Transition{"fill": fill, "empty": empty, "jam": jam},
+		handlers: control.Handlers{
+			"fill":  ok,
+			"empty": ok,
+			"jam": func(context.Context, *labeled.Event) (*labeled.Event, error) {
+				return nil, errors.New("stalled")
+			},
+		},
+	}
+}
+
+func step(deviceID, id, name string) *sequence.Step {
+	return &sequence.Step{Action: &sequence.Action{
+		Device: &device.Device{ID: deviceID, Name: "pump"},
+		Event:  &labeled.Event{ID: id, Name: name, Data: map[string]interface{}{}},
+	}}
+}
+
+// bind waits for the instance's beacon and binds the device to it.
+func bind(t *testing.T, c *client.Controller, deviceID, instanceID string) {
+	t.Helper()
+	deadline := time.After(5 * time.Second)
+	var err error
+	for err = c.Bind(deviceID, instanceID); errors.Is(err, client.ErrUnknownInstance); err = c.Bind(deviceID, instanceID) {
+		if dErr := c.Discover(); dErr != nil {
+			t.Fatal(dErr)
+		}
+		select {
+		case <-deadline:
+			t.Fatalf("timed out waiting for the beacon from %s", instanceID)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestController_Start(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger := zap.NewNop()
+	broker := transport.NewBroker()
+	defer func() {
+		_ = broker.Close()
+	}()
+
+	p := newPump()
+	srv, err := server.New(p.Net, broker, "pump-device", "pump-1", p.eventMap, p.handlers, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- srv.Listen(ctx)
+	}()
+
+	c := client.NewController(logger, broker)
+	defer c.Close()
+	if err := c.Listen(ctx); err != nil {
+		t.Fatal(err)
+	}
+	c.Sequence = &sequence.Sequence{
+		Name:  "prime",
+		Steps: []*sequence.Step{step("pump-device", "fill-id", "fill"), step("pump-device", "empty-id", "empty")},
+	}
+	bind(t, c, "pump-device", "pump-1")
+	data := make(chan *control.Event, 4)
+	c.ChannelData(ctx, data)
+	if err := c.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, data, "running")
+	for _, want := range []string{"fill", "empty"} {
+		select {
+		case ev := <-data:
+			if ev.Name != want || ev.From != "pump-1" || ev.Data["ok"] != true {
+				t.Fatalf("expected %s from pump-1, got %+v", want, ev)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %s", want)
+		}
+	}
+	marking := p.MarkingMap()
+	if marking[p.idle.ID] != 1 || marking[p.full.ID] != 0 {
+		t.Fatalf("expected the pump to be idle after the sequence, got %v", marking)
+	}
+
+	callCtx, callCancel := context.WithTimeout(ctx, 5*time.Second)
+	defer callCancel()
+	_, err = c.Call(callCtx, step("pump-device", "jam-id", "jam").Command("pump-1"))
+	var cErr *control.Error
+	if !errors.As(err, &cErr) || cErr.Kind != control.HandlerFailed || cErr.Message != "stalled" {
+		t.Fatalf("expected a handler failure, got %v", err)
+	}
+
+	if err := broker.Publish(ctx, &transport.Message{Key: "pump-1.commands.fill", Body: []byte("{")}); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.After(5 * time.Second)
+	for len(broker.DeadLetters()) == 0 {
+		select {
+		case <-deadline:
+			t.Fatal("expected the malformed command to be dead-lettered")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	cancel()
+	if err := <-stopped; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestController_Bind(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger := zap.NewNop()
+	broker := transport.NewBroker()
+	defer func() {
+		_ = broker.Close()
+	}()
+	p := newPump()
+	srv, err := server.New(p.Net, broker, "pump-device", "pump-1", p.eventMap, p.handlers, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		_ = srv.Listen(ctx)
+	}()
+	c := client.NewController(logger, broker)
+	defer c.Close()
+	if err := c.Listen(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	withFields := step("pump-device", "fill-id", "fill")
+	withFields.Event.Fields = []*labeled.Field{{Name: "volume", Type: labeled.Number}}
+	c.Sequence = &sequence.Sequence{Steps: []*sequence.Step{withFields}}
+	deadline := time.After(5 * time.Second)
+	for err = c.Bind("pump-device", "pump-1"); errors.Is(err, client.ErrUnknownInstance); err = c.Bind("pump-device", "pump-1") {
+		if dErr := c.Discover(); dErr != nil {
+			t.Fatal(dErr)
+		}
+		select {
+		case <-deadline:
+			t.Fatal("timed out waiting for the pump's beacon")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	if !errors.Is(err, control.ErrIncompatible) {
+		t.Fatalf("expected a step with unknown fields to be refused, got %v", err)
+	}
+
+	other := newPump()
+	other.Net.Net.Net.Places = other.Net.Net.Net.Places[:1]
+	wrongNet := step("pump-device", "fill-id", "fill")
+	wrongNet.Device.Nets = []*labeled.Net{other.Net}
+	c.Sequence = &sequence.Sequence{Steps: []*sequence.Step{wrongNet}}
+	if err := c.Bind("pump-device", "pump-1"); !errors.Is(err, control.ErrIncompatible) {
+		t.Fatalf("expected an instance running another net to be refused, got %v", err)
+	}
+
+	ok := step("pump-device", "fill-id", "fill")
+	ok.Device.Nets = []*labeled.Net{newPump().Net}
+	c.Sequence = &sequence.Sequence{Steps: []*sequence.Step{ok, step("pump-device", "empty-id", "empty")}}
+	if err := c.Bind("pump-device", "pump-1"); err != nil {
+		t.Fatal(err)
+	}
+	if c.Routes["pump-device"] == nil {
+		t.Fatal("expected the pump to be bound")
+	}
+}
+
+func TestController_BindUnmanifested(t *testing.T) {
+	broker := transport.NewBroker()
+	defer func() {
+		_ = broker.Close()
+	}()
+	c := client.NewController(zap.NewNop(), broker)
+	defer c.Close()
+	c.Known["pump-device"] = map[string]*client.Instance{"legacy": {ID: "legacy"}}
+	c.Sequence = &sequence.Sequence{Steps: []*sequence.Step{step("pump-device", "fill-id", "fill")}}
+	if err := c.Bind("pump-device", "legacy"); !errors.Is(err, control.ErrIncompatible) {
+		t.Fatalf("expected an instance without a manifest to be refused, got %v", err)
+	}
+	c.TrustUnmanifested = true
+	if err := c.Bind("pump-device", "legacy"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestController_Failover(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger := zap.NewNop()
+	broker := transport.NewBroker()
+	defer func() {
+		_ = broker.Close()
+	}()
+	p1Ctx, p1Cancel := context.WithCancel(ctx)
+	defer p1Cancel()
+	for id, srvCtx := range map[string]context.Context{"pump-1": p1Ctx, "pump-2": ctx} {
+		p := newPump()
+		srv, err := server.New(p.Net, broker, "pump-device", id, p.eventMap, p.handlers, logger)
+		if err != nil {
+			t.Fatal(err)
+		}
+		go func(ctx context.Context) {
+			_ = srv.Listen(ctx)
+		}(srvCtx)
+	}
+	c := client.NewController(logger, broker)
+	defer c.Close()
+	if err := c.Listen(ctx); err != nil {
+		t.Fatal(err)
+	}
+	c.Sequence = &sequence.Sequence{
+		Name:  "fill",
+		Steps: []*sequence.Step{step("pump-device", "fill-id", "fill")},
+	}
+	bind(t, c, "pump-device", "pump-1")
+	bind(t, c, "pump-device", "pump-2")
+	bind(t, c, "pump-device", "pump-1")
+
+	p1Cancel()
+	deadline := time.After(10 * time.Second)
+	for len(c.Instances("pump-device")) != 1 {
+		select {
+		case <-deadline:
+			t.Fatal("timed out waiting for pump-1 to be pruned")
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	data := make(chan *control.Event, 4)
+	c.ChannelData(ctx, data)
+	if err := c.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, data, "running")
+	for _, want := range []string{"failover", "fill"} {
+		select {
+		case ev := <-data:
+			if ev.Name != want || ev.From != "pump-2" {
+				t.Fatalf("expected %s from pump-2, got %+v", want, ev)
+			}
+			if want == "failover" && (ev.Data["from"] != "pump-1" || ev.Data["to"] != "pump-2") {
+				t.Fatalf("unexpected failover %v", ev.Data)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %s", want)
+		}
+	}
+}
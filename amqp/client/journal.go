@@ -0,0 +1,351 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jt05610/petri/control"
+	"github.com/jt05610/petri/datastore"
+	"github.com/jt05610/petri/labeled"
+	"github.com/jt05610/petri/sequence"
+	"go.uber.org/zap"
+	"sort"
+	"time"
+)
+
+// SaveTimeout bounds how long the controller waits for the journal.
+const SaveTimeout = 5 * time.Second
+
+// record adds ev to the session's event log and passes it to the relay set by
+// ChannelData. Callers hold c.sessionMu.
+func (c *Controller) record(deviceID string, ev *control.Event) {
+	c.events = append(c.events, &datastore.StepEvent{
+		RunID:      c.runID,
+		SessionID:  c.SessionID,
+		Step:       c.next,
+		DeviceID:   deviceID,
+		InstanceID: ev.From,
+		Event:      ev.Name,
+		Data:       ev.Data,
+		Marking:    ev.Marking,
+		Timestamp:  time.Now(),
+	})
+	c.forward(ev)
+}
+
+func (c *Controller) relay(deviceID string, ev *control.Event) {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+	c.record(deviceID, ev)
+}
+
+// checkpoint captures the session. Callers hold c.sessionMu.
+func (c *Controller) checkpoint() *datastore.Checkpoint {
+	cp := &datastore.Checkpoint{
+		SessionID:   c.SessionID,
+		RunID:       c.runID,
+		State:       string(c.state),
+		Step:        c.next,
+		Outstanding: c.outstanding,
+		Parameters:  c.Parameters,
+		Routes:      make(map[string]string),
+		Markings:    make(map[string]map[string]int, len(c.markings)),
+		Events:      append([]*datastore.StepEvent(nil), c.events...),
+		UpdatedAt:   time.Now(),
+	}
+	if c.Sequence != nil {
+		cp.SequenceID, cp.NetVersionID = c.Sequence.ID, c.Sequence.NetVersionID
+	}
+	for dev, m := range c.markings {
+		cp.Markings[dev] = m
+	}
+	c.mu.Lock()
+	for dev, inst := range c.Routes {
+		cp.Routes[dev] = inst.ID
+	}
+	c.mu.Unlock()
+	return cp
+}
+
+// save appends the new events to Data and writes a checkpoint to the Journal.
+// A session keeps running if either fails; the failure is logged. Callers hold
+// c.sessionMu.
+func (c *Controller) save() {
+	c.flush()
+	if c.Journal == nil || c.SessionID == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), SaveTimeout)
+	defer cancel()
+	if err := c.Journal.SaveCheckpoint(ctx, c.checkpoint()); err != nil {
+		c.logger.Error("Failed to save checkpoint", zap.String("session", c.SessionID), zap.Error(err))
+	}
+}
+
+// beginRun starts a new run of the sequence and records it in Data. Callers
+// hold c.sessionMu.
+func (c *Controller) beginRun() {
+	c.runID, c.flushed = uuid.NewString(), 0
+	if c.Data == nil || c.SessionID == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), SaveTimeout)
+	defer cancel()
+	err := c.Data.AddRun(ctx, &datastore.Run{
+		ID:           c.runID,
+		SessionID:    c.SessionID,
+		SequenceID:   c.Sequence.ID,
+		NetID:        c.Sequence.NetID,
+		NetVersionID: c.Sequence.NetVersionID,
+		State:        datastore.RunRunning,
+		StartedAt:    time.Now(),
+	})
+	if err != nil {
+		c.logger.Error("Failed to add run", zap.String("session", c.SessionID), zap.Error(err))
+	}
+}
+
+// runStates are the states a run ends in for each state that ends a session.
+var runStates = map[State]datastore.RunState{
+	Completed: datastore.RunCompleted,
+	Failed:    datastore.RunFailed,
+	Aborted:   datastore.RunAborted,
+}
+
+// finishRun records how the run ended. Callers hold c.sessionMu.
+func (c *Controller) finishRun(state State) {
+	if c.Data == nil || c.SessionID == "" || c.runID == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), SaveTimeout)
+	defer cancel()
+	if err := c.Data.FinishRun(ctx, c.runID, runStates[state], time.Now()); err != nil {
+		c.logger.Error("Failed to finish run", zap.String("run", c.runID), zap.Error(err))
+	}
+}
+
+// flush appends the events recorded since the last flush to Data. Events that
+// fail to be stored are tried again on the next flush. Callers hold
+// c.sessionMu.
+func (c *Controller) flush() {
+	if c.Data == nil || c.SessionID == "" || c.flushed == len(c.events) {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), SaveTimeout)
+	defer cancel()
+	if err := c.Data.AppendEvents(ctx, c.events[c.flushed:]...); err != nil {
+		c.logger.Error("Failed to store events", zap.String("session", c.SessionID), zap.Error(err))
+		return
+	}
+	c.flushed = len(c.events)
+}
+
+// telemetry stores an event a device raised on its own while a session was
+// under way.
+func (c *Controller) telemetry(ev *control.Event) {
+	c.sessionMu.Lock()
+	sessionID, idle := c.SessionID, c.state.Idle()
+	c.sessionMu.Unlock()
+	if c.Data == nil || sessionID == "" || idle {
+		return
+	}
+	t := &datastore.Telemetry{
+		SessionID:  sessionID,
+		InstanceID: ev.From,
+		Name:       ev.Name,
+		Value:      ev.Data,
+		Timestamp:  time.Now(),
+	}
+	c.mu.Lock()
+	for devID, instances := range c.Known {
+		if _, found := instances[ev.From]; found {
+			t.DeviceID = devID
+		}
+	}
+	c.mu.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), SaveTimeout)
+	defer cancel()
+	if err := c.Data.AppendTelemetry(ctx, t); err != nil {
+		c.logger.Error("Failed to store telemetry", zap.String("session", sessionID), zap.Error(err))
+	}
+}
+
+// sent notes the command for a step before it goes out, so a restart knows the
+// step may have run.
+func (c *Controller) sent(step *sequence.Step, to *Instance, cmd *control.Command) {
+	c.mu.Lock()
+	marking := to.Marking
+	c.mu.Unlock()
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+	c.outstanding = &datastore.Outstanding{
+		DeviceID:      step.Device.ID,
+		InstanceID:    to.ID,
+		Event:         step.Event.Name,
+		CorrelationID: cmd.CorrelationID,
+		SentAt:        time.Now(),
+	}
+	if marking != nil {
+		c.markings[step.Device.ID] = marking
+	}
+	c.save()
+}
+
+// answered records the outcome of the step in flight, moving on to the next
+// step if it succeeded.
+func (c *Controller) answered(step *sequence.Step, data *control.Event, ok bool) {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+	c.outstanding = nil
+	if data != nil {
+		c.logger.Info("Received event", zap.String("event", data.Name))
+		if data.Marking != nil {
+			c.markings[step.Device.ID] = data.Marking
+			// beacons lag behind answers, so keep the instance current
+			c.mu.Lock()
+			if inst := c.Routes[step.Device.ID]; inst != nil && inst.ID == data.From {
+				inst.Marking = data.Marking
+			}
+			c.mu.Unlock()
+		}
+		c.record(step.Device.ID, data)
+	}
+	if ok {
+		c.next++
+	}
+	c.save()
+}
+
+// Recovery is an interrupted session compared with the devices as they are
+// now.
+type Recovery struct {
+	*datastore.Checkpoint
+	// ResumeStep is the step the session would continue from.
+	ResumeStep int
+	// Problems say why resuming may be unsafe. A session with problems can
+	// still be aborted.
+	Problems []string
+}
+
+// Resumable reports whether the devices are where the session left them.
+func (r *Recovery) Resumable() bool {
+	return len(r.Problems) == 0
+}
+
+func sameMarking(a, b map[string]int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// reconcile compares a checkpoint with the live instances. If a command was
+// outstanding and the device's marking has moved since it was sent, the step
+// is taken to have run.
+func (c *Controller) reconcile(cp *datastore.Checkpoint) *Recovery {
+	r := &Recovery{Checkpoint: cp, ResumeStep: cp.Step, Problems: make([]string, 0)}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	devices := make([]string, 0, len(cp.Routes))
+	for dev := range cp.Routes {
+		devices = append(devices, dev)
+	}
+	sort.Strings(devices)
+	for _, dev := range devices {
+		instID := cp.Routes[dev]
+		inst := c.Known[dev][instID]
+		if inst == nil || inst.liveness <= 0 {
+			r.Problems = append(r.Problems, fmt.Sprintf("instance %s of device %s is not live", instID, dev))
+			continue
+		}
+		saved, found := cp.Markings[dev]
+		if !found || sameMarking(saved, inst.Marking) {
+			continue
+		}
+		if cp.Outstanding != nil && cp.Outstanding.DeviceID == dev {
+			r.ResumeStep = cp.Step + 1
+			continue
+		}
+		r.Problems = append(r.Problems, fmt.Sprintf("device %s changed marking while the session was interrupted", dev))
+	}
+	return r
+}
+
+// Interrupted lists the sessions the Journal has that had not finished. They
+// are compared with the instances the controller has discovered, so call it
+// once discovery has had time to hear from the devices.
+func (c *Controller) Interrupted(ctx context.Context) ([]*Recovery, error) {
+	if c.Journal == nil {
+		return nil, errors.New("no journal")
+	}
+	cps, err := c.Journal.Interrupted(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*Recovery, len(cps))
+	for i, cp := range cps {
+		ret[i] = c.reconcile(cp)
+	}
+	return ret, nil
+}
+
+// Recover loads an interrupted session paused at its ResumeStep, so it can be
+// continued with Resume or made safe with Abort. A session with Problems is
+// only continued by ForceResume. The controller's Sequence must already be the
+// session's.
+func (c *Controller) Recover(ctx context.Context, r *Recovery) error {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+	if !c.state.Idle() {
+		return fmt.Errorf("%w: a session is already %s", ErrInvalidTransition, c.state)
+	}
+	if c.Sequence == nil {
+		return errors.New("no sequence")
+	}
+	if r.ResumeStep > len(c.Sequence.Steps) {
+		return fmt.Errorf("session %s resumes at step %d of %d", r.SessionID, r.ResumeStep, len(c.Sequence.Steps))
+	}
+	c.mu.Lock()
+	for dev, instID := range r.Routes {
+		if inst := c.Known[dev][instID]; inst != nil {
+			c.Routes[dev] = inst
+		}
+	}
+	c.mu.Unlock()
+	c.SessionID, c.Parameters = r.SessionID, r.Parameters
+	c.next, c.outstanding = r.ResumeStep, nil
+	c.markings = make(map[string]control.Marking, len(r.Markings))
+	for dev, m := range r.Markings {
+		c.markings[dev] = m
+	}
+	c.events = append([]*datastore.StepEvent(nil), r.Events...)
+	// the recovered events were stored before the checkpoint was taken
+	c.runID, c.flushed = r.RunID, len(c.events)
+	if c.runID == "" {
+		c.beginRun()
+	}
+	c.problems = append([]string(nil), r.Problems...)
+	c.CurrentStep.Store(int32(r.ResumeStep))
+	from := c.state
+	c.state, c.resumed = Paused, make(chan struct{})
+	c.logger.Info("Recovered session", zap.String("session", r.SessionID), zap.Int("step", r.ResumeStep))
+	c.record("", &control.Event{
+		Topic: "session",
+		Event: &labeled.Event{Name: string(Paused), Data: map[string]interface{}{
+			"from":      string(from),
+			"to":        string(Paused),
+			"step":      r.ResumeStep,
+			"recovered": true,
+			"problems":  r.Problems,
+		}},
+	})
+	c.save()
+	c.launch(ctx)
+	return nil
+}
@@ -0,0 +1,277 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jt05610/petri/amqp/client"
+	"github.com/jt05610/petri/amqp/server"
+	"github.com/jt05610/petri/datastore"
+	"github.com/jt05610/petri/labeled"
+	"github.com/jt05610/petri/sqlite"
+	"github.com/jt05610/petri/transport"
+	"go.uber.org/zap"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// journal keeps checkpoints in memory.
+type journal struct {
+	mu  sync.Mutex
+	cps map[string]*datastore.Checkpoint
+}
+
+func (j *journal) SaveCheckpoint(_ context.Context, cp *datastore.Checkpoint) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.cps[cp.SessionID] = cp
+	return nil
+}
+
+func (j *journal) Checkpoint(_ context.Context, sessionID string) (*datastore.Checkpoint, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	cp, found := j.cps[sessionID]
+	if !found {
+		return nil, fmt.Errorf("checkpoint for %s: %w", sessionID, datastore.ErrNotFound)
+	}
+	return cp, nil
+}
+
+func (j *journal) Interrupted(context.Context) ([]*datastore.Checkpoint, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	ret := make([]*datastore.Checkpoint, 0)
+	for _, cp := range j.cps {
+		switch client.State(cp.State) {
+		case client.Running, client.Paused, client.Stopping:
+			ret = append(ret, cp)
+		}
+	}
+	return ret, nil
+}
+
+// mortal drops saves once it is dead, as a controller that crashed would.
+type mortal struct {
+	datastore.Journal
+	dead atomic.Bool
+}
+
+func (m *mortal) SaveCheckpoint(ctx context.Context, cp *datastore.Checkpoint) error {
+	if m.dead.Load() {
+		return nil
+	}
+	return m.Journal.SaveCheckpoint(ctx, cp)
+}
+
+func TestController_Recover(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	broker, entered, gate := gatedPump(ctx, t)
+	j := &journal{cps: make(map[string]*datastore.Checkpoint)}
+
+	// the first controller dies while the pump is filling
+	crashCtx, crash := context.WithCancel(ctx)
+	defer crash()
+	crashed, data := session(crashCtx, t, broker)
+	m := &mortal{Journal: j}
+	crashed.Journal, crashed.SessionID = m, "session"
+	crashed.Sequence.NetVersionID = "v1"
+	bind(t, crashed, "pump-device", "pump-1")
+	if err := crashed.Start(crashCtx); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, data, "running")
+	wait(t, entered)
+	m.dead.Store(true)
+	crash()
+	crashed.Close()
+	cp, err := j.Checkpoint(ctx, "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cp.State != string(client.Running) || cp.Step != 0 || cp.Outstanding == nil || cp.Outstanding.Event != "fill" || cp.Routes["pump-device"] != "pump-1" || cp.NetVersionID != "v1" {
+		t.Fatalf("expected the fill to be outstanding, got %+v", cp)
+	}
+	gate <- struct{}{}
+
+	c, data := session(ctx, t, broker)
+	c.Journal = j
+	var r *client.Recovery
+	deadline := time.After(5 * time.Second)
+	for r == nil || !r.Resumable() {
+		if err := c.Discover(); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case <-deadline:
+			t.Fatalf("expected the session to become resumable, got %+v", r)
+		case <-time.After(50 * time.Millisecond):
+		}
+		rs, err := c.Interrupted(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rs) != 1 {
+			t.Fatalf("expected one interrupted session, got %d", len(rs))
+		}
+		r = rs[0]
+	}
+	if r.ResumeStep != 1 {
+		t.Fatalf("expected the fill to be taken as done, got resume step %d", r.ResumeStep)
+	}
+
+	if err := c.Recover(ctx, r); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, data, "paused")
+	if c.State() != client.Paused {
+		t.Fatalf("expected the recovered session to wait, got %s", c.State())
+	}
+	if err := c.Resume(); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, data, "running", "empty")
+	wait(t, entered)
+	gate <- struct{}{}
+	expect(t, data, "fill", "empty", "completed")
+	cp, err = c.Journal.Checkpoint(ctx, "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cp.State != string(client.Completed) || cp.Step != 4 || cp.Outstanding != nil {
+		t.Fatalf("expected the session to be saved as completed, got %+v", cp)
+	}
+	// running and the outstanding fill from before the crash, then the
+	// recovery, resume, three steps and completion
+	if len(cp.Events) != 7 {
+		t.Fatalf("expected the event log to span the restart, got %d events", len(cp.Events))
+	}
+}
+
+func TestController_Data(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	broker, entered, gate := gatedPump(ctx, t)
+	// a second pump raises a fill the session did not ask for
+	p := newPump()
+	p.Net.AddEvent(&labeled.Event{ID: "fill-id", Name: "fill"}, p.eventMap["fill"])
+	other, err := server.New(p.Net, broker, "pump-device", "pump-2", p.eventMap, p.handlers, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		_ = other.Listen(ctx)
+	}()
+	store, err := sqlite.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+	if err := store.CreateDb(ctx, "lab"); err != nil {
+		t.Fatal(err)
+	}
+	db, err := store.Load(ctx, "lab")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	c, data := session(ctx, t, broker)
+	c.Data, c.SessionID = db, "session"
+	c.Sequence.ID, c.Sequence.NetID, c.Sequence.NetVersionID = "cycle", "pump", "v1"
+	bind(t, c, "pump-device", "pump-1")
+	if err := c.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, data, "running")
+	wait(t, entered)
+	if err := other.Raise(ctx, &labeled.Event{ID: "fill-id", Name: "fill"}); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, data, "fill")
+	gate <- struct{}{}
+	wait(t, entered)
+	gate <- struct{}{}
+	deadline := time.After(5 * time.Second)
+	for c.State() != client.Completed {
+		select {
+		case <-deadline:
+			t.Fatalf("expected the session to complete, got %s", c.State())
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	runs, err := db.Runs(ctx, "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 1 || runs[0].State != datastore.RunCompleted || runs[0].NetVersionID != "v1" || runs[0].EndedAt.IsZero() {
+		t.Fatalf("expected one completed run, got %+v", runs)
+	}
+	events, err := db.Events(ctx, &datastore.Query{RunID: runs[0].ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// running, the four steps and completed
+	if len(events) != 6 || events[0].Event != "running" || events[5].Event != "completed" {
+		t.Fatalf("expected the run's events, got %d", len(events))
+	}
+	telemetry, err := db.Telemetry(ctx, &datastore.Query{SessionID: "session"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(telemetry) != 1 || telemetry[0].InstanceID != "pump-2" || telemetry[0].Name != "fill" {
+		t.Fatalf("expected the other pump's fill as telemetry, got %+v", telemetry)
+	}
+}
+
+func TestController_ForceResume(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	broker := transport.NewBroker()
+	defer func() {
+		_ = broker.Close()
+	}()
+	p := newPump()
+	srv, err := server.New(p.Net, broker, "pump-device", "pump-1", p.eventMap, p.handlers, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		_ = srv.Listen(ctx)
+	}()
+	c, data := session(ctx, t, broker)
+	bind(t, c, "pump-device", "pump-1")
+	// the session was interrupted on an instance that has not come back
+	c.Journal = &journal{cps: map[string]*datastore.Checkpoint{"session": {
+		SessionID: "session",
+		State:     string(client.Running),
+		Step:      2,
+		Routes:    map[string]string{"pump-device": "pump-9"},
+	}}}
+	rs, err := c.Interrupted(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rs) != 1 || rs[0].Resumable() {
+		t.Fatalf("expected the session to be unsafe to resume, got %+v", rs)
+	}
+	if err := c.Recover(ctx, rs[0]); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, data, "paused")
+	if err := c.Resume(); !errors.Is(err, client.ErrNotResumable) {
+		t.Fatalf("expected the resume to be refused, got %v", err)
+	}
+	if err := c.ForceResume(); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, data, "running", "fill", "empty", "completed")
+}
@@ -0,0 +1,85 @@
+package client
+
+import (
+	"sync"
+)
+
+// Selector picks the instance of a device a step is sent to. candidates are
+// the live instances able to run the step, ordered by ID, and current is the
+// instance the device was last routed to, or nil. Returning nil fails the
+// step. Select is called with the controller locked and must not call back
+// into it.
+type Selector interface {
+	Select(deviceID string, current *Instance, candidates []*Instance) *Instance
+}
+
+type SelectorFunc func(deviceID string, current *Instance, candidates []*Instance) *Instance
+
+func (f SelectorFunc) Select(deviceID string, current *Instance, candidates []*Instance) *Instance {
+	return f(deviceID, current, candidates)
+}
+
+func find(candidates []*Instance, inst *Instance) *Instance {
+	if inst == nil {
+		return nil
+	}
+	for _, c := range candidates {
+		if c.ID == inst.ID {
+			return c
+		}
+	}
+	return nil
+}
+
+// Pinned only ever uses the instance the device was bound to, so a session
+// fails rather than moving to another instance.
+func Pinned() Selector {
+	return SelectorFunc(func(_ string, current *Instance, candidates []*Instance) *Instance {
+		return find(candidates, current)
+	})
+}
+
+// FirstHealthy keeps using the current instance while it is alive and fails
+// over to the first live instance when it is not.
+func FirstHealthy() Selector {
+	return SelectorFunc(func(_ string, current *Instance, candidates []*Instance) *Instance {
+		if inst := find(candidates, current); inst != nil {
+			return inst
+		}
+		if len(candidates) == 0 {
+			return nil
+		}
+		return candidates[0]
+	})
+}
+
+// LeastBusy picks the instance with the fewest calls in flight, preferring the
+// current instance on a tie.
+func LeastBusy() Selector {
+	return SelectorFunc(func(_ string, current *Instance, candidates []*Instance) *Instance {
+		best := find(candidates, current)
+		for _, c := range candidates {
+			if best == nil || c.busy < best.busy {
+				best = c
+			}
+		}
+		return best
+	})
+}
+
+// RoundRobin spreads the steps for each device across its live instances in
+// turn.
+func RoundRobin() Selector {
+	var mu sync.Mutex
+	next := make(map[string]int)
+	return SelectorFunc(func(deviceID string, _ *Instance, candidates []*Instance) *Instance {
+		if len(candidates) == 0 {
+			return nil
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		i := next[deviceID] % len(candidates)
+		next[deviceID] = i + 1
+		return candidates[i]
+	})
+}
@@ -0,0 +1,40 @@
+package client_test
+
+import (
+	"github.com/jt05610/petri/amqp/client"
+	"testing"
+)
+
+func TestSelectors(t *testing.T) {
+	a, b, c := &client.Instance{ID: "a"}, &client.Instance{ID: "b"}, &client.Instance{ID: "c"}
+	dead := &client.Instance{ID: "dead"}
+	live := []*client.Instance{a, b, c}
+
+	if got := client.Pinned().Select("dev", b, live); got != b {
+		t.Errorf("expected pinned to keep b, got %v", got)
+	}
+	if got := client.Pinned().Select("dev", dead, live); got != nil {
+		t.Errorf("expected pinned to refuse to fail over, got %v", got)
+	}
+	if got := client.FirstHealthy().Select("dev", b, live); got != b {
+		t.Errorf("expected first-healthy to keep b, got %v", got)
+	}
+	if got := client.FirstHealthy().Select("dev", dead, live); got != a {
+		t.Errorf("expected first-healthy to fail over to a, got %v", got)
+	}
+	if got := client.FirstHealthy().Select("dev", nil, nil); got != nil {
+		t.Errorf("expected no instance, got %v", got)
+	}
+	if got := client.LeastBusy().Select("dev", c, live); got != c {
+		t.Errorf("expected least-busy to keep c on a tie, got %v", got)
+	}
+	rr := client.RoundRobin()
+	for i, want := range []*client.Instance{a, b, c, a} {
+		if got := rr.Select("dev", nil, live); got != want {
+			t.Errorf("round-robin pick %d: expected %s, got %v", i, want.ID, got)
+		}
+	}
+	if got := rr.Select("other", nil, live); got != a {
+		t.Errorf("expected round-robin to count each device separately, got %v", got)
+	}
+}
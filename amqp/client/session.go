@@ -0,0 +1,287 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jt05610/petri/control"
+	"github.com/jt05610/petri/labeled"
+	"github.com/jt05610/petri/sequence"
+	"go.uber.org/zap"
+	"strings"
+)
+
+// State is where the controller's session is in its lifecycle.
+type State string
+
+const (
+	// Created means no sequence has been started yet.
+	Created State = "created"
+	// Running means steps are being sent to devices.
+	Running State = "running"
+	// Paused means the session waits at the next step boundary until resumed.
+	Paused State = "paused"
+	// Stopping means the step in flight is allowed to finish and no more are
+	// sent.
+	Stopping State = "stopping"
+	// Aborted means the step in flight was cancelled and the shutdown commands
+	// were sent.
+	Aborted State = "aborted"
+	// Completed means the session ran every step or was stopped.
+	Completed State = "completed"
+	// Failed means a step failed.
+	Failed State = "failed"
+)
+
+// Idle reports whether no session is under way, so a new one can be started.
+func (s State) Idle() bool {
+	return s == Created || s == Aborted || s == Completed || s == Failed
+}
+
+var ErrInvalidTransition = errors.New("invalid session transition")
+
+// ErrNotResumable is returned when resuming a recovered session whose devices
+// are not where it left them.
+var ErrNotResumable = errors.New("session is not safe to resume")
+
+// transitions lists the states each state can move to.
+var transitions = map[State][]State{
+	Created:   {Running},
+	Running:   {Paused, Stopping, Aborted, Completed, Failed},
+	Paused:    {Running, Stopping, Aborted},
+	Stopping:  {Aborted, Completed, Failed},
+	Aborted:   {Running},
+	Completed: {Running},
+	Failed:    {Running},
+}
+
+func (s State) can(to State) bool {
+	for _, t := range transitions[s] {
+		if t == to {
+			return true
+		}
+	}
+	return false
+}
+
+// State returns the state of the current session.
+func (c *Controller) State() State {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+	return c.state
+}
+
+// transition moves the session to a new state, relays the change as a
+// "session" event and saves a checkpoint. Callers hold c.sessionMu.
+func (c *Controller) transition(to State, cause error) error {
+	from := c.state
+	if !from.can(to) {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidTransition, from, to)
+	}
+	c.state = to
+	if from == Paused {
+		close(c.resumed)
+	}
+	if to == Paused {
+		c.resumed = make(chan struct{})
+	}
+	c.logger.Info("Session changed state", zap.String("from", string(from)), zap.String("to", string(to)))
+	data := map[string]interface{}{
+		"from": string(from),
+		"to":   string(to),
+		"step": int(c.CurrentStep.Load()),
+	}
+	if cause != nil {
+		data["error"] = cause.Error()
+	}
+	c.record("", &control.Event{
+		Topic: "session",
+		Event: &labeled.Event{Name: string(to), Data: data},
+	})
+	c.save()
+	if to.Idle() {
+		c.finishRun(to)
+	}
+	return nil
+}
+
+// Start runs the controller's sequence from the first step. It returns once the
+// session is running; progress is relayed as events.
+func (c *Controller) Start(ctx context.Context) error {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+	if !c.state.Idle() {
+		return fmt.Errorf("%w: a session is already %s", ErrInvalidTransition, c.state)
+	}
+	if c.Sequence == nil {
+		return errors.New("no sequence")
+	}
+	c.CurrentStep.Store(0)
+	c.next, c.outstanding = 0, nil
+	c.markings = make(map[string]control.Marking)
+	c.events, c.problems = nil, nil
+	c.beginRun()
+	if err := c.transition(Running, nil); err != nil {
+		return err
+	}
+	c.logger.Info("Starting sequence", zap.String("sequence", c.Sequence.Name))
+	c.launch(ctx)
+	return nil
+}
+
+// launch runs the sequence from c.next. Callers hold c.sessionMu.
+func (c *Controller) launch(ctx context.Context) {
+	stepCtx, cancel := context.WithCancel(ctx)
+	c.cancelStep = cancel
+	go c.run(ctx, stepCtx, cancel, c.Sequence.Steps, c.next)
+}
+
+func (c *Controller) run(ctx, stepCtx context.Context, cancel context.CancelFunc, steps []*sequence.Step, from int) {
+	defer cancel()
+	for i := from; i < len(steps); i++ {
+		step := steps[i]
+		if !c.boundary(ctx) {
+			return
+		}
+		c.CurrentStep.Store(int32(i))
+		c.logger.Info("Starting step", zap.String("step", step.Name))
+		to, err := c.route(step)
+		var data *control.Event
+		if err == nil {
+			cmd := step.Command(to.ID)
+			cmd.CorrelationID = uuid.NewString()
+			c.sent(step, to, cmd)
+			data, err = c.callStep(stepCtx, step, cmd)
+			c.release(to)
+		}
+		c.answered(step, data, err == nil)
+		if err != nil {
+			c.sessionMu.Lock()
+			switch {
+			case c.state == Aborted:
+			case ctx.Err() != nil:
+				_ = c.transition(Aborted, ctx.Err())
+			default:
+				c.logger.Error("Step failed", zap.String("step", step.Name), zap.Error(err))
+				_ = c.transition(Failed, err)
+			}
+			c.sessionMu.Unlock()
+			return
+		}
+	}
+	c.CurrentStep.Store(int32(len(steps)))
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+	if c.state == Running || c.state == Stopping {
+		c.logger.Info("Sequence complete", zap.String("sequence", c.Sequence.Name))
+		_ = c.transition(Completed, nil)
+	}
+}
+
+// boundary waits while the session is paused and reports whether the next
+// step should be sent.
+func (c *Controller) boundary(ctx context.Context) bool {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+	for c.state == Paused {
+		resumed := c.resumed
+		c.sessionMu.Unlock()
+		select {
+		case <-resumed:
+		case <-ctx.Done():
+		}
+		c.sessionMu.Lock()
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	if ctx.Err() != nil {
+		if !c.state.Idle() {
+			_ = c.transition(Aborted, ctx.Err())
+		}
+		return false
+	}
+	switch c.state {
+	case Stopping:
+		_ = c.transition(Completed, nil)
+		return false
+	case Running:
+		return true
+	}
+	return false
+}
+
+// Pause holds the session at the next step boundary. The step in flight is
+// allowed to finish.
+func (c *Controller) Pause() error {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+	return c.transition(Paused, nil)
+}
+
+// Resume continues a paused session. A recovered session whose recovery had
+// problems is refused with ErrNotResumable; use ForceResume to continue it
+// anyway.
+func (c *Controller) Resume() error {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+	if c.state != Paused {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidTransition, c.state, Running)
+	}
+	if len(c.problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrNotResumable, strings.Join(c.problems, "; "))
+	}
+	return c.transition(Running, nil)
+}
+
+// ForceResume continues a paused session even if its recovery had problems.
+func (c *Controller) ForceResume() error {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+	if c.state != Paused {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidTransition, c.state, Running)
+	}
+	if len(c.problems) > 0 {
+		c.logger.Warn("Resuming despite recovery problems", zap.Strings("problems", c.problems))
+		c.problems = nil
+	}
+	return c.transition(Running, nil)
+}
+
+// Stop ends the session once the step in flight finishes.
+func (c *Controller) Stop() error {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+	return c.transition(Stopping, nil)
+}
+
+// Abort cancels the step in flight and sends the Shutdown steps to put the
+// devices somewhere safe. Every shutdown step is tried; their errors are
+// joined.
+func (c *Controller) Abort(ctx context.Context) error {
+	c.sessionMu.Lock()
+	err := c.transition(Aborted, nil)
+	if err == nil {
+		c.cancelStep()
+	}
+	c.sessionMu.Unlock()
+	if err != nil {
+		return err
+	}
+	errs := make([]error, 0, len(c.Shutdown))
+	for _, step := range c.Shutdown {
+		c.logger.Info("Sending shutdown step", zap.String("step", step.Name))
+		data, err := c.startStep(ctx, step)
+		if data != nil {
+			c.relay(step.Device.ID, data)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("shutdown %s: %w", step.Event.Name, err))
+		}
+	}
+	c.sessionMu.Lock()
+	c.save()
+	c.sessionMu.Unlock()
+	return errors.Join(errs...)
+}
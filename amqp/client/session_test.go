@@ -0,0 +1,228 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"github.com/jt05610/petri/amqp/client"
+	"github.com/jt05610/petri/amqp/server"
+	"github.com/jt05610/petri/control"
+	"github.com/jt05610/petri/labeled"
+	"github.com/jt05610/petri/sequence"
+	"github.com/jt05610/petri/transport"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+// gatedPump runs a pump whose fill handler waits for a signal on gate.
+func gatedPump(ctx context.Context, t *testing.T) (broker *transport.Broker, entered, gate chan struct{}) {
+	t.Helper()
+	broker = transport.NewBroker()
+	t.Cleanup(func() {
+		_ = broker.Close()
+	})
+	p := newPump()
+	entered, gate = make(chan struct{}, 1), make(chan struct{})
+	fill := p.handlers["fill"]
+	p.handlers["fill"] = func(ctx context.Context, ev *labeled.Event) (*labeled.Event, error) {
+		entered <- struct{}{}
+		<-gate
+		return fill(ctx, ev)
+	}
+	srv, err := server.New(p.Net, broker, "pump-device", "pump-1", p.eventMap, p.handlers, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		_ = srv.Listen(ctx)
+	}()
+	return broker, entered, gate
+}
+
+func cycle() *sequence.Sequence {
+	return &sequence.Sequence{
+		Name: "cycle",
+		Steps: []*sequence.Step{
+			step("pump-device", "fill-id", "fill"),
+			step("pump-device", "empty-id", "empty"),
+			step("pump-device", "fill-id", "fill"),
+			step("pump-device", "empty-id", "empty"),
+		},
+	}
+}
+
+// session connects a controller to the broker with the cycle sequence loaded.
+func session(ctx context.Context, t *testing.T, broker *transport.Broker) (*client.Controller, chan *control.Event) {
+	t.Helper()
+	c := client.NewController(zap.NewNop(), broker)
+	t.Cleanup(c.Close)
+	if err := c.Listen(ctx); err != nil {
+		t.Fatal(err)
+	}
+	c.Sequence = cycle()
+	data := make(chan *control.Event, 16)
+	c.ChannelData(ctx, data)
+	return c, data
+}
+
+// gatedSession runs a gated pump and a controller bound to it.
+func gatedSession(ctx context.Context, t *testing.T) (c *client.Controller, data chan *control.Event, entered, gate chan struct{}) {
+	t.Helper()
+	broker, entered, gate := gatedPump(ctx, t)
+	c, data = session(ctx, t, broker)
+	bind(t, c, "pump-device", "pump-1")
+	return c, data, entered, gate
+}
+
+func expect(t *testing.T, data <-chan *control.Event, names ...string) {
+	t.Helper()
+	for _, want := range names {
+		select {
+		case ev := <-data:
+			if ev.Name != want {
+				t.Fatalf("expected %s, got %s %v", want, ev.Name, ev.Data)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %s", want)
+		}
+	}
+}
+
+func wait(t *testing.T, ch <-chan struct{}) {
+	t.Helper()
+	select {
+	case <-ch:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the pump")
+	}
+}
+
+func TestController_PauseResumeStop(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c, data, entered, gate := gatedSession(ctx, t)
+
+	if err := c.Resume(); !errors.Is(err, client.ErrInvalidTransition) {
+		t.Fatalf("expected resuming an idle controller to fail, got %v", err)
+	}
+	if err := c.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Start(ctx); !errors.Is(err, client.ErrInvalidTransition) {
+		t.Fatalf("expected a second start to be refused, got %v", err)
+	}
+	expect(t, data, "running")
+	wait(t, entered)
+	if err := c.Pause(); err != nil {
+		t.Fatal(err)
+	}
+	gate <- struct{}{}
+	expect(t, data, "paused", "fill")
+	select {
+	case ev := <-data:
+		t.Fatalf("expected the session to hold while paused, got %s", ev.Name)
+	case <-time.After(200 * time.Millisecond):
+	}
+	if got := c.CurrentStep.Load(); got != 0 {
+		t.Fatalf("expected to be held after the first step, got step %d", got)
+	}
+
+	if err := c.Resume(); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, data, "running", "empty")
+	wait(t, entered)
+	if err := c.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	gate <- struct{}{}
+	expect(t, data, "stopping", "fill", "completed")
+	if c.State() != client.Completed || c.CurrentStep.Load() != 2 {
+		t.Fatalf("expected to complete after step 2, got %s at %d", c.State(), c.CurrentStep.Load())
+	}
+}
+
+func TestController_Abort(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c, data, entered, gate := gatedSession(ctx, t)
+	c.Shutdown = []*sequence.Step{step("pump-device", "empty-id", "empty")}
+
+	if err := c.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, data, "running")
+	wait(t, entered)
+	aborted := make(chan error, 1)
+	go func() {
+		aborted <- c.Abort(ctx)
+	}()
+	expect(t, data, "aborted")
+	// the device finishes the fill it started before it takes the shutdown
+	gate <- struct{}{}
+	if err := <-aborted; err != nil {
+		t.Fatal(err)
+	}
+	for ev := range drain(data) {
+		if ev.Name == "failed" {
+			t.Fatalf("expected the cancelled step not to fail the session, got %v", ev.Data)
+		}
+	}
+	if c.State() != client.Aborted {
+		t.Fatalf("expected the session to be aborted, got %s", c.State())
+	}
+}
+
+func TestController_Failed(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c, data, _, _ := gatedSession(ctx, t)
+	c.Sequence.Steps = []*sequence.Step{step("pump-device", "jam-id", "jam")}
+	if err := c.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, data, "running", "jam", "failed")
+	if c.State() != client.Failed {
+		t.Fatalf("expected the session to fail, got %s", c.State())
+	}
+	if err := c.Start(ctx); err != nil {
+		t.Fatalf("expected a failed session to be restartable, got %v", err)
+	}
+}
+
+func TestController_RelayGone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c, _, entered, gate := gatedSession(ctx, t)
+	// the reader stops before the session starts, as petrid's does when its
+	// run is cancelled
+	relayCtx, stop := context.WithCancel(ctx)
+	c.ChannelData(relayCtx, make(chan *control.Event))
+	stop()
+	if err := c.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	wait(t, entered)
+	done := make(chan error, 1)
+	go func() {
+		done <- c.Pause()
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the controller not to wait for a relay nobody reads")
+	}
+	gate <- struct{}{}
+}
+
+func drain(data chan *control.Event) chan *control.Event {
+	ret := make(chan *control.Event, len(data))
+	for len(data) > 0 {
+		ret <- <-data
+	}
+	close(ret)
+	return ret
+}
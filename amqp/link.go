@@ -0,0 +1,313 @@
+package amqp
+
+import (
+	"context"
+	"errors"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/zap"
+	"sync"
+	"time"
+)
+
+var (
+	ErrNack       = errors.New("publish was not confirmed by the broker")
+	ErrLinkClosed = errors.New("link closed")
+)
+
+// DefaultReconnectDelay is how long a Link waits between attempts to reach the
+// broker after losing its connection.
+const DefaultReconnectDelay = 2 * time.Second
+
+// Topology describes how the exchange and queues used by the controller and
+// device servers are declared.
+type Topology struct {
+	Exchange string
+	// Durable exchanges and queues, and the messages on them, survive a
+	// broker restart.
+	Durable bool
+	// Prefetch bounds the deliveries a consumer holds without acking them.
+	Prefetch int
+}
+
+func DefaultTopology(exchange string) *Topology {
+	return &Topology{
+		Exchange: exchange,
+		Durable:  true,
+		Prefetch: 1,
+	}
+}
+
+// DeclareExchange declares the topic exchange.
+func (t *Topology) DeclareExchange(ch *amqp.Channel) error {
+	return ch.ExchangeDeclare(
+		t.Exchange, // name
+		"topic",    // type
+		t.Durable,  // durable
+		false,      // delete when unused
+		false,      // internal
+		false,      // no-wait
+		nil,        // arguments
+	)
+}
+
+// DeclareQueue declares the named consumer queue and binds it to keys on the
+// exchange. Durable topologies need a name so the queue can be found again
+// after a restart; when empty the broker picks a name and the queue is deleted
+// with the connection.
+func (t *Topology) DeclareQueue(ch *amqp.Channel, name string, keys []string, args amqp.Table) (string, error) {
+	if t.Prefetch > 0 {
+		if err := ch.Qos(t.Prefetch, 0, false); err != nil {
+			return "", err
+		}
+	}
+	q, err := ch.QueueDeclare(
+		name,                    // name
+		t.Durable && name != "", // durable
+		false,                   // delete when unused
+		name == "",              // exclusive
+		false,                   // no-wait
+		args,                    // arguments
+	)
+	if err != nil {
+		return "", err
+	}
+	for _, key := range keys {
+		if err := ch.QueueBind(q.Name, key, t.Exchange, false, nil); err != nil {
+			return "", err
+		}
+	}
+	return q.Name, nil
+}
+
+// Link is a channel to the broker that redials when the connection drops. The
+// channel is in confirm mode and Publish waits for the broker to confirm each
+// message. Anything registered with Setup is run again on every new channel so
+// that exchanges and queues are re-declared after the broker restarts.
+type Link struct {
+	uri    string
+	logger *zap.Logger
+	mu     sync.Mutex
+	conn   *amqp.Connection
+	ch     *amqp.Channel
+	// ready is closed while ch is usable and replaced when it is lost.
+	ready  chan struct{}
+	setups []func(ch *amqp.Channel) error
+	closed chan struct{}
+	// ReconnectDelay is the pause between attempts to redial the broker.
+	ReconnectDelay time.Duration
+}
+
+// Connect dials the broker and keeps the link open until Close is called.
+func Connect(uri string, logger *zap.Logger) (*Link, error) {
+	l := &Link{
+		uri:            uri,
+		logger:         logger,
+		ready:          make(chan struct{}),
+		closed:         make(chan struct{}),
+		ReconnectDelay: DefaultReconnectDelay,
+	}
+	if err := l.connect(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *Link) connect() error {
+	conn, err := amqp.Dial(l.uri)
+	if err != nil {
+		return err
+	}
+	ch, err := conn.Channel()
+	if err != nil {
+		return errors.Join(err, conn.Close())
+	}
+	if err := ch.Confirm(false); err != nil {
+		return errors.Join(err, conn.Close())
+	}
+	l.mu.Lock()
+	setups := l.setups
+	l.mu.Unlock()
+	for _, setup := range setups {
+		if err := setup(ch); err != nil {
+			return errors.Join(err, conn.Close())
+		}
+	}
+	closed := ch.NotifyClose(make(chan *amqp.Error, 1))
+	l.mu.Lock()
+	l.conn = conn
+	l.ch = ch
+	close(l.ready)
+	l.mu.Unlock()
+	go l.watch(closed)
+	return nil
+}
+
+func (l *Link) watch(closed <-chan *amqp.Error) {
+	err := <-closed
+	l.mu.Lock()
+	l.ready = make(chan struct{})
+	l.mu.Unlock()
+	select {
+	case <-l.closed:
+		return
+	default:
+	}
+	l.logger.Warn("Lost connection to broker", zap.Error(err))
+	for {
+		select {
+		case <-l.closed:
+			return
+		case <-time.After(l.ReconnectDelay):
+		}
+		if err := l.connect(); err != nil {
+			l.logger.Warn("Failed to reconnect to broker", zap.Error(err))
+			continue
+		}
+		l.logger.Info("Reconnected to broker")
+		return
+	}
+}
+
+// Channel waits until the link is connected and returns its channel. The
+// channel is only good until the next reconnect.
+func (l *Link) Channel(ctx context.Context) (*amqp.Channel, error) {
+	for {
+		l.mu.Lock()
+		ready, ch := l.ready, l.ch
+		l.mu.Unlock()
+		select {
+		case <-l.closed:
+			return nil, ErrLinkClosed
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ready:
+			if !ch.IsClosed() {
+				return ch, nil
+			}
+			// closed but not yet noticed by watch
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+}
+
+// Setup runs f on the current channel and again on every channel opened after
+// a reconnect.
+func (l *Link) Setup(ctx context.Context, f func(ch *amqp.Channel) error) error {
+	ch, err := l.Channel(ctx)
+	if err != nil {
+		return err
+	}
+	if err := f(ch); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	l.setups = append(l.setups, f)
+	l.mu.Unlock()
+	return nil
+}
+
+// Publish sends msg and waits for the broker to confirm it. Messages caught
+// by a dropped connection are published again once the link reconnects.
+func (l *Link) Publish(ctx context.Context, exchange, key string, msg amqp.Publishing) error {
+	for {
+		ch, err := l.Channel(ctx)
+		if err != nil {
+			return err
+		}
+		dc, err := ch.PublishWithDeferredConfirmWithContext(ctx, exchange, key, false, false, msg)
+		if err == nil {
+			var ok bool
+			ok, err = dc.WaitContext(ctx)
+			if ok {
+				return nil
+			}
+			if err == nil && !ch.IsClosed() {
+				return ErrNack
+			}
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !ch.IsClosed() {
+			return err
+		}
+		l.logger.Debug("Publish interrupted by a dropped connection, retrying", zap.String("routing_key", key))
+	}
+}
+
+// Consume delivers messages from the queue declare returns until ctx is
+// cancelled, declaring and subscribing again on every reconnect. Messages must
+// be acked or nacked. When ctx is cancelled the consumer is cancelled and the
+// deliveries the broker had already sent are still passed on before the
+// returned channel is closed.
+func (l *Link) Consume(ctx context.Context, tag string, declare func(ch *amqp.Channel) (string, error)) (<-chan amqp.Delivery, error) {
+	subscribe := func() (*amqp.Channel, <-chan amqp.Delivery, error) {
+		ch, err := l.Channel(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		q, err := declare(ch)
+		if err != nil {
+			return nil, nil, err
+		}
+		msgs, err := ch.Consume(q, tag, false, false, false, false, nil)
+		return ch, msgs, err
+	}
+	ch, msgs, err := subscribe()
+	if err != nil {
+		return nil, err
+	}
+	out := make(chan amqp.Delivery)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				if err := ch.Cancel(tag, false); err != nil {
+					l.logger.Debug("Failed to cancel consumer", zap.Error(err))
+					return
+				}
+				for d := range msgs {
+					out <- d
+				}
+				return
+			case d, ok := <-msgs:
+				if ok {
+					out <- d
+					continue
+				}
+				for {
+					ch, msgs, err = subscribe()
+					if err == nil {
+						break
+					}
+					if ctx.Err() != nil || errors.Is(err, ErrLinkClosed) {
+						return
+					}
+					l.logger.Warn("Failed to resubscribe", zap.String("consumer", tag), zap.Error(err))
+					select {
+					case <-ctx.Done():
+						return
+					case <-time.After(l.ReconnectDelay):
+					}
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+func (l *Link) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	select {
+	case <-l.closed:
+		return nil
+	default:
+	}
+	close(l.closed)
+	if l.conn == nil {
+		return nil
+	}
+	return l.conn.Close()
+}
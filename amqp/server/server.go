@@ -2,21 +2,35 @@ package server
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"github.com/jt05610/petri"
-	amqp2 "github.com/jt05610/petri/amqp"
 	"github.com/jt05610/petri/control"
 	"github.com/jt05610/petri/labeled"
-	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/jt05610/petri/transport"
 	"go.uber.org/zap"
-	"log"
+	"runtime/debug"
 	"strings"
 )
 
-func failOnError(err error, msg string) {
-	if err != nil {
-		log.Fatalf("%s: %s", msg, err)
-	}
+// DefaultMaxRedeliveries is how many times a command whose response could not
+// be published is put back on the queue before it is dead-lettered.
+const DefaultMaxRedeliveries = 3
+
+// reply is a message to publish, or to send to address if it is set.
+type reply struct {
+	address string
+	msg     *transport.Message
+}
+
+// pending is a command whose responses have not all been published. The
+// responses are kept so that a redelivered command is answered again without
+// running its handler twice.
+type pending struct {
+	attempts int
+	replies  []*reply
 }
 
 type Server struct {
@@ -24,193 +38,421 @@ type Server struct {
 	logger     *zap.Logger
 	name       string
 	deviceName string
-	ch         *amqp.Channel
-	q          *amqp.Queue
+	transport  transport.Transport
 	devEvents  <-chan *labeled.Event
-	cmd        *amqp2.CommandService
-	event      *amqp2.EventService
-	netCh      <-chan *labeled.Event
+	cmd        *transport.CommandService
+	event      *transport.EventService
 	handlers   control.Handlers
-	exchange   string
 	deviceID   string
 	instanceID string
+	pending    map[string]*pending
+	// MaxRedeliveries bounds how often a command is requeued after its
+	// response failed to publish.
+	MaxRedeliveries int
+	// Version is the software version reported in the beacon's manifest. It
+	// defaults to the version of the main module.
+	Version string
+	// NetVersion is the ID of the stored net version the net was built from.
+	// It is reported in the manifest when set.
+	NetVersion string
+	// Snapshots, when set, keeps the marking and pending responses across
+	// restarts. Startup says what to do with a snapshot found at start.
+	Snapshots SnapshotStore
+	Startup   StartupPolicy
+	startup   startup
+	confirms  chan *confirmation
+	raises    chan *raise
 }
 
 func (s *Server) AddHandler(route string, f labeled.Handler) {
 	s.handlers[route] = f
 }
 
-func (s *Server) commandName(routingKey string) string {
-	return strings.Split(routingKey, ".")[2]
-}
-
-func (s *Server) route(ctx context.Context, data *control.Command) (*control.Event, error) {
-	var res *control.Event
+// route runs the handler for the command and collects every event the net
+// emits while handling it. The first is the response to the command; the rest
+// are notifications.
+func (s *Server) route(ctx context.Context, data *control.Command) ([]*control.Event, error) {
 	s.logger.Info("Routing command", zap.String("command", data.Event.Name))
-	done := make(chan struct{})
-	go func() {
-		defer close(done)
-		ev := <-s.devEvents
-		res = &control.Event{
-			Event:   ev,
-			Topic:   "event",
-			Marking: make(map[string]int),
-			From:    s.instanceID,
+	if _, found := s.EventMap[data.Event.Name]; !found {
+		return nil, &control.Error{
+			Kind:    control.UnknownCommand,
+			Command: data.Event.Name,
+			Message: "no handler",
 		}
-
-	}()
-	if err := s.Handle(ctx, data.Event); err != nil {
-		s.logger.Error("Failed to handle event", zap.Error(err))
-		return &control.Event{
-			Event:   data.Event,
-			Topic:   "error",
-			From:    s.instanceID,
-			Marking: s.MarkingMap(),
-		}, err
 	}
-	<-done
-	s.logger.Info("Handled event", zap.String("event", data.Event.Name))
-	for k, v := range s.MarkingMap() {
-		res.Marking[k] = v
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- s.Handle(ctx, data.Event)
+	}()
+	events := make([]*control.Event, 0, 1)
+	for {
+		select {
+		case ev := <-s.devEvents:
+			events = append(events, &control.Event{
+				Event:   ev,
+				Topic:   "event",
+				Marking: make(map[string]int),
+				From:    s.instanceID,
+			})
+		case err := <-errCh:
+			if err != nil {
+				s.logger.Error("Failed to handle event", zap.Error(err))
+				return nil, &control.Error{
+					Kind:    control.HandlerFailed,
+					Command: data.Event.Name,
+					Message: err.Error(),
+				}
+			}
+			s.logger.Info("Handled event", zap.String("event", data.Event.Name))
+			if len(events) == 0 {
+				events = append(events, &control.Event{
+					Event: data.Event,
+					Topic: "event",
+					From:  s.instanceID,
+				})
+			}
+			for _, ev := range events {
+				ev.Marking = s.MarkingMap()
+			}
+			return events, nil
+		}
 	}
-	return res, nil
 }
 
 func toLowerNoSnake(s string) string {
 	return strings.ReplaceAll(strings.ToLower(s), " ", "_")
 }
 
-func New(net *labeled.Net, ch *amqp.Channel, exchange string, deviceID string, instanceID string, eventMap map[string]*petri.Transition, handlers control.Handlers, logger *zap.Logger) *Server {
+// New prepares a server for the net. Nothing is subscribed until Listen is
+// called. The queue is named after the instance so that on transports that keep
+// named queues, commands sent while the device is down are waiting for it when
+// it comes back. It fails if a handler has no transition to fire.
+func New(net *labeled.Net, t transport.Transport, deviceID string, instanceID string, eventMap map[string]*petri.Transition, handlers control.Handlers, logger *zap.Logger) (*Server, error) {
 	evIds := make(map[string]string)
 	for _, ev := range net.Events {
 		evIds[toLowerNoSnake(ev.Name)] = ev.ID
 	}
 
 	for ev, h := range handlers {
-		err := net.AddHandler(ev, evIds[ev], eventMap[ev], h)
-		failOnError(err, "Failed to add handler")
-	}
-
-	queues := make([]string, 0, len(handlers)+2)
-	err := ch.ExchangeDeclare(
-		exchange, // name
-		"topic",  // type
-		false,    // durable
-		false,    // delete when unused
-		false,    // exclusive
-		false,    // no-wait
-		nil,      // arguments
-	)
-	failOnError(err, "Failed to declare an exchange")
-	for key := range handlers {
-		queues = append(queues, instanceID+".commands."+key)
-	}
-	queues = append(queues, instanceID+".state.get")
-	queues = append(queues, "devices")
-	q, err := ch.QueueDeclare(
-		"",    // name
-		false, // durable
-		false, // delete when unused
-		false, // exclusive
-		false, // no-wait
-		nil,   // arguments
-	)
-	failOnError(err, "Failed to declare a queue")
-	for _, key := range queues {
-		err := ch.QueueBind(
-			q.Name,   // queue name
-			key,      // routing key
-			exchange, // exchange
-			false,
-			nil)
-		failOnError(err, "Failed to bind a queue")
+		tr, found := eventMap[ev]
+		if !found {
+			return nil, fmt.Errorf("handler %s: no transition for the event", ev)
+		}
+		if err := net.AddHandler(ev, evIds[ev], tr, h); err != nil {
+			return nil, fmt.Errorf("add handler %s: %w", ev, err)
+		}
 	}
-
 	return &Server{
-		Net:        net,
-		ch:         ch,
-		q:          &q,
-		devEvents:  net.Channel(),
-		exchange:   exchange,
-		handlers:   handlers,
-		deviceID:   deviceID,
-		instanceID: instanceID,
-		event:      &amqp2.EventService{},
-		cmd:        &amqp2.CommandService{},
-		logger:     logger,
+		Net:             net,
+		transport:       t,
+		devEvents:       net.Channel(),
+		handlers:        handlers,
+		deviceID:        deviceID,
+		instanceID:      instanceID,
+		event:           &transport.EventService{},
+		cmd:             &transport.CommandService{},
+		logger:          logger,
+		pending:         make(map[string]*pending),
+		MaxRedeliveries: DefaultMaxRedeliveries,
+		Version:         buildVersion(),
+		Startup:         Confirm,
+		confirms:        make(chan *confirmation),
+		raises:          make(chan *raise),
+	}, nil
+}
+
+func buildVersion() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		return info.Main.Version
+	}
+	return ""
+}
+
+func (s *Server) queue() *transport.Queue {
+	keys := make([]string, 0, len(s.handlers)+2)
+	for key := range s.handlers {
+		keys = append(keys, s.instanceID+".commands."+key)
+	}
+	keys = append(keys, s.instanceID+".state.get")
+	keys = append(keys, "devices")
+	return &transport.Queue{
+		Name:       s.instanceID,
+		Keys:       keys,
+		DeadLetter: true,
 	}
 }
 
-func (s *Server) publishBeacon() {
+func (s *Server) publishBeacon(ctx context.Context) error {
+	manifest := control.NewManifest(s.Net, s.Version)
+	manifest.NetVersion = s.NetVersion
 	event := &labeled.Event{
 		Name: "info",
 		Data: map[string]interface{}{
 			"device_name":   s.deviceName,
 			"instance_name": s.name,
+			"manifest":      manifest,
+			"startup":       s.startupInfo(),
 		},
 	}
-	resp, err := s.cmd.Flush(context.Background(), event, s.MarkingMap())
-	failOnError(err, "Failed to flush command")
-	err = s.ch.PublishWithContext(
-		context.Background(),
-		s.exchange,
-		s.instanceID+".device."+s.deviceID,
-		false,
-		false,
-		resp,
-	)
-	failOnError(err, "Failed to publish beacon")
-}
-
-func (s *Server) Listen(ctx context.Context) {
-	msgs, err := s.ch.Consume(
-		s.q.Name, // queue
-		"",       // consumer
-		true,     // auto-ack
-		false,    // exclusive
-		false,    // no-local
-		false,    // no-wait
-		nil,      // args
-	)
-	failOnError(err, "Failed to register a consumer")
-	s.netCh = s.Net.Channel()
-	go func() {
-		for {
-			select {
-			case <-ctx.Done():
-				fmt.Println("Closing connection")
+	resp, err := s.cmd.Flush(ctx, event, s.MarkingMap())
+	if err != nil {
+		return err
+	}
+	resp.Key = s.instanceID + ".device." + s.deviceID
+	return s.transport.Publish(ctx, resp)
+}
+
+// deliveryKey identifies a command across redeliveries.
+func deliveryKey(d *transport.Message) string {
+	if d.MessageID != "" {
+		return d.MessageID
+	}
+	if d.CorrelationID != "" {
+		return d.CorrelationID
+	}
+	h := sha256.New()
+	h.Write([]byte(d.Key))
+	if id, ok := d.Headers["x-event-id"].(string); ok {
+		h.Write([]byte(id))
+	}
+	h.Write(d.Body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// answer addresses the response to a command. It carries the command's
+// correlation ID and goes straight to the caller's reply-to address if there
+// is one.
+func (s *Server) answer(cmd *control.Command, key string, msg *transport.Message) *reply {
+	msg.Key = key
+	msg.CorrelationID = cmd.CorrelationID
+	return &reply{address: cmd.ReplyTo, msg: msg}
+}
+
+// replies works out what to publish in answer to a command. Handler failures
+// are answered with an error event rather than treated as delivery failures,
+// since running a hardware handler again is rarely safe.
+func (s *Server) replies(ctx context.Context, data *control.Command) ([]*reply, error) {
+	switch data.Topic {
+	case "state":
+		resp, err := s.cmd.Flush(ctx, data.Event, s.MarkingMap())
+		if err != nil {
+			return nil, err
+		}
+		return []*reply{s.answer(data, s.instanceID+".state.current", resp)}, nil
+	case "commands":
+		events, err := s.route(ctx, data)
+		var cErr *control.Error
+		if errors.As(err, &cErr) {
+			ev := &control.Event{Event: data.Event, From: s.instanceID, Error: cErr}
+			resp, err := s.cmd.FlushError(ctx, cErr, data.Event, s.MarkingMap())
+			if err != nil {
+				return nil, err
+			}
+			return []*reply{s.answer(data, ev.RoutingKey(), resp)}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		ret := make([]*reply, len(events))
+		for i, event := range events {
+			resp, err := s.cmd.Flush(ctx, event.Event, event.Marking)
+			if err != nil {
+				return nil, err
+			}
+			if i == 0 {
+				ret[i] = s.answer(data, event.RoutingKey(), resp)
+				continue
+			}
+			resp.Key = event.RoutingKey()
+			ret[i] = &reply{msg: resp}
+		}
+		return ret, nil
+	}
+	return nil, nil
+}
+
+func (s *Server) publish(ctx context.Context, r *reply) error {
+	if r.address != "" {
+		return s.transport.Send(ctx, r.address, r.msg)
+	}
+	return s.transport.Publish(ctx, r.msg)
+}
+
+func (s *Server) deadLetter(d *transport.Message, reason error) {
+	s.logger.Error("Dead-lettering command", zap.String("routing_key", d.Key), zap.Error(reason))
+	if err := d.Nack(false); err != nil {
+		s.logger.Error("Failed to nack command", zap.Error(err))
+	}
+}
+
+// handle processes one delivery and settles it. Malformed commands are
+// dead-lettered straight away. If a response cannot be published the command
+// is requeued until MaxRedeliveries is reached and then dead-lettered.
+func (s *Server) handle(ctx context.Context, d *transport.Message) {
+	s.logger.Debug("Received message", zap.String("routing_key", d.Key))
+	if d.Key == "devices" {
+		if err := s.publishBeacon(ctx); err != nil {
+			s.logger.Error("Failed to publish beacon", zap.Error(err))
+		}
+		if err := d.Ack(); err != nil {
+			s.logger.Error("Failed to ack beacon request", zap.Error(err))
+		}
+		return
+	}
+	if s.startup.state == AwaitingOperator {
+		s.refuse(ctx, d)
+		return
+	}
+	key := deliveryKey(d)
+	p, found := s.pending[key]
+	if !found {
+		data, err := s.cmd.Load(ctx, d)
+		if err != nil {
+			s.deadLetter(d, &control.Error{Kind: control.MalformedCommand, Command: d.Key, Message: err.Error()})
+			return
+		}
+		replies, err := s.replies(ctx, data)
+		if err != nil {
+			s.deadLetter(d, err)
+			return
+		}
+		p = &pending{replies: replies}
+		s.pending[key] = p
+		s.save()
+	}
+	for len(p.replies) > 0 {
+		r := p.replies[0]
+		if err := s.publish(ctx, r); err != nil {
+			p.attempts++
+			if p.attempts > s.MaxRedeliveries {
+				delete(s.pending, key)
+				s.deadLetter(d, err)
 				return
-			case <-s.netCh:
-				fmt.Println("Received a message from the net")
-			case d := <-msgs:
-				s.logger.Debug("Received message", zap.String("routing_key", d.RoutingKey))
-				if d.RoutingKey == "devices" {
-					s.publishBeacon()
-					continue
+			}
+			s.logger.Warn("Failed to publish response, requeueing", zap.Int("attempt", p.attempts), zap.Error(err))
+			if err := d.Nack(true); err != nil {
+				s.logger.Error("Failed to requeue command", zap.Error(err))
+			}
+			return
+		}
+		p.replies = p.replies[1:]
+	}
+	delete(s.pending, key)
+	s.save()
+	if err := d.Ack(); err != nil {
+		s.logger.Error("Failed to ack command", zap.Error(err))
+	}
+}
+
+// refuse answers a command that arrived while the server waits for an
+// operator to confirm its state.
+func (s *Server) refuse(ctx context.Context, d *transport.Message) {
+	data, err := s.cmd.Load(ctx, d)
+	if err != nil {
+		s.deadLetter(d, &control.Error{Kind: control.MalformedCommand, Command: d.Key, Message: err.Error()})
+		return
+	}
+	cErr := &control.Error{Kind: control.Unconfirmed, Command: data.Event.Name, Message: "waiting for an operator to confirm the device state"}
+	ev := &control.Event{Event: data.Event, From: s.instanceID, Error: cErr}
+	resp, err := s.cmd.FlushError(ctx, cErr, data.Event, s.MarkingMap())
+	if err == nil {
+		err = s.publish(ctx, s.answer(data, ev.RoutingKey(), resp))
+	}
+	if err != nil {
+		s.logger.Error("Failed to refuse command", zap.Error(err))
+	}
+	if err := d.Ack(); err != nil {
+		s.logger.Error("Failed to ack command", zap.Error(err))
+	}
+}
+
+// Listen applies the startup policy and handles commands until ctx is
+// cancelled. The command being handled
+// when ctx is cancelled is finished and answered; deliveries that were
+// prefetched but not started are requeued for another instance.
+func (s *Server) Listen(ctx context.Context) error {
+	if err := s.start(); err != nil {
+		return err
+	}
+	sub, err := s.transport.Subscribe(ctx, s.queue())
+	if err != nil {
+		return err
+	}
+	msgs := sub.Messages()
+	// handlers and responses outlive ctx so a cancelled listener still
+	// settles the command it is in the middle of
+	work := context.WithoutCancel(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Draining commands")
+			for d := range msgs {
+				if err := d.Nack(true); err != nil {
+					s.logger.Error("Failed to requeue command", zap.Error(err))
 				}
-				data, err := s.cmd.Load(ctx, d)
-				failOnError(err, "Failed to load command")
-				switch data.Topic {
-				case "state":
-					resp, err := s.cmd.Flush(ctx, data.Event, s.MarkingMap())
-					failOnError(err, "Failed to flush command")
-					err = s.ch.PublishWithContext(ctx, s.exchange, s.instanceID+".state.current", false, false, resp)
-				case "commands":
-					event, err := s.route(ctx, data)
-					failOnError(err, "Failed to handle data")
-					resp, err := s.cmd.Flush(ctx, event.Event, s.MarkingMap())
-					log.Printf("Sending response %v", resp)
-					err = s.ch.PublishWithContext(ctx,
-						s.exchange,
-						event.RoutingKey(),
-						false,
-						false,
-						resp,
-					)
-					failOnError(err, "Failed to publish event response")
+			}
+			return nil
+		case d, ok := <-msgs:
+			if !ok {
+				if ctx.Err() != nil {
+					return nil
 				}
+				return transport.ErrClosed
 			}
+			s.handle(work, d)
+		case c := <-s.confirms:
+			c.done <- s.confirm(c.restore)
+		case r := <-s.raises:
+			r.done <- s.raise(work, r.event)
 		}
-	}()
-	<-ctx.Done()
+	}
+}
+
+// raise is an event the device raised on its own, waiting to be published.
+type raise struct {
+	event *labeled.Event
+	done  chan error
+}
+
+// Raise fires the transition of an event the net added with AddEvent and
+// publishes the event, so devices can report what happens to them between
+// commands. It fails with labeled.ErrNotEnabled if the current marking does
+// not allow the event, and waits for Listen to be running.
+func (s *Server) Raise(ctx context.Context, event *labeled.Event) error {
+	r := &raise{event: event, done: make(chan error, 1)}
+	select {
+	case s.raises <- r:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err := <-r.done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Server) raise(ctx context.Context, event *labeled.Event) error {
+	if s.startup.state == AwaitingOperator {
+		return &control.Error{Kind: control.Unconfirmed, Command: event.Name, Message: "waiting for an operator to confirm the device state"}
+	}
+	events, err := s.Net.Raise(ctx, event)
+	if err != nil {
+		return err
+	}
+	s.save()
+	marking := s.MarkingMap()
+	for _, ev := range events {
+		msg, err := s.cmd.Flush(ctx, ev, marking)
+		if err != nil {
+			return err
+		}
+		msg.Key = (&control.Event{Event: ev, From: s.instanceID}).RoutingKey()
+		if err := s.transport.Publish(ctx, msg); err != nil {
+			return err
+		}
+	}
+	s.logger.Info("Raised event", zap.String("event", event.Name))
+	return nil
 }
@@ -1 +1,88 @@
 package server
+
+import (
+	"context"
+	"github.com/jt05610/petri"
+	"github.com/jt05610/petri/control"
+	"github.com/jt05610/petri/labeled"
+	"github.com/jt05610/petri/marked"
+	"github.com/jt05610/petri/transport"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	closed, open := petri.NewPlace("closed", 1), petri.NewPlace("open", 1)
+	openT := petri.NewTransition("open")
+	net := petri.NewNet("valve").
+		WithPlaces(closed, open).
+		WithTransitions(openT).
+		WithArcs(petri.NewArc(closed, openT, "", nil), petri.NewArc(openT, open, "", nil))
+	ok := func(_ context.Context, ev *labeled.Event) (*labeled.Event, error) {
+		return ev, nil
+	}
+	for _, tc := range []struct {
+		name     string
+		handlers control.Handlers
+		wantErr  bool
+	}{
+		{"bound", control.Handlers{"open": ok}, false},
+		{"no transition", control.Handlers{"open": ok, "close": ok}, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ln := labeled.New(marked.New(net, marked.Marking{1, 0}))
+			srv, err := New(ln, transport.NewBroker(), "valve-device", "valve-1", map[string]*petri.Transition{"open": openT}, tc.handlers, zap.NewNop())
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tc.wantErr)
+			}
+			if (srv == nil) != tc.wantErr {
+				t.Errorf("got server %v", srv)
+			}
+		})
+	}
+}
+
+func TestServer_Beacon(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	broker := transport.NewBroker()
+	defer func() {
+		_ = broker.Close()
+	}()
+	sub, err := broker.Subscribe(ctx, &transport.Queue{Keys: transport.Upstream})
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed, open := petri.NewPlace("closed", 1), petri.NewPlace("open", 1)
+	openT := petri.NewTransition("open")
+	net := petri.NewNet("valve").
+		WithPlaces(closed, open).
+		WithTransitions(openT).
+		WithArcs(petri.NewArc(closed, openT, "", nil), petri.NewArc(openT, open, "", nil))
+	ln := labeled.New(marked.New(net, marked.Marking{1, 0}))
+	srv, err := New(ln, broker, "valve-device", "valve-1", map[string]*petri.Transition{}, control.Handlers{}, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.NetVersion = "v3"
+	if err := srv.publishBeacon(ctx); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case msg := <-sub.Messages():
+		data, err := (&transport.EventService{}).Load(ctx, msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m, err := control.ManifestFrom(data.Data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m == nil || m.NetVersion != "v3" || m.NetHash != control.NetHash(net) {
+			t.Fatalf("expected the manifest to carry the net's hash and version, got %+v", m)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the beacon")
+	}
+}
@@ -0,0 +1,332 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jt05610/petri/control"
+	"github.com/jt05610/petri/transport"
+	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// StartupPolicy says what a server does with a snapshot it finds when it
+// starts.
+type StartupPolicy string
+
+const (
+	// Restore picks up the marking and pending responses from the snapshot.
+	Restore StartupPolicy = "restore"
+	// Reset starts from the net's initial marking and discards the snapshot.
+	Reset StartupPolicy = "reset"
+	// Confirm refuses commands until an operator calls Confirm to choose
+	// between restoring and resetting.
+	Confirm StartupPolicy = "confirm"
+)
+
+// Valid reports whether p is one of the startup policies.
+func (p StartupPolicy) Valid() bool {
+	return p == Restore || p == Reset || p == Confirm
+}
+
+// Startup states reported in the beacon.
+const (
+	StartedFresh     = "fresh"
+	StartedRestored  = "restored"
+	StartedReset     = "reset"
+	AwaitingOperator = "awaiting_confirmation"
+)
+
+var ErrNotAwaiting = errors.New("not awaiting confirmation")
+
+// SavedReply is a response kept in a snapshot. Its marking header is kept in
+// Marking rather than Headers.
+type SavedReply struct {
+	Address       string                 `json:"address,omitempty"`
+	Key           string                 `json:"key"`
+	Headers       map[string]interface{} `json:"headers,omitempty"`
+	Marking       json.RawMessage        `json:"marking,omitempty"` // JSON would turn the header's bytes into a string
+	Body          []byte                 `json:"body"`
+	MessageID     string                 `json:"message_id,omitempty"`
+	CorrelationID string                 `json:"correlation_id,omitempty"`
+}
+
+// Snapshot is the state a server saves after every command so that a restart
+// reports where the hardware is rather than the net's initial marking.
+type Snapshot struct {
+	// NetHash guards against restoring a snapshot taken with another net.
+	NetHash string `json:"net_hash"`
+	// Marking is keyed by place name, since a restarted device may build its
+	// net with new IDs.
+	Marking map[string]int `json:"marking"`
+	// Pending are the responses to commands that had not all been published,
+	// so a redelivered command is answered without running its handler again.
+	Pending map[string][]*SavedReply `json:"pending,omitempty"`
+	SavedAt time.Time                `json:"saved_at"`
+}
+
+// SnapshotStore keeps a server's latest snapshot. Load returns nil if nothing
+// was saved.
+type SnapshotStore interface {
+	Save(snap *Snapshot) error
+	Load() (*Snapshot, error)
+}
+
+type fileStore struct {
+	path string
+}
+
+// FileStore keeps the snapshot in a JSON file. Saves write a temporary file
+// and rename it over the old one, so a crash leaves one snapshot or the other.
+func FileStore(path string) SnapshotStore {
+	return &fileStore{path: path}
+}
+
+func (f *fileStore) Save(snap *Snapshot) error {
+	bytes, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+	if _, err := tmp.Write(bytes); err != nil {
+		return errors.Join(err, tmp.Close())
+	}
+	if err := tmp.Sync(); err != nil {
+		return errors.Join(err, tmp.Close())
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
+
+func (f *fileStore) Load() (*Snapshot, error) {
+	bytes, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	snap := new(Snapshot)
+	return snap, json.Unmarshal(bytes, snap)
+}
+
+// startup tracks what the server did with its snapshot. Like the net, it is
+// only touched from the Listen loop.
+type startup struct {
+	state  string
+	loaded *Snapshot
+}
+
+// confirmation is an operator's answer to the Confirm policy.
+type confirmation struct {
+	restore bool
+	done    chan error
+}
+
+func (s *Server) snapshot() *Snapshot {
+	snap := &Snapshot{
+		NetHash: control.NetHash(s.Net.Net.Net),
+		Marking: make(map[string]int, len(s.Places)),
+		Pending: make(map[string][]*SavedReply, len(s.pending)),
+		SavedAt: time.Now(),
+	}
+	marking := s.MarkingMap()
+	for _, p := range s.Places {
+		snap.Marking[p.Name] = marking[p.ID]
+	}
+	for key, p := range s.pending {
+		saved := make([]*SavedReply, len(p.replies))
+		for i, r := range p.replies {
+			headers, marking := splitMarking(r.msg.Headers)
+			saved[i] = &SavedReply{
+				Address:       r.address,
+				Key:           r.msg.Key,
+				Headers:       headers,
+				Marking:       marking,
+				Body:          r.msg.Body,
+				MessageID:     r.msg.MessageID,
+				CorrelationID: r.msg.CorrelationID,
+			}
+		}
+		snap.Pending[key] = saved
+	}
+	return snap
+}
+
+// save writes a snapshot if the server has a store. Failing to save does not
+// stop the server; it is logged.
+func (s *Server) save() {
+	if s.Snapshots == nil {
+		return
+	}
+	if s.startup.state == AwaitingOperator {
+		// keep the snapshot until the operator has decided what to do with it
+		return
+	}
+	if err := s.Snapshots.Save(s.snapshot()); err != nil {
+		s.logger.Error("Failed to save snapshot", zap.Error(err))
+	}
+}
+
+// splitMarking takes the marking header out of a reply's headers.
+func splitMarking(headers map[string]interface{}) (map[string]interface{}, json.RawMessage) {
+	mark, ok := headers[transport.MarkingHeader].([]byte)
+	if !ok {
+		return headers, nil
+	}
+	ret := make(map[string]interface{}, len(headers)-1)
+	for k, v := range headers {
+		if k != transport.MarkingHeader {
+			ret[k] = v
+		}
+	}
+	return ret, mark
+}
+
+// joinMarking puts a saved marking back in the headers the way the codec
+// writes it.
+func joinMarking(headers map[string]interface{}, marking json.RawMessage) map[string]interface{} {
+	if marking == nil {
+		return headers
+	}
+	ret := make(map[string]interface{}, len(headers)+1)
+	for k, v := range headers {
+		ret[k] = v
+	}
+	ret[transport.MarkingHeader] = []byte(marking)
+	return ret
+}
+
+// apply restores the marking and pending responses from snap.
+func (s *Server) apply(snap *Snapshot) error {
+	marking := make(map[string]int, len(s.Places))
+	for _, p := range s.Places {
+		v, found := snap.Marking[p.Name]
+		if !found {
+			return fmt.Errorf("snapshot has no marking for %s", p.Name)
+		}
+		marking[p.ID] = v
+	}
+	if err := s.Restore(marking); err != nil {
+		return err
+	}
+	s.pending = make(map[string]*pending, len(snap.Pending))
+	for key, saved := range snap.Pending {
+		p := &pending{replies: make([]*reply, len(saved))}
+		for i, r := range saved {
+			p.replies[i] = &reply{
+				address: r.Address,
+				msg: &transport.Message{
+					Key:           r.Key,
+					Headers:       joinMarking(r.Headers, r.Marking),
+					Body:          r.Body,
+					MessageID:     r.MessageID,
+					CorrelationID: r.CorrelationID,
+				},
+			}
+		}
+		s.pending[key] = p
+	}
+	return nil
+}
+
+// start loads the snapshot and applies the startup policy.
+func (s *Server) start() error {
+	s.startup.state = StartedFresh
+	if s.Snapshots == nil {
+		return nil
+	}
+	if !s.Startup.Valid() {
+		return fmt.Errorf("invalid startup policy %q", s.Startup)
+	}
+	snap, err := s.Snapshots.Load()
+	if err != nil {
+		return err
+	}
+	if snap == nil {
+		return nil
+	}
+	if hash := control.NetHash(s.Net.Net.Net); snap.NetHash != hash {
+		s.logger.Warn("Ignoring snapshot of another net", zap.String("snapshot", snap.NetHash), zap.String("net", hash))
+		return nil
+	}
+	switch s.Startup {
+	case Restore:
+		if err := s.apply(snap); err != nil {
+			return err
+		}
+		s.startup.state = StartedRestored
+	case Reset:
+		s.Net.Reset()
+		s.startup.state = StartedReset
+	case Confirm:
+		s.startup.loaded = snap
+		s.startup.state = AwaitingOperator
+		s.logger.Warn("Waiting for an operator to confirm the device state", zap.Any("snapshot", snap.Marking))
+	}
+	return nil
+}
+
+// Confirm settles a server started with the Confirm policy, restoring the
+// snapshot or resetting to the initial marking. The server must be listening.
+func (s *Server) Confirm(ctx context.Context, restore bool) error {
+	c := &confirmation{restore: restore, done: make(chan error, 1)}
+	select {
+	case s.confirms <- c:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err := <-c.done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Server) confirm(restore bool) error {
+	if s.startup.state != AwaitingOperator {
+		return ErrNotAwaiting
+	}
+	if restore {
+		if err := s.apply(s.startup.loaded); err != nil {
+			return err
+		}
+		s.startup.state = StartedRestored
+	} else {
+		s.Net.Reset()
+		s.startup.state = StartedReset
+	}
+	s.startup.loaded = nil
+	s.logger.Info("Operator confirmed the device state", zap.Bool("restored", restore))
+	s.save()
+	return nil
+}
+
+// startupInfo is the beacon's report of the startup policy.
+func (s *Server) startupInfo() map[string]interface{} {
+	info := map[string]interface{}{
+		"state": s.startup.state,
+	}
+	if s.Snapshots != nil {
+		info["policy"] = string(s.Startup)
+	}
+	if s.startup.loaded != nil {
+		info["snapshot"] = s.startup.loaded.Marking
+	}
+	return info
+}
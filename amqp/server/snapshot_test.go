@@ -0,0 +1,258 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"github.com/jt05610/petri"
+	"github.com/jt05610/petri/amqp/client"
+	"github.com/jt05610/petri/amqp/server"
+	"github.com/jt05610/petri/control"
+	"github.com/jt05610/petri/labeled"
+	"github.com/jt05610/petri/marked"
+	"github.com/jt05610/petri/transport"
+	"go.uber.org/zap"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// valve opens and closes. A fresh valve is closed.
+func valve(t *testing.T, broker transport.Transport, store server.SnapshotStore, policy server.StartupPolicy) (*server.Server, *petri.Place) {
+	t.Helper()
+	closed, open := petri.NewPlace("closed", 1), petri.NewPlace("open", 1)
+	openT, closeT := petri.NewTransition("open"), petri.NewTransition("close")
+	net := petri.NewNet("valve").
+		WithPlaces(closed, open).
+		WithTransitions(openT, closeT).
+		WithArcs(
+			petri.NewArc(closed, openT, "", nil),
+			petri.NewArc(openT, open, "", nil),
+			petri.NewArc(open, closeT, "", nil),
+			petri.NewArc(closeT, closed, "", nil),
+		)
+	ln := labeled.New(marked.New(net, marked.Marking{1, 0}))
+	ln.Events = []*labeled.Event{{ID: "open-id", Name: "open"}, {ID: "close-id", Name: "close"}}
+	ok := func(_ context.Context, ev *labeled.Event) (*labeled.Event, error) {
+		return &labeled.Event{Name: ev.Name, Data: map[string]interface{}{}}, nil
+	}
+	srv, err := server.New(ln, broker, "valve-device", "valve-1", map[string]*petri.Transition{"open": openT, "close": closeT}, control.Handlers{"open": ok, "close": ok}, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.Snapshots, srv.Startup = store, policy
+	return srv, open
+}
+
+// listen runs srv until the returned function is called.
+func listen(t *testing.T, srv *server.Server) (stop func()) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.Listen(ctx)
+	}()
+	return func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// beacon asks for the valve's beacon until it answers.
+func beacon(t *testing.T, broker *transport.Broker) *control.Event {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	sub, err := broker.Subscribe(ctx, &transport.Queue{Keys: []string{"*.device.*"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for {
+		if err := broker.Publish(ctx, &transport.Message{Key: "devices"}); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case m := <-sub.Messages():
+			ev, err := (&transport.EventService{}).Load(ctx, m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return ev
+		case <-time.After(50 * time.Millisecond):
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for a beacon")
+		}
+	}
+}
+
+func startup(ev *control.Event) map[string]interface{} {
+	info, _ := ev.Data["startup"].(map[string]interface{})
+	return info
+}
+
+func call(t *testing.T, broker *transport.Broker, name string) error {
+	t.Helper()
+	c := client.NewController(zap.NewNop(), broker)
+	defer c.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := c.Listen(ctx); err != nil {
+		t.Fatal(err)
+	}
+	_, err := c.Call(ctx, &control.Command{
+		Event: &labeled.Event{ID: name + "-id", Name: name, Data: map[string]interface{}{}},
+		To:    "valve-1",
+	})
+	return err
+}
+
+func TestFileStore(t *testing.T) {
+	store := server.FileStore(filepath.Join(t.TempDir(), "state", "valve.json"))
+	snap, err := store.Load()
+	if err != nil || snap != nil {
+		t.Fatalf("expected no snapshot, got %v %v", snap, err)
+	}
+	want := &server.Snapshot{NetHash: "hash", Marking: map[string]int{"open": 1}}
+	if err := store.Save(want); err != nil {
+		t.Fatal(err)
+	}
+	snap, err = store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snap.NetHash != "hash" || snap.Marking["open"] != 1 {
+		t.Fatalf("unexpected snapshot %+v", snap)
+	}
+}
+
+func TestServer_Startup(t *testing.T) {
+	broker := transport.NewBroker()
+	defer func() {
+		_ = broker.Close()
+	}()
+	store := server.FileStore(filepath.Join(t.TempDir(), "valve.json"))
+
+	srv, _ := valve(t, broker, store, server.Restore)
+	stop := listen(t, srv)
+	if info := startup(beacon(t, broker)); info["state"] != server.StartedFresh || info["policy"] != string(server.Restore) {
+		t.Fatalf("expected a fresh start, got %v", info)
+	}
+	if err := call(t, broker, "open"); err != nil {
+		t.Fatal(err)
+	}
+	stop()
+
+	// the restarted device builds its net again, with new IDs
+	srv, open := valve(t, broker, store, server.Restore)
+	stop = listen(t, srv)
+	ev := beacon(t, broker)
+	if info := startup(ev); info["state"] != server.StartedRestored {
+		t.Fatalf("expected the snapshot to be restored, got %v", info)
+	}
+	if ev.Marking[open.ID] != 1 {
+		t.Fatalf("expected the valve to still be open, got %v", ev.Marking)
+	}
+	stop()
+
+	srv, open = valve(t, broker, store, server.Confirm)
+	stop = listen(t, srv)
+	defer stop()
+	if info := startup(beacon(t, broker)); info["state"] != server.AwaitingOperator || info["policy"] != string(server.Confirm) {
+		t.Fatalf("expected to wait for the operator, got %v", info)
+	}
+	var cErr *control.Error
+	if err := call(t, broker, "close"); !errors.As(err, &cErr) || cErr.Kind != control.Unconfirmed {
+		t.Fatalf("expected commands to be refused, got %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.Confirm(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.Confirm(ctx, false); !errors.Is(err, server.ErrNotAwaiting) {
+		t.Fatalf("expected a second confirmation to fail, got %v", err)
+	}
+	ev = beacon(t, broker)
+	if info := startup(ev); info["state"] != server.StartedReset || ev.Marking[open.ID] != 0 {
+		t.Fatalf("expected the valve to be reset, got %v %v", info, ev.Marking)
+	}
+	if err := call(t, broker, "open"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// flaky fails to send replies while fail is set.
+type flaky struct {
+	*transport.Broker
+	fail atomic.Bool
+}
+
+func (f *flaky) Send(ctx context.Context, address string, msg *transport.Message) error {
+	if f.fail.Load() {
+		return errors.New("connection lost")
+	}
+	return f.Broker.Send(ctx, address, msg)
+}
+
+func TestServer_ReplaySnapshot(t *testing.T) {
+	broker := transport.NewBroker()
+	defer func() {
+		_ = broker.Close()
+	}()
+	link := &flaky{Broker: broker}
+	link.fail.Store(true)
+	store := server.FileStore(filepath.Join(t.TempDir(), "valve.json"))
+
+	srv, open := valve(t, link, store, server.Restore)
+	srv.MaxRedeliveries = 1 << 20
+	stop := listen(t, srv)
+	beacon(t, broker)
+	c := client.NewController(zap.NewNop(), broker)
+	defer c.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := c.Listen(ctx); err != nil {
+		t.Fatal(err)
+	}
+	answered := make(chan *control.Event, 1)
+	go func() {
+		ev, err := c.Call(ctx, &control.Command{
+			Event: &labeled.Event{ID: "open-id", Name: "open", Data: map[string]interface{}{}},
+			To:    "valve-1",
+		})
+		if err != nil {
+			t.Error(err)
+		}
+		answered <- ev
+	}()
+	// the valve opens, but its answer is stuck in the snapshot
+	for {
+		snap, err := store.Load()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if snap != nil && len(snap.Pending) > 0 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for the answer to be saved")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	stop()
+
+	link.fail.Store(false)
+	srv, _ = valve(t, link, store, server.Restore)
+	defer listen(t, srv)()
+	select {
+	case ev := <-answered:
+		if ev == nil || ev.Marking[open.ID] != 1 {
+			t.Fatalf("expected the replayed answer to carry the open marking, got %v", ev)
+		}
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for the replayed answer")
+	}
+}
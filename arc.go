@@ -108,13 +108,16 @@ func StripNodeToID(node Node) Node {
 }
 
 func (a *Arc) Document() Document {
-	return Document{
-		"_id":          a.ID,
-		"src":          &NodeMeta{ID: a.Src.Identifier(), Kind: a.Src.Kind()},
-		"dest":         &NodeMeta{ID: a.Dest.Identifier(), Kind: a.Dest.Kind()},
-		"expression":   a.Expression,
-		"outputSchema": &TokenSchema{ID: a.OutputSchema.ID},
+	doc := Document{
+		"_id":        a.ID,
+		"src":        &NodeMeta{ID: a.Src.Identifier(), Kind: a.Src.Kind()},
+		"dest":       &NodeMeta{ID: a.Dest.Identifier(), Kind: a.Dest.Kind()},
+		"expression": a.Expression,
 	}
+	if a.OutputSchema != nil {
+		doc["outputSchema"] = &TokenSchema{ID: a.OutputSchema.ID}
+	}
+	return doc
 }
 
 func MakeNode(k Kind, id string) Node {
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"github.com/jt05610/petri/access"
+	"github.com/jt05610/petri/prisma/db"
+)
+
+// authors reads the author prisma records for nets, devices and sequences.
+// Prisma authors are users of the web app, which petrid knows by their email.
+type authors struct {
+	client *db.PrismaClient
+}
+
+var _ access.Authors = (*authors)(nil)
+
+func (a *authors) authorID(ctx context.Context, res access.Resource, id string) (string, error) {
+	switch res {
+	case access.NetResource:
+		n, err := a.client.Net.FindUnique(db.Net.ID.Equals(id)).Exec(ctx)
+		if err != nil {
+			return "", err
+		}
+		return n.AuthorID, nil
+	case access.DeviceResource:
+		d, err := a.client.Device.FindUnique(db.Device.ID.Equals(id)).Exec(ctx)
+		if err != nil {
+			return "", err
+		}
+		return d.AuthorID, nil
+	case access.SequenceResource:
+		r, err := a.client.Run.FindUnique(db.Run.ID.Equals(id)).Exec(ctx)
+		if err != nil {
+			return "", err
+		}
+		return r.AuthorID, nil
+	}
+	return "", nil
+}
+
+func (a *authors) Author(ctx context.Context, res access.Resource, id string) (string, error) {
+	authorID, err := a.authorID(ctx, res, id)
+	if errors.Is(err, db.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil || authorID == "" {
+		return "", err
+	}
+	u, err := a.client.User.FindUnique(db.User.ID.Equals(authorID)).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return u.Email, nil
+}
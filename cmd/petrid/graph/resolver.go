@@ -3,12 +3,19 @@ package graph
 import (
 	"context"
 	"errors"
+	"github.com/jt05610/petri"
+	"github.com/jt05610/petri/access"
 	"github.com/jt05610/petri/amqp/client"
 	"github.com/jt05610/petri/cmd/petrid/graph/model"
 	"github.com/jt05610/petri/control"
+	"github.com/jt05610/petri/datastore"
 	prisma "github.com/jt05610/petri/db"
+	"github.com/jt05610/petri/marked"
 	"github.com/jt05610/petri/prisma/db"
+	"github.com/jt05610/petri/sequence"
 	"log"
+	"maps"
+	"sync"
 	"time"
 )
 
@@ -18,27 +25,37 @@ import (
 
 type Resolver struct {
 	*prisma.SessionClient
-	*prisma.RunClient
-	*prisma.NetClient
 	*client.Controller
-	dataCh        chan *control.Event
-	sessionEvents map[string][]*model.Event
-	seenEvents    map[string]int
-	recordCtx     context.Context
-	recordCancel  context.CancelFunc
-	runCtx        context.Context
-	runCancel     context.CancelFunc
+	access *access.Checker
+	// sequences and nets check every load against the caller
+	sequences sequence.Service
+	nets      *access.Nets
+	versions  datastore.NetVersions
+	// sessionVersions maps each session created since petrid started to the
+	// version of the net it was created with.
+	sessionVersions map[string]string
+	versionMu       sync.Mutex
+	dataCh          chan *control.Event
+	sessionEvents   map[string][]*model.Event
+	seenEvents      map[string]int
+	recordCtx       context.Context
+	recordCancel    context.CancelFunc
+	runCtx          context.Context
+	runCancel       context.CancelFunc
 }
 
-func NewResolver(cl *db.PrismaClient, controller *client.Controller) *Resolver {
+func NewResolver(cl *db.PrismaClient, controller *client.Controller, checker *access.Checker, versions datastore.NetVersions) *Resolver {
 	r := &Resolver{
-		SessionClient: &prisma.SessionClient{PrismaClient: cl},
-		RunClient:     &prisma.RunClient{PrismaClient: cl},
-		NetClient:     &prisma.NetClient{PrismaClient: cl},
-		Controller:    controller,
-		sessionEvents: make(map[string][]*model.Event),
-		runCtx:        context.Background(),
-		recordCtx:     context.Background(),
+		SessionClient:   &prisma.SessionClient{PrismaClient: cl},
+		Controller:      controller,
+		access:          checker,
+		sequences:       &access.Sequences{Service: &prisma.RunClient{PrismaClient: cl}, Checker: checker},
+		nets:            &access.Nets{NetLoader: &prisma.NetClient{PrismaClient: cl}, Checker: checker},
+		versions:        versions,
+		sessionVersions: make(map[string]string),
+		sessionEvents:   make(map[string][]*model.Event),
+		runCtx:          context.Background(),
+		recordCtx:       context.Background(),
 	}
 	return r
 }
@@ -67,10 +84,11 @@ func (r *Resolver) eventHistory(ctx context.Context, sessionID string) ([]*model
 	return dModel, nil
 }
 
-func (r *Resolver) start(sessionId string) *model.Event {
+// record stores the events the controller relays in the session.
+func (r *Resolver) record(sessionId string) {
 	r.runCtx, r.runCancel = context.WithCancel(r.runCtx)
 	ch := make(chan *control.Event)
-	r.Controller.ChannelData(ch)
+	r.Controller.ChannelData(r.runCtx, ch)
 	go func() {
 		for {
 			select {
@@ -88,11 +106,18 @@ func (r *Resolver) start(sessionId string) *model.Event {
 			}
 		}
 	}()
-	r.Start(r.runCtx)
+}
+
+func (r *Resolver) start(sessionId string) (*model.Event, error) {
+	r.record(sessionId)
+	if err := r.Start(r.runCtx); err != nil {
+		r.runCancel()
+		return nil, err
+	}
 	return &model.Event{
 		Name: "start",
 		Data: nil,
-	}
+	}, nil
 }
 
 func (r *Resolver) stopRecording() {
@@ -112,3 +137,66 @@ func (r *Resolver) newEvents(sessionID string) ([]*model.Event, error) {
 	r.seenEvents[sessionID] = len(events)
 	return newEvents, nil
 }
+
+// checkSession returns access.ErrDenied unless the identity on ctx may run the
+// sequence of the session.
+func (r *Resolver) checkSession(ctx context.Context, sessionID string) error {
+	session, err := r.SessionClient.Load(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	return r.access.Check(ctx, access.SequenceResource, session.RunID, access.Run)
+}
+
+// pin returns the version of the net that sessions created now run on, adding
+// a version if the net or its initial marking changed since the latest one.
+func (r *Resolver) pin(ctx context.Context, net *marked.Net) (*datastore.NetVersion, error) {
+	latest, err := r.versions.LatestNetVersion(ctx, net.ID)
+	if err != nil && !errors.Is(err, datastore.ErrNotFound) {
+		return nil, err
+	}
+	marking := net.MarkingMap()
+	if latest != nil && petri.Diff(latest.Net(), net.Net).Empty() && maps.Equal(latest.Marking, marking) {
+		return latest, nil
+	}
+	input := &petri.NetVersionInput{Net: net.Net, Message: "used by a session"}
+	if latest != nil {
+		input.Parent = latest.NetVersion
+	}
+	v := &datastore.NetVersion{NetVersion: input.Object().(*petri.NetVersion), Marking: marking}
+	return v, r.versions.AddNetVersion(ctx, v)
+}
+
+// applyVersion runs seq on the given version of its net rather than on the net
+// as it is now.
+func (r *Resolver) applyVersion(ctx context.Context, seq *sequence.Sequence, versionID string) error {
+	v, err := r.versions.NetVersion(ctx, versionID)
+	if err != nil {
+		return err
+	}
+	if err := seq.ApplyNet(marked.NewFromMap(v.Net(), v.Marking)); err != nil {
+		return err
+	}
+	seq.NetVersionID = v.ID
+	return nil
+}
+
+// sessionVersion returns the version of the net the session was created with.
+// Sessions created before petrid restarted are pinned to the net as it is now.
+func (r *Resolver) sessionVersion(ctx context.Context, sessionID string, seq *sequence.Sequence) (string, error) {
+	r.versionMu.Lock()
+	id, found := r.sessionVersions[sessionID]
+	r.versionMu.Unlock()
+	if found {
+		return id, nil
+	}
+	net, err := r.nets.Load(ctx, seq.NetID)
+	if err != nil {
+		return "", err
+	}
+	v, err := r.pin(ctx, net)
+	if err != nil {
+		return "", err
+	}
+	return v.ID, nil
+}
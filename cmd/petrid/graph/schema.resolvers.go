@@ -10,8 +10,12 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/jt05610/petri/access"
+	"github.com/jt05610/petri/amqp/client"
 	"github.com/jt05610/petri/cmd/petrid/graph/generated"
 	"github.com/jt05610/petri/cmd/petrid/graph/model"
+	"github.com/jt05610/petri/datastore"
+	"github.com/jt05610/petri/middleware"
 	"github.com/jt05610/petri/prisma/db"
 )
 
@@ -21,10 +25,24 @@ func (r *mutationResolver) StartSession(ctx context.Context, input model.StartSe
 	if err != nil {
 		return nil, err
 	}
-	sequence, err := r.RunClient.Load(ctx, session.RunID)
+	sequence, err := r.sequences.Load(ctx, session.RunID)
 	if err != nil {
 		return nil, err
 	}
+	// a run grant lets users set sessions up on shared instances, but only
+	// admins and the devices' owners may start them
+	for _, dev := range sequence.Devices() {
+		if err := r.access.CheckOwner(ctx, access.DeviceResource, dev.ID); err != nil {
+			return nil, err
+		}
+	}
+	versionID, err := r.sessionVersion(ctx, input.SessionID, sequence)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.applyVersion(ctx, sequence, versionID); err != nil {
+		return nil, err
+	}
 	r.Sequence = sequence
 	r.Sequence.ExtractParameters()
 	err = r.Sequence.ApplyParameters(input.Parameters)
@@ -35,18 +53,32 @@ func (r *mutationResolver) StartSession(ctx context.Context, input model.StartSe
 	if err != nil {
 		return nil, err
 	}
-	return r.start(input.SessionID), nil
+	r.SessionID, r.Parameters = input.SessionID, input.Parameters
+	return r.start(input.SessionID)
 }
 
 // NewSession is the resolver for the newSession field.
 func (r *mutationResolver) NewSession(ctx context.Context, input model.NewSessionInput) (*model.Session, error) {
-	run, err := r.RunClient.Load(ctx, input.SequenceID)
+	user := middleware.IdentityFrom(ctx)
+	if user == nil {
+		return nil, middleware.ErrUnauthenticated
+	}
+	run, err := r.sequences.Load(ctx, input.SequenceID)
 	if err != nil {
 		return nil, err
 	}
 	devices := run.Devices()
+	for _, dev := range devices {
+		if err := r.access.Check(ctx, access.DeviceResource, dev.ID, access.Run); err != nil {
+			return nil, err
+		}
+	}
 	r.Sequence = run
-	net, err := r.NetClient.Load(ctx, run.NetID)
+	net, err := r.nets.Load(ctx, run.NetID)
+	if err != nil {
+		return nil, err
+	}
+	version, err := r.pin(ctx, net)
 	if err != nil {
 		return nil, err
 	}
@@ -54,6 +86,7 @@ func (r *mutationResolver) NewSession(ctx context.Context, input model.NewSessio
 	if err != nil {
 		return nil, err
 	}
+	r.Sequence.NetVersionID = version.ID
 	r.Sequence.ExtractParameters()
 	if len(input.Instances) != len(devices) {
 		return nil, errors.New("wrong number of instances")
@@ -63,14 +96,19 @@ func (r *mutationResolver) NewSession(ctx context.Context, input model.NewSessio
 		if _, ok := r.Known[inst.DeviceID]; !ok {
 			return nil, errors.New("unknown device")
 		}
-		r.Routes[inst.DeviceID] = r.Known[inst.DeviceID][inst.InstanceID]
+		if err := r.Bind(inst.DeviceID, inst.InstanceID); err != nil {
+			return nil, err
+		}
 		instanceIDs[i] = inst.InstanceID
 	}
 
-	s, err := r.CreateSession(ctx, input.SequenceID, input.UserID, instanceIDs)
+	s, err := r.CreateSession(ctx, input.SequenceID, user.User, instanceIDs)
 	if err != nil {
 		return nil, err
 	}
+	r.versionMu.Lock()
+	r.sessionVersions[s.ID] = version.ID
+	r.versionMu.Unlock()
 	return &model.Session{
 		ID:        s.ID,
 		UserID:    s.UserID,
@@ -83,6 +121,14 @@ func (r *mutationResolver) NewSession(ctx context.Context, input model.NewSessio
 
 // StopSession is the resolver for the stopSession field.
 func (r *mutationResolver) StopSession(ctx context.Context, sessionID string) (*model.Session, error) {
+	if err := r.checkSession(ctx, sessionID); err != nil {
+		return nil, err
+	}
+	if !r.State().Idle() {
+		if err := r.Stop(); err != nil {
+			return nil, err
+		}
+	}
 	s, err := r.SessionClient.StopSession(ctx, sessionID, time.Now())
 	if err != nil {
 		return nil, err
@@ -102,6 +148,12 @@ func (r *mutationResolver) StopSession(ctx context.Context, sessionID string) (*
 
 // PauseSession is the resolver for the pauseSession field.
 func (r *mutationResolver) PauseSession(ctx context.Context, sessionID string) (*model.Session, error) {
+	if err := r.checkSession(ctx, sessionID); err != nil {
+		return nil, err
+	}
+	if err := r.Pause(); err != nil {
+		return nil, err
+	}
 	s, err := r.SessionClient.PauseSession(ctx, sessionID, time.Now())
 	if err != nil {
 		return nil, err
@@ -115,7 +167,20 @@ func (r *mutationResolver) PauseSession(ctx context.Context, sessionID string) (
 }
 
 // ResumeSession is the resolver for the resumeSession field.
-func (r *mutationResolver) ResumeSession(ctx context.Context, sessionID string) (*model.Session, error) {
+func (r *mutationResolver) ResumeSession(ctx context.Context, sessionID string, force *bool) (*model.Session, error) {
+	if err := r.checkSession(ctx, sessionID); err != nil {
+		return nil, err
+	}
+	resume := r.Resume
+	if force != nil && *force {
+		if err := middleware.RequireRole(ctx, datastore.AdminRole); err != nil {
+			return nil, err
+		}
+		resume = r.ForceResume
+	}
+	if err := resume(); err != nil {
+		return nil, err
+	}
 	s, err := r.SessionClient.ResumeSession(ctx, sessionID, time.Now())
 	if err != nil {
 		return nil, err
@@ -128,6 +193,92 @@ func (r *mutationResolver) ResumeSession(ctx context.Context, sessionID string)
 	}, nil
 }
 
+// AbortSession is the resolver for the abortSession field.
+func (r *mutationResolver) AbortSession(ctx context.Context, sessionID string) (*model.Session, error) {
+	if err := r.checkSession(ctx, sessionID); err != nil {
+		return nil, err
+	}
+	abortErr := r.Abort(ctx)
+	s, err := r.SessionClient.StopSession(ctx, sessionID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if abortErr != nil {
+		return nil, abortErr
+	}
+	events, err := r.eventHistory(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.Session{
+		ID:     s.ID,
+		UserID: s.UserID,
+		RunID:  s.RunID,
+		Active: false,
+		Events: events,
+	}, nil
+}
+
+// RecoverSession is the resolver for the recoverSession field.
+func (r *mutationResolver) RecoverSession(ctx context.Context, sessionID string) (*model.Session, error) {
+	if err := middleware.RequireRole(ctx, datastore.AdminRole); err != nil {
+		return nil, err
+	}
+	recoveries, err := r.Interrupted(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var rec *client.Recovery
+	for _, candidate := range recoveries {
+		if candidate.SessionID == sessionID {
+			rec = candidate
+		}
+	}
+	if rec == nil {
+		return nil, fmt.Errorf("session %s was not interrupted", sessionID)
+	}
+	session, err := r.SessionClient.Load(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	sequence, err := r.sequences.Load(ctx, session.RunID)
+	if err != nil {
+		return nil, err
+	}
+	// resume on the net the session started with, even if it was edited since
+	versionID := rec.NetVersionID
+	if versionID == "" {
+		versionID, err = r.sessionVersion(ctx, sessionID, sequence)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := r.applyVersion(ctx, sequence, versionID); err != nil {
+		return nil, err
+	}
+	sequence.ExtractParameters()
+	if err := sequence.ApplyParameters(rec.Parameters); err != nil {
+		return nil, err
+	}
+	r.Sequence = sequence
+	r.record(sessionID)
+	if err := r.Recover(r.runCtx, rec); err != nil {
+		r.runCancel()
+		return nil, err
+	}
+	events, err := r.eventHistory(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.Session{
+		ID:     session.ID,
+		UserID: session.UserID,
+		RunID:  session.RunID,
+		Active: true,
+		Events: events,
+	}, nil
+}
+
 // ActiveSessions is the resolver for the activeSessions field.
 func (r *queryResolver) ActiveSessions(ctx context.Context) ([]*model.Session, error) {
 	s, err := r.SessionClient.ActiveSessions(ctx)
@@ -201,6 +352,13 @@ func (r *queryResolver) Instances(ctx context.Context, runID string) ([]*model.I
 func (r *queryResolver) Devices(ctx context.Context, filter *string) ([]*model.Device, error) {
 	ret := make([]*model.Device, 0)
 	for deviceID, instances := range r.Known {
+		ok, err := r.access.Allowed(ctx, access.DeviceResource, deviceID, access.Read)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
 		devInstances := make([]*model.Instance, len(instances))
 		i := 0
 		for _, instance := range instances {
@@ -235,6 +393,27 @@ func (r *queryResolver) DeviceMarkings(ctx context.Context, input model.DeviceMa
 	return ret, nil
 }
 
+// InterruptedSessions is the resolver for the interruptedSessions field.
+func (r *queryResolver) InterruptedSessions(ctx context.Context) ([]*model.InterruptedSession, error) {
+	recoveries, err := r.Interrupted(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*model.InterruptedSession, len(recoveries))
+	for i, rec := range recoveries {
+		ret[i] = &model.InterruptedSession{
+			SessionID:  rec.SessionID,
+			State:      rec.State,
+			Step:       rec.Step,
+			ResumeStep: rec.ResumeStep,
+			Resumable:  rec.Resumable(),
+			Problems:   rec.Problems,
+			UpdatedAt:  rec.UpdatedAt.Format(time.RFC3339Nano),
+		}
+	}
+	return ret, nil
+}
+
 // NewEvents is the resolver for the newEvents field.
 func (r *queryResolver) NewEvents(ctx context.Context, sessionID string) ([]*model.Event, error) {
 	return r.newEvents(sessionID)
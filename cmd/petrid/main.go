@@ -8,15 +8,22 @@ import (
 	"github.com/99designs/gqlgen/graphql/handler/lru"
 	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/jt05610/petri/access"
+	"github.com/jt05610/petri/amqp"
 	"github.com/jt05610/petri/amqp/client"
 	"github.com/jt05610/petri/cmd/petrid/graph"
 	"github.com/jt05610/petri/cmd/petrid/graph/generated"
+	"github.com/jt05610/petri/datastore"
+	"github.com/jt05610/petri/middleware"
+	"github.com/jt05610/petri/mqtt"
 	"github.com/jt05610/petri/prisma/db"
-	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/jt05610/petri/sqlite"
+	petriTransport "github.com/jt05610/petri/transport"
 	"go.uber.org/zap"
 	"log"
 	"net/http"
 	"os"
+	"time"
 )
 
 func main() {
@@ -37,25 +44,99 @@ func main() {
 	if !found {
 		panic(errors.New("RABBITMQ_URI not set"))
 	}
-	conn, err := amqp.Dial(uri)
+	link, err := amqp.Connect(uri, logger)
 	if err != nil {
 		panic(err)
 	}
-	ch, err := conn.Channel()
+	defer func() {
+		_ = link.Close()
+	}()
+	exchange, found := os.LookupEnv("AMQP_EXCHANGE")
+	if !found {
+		panic(errors.New("AMQP_EXCHANGE not set"))
+	}
+	secret, found := os.LookupEnv("PETRID_SECRET")
+	if !found {
+		panic(errors.New("PETRID_SECRET not set"))
+	}
+	dataDir, found := os.LookupEnv("PETRID_DATA")
+	if !found {
+		dataDir = "data"
+	}
+	store, err := sqlite.Open(dataDir)
 	if err != nil {
 		panic(err)
 	}
-	exchange, found := os.LookupEnv("AMQP_EXCHANGE")
+	defer func() {
+		_ = store.Close()
+	}()
+	if pass, found := os.LookupEnv("PETRID_ADMIN_PASSWORD"); found {
+		err := store.CreateUser(context.Background(), "admin", pass, datastore.AdminRole)
+		if err != nil && !errors.Is(err, datastore.ErrExists) {
+			panic(err)
+		}
+	}
+	// runs, their events and device telemetry are kept in a database of the
+	// store
+	dbName, found := os.LookupEnv("PETRID_DB")
 	if !found {
-		panic(errors.New("AMQP_EXCHANGE not set"))
+		dbName = "petrid"
 	}
-	controller := client.NewController(logger, ch, exchange)
+	if err := store.CreateDb(context.Background(), dbName); err != nil && !errors.Is(err, datastore.ErrExists) {
+		panic(err)
+	}
+	data, err := store.Load(context.Background(), dbName)
+	if err != nil {
+		panic(err)
+	}
+	defer func() {
+		_ = data.Close()
+	}()
+	tokens := middleware.NewTokens([]byte(secret), 12*time.Hour)
+	topology := amqp.DefaultTopology(exchange)
+	if durable, found := os.LookupEnv("AMQP_DURABLE"); found {
+		topology.Durable = durable != "false"
+	}
+	t, err := amqp.NewTransport(context.Background(), link, topology)
+	if err != nil {
+		panic(err)
+	}
+	if mqttURI, found := os.LookupEnv("MQTT_URI"); found {
+		// devices that only speak MQTT reach the controller through a pair
+		// of bridges
+		remote, err := mqtt.Connect(mqttURI, &mqtt.Topology{Prefix: exchange, ClientID: "petrid"}, logger)
+		if err != nil {
+			panic(err)
+		}
+		defer func() {
+			_ = remote.Close()
+		}()
+		bridge := func(from, to petriTransport.Transport, q *petriTransport.Queue) {
+			if err := petriTransport.Bridge(context.Background(), from, to, q); err != nil {
+				logger.Error("Bridge stopped", zap.Strings("keys", q.Keys), zap.Error(err))
+			}
+		}
+		go bridge(t, remote, &petriTransport.Queue{Name: "mqtt.downstream", Keys: petriTransport.Downstream})
+		go bridge(remote, t, &petriTransport.Queue{Name: "mqtt.upstream", Keys: petriTransport.Upstream})
+	}
+	controller := client.NewController(logger, t)
 	defer controller.Close()
-	controller.Listen(context.Background())
+	// checkpoints let sessions interrupted by a restart be resumed
+	controller.Journal = store
+	controller.Data = data
+	// devices built before manifests can only be used by opting in
+	if trust, found := os.LookupEnv("PETRID_TRUST_UNMANIFESTED"); found {
+		controller.TrustUnmanifested = trust != "false"
+	}
+	if err := controller.Listen(context.Background()); err != nil {
+		panic(err)
+	}
+	// resources created in the web app belong to their prisma author
+	checker := access.NewChecker(access.WithAuthors(store, &authors{client: dbClient}))
 	srv := handler.New(
 		generated.NewExecutableSchema(
 			generated.Config{
-				Resolvers: graph.NewResolver(dbClient, controller),
+				Resolvers: graph.NewResolver(dbClient, controller, checker, store),
 			},
 		),
 	)
@@ -78,7 +159,8 @@ func main() {
 		return errors.New("user message on panic")
 	})
 
-	http.Handle("/", srv)
+	http.Handle("/", tokens.RequireUser(srv.ServeHTTP))
+	http.Handle("/login", tokens.Login(store))
 	http.Handle("/playground", playground.Handler("Session", "/api/"))
 	http.Handle("/schema", http.FileServer(http.Dir("public")))
 	log.Fatal(http.ListenAndServe(":8081", nil))
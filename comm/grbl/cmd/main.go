@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"github.com/joho/godotenv"
 	"github.com/jt05610/petri/amqp"
+	"github.com/jt05610/petri/comm/grbl"
 	"github.com/jt05610/petri/comm/grbl/proto/v1"
 	"github.com/jt05610/petri/comm/grbl/server"
+	"github.com/jt05610/petri/comm/grbl/sim"
 	"github.com/jt05610/petri/comm/serial"
 	"github.com/jt05610/petri/devices/grbl/aqueouspump"
 	"github.com/jt05610/petri/devices/grbl/rheoten"
@@ -84,7 +86,13 @@ func main() {
 		logger.Fatal("Failed to load .env", zap.Error(err))
 	}
 	environ := load()
-	port, err := serial.OpenPort(environ.SerialPort, environ.Baud)
+	// a SERIAL_PORT of sim runs against a simulated controller instead of hardware
+	environ.SerialPort, err = serial.SimulateSpec(context.Background(), environ.SerialPort, sim.New())
+	if err != nil {
+		logger.Fatal("Failed to start simulator", zap.Error(err))
+	}
+	// SERIAL_PORT is a path or a USB device such as usb:0403:6001:A10KZP4S
+	port, err := serial.Open(environ.SerialPort, environ.Baud)
 	if err != nil {
 		logger.Fatal("Failed to open port", zap.Error(err))
 	}
@@ -98,13 +106,21 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	go s.RunHeartbeat(ctx)
+	if path, found := os.LookupEnv("SETTINGS_PROFILE"); found {
+		profile, err := grbl.LoadProfileFile(path)
+		if err != nil {
+			logger.Fatal("Failed to load settings profile", zap.Error(err))
+		}
+		if _, err := s.Configure(ctx, profile); err != nil {
+			logger.Fatal("Failed to apply settings profile", zap.Error(err))
+		}
+	}
 	_, err = s.Home(ctx, &v1.HomeRequest{})
-	s.TxChan <- []byte("G55\n")
 	if err != nil {
 		logger.Fatal("Failed to home", zap.Error(err))
 	}
-	for !s.Cts.Load() {
-
+	if err := s.Send(ctx, []byte("G55\n")); err != nil {
+		logger.Fatal("Failed to select work coordinates", zap.Error(err))
 	}
 	resp, err := s.FloodOn(ctx, &v1.FloodOnRequest{})
 	logger.Info("Flood on", zap.Any("resp", resp))
@@ -122,9 +138,9 @@ func main() {
 			logger.Fatal("Failed to serve grpc", zap.Error(err))
 		}
 	}()
-	connections := make([]*amqp.Connection, 2)
+	connections := make([]*amqp.Link, 2)
 	for i := 0; i < 2; i++ {
-		conn, err := amqp.Dial(amqpEnv())
+		conn, err := amqp.Dial(amqpEnv(), logger)
 		if err != nil {
 			logger.Fatal("Failed to dial amqp", zap.Error(err))
 		}
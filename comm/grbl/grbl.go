@@ -1 +1,657 @@
 package grbl
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Position is an axis position in mm.
+type Position struct {
+	X float32
+	Y float32
+	Z float32
+}
+
+// StatusUpdate is one line received from the controller.
+type StatusUpdate interface {
+	IsStatusUpdate()
+}
+
+// Override is the feed, rapid and spindle overrides, in percent.
+type Override struct {
+	Feed    int
+	Rapid   int
+	Spindle int
+}
+
+// LimitPins are the input pins reported as triggered by Pn.
+type LimitPins struct {
+	X          bool
+	Y          bool
+	Z          bool
+	Probe      bool
+	Door       bool
+	Hold       bool
+	SoftReset  bool
+	CycleStart bool
+}
+
+// Buffer is the free space in the planner and serial RX buffers.
+type Buffer struct {
+	Blocks int
+	Bytes  int
+}
+
+// Active are the accessories reported on by A.
+type Active struct {
+	Spindle    bool
+	SpindleCCW bool
+	Flood      bool
+	Mist       bool
+}
+
+// Status is a real-time status report. Fields the report did not include are
+// left nil; GRBL only sends WCO and Ov every few reports.
+type Status struct {
+	// State is the machine state in lower case, such as "idle", "run" or
+	// "alarm".
+	State string
+	// SubState is the code after Hold and Door states.
+	SubState        *int
+	Error           *Error
+	Alarm           *Alarm
+	MachinePosition *Position
+	WorkPosition    *Position
+	// WorkOffset is the work coordinate offset, so that MPos = WPos + WCO.
+	WorkOffset *Position
+	Feed       float32
+	Spindle    float32
+	Override   *Override
+	LimitPins  *LimitPins
+	Buffer     *Buffer
+	Line       *int
+	Active     *Active
+}
+
+func (s *Status) IsStatusUpdate() {}
+
+type Ack struct {
+}
+
+func (a *Ack) String() string {
+	return "ok"
+}
+
+func (a *Ack) IsStatusUpdate() {}
+
+// Error is the code of an error:N response to a command.
+type Error int
+
+func (e Error) IsStatusUpdate() {}
+
+// Alarm is the code of an ALARM:N message.
+type Alarm int
+
+func (a Alarm) IsStatusUpdate() {}
+
+// Message is the text of a [MSG:] feedback message.
+type Message string
+
+func (m Message) IsStatusUpdate() {}
+
+// ParserState is the G-code parser state reported by [GC:] in response to $G.
+type ParserState struct {
+	// Modes are the active modal G and M words, such as G54 or M5.
+	Modes   []string
+	Tool    int
+	Feed    float32
+	Spindle float32
+}
+
+func (p *ParserState) IsStatusUpdate() {}
+
+// Feedback is any other bracketed message, such as [VER:] or [PRB:].
+type Feedback struct {
+	Key   string
+	Value string
+}
+
+func (f *Feedback) IsStatusUpdate() {}
+
+// Setting is a $n=value line, sent for each setting in response to $$.
+type Setting struct {
+	Number int
+	Value  float64
+}
+
+func (s *Setting) IsStatusUpdate() {}
+
+// Welcome is the message GRBL sends after a reset.
+type Welcome struct {
+	Version string
+}
+
+func (w *Welcome) IsStatusUpdate() {}
+
+type Token int
+
+const (
+	EOF Token = iota
+	Return
+	Space
+	Newline
+	Colon
+	Comma
+	Bar
+	LAngle
+	RAngle
+	LBracket
+	RBracket
+	Identifier
+	Float
+	Illegal
+)
+
+var tokens = []string{
+	EOF:        "EOF",
+	Return:     "RETURN",
+	Space:      "SPACE",
+	Newline:    "NEWLINE",
+	Colon:      ":",
+	Comma:      ",",
+	Bar:        "|",
+	LAngle:     "<",
+	RAngle:     ">",
+	LBracket:   "[",
+	RBracket:   "]",
+	Identifier: "IDENT",
+	Float:      "FLOAT",
+	Illegal:    "ILLEGAL",
+}
+
+func (t Token) String() string {
+	return tokens[t]
+}
+
+type Lexer struct {
+	pos  int
+	last Token
+	rdr  *bufio.Reader
+}
+
+func NewLexer(r io.Reader) *Lexer {
+	return &Lexer{
+		rdr: bufio.NewReader(r),
+		pos: 0,
+	}
+}
+
+func (l *Lexer) Lex() (int, Token, string) {
+	pos, tok, lit := l.lex()
+	l.last = tok
+	return pos, tok, lit
+}
+
+func (l *Lexer) lex() (int, Token, string) {
+	l.pos++
+	r, _, err := l.rdr.ReadRune()
+	if err != nil {
+		return l.pos, EOF, EOF.String()
+	}
+	switch r {
+	case ' ':
+		return l.pos, Space, Space.String()
+	case '\n':
+		return l.pos, Newline, Newline.String()
+	case '\r':
+		return l.pos, Return, Return.String()
+	case ':':
+		return l.pos, Colon, Colon.String()
+	case ',':
+		return l.pos, Comma, Comma.String()
+	case '|':
+		return l.pos, Bar, Bar.String()
+	case '<':
+		return l.pos, LAngle, LAngle.String()
+	case '>':
+		return l.pos, RAngle, RAngle.String()
+	case '[':
+		return l.pos, LBracket, LBracket.String()
+	case ']':
+		return l.pos, RBracket, RBracket.String()
+	}
+	startPos := l.pos
+	l.backup()
+	if isFloatPart(r) {
+		return startPos, Float, l.lexWhile(isFloatPart)
+	}
+	if unicode.IsLetter(r) {
+		return startPos, Identifier, l.lexWhile(unicode.IsLetter)
+	}
+	l.pos++
+	_, _, _ = l.rdr.ReadRune()
+	return startPos, Illegal, string(r)
+}
+
+func isFloatPart(r rune) bool {
+	return unicode.IsDigit(r) || r == '.' || r == '-'
+}
+
+func (l *Lexer) lexWhile(f func(rune) bool) string {
+	var lit strings.Builder
+	for {
+		r, _, err := l.rdr.ReadRune()
+		if err != nil {
+			return lit.String()
+		}
+		l.pos++
+		if !f(r) {
+			l.backup()
+			return lit.String()
+		}
+		lit.WriteRune(r)
+	}
+}
+
+// raw reads the text up to delim, which is consumed, or the end of the line,
+// which is not.
+func (l *Lexer) raw(delim rune) string {
+	var lit strings.Builder
+	for {
+		r, _, err := l.rdr.ReadRune()
+		if err != nil {
+			return lit.String()
+		}
+		l.pos++
+		if r == '\n' {
+			l.backup()
+			return lit.String()
+		}
+		if r == delim {
+			return lit.String()
+		}
+		lit.WriteRune(r)
+	}
+}
+
+func (l *Lexer) backup() {
+	l.pos--
+	err := l.rdr.UnreadRune()
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Parser reads the controller's output one line at a time.
+type Parser struct {
+	lexer *Lexer
+}
+
+func NewParser(r io.Reader) *Parser {
+	return &Parser{
+		lexer: NewLexer(r),
+	}
+}
+
+func (p *Parser) errorf(pos int, format string, args ...interface{}) error {
+	return fmt.Errorf("%d: %s", pos, fmt.Sprintf(format, args...))
+}
+
+// skipLine discards the rest of the current line.
+func (p *Parser) skipLine() {
+	if p.lexer.last == Newline || p.lexer.last == EOF {
+		return
+	}
+	for {
+		_, tok, _ := p.lexer.Lex()
+		if tok == Newline || tok == EOF {
+			return
+		}
+	}
+}
+
+func (p *Parser) parseInt() (int, error) {
+	pos, tok, lit := p.lexer.Lex()
+	if tok != Float {
+		return 0, p.errorf(pos, "expected integer, got %q", lit)
+	}
+	i, err := strconv.Atoi(lit)
+	if err != nil {
+		return 0, p.errorf(pos, "expected integer, got %q", lit)
+	}
+	return i, nil
+}
+
+// parseCode parses the :N after error and ALARM.
+func (p *Parser) parseCode() (int, error) {
+	pos, tok, lit := p.lexer.Lex()
+	if tok != Colon {
+		return 0, p.errorf(pos, "expected %q, got %q", Colon, lit)
+	}
+	return p.parseInt()
+}
+
+// parseFloats parses a comma separated list of numbers, stopping before the
+// token that ends it.
+func (p *Parser) parseFloats() ([]float32, error) {
+	ret := make([]float32, 0, 3)
+	for {
+		pos, tok, lit := p.lexer.Lex()
+		if tok != Float {
+			return nil, p.errorf(pos, "expected float, got %q", lit)
+		}
+		f, err := strconv.ParseFloat(lit, 32)
+		if err != nil {
+			return nil, p.errorf(pos, "expected float, got %q", lit)
+		}
+		ret = append(ret, float32(f))
+		r, _, err := p.lexer.rdr.ReadRune()
+		if err != nil {
+			return ret, nil
+		}
+		if r != ',' {
+			_ = p.lexer.rdr.UnreadRune()
+			return ret, nil
+		}
+		p.lexer.pos++
+	}
+}
+
+func (p *Parser) parsePosition() (*Position, error) {
+	ff, err := p.parseFloats()
+	if err != nil {
+		return nil, err
+	}
+	ret := new(Position)
+	for i, f := range ff {
+		switch i {
+		case 0:
+			ret.X = f
+		case 1:
+			ret.Y = f
+		case 2:
+			ret.Z = f
+		}
+	}
+	return ret, nil
+}
+
+// parseInts parses exactly n comma separated integers.
+func (p *Parser) parseInts(n int) ([]int, error) {
+	pos := p.lexer.pos
+	ff, err := p.parseFloats()
+	if err != nil {
+		return nil, err
+	}
+	if len(ff) != n {
+		return nil, p.errorf(pos, "expected %d values, got %d", n, len(ff))
+	}
+	ret := make([]int, n)
+	for i, f := range ff {
+		ret[i] = int(f)
+	}
+	return ret, nil
+}
+
+func (p *Parser) parseLetters() (string, error) {
+	pos, tok, lit := p.lexer.Lex()
+	if tok != Identifier {
+		return "", p.errorf(pos, "expected identifier, got %q", lit)
+	}
+	return lit, nil
+}
+
+func parsePins(pins string) *LimitPins {
+	ret := new(LimitPins)
+	for _, r := range pins {
+		switch r {
+		case 'X':
+			ret.X = true
+		case 'Y':
+			ret.Y = true
+		case 'Z':
+			ret.Z = true
+		case 'P':
+			ret.Probe = true
+		case 'D':
+			ret.Door = true
+		case 'H':
+			ret.Hold = true
+		case 'R':
+			ret.SoftReset = true
+		case 'S':
+			ret.CycleStart = true
+		}
+	}
+	return ret
+}
+
+func parseActive(active string) *Active {
+	ret := new(Active)
+	for _, r := range active {
+		switch r {
+		case 'S':
+			ret.Spindle = true
+		case 'C':
+			ret.SpindleCCW = true
+		case 'F':
+			ret.Flood = true
+		case 'M':
+			ret.Mist = true
+		}
+	}
+	return ret
+}
+
+// parseField parses the value of one status report field. Fields this parser
+// does not know are skipped.
+func (p *Parser) parseField(s *Status, key string) error {
+	var err error
+	switch key {
+	case "MPos":
+		s.MachinePosition, err = p.parsePosition()
+	case "WPos":
+		s.WorkPosition, err = p.parsePosition()
+	case "WCO":
+		s.WorkOffset, err = p.parsePosition()
+	case "F":
+		var ff []float32
+		if ff, err = p.parseFloats(); err == nil {
+			s.Feed = ff[0]
+		}
+	case "FS":
+		var ff []float32
+		if ff, err = p.parseFloats(); err == nil && len(ff) == 2 {
+			s.Feed, s.Spindle = ff[0], ff[1]
+		}
+	case "Ov":
+		var ov []int
+		if ov, err = p.parseInts(3); err == nil {
+			s.Override = &Override{Feed: ov[0], Rapid: ov[1], Spindle: ov[2]}
+		}
+	case "Bf":
+		var bf []int
+		if bf, err = p.parseInts(2); err == nil {
+			s.Buffer = &Buffer{Blocks: bf[0], Bytes: bf[1]}
+		}
+	case "Ln":
+		var ln int
+		if ln, err = p.parseInt(); err == nil {
+			s.Line = &ln
+		}
+	case "Pn":
+		var pins string
+		if pins, err = p.parseLetters(); err == nil {
+			s.LimitPins = parsePins(pins)
+		}
+	case "A":
+		var active string
+		if active, err = p.parseLetters(); err == nil {
+			s.Active = parseActive(active)
+		}
+	default:
+		p.skipField()
+	}
+	return err
+}
+
+// skipField discards a field's value, stopping before the token that ends it.
+func (p *Parser) skipField() {
+	for {
+		_, tok, _ := p.lexer.Lex()
+		switch tok {
+		case Bar, RAngle, Newline:
+			p.lexer.backup()
+			return
+		case EOF:
+			return
+		}
+	}
+}
+
+func (p *Parser) parseStatus() (*Status, error) {
+	pos, tok, lit := p.lexer.Lex()
+	if tok != Identifier {
+		return nil, p.errorf(pos, "expected state, got %q", lit)
+	}
+	s := &Status{State: strings.ToLower(lit)}
+	for {
+		pos, tok, lit = p.lexer.Lex()
+		switch tok {
+		case Colon:
+			sub, err := p.parseInt()
+			if err != nil {
+				return nil, err
+			}
+			s.SubState = &sub
+		case Bar:
+			pos, tok, lit = p.lexer.Lex()
+			if tok != Identifier {
+				return nil, p.errorf(pos, "expected field, got %q", lit)
+			}
+			key := lit
+			pos, tok, lit = p.lexer.Lex()
+			if tok != Colon {
+				return nil, p.errorf(pos, "expected %q after %s, got %q", Colon, key, lit)
+			}
+			if err := p.parseField(s, key); err != nil {
+				return nil, err
+			}
+		case RAngle:
+			return s, nil
+		default:
+			return nil, p.errorf(pos, "unexpected %q in status report", lit)
+		}
+	}
+}
+
+func parseParserState(words string) (*ParserState, error) {
+	ret := &ParserState{Modes: make([]string, 0, 12)}
+	for _, w := range strings.Fields(words) {
+		if len(w) < 2 {
+			return nil, fmt.Errorf("bad parser state word %q", w)
+		}
+		var err error
+		switch w[0] {
+		case 'G', 'M':
+			ret.Modes = append(ret.Modes, w)
+		case 'T':
+			ret.Tool, err = strconv.Atoi(w[1:])
+		case 'F', 'S':
+			var f float64
+			f, err = strconv.ParseFloat(w[1:], 32)
+			if w[0] == 'F' {
+				ret.Feed = float32(f)
+			} else {
+				ret.Spindle = float32(f)
+			}
+		default:
+			err = fmt.Errorf("unknown parser state word %q", w)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+func (p *Parser) parseFeedback() (StatusUpdate, error) {
+	body := p.lexer.raw(']')
+	key, value, _ := strings.Cut(body, ":")
+	switch key {
+	case "MSG":
+		return Message(value), nil
+	case "GC":
+		return parseParserState(value)
+	}
+	return &Feedback{Key: key, Value: value}, nil
+}
+
+func (p *Parser) parseSetting() (*Setting, error) {
+	n, err := p.parseInt()
+	if err != nil {
+		return nil, err
+	}
+	pos, tok, lit := p.lexer.Lex()
+	if tok != Illegal || lit != "=" {
+		return nil, p.errorf(pos, "expected = after $%d, got %q", n, lit)
+	}
+	value, err := strconv.ParseFloat(strings.TrimSpace(p.lexer.raw('\n')), 64)
+	if err != nil {
+		return nil, p.errorf(pos, "bad value for $%d: %v", n, err)
+	}
+	return &Setting{Number: n, Value: value}, nil
+}
+
+// Parse reads the next line and returns what it reports. It returns io.EOF
+// once the reader is exhausted.
+func (p *Parser) Parse() (ret StatusUpdate, err error) {
+	for {
+		pos, tok, lit := p.lexer.Lex()
+		switch tok {
+		case EOF:
+			return nil, io.EOF
+		case Newline, Return, Space:
+			continue
+		case LAngle:
+			ret, err = p.parseStatus()
+		case LBracket:
+			ret, err = p.parseFeedback()
+		case Illegal:
+			if lit != "$" {
+				err = p.errorf(pos, "expected identifier, got %q", lit)
+				break
+			}
+			ret, err = p.parseSetting()
+		case Identifier:
+			switch lit {
+			case "ok":
+				ret = &Ack{}
+			case "error":
+				var code int
+				code, err = p.parseCode()
+				ret = Error(code)
+			case "ALARM":
+				var code int
+				code, err = p.parseCode()
+				ret = Alarm(code)
+			case "Grbl":
+				version, _, _ := strings.Cut(strings.TrimSpace(p.lexer.raw('[')), " ")
+				ret = &Welcome{Version: version}
+			default:
+				err = p.errorf(pos, "unknown identifier %q", lit)
+			}
+		default:
+			err = p.errorf(pos, "expected identifier, got %q", lit)
+		}
+		p.skipLine()
+		if err != nil {
+			return nil, err
+		}
+		return ret, nil
+	}
+}
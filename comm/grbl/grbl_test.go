@@ -3,6 +3,7 @@ package grbl_test
 import (
 	"bytes"
 	"github.com/jt05610/petri/comm/grbl"
+	"io"
 	"testing"
 )
 
@@ -153,3 +154,107 @@ func TestParse(t *testing.T) {
 		}
 	}
 }
+
+func TestParse_Report(t *testing.T) {
+	p := grbl.NewParser(bytes.NewReader([]byte("<Hold:1|WPos:1.500,-2.000,3.250|Bf:15,128|Ln:42|FS:500,8000|A:SFM|Pn:PZ>\r\n")))
+	u, err := p.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, ok := u.(*grbl.Status)
+	if !ok {
+		t.Fatalf("expected status, got %T", u)
+	}
+	if s.State != "hold" || s.SubState == nil || *s.SubState != 1 {
+		t.Fatalf("expected hold:1, got %s %v", s.State, s.SubState)
+	}
+	if s.MachinePosition != nil || s.WorkPosition == nil || s.WorkPosition.X != 1.5 || s.WorkPosition.Y != -2 || s.WorkPosition.Z != 3.25 {
+		t.Fatalf("unexpected positions %v %v", s.MachinePosition, s.WorkPosition)
+	}
+	if s.Buffer == nil || s.Buffer.Blocks != 15 || s.Buffer.Bytes != 128 {
+		t.Fatalf("unexpected buffer %v", s.Buffer)
+	}
+	if s.Line == nil || *s.Line != 42 {
+		t.Fatalf("unexpected line %v", s.Line)
+	}
+	if s.Feed != 500 || s.Spindle != 8000 {
+		t.Fatalf("unexpected feed and speed %v %v", s.Feed, s.Spindle)
+	}
+	if s.Active == nil || !s.Active.Spindle || !s.Active.Flood || !s.Active.Mist || s.Active.SpindleCCW {
+		t.Fatalf("unexpected accessories %v", s.Active)
+	}
+	if s.LimitPins == nil || !s.LimitPins.Probe || !s.LimitPins.Z || s.LimitPins.X {
+		t.Fatalf("unexpected pins %v", s.LimitPins)
+	}
+	if _, err := p.Parse(); err != io.EOF {
+		t.Fatalf("expected EOF, got %v", err)
+	}
+}
+
+func TestParse_Lines(t *testing.T) {
+	p := grbl.NewParser(bytes.NewReader([]byte("Grbl 1.1h ['$' for help]\r\n" +
+		"[MSG:'$H'|'$X' to unlock]\r\n" +
+		"ALARM:9\r\n" +
+		"error:20\r\n" +
+		"[GC:G0 G54 G17 G21 G90 G94 M5 M9 T0 F250 S0]\r\n" +
+		"[VER:1.1h.20190825:]\r\n" +
+		"$100=250.000\r\n" +
+		"<Idle|MPos:0.000,0.000,0.000|Unknown:1,2|F:0>\r\n" +
+		"ok\r\n")))
+	next := func() grbl.StatusUpdate {
+		t.Helper()
+		u, err := p.Parse()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return u
+	}
+	if w, ok := next().(*grbl.Welcome); !ok || w.Version != "1.1h" {
+		t.Fatalf("expected the welcome message, got %v", w)
+	}
+	if m, ok := next().(grbl.Message); !ok || m != "'$H'|'$X' to unlock" {
+		t.Fatalf("expected a message, got %v", m)
+	}
+	if a, ok := next().(grbl.Alarm); !ok || a != 9 {
+		t.Fatalf("expected alarm 9, got %v", a)
+	}
+	if e, ok := next().(grbl.Error); !ok || e != 20 {
+		t.Fatalf("expected error 20, got %v", e)
+	}
+	gc, ok := next().(*grbl.ParserState)
+	if !ok || len(gc.Modes) != 8 || gc.Modes[1] != "G54" || gc.Feed != 250 {
+		t.Fatalf("unexpected parser state %v", gc)
+	}
+	if f, ok := next().(*grbl.Feedback); !ok || f.Key != "VER" || f.Value != "1.1h.20190825:" {
+		t.Fatalf("unexpected feedback %v", f)
+	}
+	if s, ok := next().(*grbl.Setting); !ok || s.Number != 100 || s.Value != 250 {
+		t.Fatalf("unexpected setting %v", s)
+	}
+	if s, ok := next().(*grbl.Status); !ok || s.State != "idle" {
+		t.Fatalf("expected an idle status skipping the unknown field, got %v", s)
+	}
+	if _, ok := next().(*grbl.Ack); !ok {
+		t.Fatal("expected ok")
+	}
+}
+
+func TestProfile_Diff(t *testing.T) {
+	p, err := grbl.LoadProfile(bytes.NewReader([]byte(`{"version": "2", "settings": {"100": 250, "101": 250, "110": 500}}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff := p.Diff(map[int]float64{100: 250.0004, 101: 80, 130: 200})
+	if len(diff) != 3 {
+		t.Fatalf("expected 3 differences, got %d", len(diff))
+	}
+	if d := diff[0]; d.Number != 101 || *d.Want != 250 || *d.Got != 80 {
+		t.Errorf("unexpected difference %v", d)
+	}
+	if d := diff[1]; d.Number != 110 || *d.Want != 500 || d.Got != nil {
+		t.Errorf("expected a setting missing from the controller, got %v", d)
+	}
+	if d := diff[2]; d.Number != 130 || d.Want != nil || *d.Got != 200 {
+		t.Errorf("expected a setting missing from the profile, got %v", d)
+	}
+}
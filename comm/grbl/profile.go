@@ -0,0 +1,81 @@
+package grbl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+)
+
+// Profile is a versioned set of controller settings, so that every machine
+// of a kind runs the same firmware configuration.
+type Profile struct {
+	Version string `json:"version"`
+	// Settings maps setting numbers, such as 100 for X steps/mm, to values.
+	Settings map[int]float64 `json:"settings"`
+}
+
+func LoadProfile(r io.Reader) (*Profile, error) {
+	var ret Profile
+	if err := json.NewDecoder(r).Decode(&ret); err != nil {
+		return nil, fmt.Errorf("decode settings profile: %w", err)
+	}
+	if ret.Settings == nil {
+		ret.Settings = make(map[int]float64)
+	}
+	return &ret, nil
+}
+
+func LoadProfileFile(path string) (*Profile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	return LoadProfile(f)
+}
+
+// settingTolerance covers the rounding of GRBL printing values with three
+// decimals.
+const settingTolerance = 0.0005
+
+// SettingDiff is a setting whose value on the controller differs from the
+// profile.
+type SettingDiff struct {
+	Number int
+	// Want is nil if the profile does not have the setting.
+	Want *float64
+	// Got is nil if the controller did not report the setting.
+	Got *float64
+}
+
+// Diff compares the controller's settings with the profile, ordered by
+// setting number.
+func (p *Profile) Diff(settings map[int]float64) []SettingDiff {
+	ret := make([]SettingDiff, 0)
+	for n, want := range p.Settings {
+		want := want
+		got, found := settings[n]
+		if !found {
+			ret = append(ret, SettingDiff{Number: n, Want: &want})
+			continue
+		}
+		if math.Abs(got-want) > settingTolerance {
+			ret = append(ret, SettingDiff{Number: n, Want: &want, Got: &got})
+		}
+	}
+	for n, got := range settings {
+		got := got
+		if _, found := p.Settings[n]; !found {
+			ret = append(ret, SettingDiff{Number: n, Got: &got})
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Number < ret[j].Number
+	})
+	return ret
+}
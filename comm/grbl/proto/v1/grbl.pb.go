@@ -3,7 +3,7 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
 // 	protoc-gen-go v1.31.0
-// 	protoc        v3.19.4
+// 	protoc        (unknown)
 // source: grbl.proto
 
 package v1
@@ -295,6 +295,119 @@ func (ErrorCode) EnumDescriptor() ([]byte, []int) {
 	return file_grbl_proto_rawDescGZIP(), []int{2}
 }
 
+type ProgramControl int32
+
+const (
+	ProgramControl_ProgramControl_None   ProgramControl = 0
+	ProgramControl_ProgramControl_Hold   ProgramControl = 1 // stop sending and pause motion
+	ProgramControl_ProgramControl_Resume ProgramControl = 2 // continue after a hold
+	ProgramControl_ProgramControl_Cancel ProgramControl = 3 // stop and discard the rest of the program
+)
+
+// Enum value maps for ProgramControl.
+var (
+	ProgramControl_name = map[int32]string{
+		0: "ProgramControl_None",
+		1: "ProgramControl_Hold",
+		2: "ProgramControl_Resume",
+		3: "ProgramControl_Cancel",
+	}
+	ProgramControl_value = map[string]int32{
+		"ProgramControl_None":   0,
+		"ProgramControl_Hold":   1,
+		"ProgramControl_Resume": 2,
+		"ProgramControl_Cancel": 3,
+	}
+)
+
+func (x ProgramControl) Enum() *ProgramControl {
+	p := new(ProgramControl)
+	*p = x
+	return p
+}
+
+func (x ProgramControl) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProgramControl) Descriptor() protoreflect.EnumDescriptor {
+	return file_grbl_proto_enumTypes[3].Descriptor()
+}
+
+func (ProgramControl) Type() protoreflect.EnumType {
+	return &file_grbl_proto_enumTypes[3]
+}
+
+func (x ProgramControl) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProgramControl.Descriptor instead.
+func (ProgramControl) EnumDescriptor() ([]byte, []int) {
+	return file_grbl_proto_rawDescGZIP(), []int{3}
+}
+
+type ProgramEvent int32
+
+const (
+	ProgramEvent_ProgramEvent_Started   ProgramEvent = 0
+	ProgramEvent_ProgramEvent_Acked     ProgramEvent = 1 // a block was acknowledged
+	ProgramEvent_ProgramEvent_Held      ProgramEvent = 2
+	ProgramEvent_ProgramEvent_Resumed   ProgramEvent = 3
+	ProgramEvent_ProgramEvent_Completed ProgramEvent = 4
+	ProgramEvent_ProgramEvent_Cancelled ProgramEvent = 5
+	ProgramEvent_ProgramEvent_Failed    ProgramEvent = 6
+)
+
+// Enum value maps for ProgramEvent.
+var (
+	ProgramEvent_name = map[int32]string{
+		0: "ProgramEvent_Started",
+		1: "ProgramEvent_Acked",
+		2: "ProgramEvent_Held",
+		3: "ProgramEvent_Resumed",
+		4: "ProgramEvent_Completed",
+		5: "ProgramEvent_Cancelled",
+		6: "ProgramEvent_Failed",
+	}
+	ProgramEvent_value = map[string]int32{
+		"ProgramEvent_Started":   0,
+		"ProgramEvent_Acked":     1,
+		"ProgramEvent_Held":      2,
+		"ProgramEvent_Resumed":   3,
+		"ProgramEvent_Completed": 4,
+		"ProgramEvent_Cancelled": 5,
+		"ProgramEvent_Failed":    6,
+	}
+)
+
+func (x ProgramEvent) Enum() *ProgramEvent {
+	p := new(ProgramEvent)
+	*p = x
+	return p
+}
+
+func (x ProgramEvent) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProgramEvent) Descriptor() protoreflect.EnumDescriptor {
+	return file_grbl_proto_enumTypes[4].Descriptor()
+}
+
+func (ProgramEvent) Type() protoreflect.EnumType {
+	return &file_grbl_proto_enumTypes[4]
+}
+
+func (x ProgramEvent) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProgramEvent.Descriptor instead.
+func (ProgramEvent) EnumDescriptor() ([]byte, []int) {
+	return file_grbl_proto_rawDescGZIP(), []int{4}
+}
+
 type Position struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -727,7 +840,6 @@ type HomeRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	// Types that are assignable to Axis:
-	//
 	//	*HomeRequest_All
 	//	*HomeRequest_X
 	//	*HomeRequest_Y
@@ -1396,7 +1508,6 @@ type Response struct {
 
 	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
 	// Types that are assignable to Response:
-	//
 	//	*Response_State
 	//	*Response_Move
 	//	*Response_SpindleOn
@@ -1548,350 +1659,1879 @@ func (*Response_FloodOn) isResponse_Response() {}
 
 func (*Response_CoolantOff) isResponse_Response() {}
 
-var File_grbl_proto protoreflect.FileDescriptor
+type Program struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_grbl_proto_rawDesc = []byte{
-	0x0a, 0x0a, 0x67, 0x72, 0x62, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x34, 0x0a, 0x08,
-	0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x02, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x02, 0x52, 0x01, 0x79, 0x12, 0x0c, 0x0a, 0x01, 0x7a, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52,
-	0x01, 0x7a, 0x22, 0x7b, 0x0a, 0x07, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x73, 0x12, 0x17, 0x0a,
-	0x04, 0x66, 0x65, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x04, 0x66,
-	0x65, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x19, 0x0a, 0x05, 0x72, 0x61, 0x70, 0x69, 0x64, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x01, 0x52, 0x05, 0x72, 0x61, 0x70, 0x69, 0x64, 0x88, 0x01,
-	0x01, 0x12, 0x1d, 0x0a, 0x07, 0x73, 0x70, 0x69, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0d, 0x48, 0x02, 0x52, 0x07, 0x73, 0x70, 0x69, 0x6e, 0x64, 0x6c, 0x65, 0x88, 0x01, 0x01,
-	0x42, 0x07, 0x0a, 0x05, 0x5f, 0x66, 0x65, 0x65, 0x64, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x72, 0x61,
-	0x70, 0x69, 0x64, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x73, 0x70, 0x69, 0x6e, 0x64, 0x6c, 0x65, 0x22,
-	0x63, 0x0a, 0x05, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x12, 0x25, 0x0a, 0x05, 0x61, 0x6c, 0x61, 0x72,
-	0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0a, 0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x43,
-	0x6f, 0x64, 0x65, 0x48, 0x00, 0x52, 0x05, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x88, 0x01, 0x01, 0x12,
-	0x1d, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x48, 0x01, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x88, 0x01, 0x01, 0x42, 0x08,
-	0x0a, 0x06, 0x5f, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x22, 0x63, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x25, 0x0a,
-	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0a, 0x2e, 0x45,
-	0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
-	0x72, 0x88, 0x01, 0x01, 0x12, 0x1d, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x42, 0x0a, 0x0a,
-	0x08, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xf6, 0x01, 0x0a, 0x05, 0x53, 0x74,
-	0x61, 0x74, 0x65, 0x12, 0x25, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e,
-	0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x65,
-	0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x04, 0x66, 0x65, 0x65, 0x64, 0x12, 0x27,
-	0x0a, 0x07, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x08, 0x2e, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x73, 0x48, 0x00, 0x52, 0x07, 0x6f, 0x66, 0x66,
-	0x73, 0x65, 0x74, 0x73, 0x88, 0x01, 0x01, 0x12, 0x21, 0x0a, 0x05, 0x61, 0x6c, 0x61, 0x72, 0x6d,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x48, 0x01,
-	0x52, 0x05, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x88, 0x01, 0x01, 0x12, 0x21, 0x0a, 0x05, 0x65, 0x72,
-	0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x45, 0x72, 0x72, 0x6f,
-	0x72, 0x48, 0x02, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x88, 0x01, 0x01, 0x12, 0x23, 0x0a,
-	0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x0b, 0x2e,
-	0x50, 0x65, 0x72, 0x69, 0x70, 0x68, 0x65, 0x72, 0x61, 0x6c, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69,
-	0x76, 0x65, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x73, 0x42, 0x08,
-	0x0a, 0x06, 0x5f, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x65, 0x72, 0x72,
-	0x6f, 0x72, 0x22, 0x14, 0x0a, 0x12, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61,
-	0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x51, 0x0a, 0x13, 0x53, 0x74, 0x61, 0x74,
-	0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x1c, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x06,
-	0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a,
-	0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x59, 0x0a, 0x0b, 0x48,
-	0x6f, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x03, 0x61, 0x6c,
-	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x03, 0x61, 0x6c, 0x6c, 0x12, 0x0e,
-	0x0a, 0x01, 0x58, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x01, 0x58, 0x12, 0x0e,
-	0x0a, 0x01, 0x59, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x01, 0x59, 0x12, 0x0e,
-	0x0a, 0x01, 0x5a, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x01, 0x5a, 0x42, 0x06,
-	0x0a, 0x04, 0x61, 0x78, 0x69, 0x73, 0x22, 0x7d, 0x0a, 0x0b, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x11, 0x0a, 0x01, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02,
-	0x48, 0x00, 0x52, 0x01, 0x78, 0x88, 0x01, 0x01, 0x12, 0x11, 0x0a, 0x01, 0x79, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x02, 0x48, 0x01, 0x52, 0x01, 0x79, 0x88, 0x01, 0x01, 0x12, 0x11, 0x0a, 0x01, 0x7a,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x48, 0x02, 0x52, 0x01, 0x7a, 0x88, 0x01, 0x01, 0x12, 0x19,
-	0x0a, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x48, 0x03, 0x52,
-	0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x88, 0x01, 0x01, 0x42, 0x04, 0x0a, 0x02, 0x5f, 0x78, 0x42,
-	0x04, 0x0a, 0x02, 0x5f, 0x79, 0x42, 0x04, 0x0a, 0x02, 0x5f, 0x7a, 0x42, 0x08, 0x0a, 0x06, 0x5f,
-	0x73, 0x70, 0x65, 0x65, 0x64, 0x22, 0x28, 0x0a, 0x0c, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
-	0x37, 0x0a, 0x10, 0x53, 0x70, 0x69, 0x6e, 0x64, 0x6c, 0x65, 0x4f, 0x6e, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x05, 0x48, 0x00, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x88, 0x01, 0x01, 0x42, 0x08,
-	0x0a, 0x06, 0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x22, 0x2d, 0x0a, 0x11, 0x53, 0x70, 0x69, 0x6e,
-	0x64, 0x6c, 0x65, 0x4f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a,
-	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x13, 0x0a, 0x11, 0x53, 0x70, 0x69, 0x6e, 0x64,
-	0x6c, 0x65, 0x4f, 0x66, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x2e, 0x0a, 0x12,
-	0x53, 0x70, 0x69, 0x6e, 0x64, 0x6c, 0x65, 0x4f, 0x66, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x0f, 0x0a, 0x0d,
-	0x4d, 0x69, 0x73, 0x74, 0x4f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x2a, 0x0a,
-	0x0e, 0x4d, 0x69, 0x73, 0x74, 0x4f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x10, 0x0a, 0x0e, 0x46, 0x6c, 0x6f,
-	0x6f, 0x64, 0x4f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x2b, 0x0a, 0x0f, 0x46,
-	0x6c, 0x6f, 0x6f, 0x64, 0x4f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18,
-	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x13, 0x0a, 0x11, 0x43, 0x6f, 0x6f, 0x6c,
-	0x61, 0x6e, 0x74, 0x4f, 0x66, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x2e, 0x0a,
-	0x12, 0x43, 0x6f, 0x6f, 0x6c, 0x61, 0x6e, 0x74, 0x4f, 0x66, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xf0, 0x02,
-	0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x12, 0x1e, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52, 0x05, 0x73,
-	0x74, 0x61, 0x74, 0x65, 0x12, 0x23, 0x0a, 0x04, 0x6d, 0x6f, 0x76, 0x65, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x48, 0x00, 0x52, 0x04, 0x6d, 0x6f, 0x76, 0x65, 0x12, 0x32, 0x0a, 0x09, 0x73, 0x70, 0x69,
-	0x6e, 0x64, 0x6c, 0x65, 0x4f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x53,
-	0x70, 0x69, 0x6e, 0x64, 0x6c, 0x65, 0x4f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x48, 0x00, 0x52, 0x09, 0x73, 0x70, 0x69, 0x6e, 0x64, 0x6c, 0x65, 0x4f, 0x6e, 0x12, 0x35, 0x0a,
-	0x0a, 0x73, 0x70, 0x69, 0x6e, 0x64, 0x6c, 0x65, 0x4f, 0x66, 0x66, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x13, 0x2e, 0x53, 0x70, 0x69, 0x6e, 0x64, 0x6c, 0x65, 0x4f, 0x66, 0x66, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x73, 0x70, 0x69, 0x6e, 0x64, 0x6c,
-	0x65, 0x4f, 0x66, 0x66, 0x12, 0x29, 0x0a, 0x06, 0x6d, 0x69, 0x73, 0x74, 0x4f, 0x6e, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x4d, 0x69, 0x73, 0x74, 0x4f, 0x6e, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x06, 0x6d, 0x69, 0x73, 0x74, 0x4f, 0x6e, 0x12,
-	0x2c, 0x0a, 0x07, 0x66, 0x6c, 0x6f, 0x6f, 0x64, 0x4f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x10, 0x2e, 0x46, 0x6c, 0x6f, 0x6f, 0x64, 0x4f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x48, 0x00, 0x52, 0x07, 0x66, 0x6c, 0x6f, 0x6f, 0x64, 0x4f, 0x6e, 0x12, 0x35, 0x0a,
-	0x0a, 0x63, 0x6f, 0x6f, 0x6c, 0x61, 0x6e, 0x74, 0x4f, 0x66, 0x66, 0x18, 0x08, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x13, 0x2e, 0x43, 0x6f, 0x6f, 0x6c, 0x61, 0x6e, 0x74, 0x4f, 0x66, 0x66, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x63, 0x6f, 0x6f, 0x6c, 0x61, 0x6e,
-	0x74, 0x4f, 0x66, 0x66, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x2a, 0x38, 0x0a, 0x0a, 0x50, 0x65, 0x72, 0x69, 0x70, 0x68, 0x65, 0x72, 0x61, 0x6c, 0x12, 0x08,
-	0x0a, 0x04, 0x4e, 0x6f, 0x6e, 0x65, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x70, 0x69, 0x6e,
-	0x64, 0x6c, 0x65, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x4d, 0x69, 0x73, 0x74, 0x10, 0x02, 0x12,
-	0x09, 0x0a, 0x05, 0x46, 0x6c, 0x6f, 0x6f, 0x64, 0x10, 0x03, 0x2a, 0x87, 0x02, 0x0a, 0x09, 0x41,
-	0x6c, 0x61, 0x72, 0x6d, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x15, 0x0a, 0x11, 0x41, 0x6c, 0x61, 0x72,
-	0x6d, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x4e, 0x6f, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x10, 0x00, 0x12,
-	0x17, 0x0a, 0x13, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x48, 0x61, 0x72,
-	0x64, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x41, 0x6c, 0x61, 0x72,
-	0x6d, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x53, 0x6f, 0x66, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x10,
-	0x02, 0x12, 0x13, 0x0a, 0x0f, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x41,
-	0x62, 0x6f, 0x72, 0x74, 0x10, 0x03, 0x12, 0x17, 0x0a, 0x13, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x43,
-	0x6f, 0x64, 0x65, 0x5f, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x10, 0x04, 0x12,
-	0x18, 0x0a, 0x14, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x50, 0x72, 0x6f,
-	0x62, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x32, 0x10, 0x05, 0x12, 0x18, 0x0a, 0x14, 0x41, 0x6c, 0x61,
-	0x72, 0x6d, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x48, 0x6f, 0x6d, 0x69, 0x6e, 0x67, 0x46, 0x61, 0x69,
-	0x6c, 0x10, 0x06, 0x12, 0x19, 0x0a, 0x15, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x43, 0x6f, 0x64, 0x65,
-	0x5f, 0x48, 0x6f, 0x6d, 0x69, 0x6e, 0x67, 0x46, 0x61, 0x69, 0x6c, 0x32, 0x10, 0x07, 0x12, 0x19,
-	0x0a, 0x15, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x48, 0x6f, 0x6d, 0x69,
-	0x6e, 0x67, 0x46, 0x61, 0x69, 0x6c, 0x33, 0x10, 0x08, 0x12, 0x19, 0x0a, 0x15, 0x41, 0x6c, 0x61,
-	0x72, 0x6d, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x48, 0x6f, 0x6d, 0x69, 0x6e, 0x67, 0x46, 0x61, 0x69,
-	0x6c, 0x34, 0x10, 0x09, 0x2a, 0x87, 0x09, 0x0a, 0x09, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f,
-	0x64, 0x65, 0x12, 0x15, 0x0a, 0x11, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f,
-	0x4e, 0x6f, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x10, 0x00, 0x12, 0x23, 0x0a, 0x1f, 0x45, 0x72, 0x72,
-	0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x45, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x43,
-	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x10, 0x01, 0x12, 0x1d,
-	0x0a, 0x19, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x42, 0x61, 0x64, 0x4e,
-	0x75, 0x6d, 0x62, 0x65, 0x72, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x10, 0x02, 0x12, 0x1e, 0x0a,
-	0x1a, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x49, 0x6e, 0x76, 0x61, 0x6c,
-	0x69, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x10, 0x03, 0x12, 0x1b, 0x0a,
-	0x17, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x4e, 0x65, 0x67, 0x61, 0x74,
-	0x69, 0x76, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x10, 0x04, 0x12, 0x20, 0x0a, 0x1c, 0x45, 0x72,
-	0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x48, 0x6f, 0x6d, 0x69, 0x6e, 0x67, 0x43, 0x79,
-	0x63, 0x6c, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x10, 0x05, 0x12, 0x1e, 0x0a, 0x1a,
-	0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x4d, 0x69, 0x6e, 0x53, 0x74, 0x65,
-	0x70, 0x50, 0x75, 0x6c, 0x73, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x10, 0x06, 0x12, 0x1f, 0x0a, 0x1b,
-	0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x45, 0x45, 0x50, 0x52, 0x4f, 0x4d,
-	0x52, 0x65, 0x61, 0x64, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x10, 0x07, 0x12, 0x15, 0x0a,
-	0x11, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x4e, 0x6f, 0x74, 0x49, 0x64,
-	0x6c, 0x65, 0x10, 0x08, 0x12, 0x17, 0x0a, 0x13, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64,
-	0x65, 0x5f, 0x47, 0x43, 0x6f, 0x64, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x10, 0x09, 0x12, 0x1e, 0x0a,
-	0x1a, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x48, 0x6f, 0x6d, 0x69, 0x6e,
-	0x67, 0x4e, 0x6f, 0x74, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x10, 0x0a, 0x12, 0x1a, 0x0a,
-	0x16, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x4c, 0x69, 0x6e, 0x65, 0x4f,
-	0x76, 0x65, 0x72, 0x66, 0x6c, 0x6f, 0x77, 0x10, 0x0b, 0x12, 0x21, 0x0a, 0x1d, 0x45, 0x72, 0x72,
-	0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x4d, 0x61, 0x78, 0x53, 0x74, 0x65, 0x70, 0x52, 0x61,
-	0x74, 0x65, 0x45, 0x78, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x10, 0x0c, 0x12, 0x20, 0x0a, 0x1c,
-	0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x53, 0x61, 0x66, 0x65, 0x74, 0x79,
-	0x44, 0x6f, 0x6f, 0x72, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x65, 0x64, 0x10, 0x0d, 0x12, 0x25,
-	0x0a, 0x21, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x4c, 0x69, 0x6e, 0x65,
-	0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x45, 0x78, 0x63, 0x65, 0x65,
-	0x64, 0x65, 0x64, 0x10, 0x0e, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f,
-	0x64, 0x65, 0x5f, 0x54, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x45, 0x78, 0x63, 0x65, 0x65, 0x64, 0x65,
-	0x64, 0x10, 0x0f, 0x12, 0x1f, 0x0a, 0x1b, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65,
-	0x5f, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x4a, 0x6f, 0x67, 0x43, 0x6f, 0x6d, 0x6d, 0x61,
-	0x6e, 0x64, 0x10, 0x10, 0x12, 0x22, 0x0a, 0x1e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64,
-	0x65, 0x5f, 0x4c, 0x61, 0x73, 0x65, 0x72, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x69,
-	0x72, 0x65, 0x73, 0x50, 0x57, 0x4d, 0x10, 0x11, 0x12, 0x20, 0x0a, 0x1c, 0x45, 0x72, 0x72, 0x6f,
-	0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x55, 0x6e, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65,
-	0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x10, 0x14, 0x12, 0x21, 0x0a, 0x1d, 0x45, 0x72,
-	0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x4d, 0x6f, 0x64, 0x61, 0x6c, 0x47, 0x72, 0x6f,
-	0x75, 0x70, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x10, 0x15, 0x12, 0x1f, 0x0a,
-	0x1b, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x55, 0x6e, 0x64, 0x65, 0x66,
-	0x69, 0x6e, 0x65, 0x64, 0x46, 0x65, 0x65, 0x64, 0x52, 0x61, 0x74, 0x65, 0x10, 0x16, 0x12, 0x1c,
-	0x0a, 0x18, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x49, 0x6e, 0x76, 0x61,
-	0x6c, 0x69, 0x64, 0x47, 0x43, 0x6f, 0x64, 0x65, 0x32, 0x33, 0x10, 0x17, 0x12, 0x1c, 0x0a, 0x18,
-	0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69,
-	0x64, 0x47, 0x43, 0x6f, 0x64, 0x65, 0x32, 0x34, 0x10, 0x18, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x72,
-	0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x47,
-	0x43, 0x6f, 0x64, 0x65, 0x32, 0x35, 0x10, 0x19, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x72, 0x72, 0x6f,
-	0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x47, 0x43, 0x6f,
-	0x64, 0x65, 0x32, 0x36, 0x10, 0x1a, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43,
-	0x6f, 0x64, 0x65, 0x5f, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x47, 0x43, 0x6f, 0x64, 0x65,
-	0x32, 0x37, 0x10, 0x1b, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64,
-	0x65, 0x5f, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x47, 0x43, 0x6f, 0x64, 0x65, 0x32, 0x38,
-	0x10, 0x1c, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f,
-	0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x47, 0x43, 0x6f, 0x64, 0x65, 0x32, 0x39, 0x10, 0x1d,
-	0x12, 0x1c, 0x0a, 0x18, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x49, 0x6e,
-	0x76, 0x61, 0x6c, 0x69, 0x64, 0x47, 0x43, 0x6f, 0x64, 0x65, 0x33, 0x30, 0x10, 0x1e, 0x12, 0x1c,
-	0x0a, 0x18, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x49, 0x6e, 0x76, 0x61,
-	0x6c, 0x69, 0x64, 0x47, 0x43, 0x6f, 0x64, 0x65, 0x33, 0x31, 0x10, 0x1f, 0x12, 0x1c, 0x0a, 0x18,
-	0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69,
-	0x64, 0x47, 0x43, 0x6f, 0x64, 0x65, 0x33, 0x32, 0x10, 0x20, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x72,
-	0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x47,
-	0x43, 0x6f, 0x64, 0x65, 0x33, 0x33, 0x10, 0x21, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x72, 0x72, 0x6f,
-	0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x47, 0x43, 0x6f,
-	0x64, 0x65, 0x33, 0x34, 0x10, 0x22, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43,
-	0x6f, 0x64, 0x65, 0x5f, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x47, 0x43, 0x6f, 0x64, 0x65,
-	0x33, 0x35, 0x10, 0x23, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64,
-	0x65, 0x5f, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x47, 0x43, 0x6f, 0x64, 0x65, 0x33, 0x36,
-	0x10, 0x24, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f,
-	0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x47, 0x43, 0x6f, 0x64, 0x65, 0x33, 0x37, 0x10, 0x25,
-	0x12, 0x1c, 0x0a, 0x18, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x49, 0x6e,
-	0x76, 0x61, 0x6c, 0x69, 0x64, 0x47, 0x43, 0x6f, 0x64, 0x65, 0x33, 0x38, 0x10, 0x26, 0x32, 0xe5,
-	0x02, 0x0a, 0x04, 0x47, 0x52, 0x42, 0x4c, 0x12, 0x21, 0x0a, 0x04, 0x48, 0x6f, 0x6d, 0x65, 0x12,
-	0x0c, 0x2e, 0x48, 0x6f, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x0b, 0x53, 0x74,
-	0x61, 0x74, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x13, 0x2e, 0x53, 0x74, 0x61, 0x74,
-	0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14,
-	0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x21, 0x0a, 0x04, 0x4d, 0x6f, 0x76, 0x65,
-	0x12, 0x0c, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x09,
-	0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x2b, 0x0a, 0x09, 0x53,
-	0x70, 0x69, 0x6e, 0x64, 0x6c, 0x65, 0x4f, 0x6e, 0x12, 0x11, 0x2e, 0x53, 0x70, 0x69, 0x6e, 0x64,
-	0x6c, 0x65, 0x4f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x2d, 0x0a, 0x0a, 0x53, 0x70, 0x69, 0x6e,
-	0x64, 0x6c, 0x65, 0x4f, 0x66, 0x66, 0x12, 0x12, 0x2e, 0x53, 0x70, 0x69, 0x6e, 0x64, 0x6c, 0x65,
-	0x4f, 0x66, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x25, 0x0a, 0x06, 0x4d, 0x69, 0x73, 0x74, 0x4f,
-	0x6e, 0x12, 0x0e, 0x2e, 0x4d, 0x69, 0x73, 0x74, 0x4f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x09, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x27,
-	0x0a, 0x07, 0x46, 0x6c, 0x6f, 0x6f, 0x64, 0x4f, 0x6e, 0x12, 0x0f, 0x2e, 0x46, 0x6c, 0x6f, 0x6f,
-	0x64, 0x4f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x2d, 0x0a, 0x0a, 0x43, 0x6f, 0x6f, 0x6c, 0x61,
-	0x6e, 0x74, 0x4f, 0x66, 0x66, 0x12, 0x12, 0x2e, 0x43, 0x6f, 0x6f, 0x6c, 0x61, 0x6e, 0x74, 0x4f,
-	0x66, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x09, 0x5a, 0x07, 0x76, 0x31, 0x2f, 0x67, 0x72, 0x62,
-	0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	// name shown in logs
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// G-code, one block per line
+	Gcode string `protobuf:"bytes,2,opt,name=gcode,proto3" json:"gcode,omitempty"`
 }
 
-var (
-	file_grbl_proto_rawDescOnce sync.Once
-	file_grbl_proto_rawDescData = file_grbl_proto_rawDesc
-)
+func (x *Program) Reset() {
+	*x = Program{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grbl_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_grbl_proto_rawDescGZIP() []byte {
-	file_grbl_proto_rawDescOnce.Do(func() {
-		file_grbl_proto_rawDescData = protoimpl.X.CompressGZIP(file_grbl_proto_rawDescData)
-	})
-	return file_grbl_proto_rawDescData
+func (x *Program) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_grbl_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_grbl_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
-var file_grbl_proto_goTypes = []interface{}{
-	(Peripheral)(0),             // 0: Peripheral
-	(AlarmCode)(0),              // 1: AlarmCode
-	(ErrorCode)(0),              // 2: ErrorCode
-	(*Position)(nil),            // 3: Position
-	(*Offsets)(nil),             // 4: Offsets
-	(*Alarm)(nil),               // 5: Alarm
-	(*Error)(nil),               // 6: Error
-	(*State)(nil),               // 7: State
-	(*StateStreamRequest)(nil),  // 8: StateStreamRequest
-	(*StateStreamResponse)(nil), // 9: StateStreamResponse
-	(*HomeRequest)(nil),         // 10: HomeRequest
-	(*MoveRequest)(nil),         // 11: MoveRequest
-	(*MoveResponse)(nil),        // 12: MoveResponse
-	(*SpindleOnRequest)(nil),    // 13: SpindleOnRequest
-	(*SpindleOnResponse)(nil),   // 14: SpindleOnResponse
-	(*SpindleOffRequest)(nil),   // 15: SpindleOffRequest
-	(*SpindleOffResponse)(nil),  // 16: SpindleOffResponse
-	(*MistOnRequest)(nil),       // 17: MistOnRequest
-	(*MistOnResponse)(nil),      // 18: MistOnResponse
-	(*FloodOnRequest)(nil),      // 19: FloodOnRequest
-	(*FloodOnResponse)(nil),     // 20: FloodOnResponse
-	(*CoolantOffRequest)(nil),   // 21: CoolantOffRequest
-	(*CoolantOffResponse)(nil),  // 22: CoolantOffResponse
-	(*Response)(nil),            // 23: Response
+func (*Program) ProtoMessage() {}
+
+func (x *Program) ProtoReflect() protoreflect.Message {
+	mi := &file_grbl_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_grbl_proto_depIdxs = []int32{
-	1,  // 0: Alarm.alarm:type_name -> AlarmCode
-	2,  // 1: Error.error:type_name -> ErrorCode
-	3,  // 2: State.position:type_name -> Position
-	4,  // 3: State.offsets:type_name -> Offsets
-	5,  // 4: State.alarm:type_name -> Alarm
-	6,  // 5: State.error:type_name -> Error
-	0,  // 6: State.active:type_name -> Peripheral
-	7,  // 7: StateStreamResponse.state:type_name -> State
-	7,  // 8: Response.state:type_name -> State
-	12, // 9: Response.move:type_name -> MoveResponse
-	14, // 10: Response.spindleOn:type_name -> SpindleOnResponse
-	16, // 11: Response.spindleOff:type_name -> SpindleOffResponse
-	18, // 12: Response.mistOn:type_name -> MistOnResponse
-	20, // 13: Response.floodOn:type_name -> FloodOnResponse
-	22, // 14: Response.coolantOff:type_name -> CoolantOffResponse
-	10, // 15: GRBL.Home:input_type -> HomeRequest
-	8,  // 16: GRBL.StateStream:input_type -> StateStreamRequest
-	11, // 17: GRBL.Move:input_type -> MoveRequest
-	13, // 18: GRBL.SpindleOn:input_type -> SpindleOnRequest
-	15, // 19: GRBL.SpindleOff:input_type -> SpindleOffRequest
-	17, // 20: GRBL.MistOn:input_type -> MistOnRequest
-	19, // 21: GRBL.FloodOn:input_type -> FloodOnRequest
-	21, // 22: GRBL.CoolantOff:input_type -> CoolantOffRequest
-	23, // 23: GRBL.Home:output_type -> Response
-	9,  // 24: GRBL.StateStream:output_type -> StateStreamResponse
-	23, // 25: GRBL.Move:output_type -> Response
-	23, // 26: GRBL.SpindleOn:output_type -> Response
-	23, // 27: GRBL.SpindleOff:output_type -> Response
-	23, // 28: GRBL.MistOn:output_type -> Response
-	23, // 29: GRBL.FloodOn:output_type -> Response
-	23, // 30: GRBL.CoolantOff:output_type -> Response
-	23, // [23:31] is the sub-list for method output_type
-	15, // [15:23] is the sub-list for method input_type
-	15, // [15:15] is the sub-list for extension type_name
-	15, // [15:15] is the sub-list for extension extendee
-	0,  // [0:15] is the sub-list for field type_name
+
+// Deprecated: Use Program.ProtoReflect.Descriptor instead.
+func (*Program) Descriptor() ([]byte, []int) {
+	return file_grbl_proto_rawDescGZIP(), []int{21}
 }
 
-func init() { file_grbl_proto_init() }
-func file_grbl_proto_init() {
-	if File_grbl_proto != nil {
-		return
+func (x *Program) GetName() string {
+	if x != nil {
+		return x.Name
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_grbl_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Position); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_grbl_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Offsets); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return ""
+}
+
+func (x *Program) GetGcode() string {
+	if x != nil {
+		return x.Gcode
+	}
+	return ""
+}
+
+// The first request carries the program; later ones control it.
+type StreamProgramRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Request:
+	//	*StreamProgramRequest_Program
+	//	*StreamProgramRequest_Control
+	Request isStreamProgramRequest_Request `protobuf_oneof:"request"`
+}
+
+func (x *StreamProgramRequest) Reset() {
+	*x = StreamProgramRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grbl_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamProgramRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamProgramRequest) ProtoMessage() {}
+
+func (x *StreamProgramRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grbl_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_grbl_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Alarm); i {
-			case 0:
-				return &v.state
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamProgramRequest.ProtoReflect.Descriptor instead.
+func (*StreamProgramRequest) Descriptor() ([]byte, []int) {
+	return file_grbl_proto_rawDescGZIP(), []int{22}
+}
+
+func (m *StreamProgramRequest) GetRequest() isStreamProgramRequest_Request {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (x *StreamProgramRequest) GetProgram() *Program {
+	if x, ok := x.GetRequest().(*StreamProgramRequest_Program); ok {
+		return x.Program
+	}
+	return nil
+}
+
+func (x *StreamProgramRequest) GetControl() ProgramControl {
+	if x, ok := x.GetRequest().(*StreamProgramRequest_Control); ok {
+		return x.Control
+	}
+	return ProgramControl_ProgramControl_None
+}
+
+type isStreamProgramRequest_Request interface {
+	isStreamProgramRequest_Request()
+}
+
+type StreamProgramRequest_Program struct {
+	Program *Program `protobuf:"bytes,1,opt,name=program,proto3,oneof"`
+}
+
+type StreamProgramRequest_Control struct {
+	Control ProgramControl `protobuf:"varint,2,opt,name=control,proto3,enum=ProgramControl,oneof"`
+}
+
+func (*StreamProgramRequest_Program) isStreamProgramRequest_Request() {}
+
+func (*StreamProgramRequest_Control) isStreamProgramRequest_Request() {}
+
+type StreamProgramResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Event ProgramEvent `protobuf:"varint,1,opt,name=event,proto3,enum=ProgramEvent" json:"event,omitempty"`
+	// line of the program the block came from, counting from 1
+	Line  int32  `protobuf:"varint,2,opt,name=line,proto3" json:"line,omitempty"`
+	Block string `protobuf:"bytes,3,opt,name=block,proto3" json:"block,omitempty"`
+	// blocks acknowledged so far
+	Done    int32  `protobuf:"varint,4,opt,name=done,proto3" json:"done,omitempty"`
+	Total   int32  `protobuf:"varint,5,opt,name=total,proto3" json:"total,omitempty"`
+	Message string `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *StreamProgramResponse) Reset() {
+	*x = StreamProgramResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grbl_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamProgramResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamProgramResponse) ProtoMessage() {}
+
+func (x *StreamProgramResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_grbl_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamProgramResponse.ProtoReflect.Descriptor instead.
+func (*StreamProgramResponse) Descriptor() ([]byte, []int) {
+	return file_grbl_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *StreamProgramResponse) GetEvent() ProgramEvent {
+	if x != nil {
+		return x.Event
+	}
+	return ProgramEvent_ProgramEvent_Started
+}
+
+func (x *StreamProgramResponse) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *StreamProgramResponse) GetBlock() string {
+	if x != nil {
+		return x.Block
+	}
+	return ""
+}
+
+func (x *StreamProgramResponse) GetDone() int32 {
+	if x != nil {
+		return x.Done
+	}
+	return 0
+}
+
+func (x *StreamProgramResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *StreamProgramResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// ! -- pause motion, decelerating to a stop
+type FeedHoldRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// return once the machine has come to rest
+	Wait bool `protobuf:"varint,1,opt,name=wait,proto3" json:"wait,omitempty"`
+}
+
+func (x *FeedHoldRequest) Reset() {
+	*x = FeedHoldRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grbl_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FeedHoldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedHoldRequest) ProtoMessage() {}
+
+func (x *FeedHoldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grbl_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedHoldRequest.ProtoReflect.Descriptor instead.
+func (*FeedHoldRequest) Descriptor() ([]byte, []int) {
+	return file_grbl_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *FeedHoldRequest) GetWait() bool {
+	if x != nil {
+		return x.Wait
+	}
+	return false
+}
+
+// ~ -- resume after a feed hold
+type CycleStartRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CycleStartRequest) Reset() {
+	*x = CycleStartRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grbl_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CycleStartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CycleStartRequest) ProtoMessage() {}
+
+func (x *CycleStartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grbl_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CycleStartRequest.ProtoReflect.Descriptor instead.
+func (*CycleStartRequest) Descriptor() ([]byte, []int) {
+	return file_grbl_proto_rawDescGZIP(), []int{25}
+}
+
+// Ctrl-X -- stop immediately and discard everything buffered
+type SoftResetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SoftResetRequest) Reset() {
+	*x = SoftResetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grbl_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SoftResetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SoftResetRequest) ProtoMessage() {}
+
+func (x *SoftResetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grbl_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SoftResetRequest.ProtoReflect.Descriptor instead.
+func (*SoftResetRequest) Descriptor() ([]byte, []int) {
+	return file_grbl_proto_rawDescGZIP(), []int{26}
+}
+
+// ? -- ask for a status report
+type StatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *StatusRequest) Reset() {
+	*x = StatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grbl_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusRequest) ProtoMessage() {}
+
+func (x *StatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grbl_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusRequest.ProtoReflect.Descriptor instead.
+func (*StatusRequest) Descriptor() ([]byte, []int) {
+	return file_grbl_proto_rawDescGZIP(), []int{27}
+}
+
+// $J= -- jog to a position, or by a distance when relative
+type JogRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// xyz position in mm
+	X *float32 `protobuf:"fixed32,1,opt,name=x,proto3,oneof" json:"x,omitempty"`
+	// xyz position in mm
+	Y *float32 `protobuf:"fixed32,2,opt,name=y,proto3,oneof" json:"y,omitempty"`
+	// xyz position in mm
+	Z *float32 `protobuf:"fixed32,3,opt,name=z,proto3,oneof" json:"z,omitempty"`
+	// speed in mm/min
+	Feed     float32 `protobuf:"fixed32,4,opt,name=feed,proto3" json:"feed,omitempty"`
+	Relative bool    `protobuf:"varint,5,opt,name=relative,proto3" json:"relative,omitempty"`
+}
+
+func (x *JogRequest) Reset() {
+	*x = JogRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grbl_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JogRequest) ProtoMessage() {}
+
+func (x *JogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grbl_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JogRequest.ProtoReflect.Descriptor instead.
+func (*JogRequest) Descriptor() ([]byte, []int) {
+	return file_grbl_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *JogRequest) GetX() float32 {
+	if x != nil && x.X != nil {
+		return *x.X
+	}
+	return 0
+}
+
+func (x *JogRequest) GetY() float32 {
+	if x != nil && x.Y != nil {
+		return *x.Y
+	}
+	return 0
+}
+
+func (x *JogRequest) GetZ() float32 {
+	if x != nil && x.Z != nil {
+		return *x.Z
+	}
+	return 0
+}
+
+func (x *JogRequest) GetFeed() float32 {
+	if x != nil {
+		return x.Feed
+	}
+	return 0
+}
+
+func (x *JogRequest) GetRelative() bool {
+	if x != nil {
+		return x.Relative
+	}
+	return false
+}
+
+// 0x85 -- stop jogging and discard the queued jog motions
+type JogCancelRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *JogCancelRequest) Reset() {
+	*x = JogCancelRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grbl_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JogCancelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JogCancelRequest) ProtoMessage() {}
+
+func (x *JogCancelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grbl_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JogCancelRequest.ProtoReflect.Descriptor instead.
+func (*JogCancelRequest) Descriptor() ([]byte, []int) {
+	return file_grbl_proto_rawDescGZIP(), []int{29}
+}
+
+// Overrides are percentages of the programmed value. Fields not set are
+// left as they are.
+type OverrideRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// 10-200, in steps of 1
+	Feed *uint32 `protobuf:"varint,1,opt,name=feed,proto3,oneof" json:"feed,omitempty"`
+	// 25, 50 or 100
+	Rapid *uint32 `protobuf:"varint,2,opt,name=rapid,proto3,oneof" json:"rapid,omitempty"`
+	// 10-200, in steps of 1
+	Spindle *uint32 `protobuf:"varint,3,opt,name=spindle,proto3,oneof" json:"spindle,omitempty"`
+}
+
+func (x *OverrideRequest) Reset() {
+	*x = OverrideRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grbl_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OverrideRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OverrideRequest) ProtoMessage() {}
+
+func (x *OverrideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grbl_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OverrideRequest.ProtoReflect.Descriptor instead.
+func (*OverrideRequest) Descriptor() ([]byte, []int) {
+	return file_grbl_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *OverrideRequest) GetFeed() uint32 {
+	if x != nil && x.Feed != nil {
+		return *x.Feed
+	}
+	return 0
+}
+
+func (x *OverrideRequest) GetRapid() uint32 {
+	if x != nil && x.Rapid != nil {
+		return *x.Rapid
+	}
+	return 0
+}
+
+func (x *OverrideRequest) GetSpindle() uint32 {
+	if x != nil && x.Spindle != nil {
+		return *x.Spindle
+	}
+	return 0
+}
+
+// $n=value -- a controller setting, such as 100 for X steps/mm
+type Setting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Number uint32  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Value  float64 `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *Setting) Reset() {
+	*x = Setting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grbl_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Setting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Setting) ProtoMessage() {}
+
+func (x *Setting) ProtoReflect() protoreflect.Message {
+	mi := &file_grbl_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Setting.ProtoReflect.Descriptor instead.
+func (*Setting) Descriptor() ([]byte, []int) {
+	return file_grbl_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *Setting) GetNumber() uint32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *Setting) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+// $$ -- read every setting
+type GetSettingsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetSettingsRequest) Reset() {
+	*x = GetSettingsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grbl_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSettingsRequest) ProtoMessage() {}
+
+func (x *GetSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grbl_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSettingsRequest.ProtoReflect.Descriptor instead.
+func (*GetSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_grbl_proto_rawDescGZIP(), []int{32}
+}
+
+type GetSettingsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Settings []*Setting `protobuf:"bytes,1,rep,name=settings,proto3" json:"settings,omitempty"`
+}
+
+func (x *GetSettingsResponse) Reset() {
+	*x = GetSettingsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grbl_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSettingsResponse) ProtoMessage() {}
+
+func (x *GetSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_grbl_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSettingsResponse.ProtoReflect.Descriptor instead.
+func (*GetSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_grbl_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetSettingsResponse) GetSettings() []*Setting {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+// $n=value -- write a setting. GRBL must be idle.
+type SetSettingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Setting *Setting `protobuf:"bytes,1,opt,name=setting,proto3" json:"setting,omitempty"`
+}
+
+func (x *SetSettingRequest) Reset() {
+	*x = SetSettingRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grbl_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetSettingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSettingRequest) ProtoMessage() {}
+
+func (x *SetSettingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grbl_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetSettingRequest.ProtoReflect.Descriptor instead.
+func (*SetSettingRequest) Descriptor() ([]byte, []int) {
+	return file_grbl_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SetSettingRequest) GetSetting() *Setting {
+	if x != nil {
+		return x.Setting
+	}
+	return nil
+}
+
+type SettingsProfile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version  string     `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Settings []*Setting `protobuf:"bytes,2,rep,name=settings,proto3" json:"settings,omitempty"`
+}
+
+func (x *SettingsProfile) Reset() {
+	*x = SettingsProfile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grbl_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SettingsProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SettingsProfile) ProtoMessage() {}
+
+func (x *SettingsProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_grbl_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SettingsProfile.ProtoReflect.Descriptor instead.
+func (*SettingsProfile) Descriptor() ([]byte, []int) {
+	return file_grbl_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SettingsProfile) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *SettingsProfile) GetSettings() []*Setting {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type SettingDiff struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Number uint32 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	// value in the profile, unset if the profile does not have the setting
+	Want *float64 `protobuf:"fixed64,2,opt,name=want,proto3,oneof" json:"want,omitempty"`
+	// value on the controller, unset if the controller did not report it
+	Got *float64 `protobuf:"fixed64,3,opt,name=got,proto3,oneof" json:"got,omitempty"`
+}
+
+func (x *SettingDiff) Reset() {
+	*x = SettingDiff{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grbl_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SettingDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SettingDiff) ProtoMessage() {}
+
+func (x *SettingDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_grbl_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SettingDiff.ProtoReflect.Descriptor instead.
+func (*SettingDiff) Descriptor() ([]byte, []int) {
+	return file_grbl_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *SettingDiff) GetNumber() uint32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *SettingDiff) GetWant() float64 {
+	if x != nil && x.Want != nil {
+		return *x.Want
+	}
+	return 0
+}
+
+func (x *SettingDiff) GetGot() float64 {
+	if x != nil && x.Got != nil {
+		return *x.Got
+	}
+	return 0
+}
+
+type DiffSettingsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Profile *SettingsProfile `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+}
+
+func (x *DiffSettingsRequest) Reset() {
+	*x = DiffSettingsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grbl_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DiffSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffSettingsRequest) ProtoMessage() {}
+
+func (x *DiffSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grbl_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffSettingsRequest.ProtoReflect.Descriptor instead.
+func (*DiffSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_grbl_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *DiffSettingsRequest) GetProfile() *SettingsProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+type DiffSettingsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Diffs []*SettingDiff `protobuf:"bytes,1,rep,name=diffs,proto3" json:"diffs,omitempty"`
+}
+
+func (x *DiffSettingsResponse) Reset() {
+	*x = DiffSettingsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grbl_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DiffSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffSettingsResponse) ProtoMessage() {}
+
+func (x *DiffSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_grbl_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffSettingsResponse.ProtoReflect.Descriptor instead.
+func (*DiffSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_grbl_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *DiffSettingsResponse) GetDiffs() []*SettingDiff {
+	if x != nil {
+		return x.Diffs
+	}
+	return nil
+}
+
+// write the settings that differ from the profile
+type ApplyProfileRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Profile *SettingsProfile `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+}
+
+func (x *ApplyProfileRequest) Reset() {
+	*x = ApplyProfileRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grbl_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApplyProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyProfileRequest) ProtoMessage() {}
+
+func (x *ApplyProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grbl_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyProfileRequest.ProtoReflect.Descriptor instead.
+func (*ApplyProfileRequest) Descriptor() ([]byte, []int) {
+	return file_grbl_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ApplyProfileRequest) GetProfile() *SettingsProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+type ApplyProfileResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// settings that were written
+	Applied []*SettingDiff `protobuf:"bytes,1,rep,name=applied,proto3" json:"applied,omitempty"`
+}
+
+func (x *ApplyProfileResponse) Reset() {
+	*x = ApplyProfileResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grbl_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApplyProfileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyProfileResponse) ProtoMessage() {}
+
+func (x *ApplyProfileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_grbl_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyProfileResponse.ProtoReflect.Descriptor instead.
+func (*ApplyProfileResponse) Descriptor() ([]byte, []int) {
+	return file_grbl_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ApplyProfileResponse) GetApplied() []*SettingDiff {
+	if x != nil {
+		return x.Applied
+	}
+	return nil
+}
+
+// $G -- read the G-code parser state
+type ParserStateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ParserStateRequest) Reset() {
+	*x = ParserStateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grbl_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ParserStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParserStateRequest) ProtoMessage() {}
+
+func (x *ParserStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grbl_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParserStateRequest.ProtoReflect.Descriptor instead.
+func (*ParserStateRequest) Descriptor() ([]byte, []int) {
+	return file_grbl_proto_rawDescGZIP(), []int{41}
+}
+
+type ParserState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// active modal G and M words, such as G54 or M5
+	Modes []string `protobuf:"bytes,1,rep,name=modes,proto3" json:"modes,omitempty"`
+	Tool  int32    `protobuf:"varint,2,opt,name=tool,proto3" json:"tool,omitempty"`
+	// mm/min
+	Feed float32 `protobuf:"fixed32,3,opt,name=feed,proto3" json:"feed,omitempty"`
+	// rpm
+	Spindle float32 `protobuf:"fixed32,4,opt,name=spindle,proto3" json:"spindle,omitempty"`
+}
+
+func (x *ParserState) Reset() {
+	*x = ParserState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grbl_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ParserState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParserState) ProtoMessage() {}
+
+func (x *ParserState) ProtoReflect() protoreflect.Message {
+	mi := &file_grbl_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParserState.ProtoReflect.Descriptor instead.
+func (*ParserState) Descriptor() ([]byte, []int) {
+	return file_grbl_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ParserState) GetModes() []string {
+	if x != nil {
+		return x.Modes
+	}
+	return nil
+}
+
+func (x *ParserState) GetTool() int32 {
+	if x != nil {
+		return x.Tool
+	}
+	return 0
+}
+
+func (x *ParserState) GetFeed() float32 {
+	if x != nil {
+		return x.Feed
+	}
+	return 0
+}
+
+func (x *ParserState) GetSpindle() float32 {
+	if x != nil {
+		return x.Spindle
+	}
+	return 0
+}
+
+// $I -- read the build info
+type BuildInfoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *BuildInfoRequest) Reset() {
+	*x = BuildInfoRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grbl_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BuildInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildInfoRequest) ProtoMessage() {}
+
+func (x *BuildInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grbl_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildInfoRequest.ProtoReflect.Descriptor instead.
+func (*BuildInfoRequest) Descriptor() ([]byte, []int) {
+	return file_grbl_proto_rawDescGZIP(), []int{43}
+}
+
+type BuildInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// build info string stored on the controller
+	Info string `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	// compile-time options, as reported by [OPT:]
+	Options string `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+}
+
+func (x *BuildInfo) Reset() {
+	*x = BuildInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grbl_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BuildInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildInfo) ProtoMessage() {}
+
+func (x *BuildInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_grbl_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildInfo.ProtoReflect.Descriptor instead.
+func (*BuildInfo) Descriptor() ([]byte, []int) {
+	return file_grbl_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *BuildInfo) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *BuildInfo) GetInfo() string {
+	if x != nil {
+		return x.Info
+	}
+	return ""
+}
+
+func (x *BuildInfo) GetOptions() string {
+	if x != nil {
+		return x.Options
+	}
+	return ""
+}
+
+var File_grbl_proto protoreflect.FileDescriptor
+
+var file_grbl_proto_rawDesc = []byte{
+	0x0a, 0x0a, 0x67, 0x72, 0x62, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x34, 0x0a, 0x08,
+	0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x02, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x02, 0x52, 0x01, 0x79, 0x12, 0x0c, 0x0a, 0x01, 0x7a, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52,
+	0x01, 0x7a, 0x22, 0x7b, 0x0a, 0x07, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x73, 0x12, 0x17, 0x0a,
+	0x04, 0x66, 0x65, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x04, 0x66,
+	0x65, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x19, 0x0a, 0x05, 0x72, 0x61, 0x70, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x01, 0x52, 0x05, 0x72, 0x61, 0x70, 0x69, 0x64, 0x88, 0x01,
+	0x01, 0x12, 0x1d, 0x0a, 0x07, 0x73, 0x70, 0x69, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0d, 0x48, 0x02, 0x52, 0x07, 0x73, 0x70, 0x69, 0x6e, 0x64, 0x6c, 0x65, 0x88, 0x01, 0x01,
+	0x42, 0x07, 0x0a, 0x05, 0x5f, 0x66, 0x65, 0x65, 0x64, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x72, 0x61,
+	0x70, 0x69, 0x64, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x73, 0x70, 0x69, 0x6e, 0x64, 0x6c, 0x65, 0x22,
+	0x63, 0x0a, 0x05, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x12, 0x25, 0x0a, 0x05, 0x61, 0x6c, 0x61, 0x72,
+	0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0a, 0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x43,
+	0x6f, 0x64, 0x65, 0x48, 0x00, 0x52, 0x05, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x88, 0x01, 0x01, 0x12,
+	0x1d, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x01, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x88, 0x01, 0x01, 0x42, 0x08,
+	0x0a, 0x06, 0x5f, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x22, 0x63, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x25, 0x0a,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0a, 0x2e, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x88, 0x01, 0x01, 0x12, 0x1d, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x42, 0x0a, 0x0a,
+	0x08, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xf6, 0x01, 0x0a, 0x05, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x25, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x65,
+	0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x04, 0x66, 0x65, 0x65, 0x64, 0x12, 0x27,
+	0x0a, 0x07, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x08, 0x2e, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x73, 0x48, 0x00, 0x52, 0x07, 0x6f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x73, 0x88, 0x01, 0x01, 0x12, 0x21, 0x0a, 0x05, 0x61, 0x6c, 0x61, 0x72, 0x6d,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x48, 0x01,
+	0x52, 0x05, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x88, 0x01, 0x01, 0x12, 0x21, 0x0a, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x48, 0x02, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x88, 0x01, 0x01, 0x12, 0x23, 0x0a,
+	0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x0b, 0x2e,
+	0x50, 0x65, 0x72, 0x69, 0x70, 0x68, 0x65, 0x72, 0x61, 0x6c, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x73, 0x42, 0x08,
+	0x0a, 0x06, 0x5f, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x22, 0x14, 0x0a, 0x12, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x51, 0x0a, 0x13, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x1c, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x06,
+	0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x59, 0x0a, 0x0b, 0x48,
+	0x6f, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x03, 0x61, 0x6c,
+	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x03, 0x61, 0x6c, 0x6c, 0x12, 0x0e,
+	0x0a, 0x01, 0x58, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x01, 0x58, 0x12, 0x0e,
+	0x0a, 0x01, 0x59, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x01, 0x59, 0x12, 0x0e,
+	0x0a, 0x01, 0x5a, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x01, 0x5a, 0x42, 0x06,
+	0x0a, 0x04, 0x61, 0x78, 0x69, 0x73, 0x22, 0x7d, 0x0a, 0x0b, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x11, 0x0a, 0x01, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02,
+	0x48, 0x00, 0x52, 0x01, 0x78, 0x88, 0x01, 0x01, 0x12, 0x11, 0x0a, 0x01, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x02, 0x48, 0x01, 0x52, 0x01, 0x79, 0x88, 0x01, 0x01, 0x12, 0x11, 0x0a, 0x01, 0x7a,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x48, 0x02, 0x52, 0x01, 0x7a, 0x88, 0x01, 0x01, 0x12, 0x19,
+	0x0a, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x48, 0x03, 0x52,
+	0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x88, 0x01, 0x01, 0x42, 0x04, 0x0a, 0x02, 0x5f, 0x78, 0x42,
+	0x04, 0x0a, 0x02, 0x5f, 0x79, 0x42, 0x04, 0x0a, 0x02, 0x5f, 0x7a, 0x42, 0x08, 0x0a, 0x06, 0x5f,
+	0x73, 0x70, 0x65, 0x65, 0x64, 0x22, 0x28, 0x0a, 0x0c, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
+	0x37, 0x0a, 0x10, 0x53, 0x70, 0x69, 0x6e, 0x64, 0x6c, 0x65, 0x4f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x48, 0x00, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x88, 0x01, 0x01, 0x42, 0x08,
+	0x0a, 0x06, 0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x22, 0x2d, 0x0a, 0x11, 0x53, 0x70, 0x69, 0x6e,
+	0x64, 0x6c, 0x65, 0x4f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x13, 0x0a, 0x11, 0x53, 0x70, 0x69, 0x6e, 0x64,
+	0x6c, 0x65, 0x4f, 0x66, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x2e, 0x0a, 0x12,
+	0x53, 0x70, 0x69, 0x6e, 0x64, 0x6c, 0x65, 0x4f, 0x66, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x0f, 0x0a, 0x0d,
+	0x4d, 0x69, 0x73, 0x74, 0x4f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x2a, 0x0a,
+	0x0e, 0x4d, 0x69, 0x73, 0x74, 0x4f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x10, 0x0a, 0x0e, 0x46, 0x6c, 0x6f,
+	0x6f, 0x64, 0x4f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x2b, 0x0a, 0x0f, 0x46,
+	0x6c, 0x6f, 0x6f, 0x64, 0x4f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x13, 0x0a, 0x11, 0x43, 0x6f, 0x6f, 0x6c,
+	0x61, 0x6e, 0x74, 0x4f, 0x66, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x2e, 0x0a,
+	0x12, 0x43, 0x6f, 0x6f, 0x6c, 0x61, 0x6e, 0x74, 0x4f, 0x66, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xf0, 0x02,
+	0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x1e, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x12, 0x23, 0x0a, 0x04, 0x6d, 0x6f, 0x76, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x48, 0x00, 0x52, 0x04, 0x6d, 0x6f, 0x76, 0x65, 0x12, 0x32, 0x0a, 0x09, 0x73, 0x70, 0x69,
+	0x6e, 0x64, 0x6c, 0x65, 0x4f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x53,
+	0x70, 0x69, 0x6e, 0x64, 0x6c, 0x65, 0x4f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x48, 0x00, 0x52, 0x09, 0x73, 0x70, 0x69, 0x6e, 0x64, 0x6c, 0x65, 0x4f, 0x6e, 0x12, 0x35, 0x0a,
+	0x0a, 0x73, 0x70, 0x69, 0x6e, 0x64, 0x6c, 0x65, 0x4f, 0x66, 0x66, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x53, 0x70, 0x69, 0x6e, 0x64, 0x6c, 0x65, 0x4f, 0x66, 0x66, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x73, 0x70, 0x69, 0x6e, 0x64, 0x6c,
+	0x65, 0x4f, 0x66, 0x66, 0x12, 0x29, 0x0a, 0x06, 0x6d, 0x69, 0x73, 0x74, 0x4f, 0x6e, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x4d, 0x69, 0x73, 0x74, 0x4f, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x06, 0x6d, 0x69, 0x73, 0x74, 0x4f, 0x6e, 0x12,
+	0x2c, 0x0a, 0x07, 0x66, 0x6c, 0x6f, 0x6f, 0x64, 0x4f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x46, 0x6c, 0x6f, 0x6f, 0x64, 0x4f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x48, 0x00, 0x52, 0x07, 0x66, 0x6c, 0x6f, 0x6f, 0x64, 0x4f, 0x6e, 0x12, 0x35, 0x0a,
+	0x0a, 0x63, 0x6f, 0x6f, 0x6c, 0x61, 0x6e, 0x74, 0x4f, 0x66, 0x66, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x43, 0x6f, 0x6f, 0x6c, 0x61, 0x6e, 0x74, 0x4f, 0x66, 0x66, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x63, 0x6f, 0x6f, 0x6c, 0x61, 0x6e,
+	0x74, 0x4f, 0x66, 0x66, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x33, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x67, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x67, 0x63, 0x6f, 0x64, 0x65, 0x22, 0x74, 0x0a, 0x14, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x50,
+	0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x24, 0x0a,
+	0x07, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08,
+	0x2e, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x48, 0x00, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x67,
+	0x72, 0x61, 0x6d, 0x12, 0x2b, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x43, 0x6f,
+	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x48, 0x00, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x42, 0x09, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xaa, 0x01, 0x0a, 0x15,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69,
+	0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x6f, 0x6e, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x04, 0x64, 0x6f, 0x6e, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x18,
+	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x25, 0x0a, 0x0f, 0x46, 0x65, 0x65, 0x64,
+	0x48, 0x6f, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x77,
+	0x61, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x77, 0x61, 0x69, 0x74, 0x22,
+	0x13, 0x0a, 0x11, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x12, 0x0a, 0x10, 0x53, 0x6f, 0x66, 0x74, 0x52, 0x65, 0x73, 0x65,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x0f, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x87, 0x01, 0x0a, 0x0a, 0x4a, 0x6f,
+	0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x11, 0x0a, 0x01, 0x78, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x02, 0x48, 0x00, 0x52, 0x01, 0x78, 0x88, 0x01, 0x01, 0x12, 0x11, 0x0a, 0x01, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x48, 0x01, 0x52, 0x01, 0x79, 0x88, 0x01, 0x01, 0x12, 0x11,
+	0x0a, 0x01, 0x7a, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x48, 0x02, 0x52, 0x01, 0x7a, 0x88, 0x01,
+	0x01, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x65, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x52,
+	0x04, 0x66, 0x65, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76,
+	0x65, 0x42, 0x04, 0x0a, 0x02, 0x5f, 0x78, 0x42, 0x04, 0x0a, 0x02, 0x5f, 0x79, 0x42, 0x04, 0x0a,
+	0x02, 0x5f, 0x7a, 0x22, 0x12, 0x0a, 0x10, 0x4a, 0x6f, 0x67, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x83, 0x01, 0x0a, 0x0f, 0x4f, 0x76, 0x65, 0x72,
+	0x72, 0x69, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x04, 0x66,
+	0x65, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x04, 0x66, 0x65, 0x65,
+	0x64, 0x88, 0x01, 0x01, 0x12, 0x19, 0x0a, 0x05, 0x72, 0x61, 0x70, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x48, 0x01, 0x52, 0x05, 0x72, 0x61, 0x70, 0x69, 0x64, 0x88, 0x01, 0x01, 0x12,
+	0x1d, 0x0a, 0x07, 0x73, 0x70, 0x69, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
+	0x48, 0x02, 0x52, 0x07, 0x73, 0x70, 0x69, 0x6e, 0x64, 0x6c, 0x65, 0x88, 0x01, 0x01, 0x42, 0x07,
+	0x0a, 0x05, 0x5f, 0x66, 0x65, 0x65, 0x64, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x72, 0x61, 0x70, 0x69,
+	0x64, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x73, 0x70, 0x69, 0x6e, 0x64, 0x6c, 0x65, 0x22, 0x37, 0x0a,
+	0x07, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x14, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x53, 0x65, 0x74,
+	0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x3b, 0x0a, 0x13,
+	0x47, 0x65, 0x74, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a, 0x08, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52,
+	0x08, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x37, 0x0a, 0x11, 0x53, 0x65, 0x74,
+	0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22,
+	0x0a, 0x07, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x08, 0x2e, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x07, 0x73, 0x65, 0x74, 0x74, 0x69,
+	0x6e, 0x67, 0x22, 0x51, 0x0a, 0x0f, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x50, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x24, 0x0a, 0x08, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x08, 0x2e, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x08, 0x73, 0x65, 0x74,
+	0x74, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x66, 0x0a, 0x0b, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x44, 0x69, 0x66, 0x66, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x17, 0x0a, 0x04,
+	0x77, 0x61, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x04, 0x77, 0x61,
+	0x6e, 0x74, 0x88, 0x01, 0x01, 0x12, 0x15, 0x0a, 0x03, 0x67, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x01, 0x48, 0x01, 0x52, 0x03, 0x67, 0x6f, 0x74, 0x88, 0x01, 0x01, 0x42, 0x07, 0x0a, 0x05,
+	0x5f, 0x77, 0x61, 0x6e, 0x74, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x67, 0x6f, 0x74, 0x22, 0x41, 0x0a,
+	0x13, 0x44, 0x69, 0x66, 0x66, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73,
+	0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x22, 0x3a, 0x0a, 0x14, 0x44, 0x69, 0x66, 0x66, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x64, 0x69, 0x66, 0x66,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e,
+	0x67, 0x44, 0x69, 0x66, 0x66, 0x52, 0x05, 0x64, 0x69, 0x66, 0x66, 0x73, 0x22, 0x41, 0x0a, 0x13,
+	0x41, 0x70, 0x70, 0x6c, 0x79, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x50,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x22,
+	0x3e, 0x0a, 0x14, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x07, 0x61, 0x70, 0x70, 0x6c, 0x69,
+	0x65, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x53, 0x65, 0x74, 0x74, 0x69,
+	0x6e, 0x67, 0x44, 0x69, 0x66, 0x66, 0x52, 0x07, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x22,
+	0x14, 0x0a, 0x12, 0x50, 0x61, 0x72, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x65, 0x0a, 0x0b, 0x50, 0x61, 0x72, 0x73, 0x65, 0x72, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x6f,
+	0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x74, 0x6f, 0x6f, 0x6c, 0x12, 0x12,
+	0x0a, 0x04, 0x66, 0x65, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x04, 0x66, 0x65,
+	0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x70, 0x69, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x02, 0x52, 0x07, 0x73, 0x70, 0x69, 0x6e, 0x64, 0x6c, 0x65, 0x22, 0x12, 0x0a, 0x10,
+	0x42, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x53, 0x0a, 0x09, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18, 0x0a,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x6f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2a, 0x38, 0x0a, 0x0a, 0x50, 0x65, 0x72, 0x69, 0x70, 0x68, 0x65,
+	0x72, 0x61, 0x6c, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x6f, 0x6e, 0x65, 0x10, 0x00, 0x12, 0x0b, 0x0a,
+	0x07, 0x53, 0x70, 0x69, 0x6e, 0x64, 0x6c, 0x65, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x4d, 0x69,
+	0x73, 0x74, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x46, 0x6c, 0x6f, 0x6f, 0x64, 0x10, 0x03, 0x2a,
+	0x87, 0x02, 0x0a, 0x09, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x15, 0x0a,
+	0x11, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x4e, 0x6f, 0x41, 0x6c, 0x61,
+	0x72, 0x6d, 0x10, 0x00, 0x12, 0x17, 0x0a, 0x13, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x43, 0x6f, 0x64,
+	0x65, 0x5f, 0x48, 0x61, 0x72, 0x64, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x10, 0x01, 0x12, 0x17, 0x0a,
+	0x13, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x53, 0x6f, 0x66, 0x74, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x43,
+	0x6f, 0x64, 0x65, 0x5f, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x10, 0x03, 0x12, 0x17, 0x0a, 0x13, 0x41,
+	0x6c, 0x61, 0x72, 0x6d, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x46, 0x61,
+	0x69, 0x6c, 0x10, 0x04, 0x12, 0x18, 0x0a, 0x14, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x43, 0x6f, 0x64,
+	0x65, 0x5f, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x32, 0x10, 0x05, 0x12, 0x18,
+	0x0a, 0x14, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x48, 0x6f, 0x6d, 0x69,
+	0x6e, 0x67, 0x46, 0x61, 0x69, 0x6c, 0x10, 0x06, 0x12, 0x19, 0x0a, 0x15, 0x41, 0x6c, 0x61, 0x72,
+	0x6d, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x48, 0x6f, 0x6d, 0x69, 0x6e, 0x67, 0x46, 0x61, 0x69, 0x6c,
+	0x32, 0x10, 0x07, 0x12, 0x19, 0x0a, 0x15, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x43, 0x6f, 0x64, 0x65,
+	0x5f, 0x48, 0x6f, 0x6d, 0x69, 0x6e, 0x67, 0x46, 0x61, 0x69, 0x6c, 0x33, 0x10, 0x08, 0x12, 0x19,
+	0x0a, 0x15, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x48, 0x6f, 0x6d, 0x69,
+	0x6e, 0x67, 0x46, 0x61, 0x69, 0x6c, 0x34, 0x10, 0x09, 0x2a, 0x87, 0x09, 0x0a, 0x09, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x15, 0x0a, 0x11, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x43, 0x6f, 0x64, 0x65, 0x5f, 0x4e, 0x6f, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x10, 0x00, 0x12, 0x23,
+	0x0a, 0x1f, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x45, 0x78, 0x70, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65,
+	0x72, 0x10, 0x01, 0x12, 0x1d, 0x0a, 0x19, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65,
+	0x5f, 0x42, 0x61, 0x64, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74,
+	0x10, 0x02, 0x12, 0x1e, 0x0a, 0x1a, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f,
+	0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x10, 0x03, 0x12, 0x1b, 0x0a, 0x17, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f,
+	0x4e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x10, 0x04, 0x12,
+	0x20, 0x0a, 0x1c, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x48, 0x6f, 0x6d,
+	0x69, 0x6e, 0x67, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x10,
+	0x05, 0x12, 0x1e, 0x0a, 0x1a, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x4d,
+	0x69, 0x6e, 0x53, 0x74, 0x65, 0x70, 0x50, 0x75, 0x6c, 0x73, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x10,
+	0x06, 0x12, 0x1f, 0x0a, 0x1b, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x45,
+	0x45, 0x50, 0x52, 0x4f, 0x4d, 0x52, 0x65, 0x61, 0x64, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65,
+	0x10, 0x07, 0x12, 0x15, 0x0a, 0x11, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f,
+	0x4e, 0x6f, 0x74, 0x49, 0x64, 0x6c, 0x65, 0x10, 0x08, 0x12, 0x17, 0x0a, 0x13, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x47, 0x43, 0x6f, 0x64, 0x65, 0x4c, 0x6f, 0x63, 0x6b,
+	0x10, 0x09, 0x12, 0x1e, 0x0a, 0x1a, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f,
+	0x48, 0x6f, 0x6d, 0x69, 0x6e, 0x67, 0x4e, 0x6f, 0x74, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64,
+	0x10, 0x0a, 0x12, 0x1a, 0x0a, 0x16, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f,
+	0x4c, 0x69, 0x6e, 0x65, 0x4f, 0x76, 0x65, 0x72, 0x66, 0x6c, 0x6f, 0x77, 0x10, 0x0b, 0x12, 0x21,
+	0x0a, 0x1d, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x4d, 0x61, 0x78, 0x53,
+	0x74, 0x65, 0x70, 0x52, 0x61, 0x74, 0x65, 0x45, 0x78, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x10,
+	0x0c, 0x12, 0x20, 0x0a, 0x1c, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x53,
+	0x61, 0x66, 0x65, 0x74, 0x79, 0x44, 0x6f, 0x6f, 0x72, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x65,
+	0x64, 0x10, 0x0d, 0x12, 0x25, 0x0a, 0x21, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65,
+	0x5f, 0x4c, 0x69, 0x6e, 0x65, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x45, 0x78, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x10, 0x0e, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x54, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x45, 0x78,
+	0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x10, 0x0f, 0x12, 0x1f, 0x0a, 0x1b, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x4a, 0x6f, 0x67,
+	0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x10, 0x10, 0x12, 0x22, 0x0a, 0x1e, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x4c, 0x61, 0x73, 0x65, 0x72, 0x4d, 0x6f, 0x64, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x73, 0x50, 0x57, 0x4d, 0x10, 0x11, 0x12, 0x20, 0x0a,
+	0x1c, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x55, 0x6e, 0x73, 0x75, 0x70,
+	0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x10, 0x14, 0x12,
+	0x21, 0x0a, 0x1d, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x4d, 0x6f, 0x64,
+	0x61, 0x6c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x10, 0x15, 0x12, 0x1f, 0x0a, 0x1b, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f,
+	0x55, 0x6e, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x65, 0x64, 0x46, 0x65, 0x65, 0x64, 0x52, 0x61, 0x74,
+	0x65, 0x10, 0x16, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65,
+	0x5f, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x47, 0x43, 0x6f, 0x64, 0x65, 0x32, 0x33, 0x10,
+	0x17, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x49,
+	0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x47, 0x43, 0x6f, 0x64, 0x65, 0x32, 0x34, 0x10, 0x18, 0x12,
+	0x1c, 0x0a, 0x18, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x49, 0x6e, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x47, 0x43, 0x6f, 0x64, 0x65, 0x32, 0x35, 0x10, 0x19, 0x12, 0x1c, 0x0a,
+	0x18, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x49, 0x6e, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x47, 0x43, 0x6f, 0x64, 0x65, 0x32, 0x36, 0x10, 0x1a, 0x12, 0x1c, 0x0a, 0x18, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x47, 0x43, 0x6f, 0x64, 0x65, 0x32, 0x37, 0x10, 0x1b, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x47, 0x43,
+	0x6f, 0x64, 0x65, 0x32, 0x38, 0x10, 0x1c, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x43, 0x6f, 0x64, 0x65, 0x5f, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x47, 0x43, 0x6f, 0x64,
+	0x65, 0x32, 0x39, 0x10, 0x1d, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f,
+	0x64, 0x65, 0x5f, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x47, 0x43, 0x6f, 0x64, 0x65, 0x33,
+	0x30, 0x10, 0x1e, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65,
+	0x5f, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x47, 0x43, 0x6f, 0x64, 0x65, 0x33, 0x31, 0x10,
+	0x1f, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x49,
+	0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x47, 0x43, 0x6f, 0x64, 0x65, 0x33, 0x32, 0x10, 0x20, 0x12,
+	0x1c, 0x0a, 0x18, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x49, 0x6e, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x47, 0x43, 0x6f, 0x64, 0x65, 0x33, 0x33, 0x10, 0x21, 0x12, 0x1c, 0x0a,
+	0x18, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x49, 0x6e, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x47, 0x43, 0x6f, 0x64, 0x65, 0x33, 0x34, 0x10, 0x22, 0x12, 0x1c, 0x0a, 0x18, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x47, 0x43, 0x6f, 0x64, 0x65, 0x33, 0x35, 0x10, 0x23, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x47, 0x43,
+	0x6f, 0x64, 0x65, 0x33, 0x36, 0x10, 0x24, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x43, 0x6f, 0x64, 0x65, 0x5f, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x47, 0x43, 0x6f, 0x64,
+	0x65, 0x33, 0x37, 0x10, 0x25, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f,
+	0x64, 0x65, 0x5f, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x47, 0x43, 0x6f, 0x64, 0x65, 0x33,
+	0x38, 0x10, 0x26, 0x2a, 0x78, 0x0a, 0x0e, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x43, 0x6f,
+	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x12, 0x17, 0x0a, 0x13, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d,
+	0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x4e, 0x6f, 0x6e, 0x65, 0x10, 0x00, 0x12, 0x17,
+	0x0a, 0x13, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x5f, 0x48, 0x6f, 0x6c, 0x64, 0x10, 0x01, 0x12, 0x19, 0x0a, 0x15, 0x50, 0x72, 0x6f, 0x67, 0x72,
+	0x61, 0x6d, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65,
+	0x10, 0x02, 0x12, 0x19, 0x0a, 0x15, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x43, 0x6f, 0x6e,
+	0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x10, 0x03, 0x2a, 0xc2, 0x01,
+	0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x18,
+	0x0a, 0x14, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x53,
+	0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x50, 0x72, 0x6f, 0x67,
+	0x72, 0x61, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x41, 0x63, 0x6b, 0x65, 0x64, 0x10, 0x01,
+	0x12, 0x15, 0x0a, 0x11, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x5f, 0x48, 0x65, 0x6c, 0x64, 0x10, 0x02, 0x12, 0x18, 0x0a, 0x14, 0x50, 0x72, 0x6f, 0x67, 0x72,
+	0x61, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x10,
+	0x03, 0x12, 0x1a, 0x0a, 0x16, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x5f, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x10, 0x04, 0x12, 0x1a, 0x0a,
+	0x16, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x43, 0x61,
+	0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x65, 0x64, 0x10, 0x05, 0x12, 0x17, 0x0a, 0x13, 0x50, 0x72, 0x6f,
+	0x67, 0x72, 0x61, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64,
+	0x10, 0x06, 0x32, 0xa3, 0x08, 0x0a, 0x04, 0x47, 0x52, 0x42, 0x4c, 0x12, 0x21, 0x0a, 0x04, 0x48,
+	0x6f, 0x6d, 0x65, 0x12, 0x0c, 0x2e, 0x48, 0x6f, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x09, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3c,
+	0x0a, 0x0b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x13, 0x2e,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x14, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x44, 0x0a, 0x0d,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x12, 0x15, 0x2e,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x50, 0x72, 0x6f,
+	0x67, 0x72, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01,
+	0x30, 0x01, 0x12, 0x21, 0x0a, 0x04, 0x4d, 0x6f, 0x76, 0x65, 0x12, 0x0c, 0x2e, 0x4d, 0x6f, 0x76,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x2b, 0x0a, 0x09, 0x53, 0x70, 0x69, 0x6e, 0x64, 0x6c, 0x65,
+	0x4f, 0x6e, 0x12, 0x11, 0x2e, 0x53, 0x70, 0x69, 0x6e, 0x64, 0x6c, 0x65, 0x4f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x2d, 0x0a, 0x0a, 0x53, 0x70, 0x69, 0x6e, 0x64, 0x6c, 0x65, 0x4f, 0x66, 0x66,
+	0x12, 0x12, 0x2e, 0x53, 0x70, 0x69, 0x6e, 0x64, 0x6c, 0x65, 0x4f, 0x66, 0x66, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x25, 0x0a, 0x06, 0x4d, 0x69, 0x73, 0x74, 0x4f, 0x6e, 0x12, 0x0e, 0x2e, 0x4d, 0x69,
+	0x73, 0x74, 0x4f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x27, 0x0a, 0x07, 0x46, 0x6c, 0x6f, 0x6f,
+	0x64, 0x4f, 0x6e, 0x12, 0x0f, 0x2e, 0x46, 0x6c, 0x6f, 0x6f, 0x64, 0x4f, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x2d, 0x0a, 0x0a, 0x43, 0x6f, 0x6f, 0x6c, 0x61, 0x6e, 0x74, 0x4f, 0x66, 0x66, 0x12,
+	0x12, 0x2e, 0x43, 0x6f, 0x6f, 0x6c, 0x61, 0x6e, 0x74, 0x4f, 0x66, 0x66, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x29, 0x0a, 0x08, 0x46, 0x65, 0x65, 0x64, 0x48, 0x6f, 0x6c, 0x64, 0x12, 0x10, 0x2e, 0x46,
+	0x65, 0x65, 0x64, 0x48, 0x6f, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x09,
+	0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x2d, 0x0a, 0x0a, 0x43,
+	0x79, 0x63, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x12, 0x2e, 0x43, 0x79, 0x63, 0x6c,
+	0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x2b, 0x0a, 0x09, 0x53, 0x6f,
+	0x66, 0x74, 0x52, 0x65, 0x73, 0x65, 0x74, 0x12, 0x11, 0x2e, 0x53, 0x6f, 0x66, 0x74, 0x52, 0x65,
+	0x73, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x25, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x0e, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x09, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x1f,
+	0x0a, 0x03, 0x4a, 0x6f, 0x67, 0x12, 0x0b, 0x2e, 0x4a, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x09, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x2b, 0x0a, 0x09, 0x4a, 0x6f, 0x67, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x12, 0x11, 0x2e, 0x4a,
+	0x6f, 0x67, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x09, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x29, 0x0a, 0x08,
+	0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x12, 0x10, 0x2e, 0x4f, 0x76, 0x65, 0x72, 0x72,
+	0x69, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x53, 0x65,
+	0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x13, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x74, 0x74,
+	0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x47, 0x65,
+	0x74, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x2d, 0x0a, 0x0a, 0x53, 0x65, 0x74, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e,
+	0x67, 0x12, 0x12, 0x2e, 0x53, 0x65, 0x74, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x3d, 0x0a, 0x0c, 0x44, 0x69, 0x66, 0x66, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e,
+	0x67, 0x73, 0x12, 0x14, 0x2e, 0x44, 0x69, 0x66, 0x66, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x44, 0x69, 0x66, 0x66, 0x53,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x3d, 0x0a, 0x0c, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c,
+	0x65, 0x12, 0x14, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x50,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x35, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x50, 0x61, 0x72, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x13, 0x2e, 0x50, 0x61, 0x72, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x50, 0x61, 0x72, 0x73, 0x65, 0x72,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x22, 0x00, 0x12, 0x2f, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x42, 0x75,
+	0x69, 0x6c, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x11, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x42, 0x75, 0x69,
+	0x6c, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x00, 0x42, 0x0c, 0x5a, 0x0a, 0x76, 0x31, 0x2f, 0x67,
+	0x72, 0x62, 0x6c, 0x3b, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_grbl_proto_rawDescOnce sync.Once
+	file_grbl_proto_rawDescData = file_grbl_proto_rawDesc
+)
+
+func file_grbl_proto_rawDescGZIP() []byte {
+	file_grbl_proto_rawDescOnce.Do(func() {
+		file_grbl_proto_rawDescData = protoimpl.X.CompressGZIP(file_grbl_proto_rawDescData)
+	})
+	return file_grbl_proto_rawDescData
+}
+
+var file_grbl_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_grbl_proto_msgTypes = make([]protoimpl.MessageInfo, 45)
+var file_grbl_proto_goTypes = []interface{}{
+	(Peripheral)(0),               // 0: Peripheral
+	(AlarmCode)(0),                // 1: AlarmCode
+	(ErrorCode)(0),                // 2: ErrorCode
+	(ProgramControl)(0),           // 3: ProgramControl
+	(ProgramEvent)(0),             // 4: ProgramEvent
+	(*Position)(nil),              // 5: Position
+	(*Offsets)(nil),               // 6: Offsets
+	(*Alarm)(nil),                 // 7: Alarm
+	(*Error)(nil),                 // 8: Error
+	(*State)(nil),                 // 9: State
+	(*StateStreamRequest)(nil),    // 10: StateStreamRequest
+	(*StateStreamResponse)(nil),   // 11: StateStreamResponse
+	(*HomeRequest)(nil),           // 12: HomeRequest
+	(*MoveRequest)(nil),           // 13: MoveRequest
+	(*MoveResponse)(nil),          // 14: MoveResponse
+	(*SpindleOnRequest)(nil),      // 15: SpindleOnRequest
+	(*SpindleOnResponse)(nil),     // 16: SpindleOnResponse
+	(*SpindleOffRequest)(nil),     // 17: SpindleOffRequest
+	(*SpindleOffResponse)(nil),    // 18: SpindleOffResponse
+	(*MistOnRequest)(nil),         // 19: MistOnRequest
+	(*MistOnResponse)(nil),        // 20: MistOnResponse
+	(*FloodOnRequest)(nil),        // 21: FloodOnRequest
+	(*FloodOnResponse)(nil),       // 22: FloodOnResponse
+	(*CoolantOffRequest)(nil),     // 23: CoolantOffRequest
+	(*CoolantOffResponse)(nil),    // 24: CoolantOffResponse
+	(*Response)(nil),              // 25: Response
+	(*Program)(nil),               // 26: Program
+	(*StreamProgramRequest)(nil),  // 27: StreamProgramRequest
+	(*StreamProgramResponse)(nil), // 28: StreamProgramResponse
+	(*FeedHoldRequest)(nil),       // 29: FeedHoldRequest
+	(*CycleStartRequest)(nil),     // 30: CycleStartRequest
+	(*SoftResetRequest)(nil),      // 31: SoftResetRequest
+	(*StatusRequest)(nil),         // 32: StatusRequest
+	(*JogRequest)(nil),            // 33: JogRequest
+	(*JogCancelRequest)(nil),      // 34: JogCancelRequest
+	(*OverrideRequest)(nil),       // 35: OverrideRequest
+	(*Setting)(nil),               // 36: Setting
+	(*GetSettingsRequest)(nil),    // 37: GetSettingsRequest
+	(*GetSettingsResponse)(nil),   // 38: GetSettingsResponse
+	(*SetSettingRequest)(nil),     // 39: SetSettingRequest
+	(*SettingsProfile)(nil),       // 40: SettingsProfile
+	(*SettingDiff)(nil),           // 41: SettingDiff
+	(*DiffSettingsRequest)(nil),   // 42: DiffSettingsRequest
+	(*DiffSettingsResponse)(nil),  // 43: DiffSettingsResponse
+	(*ApplyProfileRequest)(nil),   // 44: ApplyProfileRequest
+	(*ApplyProfileResponse)(nil),  // 45: ApplyProfileResponse
+	(*ParserStateRequest)(nil),    // 46: ParserStateRequest
+	(*ParserState)(nil),           // 47: ParserState
+	(*BuildInfoRequest)(nil),      // 48: BuildInfoRequest
+	(*BuildInfo)(nil),             // 49: BuildInfo
+}
+var file_grbl_proto_depIdxs = []int32{
+	1,  // 0: Alarm.alarm:type_name -> AlarmCode
+	2,  // 1: Error.error:type_name -> ErrorCode
+	5,  // 2: State.position:type_name -> Position
+	6,  // 3: State.offsets:type_name -> Offsets
+	7,  // 4: State.alarm:type_name -> Alarm
+	8,  // 5: State.error:type_name -> Error
+	0,  // 6: State.active:type_name -> Peripheral
+	9,  // 7: StateStreamResponse.state:type_name -> State
+	9,  // 8: Response.state:type_name -> State
+	14, // 9: Response.move:type_name -> MoveResponse
+	16, // 10: Response.spindleOn:type_name -> SpindleOnResponse
+	18, // 11: Response.spindleOff:type_name -> SpindleOffResponse
+	20, // 12: Response.mistOn:type_name -> MistOnResponse
+	22, // 13: Response.floodOn:type_name -> FloodOnResponse
+	24, // 14: Response.coolantOff:type_name -> CoolantOffResponse
+	26, // 15: StreamProgramRequest.program:type_name -> Program
+	3,  // 16: StreamProgramRequest.control:type_name -> ProgramControl
+	4,  // 17: StreamProgramResponse.event:type_name -> ProgramEvent
+	36, // 18: GetSettingsResponse.settings:type_name -> Setting
+	36, // 19: SetSettingRequest.setting:type_name -> Setting
+	36, // 20: SettingsProfile.settings:type_name -> Setting
+	40, // 21: DiffSettingsRequest.profile:type_name -> SettingsProfile
+	41, // 22: DiffSettingsResponse.diffs:type_name -> SettingDiff
+	40, // 23: ApplyProfileRequest.profile:type_name -> SettingsProfile
+	41, // 24: ApplyProfileResponse.applied:type_name -> SettingDiff
+	12, // 25: GRBL.Home:input_type -> HomeRequest
+	10, // 26: GRBL.StateStream:input_type -> StateStreamRequest
+	27, // 27: GRBL.StreamProgram:input_type -> StreamProgramRequest
+	13, // 28: GRBL.Move:input_type -> MoveRequest
+	15, // 29: GRBL.SpindleOn:input_type -> SpindleOnRequest
+	17, // 30: GRBL.SpindleOff:input_type -> SpindleOffRequest
+	19, // 31: GRBL.MistOn:input_type -> MistOnRequest
+	21, // 32: GRBL.FloodOn:input_type -> FloodOnRequest
+	23, // 33: GRBL.CoolantOff:input_type -> CoolantOffRequest
+	29, // 34: GRBL.FeedHold:input_type -> FeedHoldRequest
+	30, // 35: GRBL.CycleStart:input_type -> CycleStartRequest
+	31, // 36: GRBL.SoftReset:input_type -> SoftResetRequest
+	32, // 37: GRBL.Status:input_type -> StatusRequest
+	33, // 38: GRBL.Jog:input_type -> JogRequest
+	34, // 39: GRBL.JogCancel:input_type -> JogCancelRequest
+	35, // 40: GRBL.Override:input_type -> OverrideRequest
+	37, // 41: GRBL.GetSettings:input_type -> GetSettingsRequest
+	39, // 42: GRBL.SetSetting:input_type -> SetSettingRequest
+	42, // 43: GRBL.DiffSettings:input_type -> DiffSettingsRequest
+	44, // 44: GRBL.ApplyProfile:input_type -> ApplyProfileRequest
+	46, // 45: GRBL.GetParserState:input_type -> ParserStateRequest
+	48, // 46: GRBL.GetBuildInfo:input_type -> BuildInfoRequest
+	25, // 47: GRBL.Home:output_type -> Response
+	11, // 48: GRBL.StateStream:output_type -> StateStreamResponse
+	28, // 49: GRBL.StreamProgram:output_type -> StreamProgramResponse
+	25, // 50: GRBL.Move:output_type -> Response
+	25, // 51: GRBL.SpindleOn:output_type -> Response
+	25, // 52: GRBL.SpindleOff:output_type -> Response
+	25, // 53: GRBL.MistOn:output_type -> Response
+	25, // 54: GRBL.FloodOn:output_type -> Response
+	25, // 55: GRBL.CoolantOff:output_type -> Response
+	25, // 56: GRBL.FeedHold:output_type -> Response
+	25, // 57: GRBL.CycleStart:output_type -> Response
+	25, // 58: GRBL.SoftReset:output_type -> Response
+	25, // 59: GRBL.Status:output_type -> Response
+	25, // 60: GRBL.Jog:output_type -> Response
+	25, // 61: GRBL.JogCancel:output_type -> Response
+	25, // 62: GRBL.Override:output_type -> Response
+	38, // 63: GRBL.GetSettings:output_type -> GetSettingsResponse
+	25, // 64: GRBL.SetSetting:output_type -> Response
+	43, // 65: GRBL.DiffSettings:output_type -> DiffSettingsResponse
+	45, // 66: GRBL.ApplyProfile:output_type -> ApplyProfileResponse
+	47, // 67: GRBL.GetParserState:output_type -> ParserState
+	49, // 68: GRBL.GetBuildInfo:output_type -> BuildInfo
+	47, // [47:69] is the sub-list for method output_type
+	25, // [25:47] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
+}
+
+func init() { file_grbl_proto_init() }
+func file_grbl_proto_init() {
+	if File_grbl_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_grbl_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Position); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grbl_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Offsets); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grbl_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Alarm); i {
+			case 0:
+				return &v.state
 			case 1:
 				return &v.sizeCache
 			case 2:
@@ -2116,6 +3756,294 @@ func file_grbl_proto_init() {
 				return nil
 			}
 		}
+		file_grbl_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Program); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grbl_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamProgramRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grbl_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamProgramResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grbl_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FeedHoldRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grbl_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CycleStartRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grbl_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SoftResetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grbl_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grbl_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JogRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grbl_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JogCancelRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grbl_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OverrideRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grbl_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Setting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grbl_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSettingsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grbl_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSettingsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grbl_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetSettingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grbl_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SettingsProfile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grbl_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SettingDiff); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grbl_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DiffSettingsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grbl_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DiffSettingsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grbl_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApplyProfileRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grbl_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApplyProfileResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grbl_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ParserStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grbl_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ParserState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grbl_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BuildInfoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grbl_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BuildInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_grbl_proto_msgTypes[1].OneofWrappers = []interface{}{}
 	file_grbl_proto_msgTypes[2].OneofWrappers = []interface{}{}
@@ -2138,13 +4066,20 @@ func file_grbl_proto_init() {
 		(*Response_FloodOn)(nil),
 		(*Response_CoolantOff)(nil),
 	}
+	file_grbl_proto_msgTypes[22].OneofWrappers = []interface{}{
+		(*StreamProgramRequest_Program)(nil),
+		(*StreamProgramRequest_Control)(nil),
+	}
+	file_grbl_proto_msgTypes[28].OneofWrappers = []interface{}{}
+	file_grbl_proto_msgTypes[30].OneofWrappers = []interface{}{}
+	file_grbl_proto_msgTypes[36].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_grbl_proto_rawDesc,
-			NumEnums:      3,
-			NumMessages:   21,
+			NumEnums:      5,
+			NumMessages:   45,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
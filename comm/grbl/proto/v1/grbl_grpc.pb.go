@@ -3,7 +3,7 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
 // - protoc-gen-go-grpc v1.3.0
-// - protoc             v3.19.4
+// - protoc             (unknown)
 // source: grbl.proto
 
 package v1
@@ -21,14 +21,28 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	GRBL_Home_FullMethodName        = "/GRBL/Home"
-	GRBL_StateStream_FullMethodName = "/GRBL/StateStream"
-	GRBL_Move_FullMethodName        = "/GRBL/Move"
-	GRBL_SpindleOn_FullMethodName   = "/GRBL/SpindleOn"
-	GRBL_SpindleOff_FullMethodName  = "/GRBL/SpindleOff"
-	GRBL_MistOn_FullMethodName      = "/GRBL/MistOn"
-	GRBL_FloodOn_FullMethodName     = "/GRBL/FloodOn"
-	GRBL_CoolantOff_FullMethodName  = "/GRBL/CoolantOff"
+	GRBL_Home_FullMethodName           = "/GRBL/Home"
+	GRBL_StateStream_FullMethodName    = "/GRBL/StateStream"
+	GRBL_StreamProgram_FullMethodName  = "/GRBL/StreamProgram"
+	GRBL_Move_FullMethodName           = "/GRBL/Move"
+	GRBL_SpindleOn_FullMethodName      = "/GRBL/SpindleOn"
+	GRBL_SpindleOff_FullMethodName     = "/GRBL/SpindleOff"
+	GRBL_MistOn_FullMethodName         = "/GRBL/MistOn"
+	GRBL_FloodOn_FullMethodName        = "/GRBL/FloodOn"
+	GRBL_CoolantOff_FullMethodName     = "/GRBL/CoolantOff"
+	GRBL_FeedHold_FullMethodName       = "/GRBL/FeedHold"
+	GRBL_CycleStart_FullMethodName     = "/GRBL/CycleStart"
+	GRBL_SoftReset_FullMethodName      = "/GRBL/SoftReset"
+	GRBL_Status_FullMethodName         = "/GRBL/Status"
+	GRBL_Jog_FullMethodName            = "/GRBL/Jog"
+	GRBL_JogCancel_FullMethodName      = "/GRBL/JogCancel"
+	GRBL_Override_FullMethodName       = "/GRBL/Override"
+	GRBL_GetSettings_FullMethodName    = "/GRBL/GetSettings"
+	GRBL_SetSetting_FullMethodName     = "/GRBL/SetSetting"
+	GRBL_DiffSettings_FullMethodName   = "/GRBL/DiffSettings"
+	GRBL_ApplyProfile_FullMethodName   = "/GRBL/ApplyProfile"
+	GRBL_GetParserState_FullMethodName = "/GRBL/GetParserState"
+	GRBL_GetBuildInfo_FullMethodName   = "/GRBL/GetBuildInfo"
 )
 
 // GRBLClient is the client API for GRBL service.
@@ -37,12 +51,26 @@ const (
 type GRBLClient interface {
 	Home(ctx context.Context, in *HomeRequest, opts ...grpc.CallOption) (*Response, error)
 	StateStream(ctx context.Context, in *StateStreamRequest, opts ...grpc.CallOption) (GRBL_StateStreamClient, error)
+	StreamProgram(ctx context.Context, opts ...grpc.CallOption) (GRBL_StreamProgramClient, error)
 	Move(ctx context.Context, in *MoveRequest, opts ...grpc.CallOption) (*Response, error)
 	SpindleOn(ctx context.Context, in *SpindleOnRequest, opts ...grpc.CallOption) (*Response, error)
 	SpindleOff(ctx context.Context, in *SpindleOffRequest, opts ...grpc.CallOption) (*Response, error)
 	MistOn(ctx context.Context, in *MistOnRequest, opts ...grpc.CallOption) (*Response, error)
 	FloodOn(ctx context.Context, in *FloodOnRequest, opts ...grpc.CallOption) (*Response, error)
 	CoolantOff(ctx context.Context, in *CoolantOffRequest, opts ...grpc.CallOption) (*Response, error)
+	FeedHold(ctx context.Context, in *FeedHoldRequest, opts ...grpc.CallOption) (*Response, error)
+	CycleStart(ctx context.Context, in *CycleStartRequest, opts ...grpc.CallOption) (*Response, error)
+	SoftReset(ctx context.Context, in *SoftResetRequest, opts ...grpc.CallOption) (*Response, error)
+	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*Response, error)
+	Jog(ctx context.Context, in *JogRequest, opts ...grpc.CallOption) (*Response, error)
+	JogCancel(ctx context.Context, in *JogCancelRequest, opts ...grpc.CallOption) (*Response, error)
+	Override(ctx context.Context, in *OverrideRequest, opts ...grpc.CallOption) (*Response, error)
+	GetSettings(ctx context.Context, in *GetSettingsRequest, opts ...grpc.CallOption) (*GetSettingsResponse, error)
+	SetSetting(ctx context.Context, in *SetSettingRequest, opts ...grpc.CallOption) (*Response, error)
+	DiffSettings(ctx context.Context, in *DiffSettingsRequest, opts ...grpc.CallOption) (*DiffSettingsResponse, error)
+	ApplyProfile(ctx context.Context, in *ApplyProfileRequest, opts ...grpc.CallOption) (*ApplyProfileResponse, error)
+	GetParserState(ctx context.Context, in *ParserStateRequest, opts ...grpc.CallOption) (*ParserState, error)
+	GetBuildInfo(ctx context.Context, in *BuildInfoRequest, opts ...grpc.CallOption) (*BuildInfo, error)
 }
 
 type gRBLClient struct {
@@ -94,6 +122,37 @@ func (x *gRBLStateStreamClient) Recv() (*StateStreamResponse, error) {
 	return m, nil
 }
 
+func (c *gRBLClient) StreamProgram(ctx context.Context, opts ...grpc.CallOption) (GRBL_StreamProgramClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GRBL_ServiceDesc.Streams[1], GRBL_StreamProgram_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gRBLStreamProgramClient{stream}
+	return x, nil
+}
+
+type GRBL_StreamProgramClient interface {
+	Send(*StreamProgramRequest) error
+	Recv() (*StreamProgramResponse, error)
+	grpc.ClientStream
+}
+
+type gRBLStreamProgramClient struct {
+	grpc.ClientStream
+}
+
+func (x *gRBLStreamProgramClient) Send(m *StreamProgramRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *gRBLStreamProgramClient) Recv() (*StreamProgramResponse, error) {
+	m := new(StreamProgramResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *gRBLClient) Move(ctx context.Context, in *MoveRequest, opts ...grpc.CallOption) (*Response, error) {
 	out := new(Response)
 	err := c.cc.Invoke(ctx, GRBL_Move_FullMethodName, in, out, opts...)
@@ -148,18 +207,149 @@ func (c *gRBLClient) CoolantOff(ctx context.Context, in *CoolantOffRequest, opts
 	return out, nil
 }
 
+func (c *gRBLClient) FeedHold(ctx context.Context, in *FeedHoldRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, GRBL_FeedHold_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gRBLClient) CycleStart(ctx context.Context, in *CycleStartRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, GRBL_CycleStart_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gRBLClient) SoftReset(ctx context.Context, in *SoftResetRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, GRBL_SoftReset_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gRBLClient) Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, GRBL_Status_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gRBLClient) Jog(ctx context.Context, in *JogRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, GRBL_Jog_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gRBLClient) JogCancel(ctx context.Context, in *JogCancelRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, GRBL_JogCancel_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gRBLClient) Override(ctx context.Context, in *OverrideRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, GRBL_Override_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gRBLClient) GetSettings(ctx context.Context, in *GetSettingsRequest, opts ...grpc.CallOption) (*GetSettingsResponse, error) {
+	out := new(GetSettingsResponse)
+	err := c.cc.Invoke(ctx, GRBL_GetSettings_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gRBLClient) SetSetting(ctx context.Context, in *SetSettingRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, GRBL_SetSetting_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gRBLClient) DiffSettings(ctx context.Context, in *DiffSettingsRequest, opts ...grpc.CallOption) (*DiffSettingsResponse, error) {
+	out := new(DiffSettingsResponse)
+	err := c.cc.Invoke(ctx, GRBL_DiffSettings_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gRBLClient) ApplyProfile(ctx context.Context, in *ApplyProfileRequest, opts ...grpc.CallOption) (*ApplyProfileResponse, error) {
+	out := new(ApplyProfileResponse)
+	err := c.cc.Invoke(ctx, GRBL_ApplyProfile_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gRBLClient) GetParserState(ctx context.Context, in *ParserStateRequest, opts ...grpc.CallOption) (*ParserState, error) {
+	out := new(ParserState)
+	err := c.cc.Invoke(ctx, GRBL_GetParserState_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gRBLClient) GetBuildInfo(ctx context.Context, in *BuildInfoRequest, opts ...grpc.CallOption) (*BuildInfo, error) {
+	out := new(BuildInfo)
+	err := c.cc.Invoke(ctx, GRBL_GetBuildInfo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GRBLServer is the server API for GRBL service.
 // All implementations must embed UnimplementedGRBLServer
 // for forward compatibility
 type GRBLServer interface {
 	Home(context.Context, *HomeRequest) (*Response, error)
 	StateStream(*StateStreamRequest, GRBL_StateStreamServer) error
+	StreamProgram(GRBL_StreamProgramServer) error
 	Move(context.Context, *MoveRequest) (*Response, error)
 	SpindleOn(context.Context, *SpindleOnRequest) (*Response, error)
 	SpindleOff(context.Context, *SpindleOffRequest) (*Response, error)
 	MistOn(context.Context, *MistOnRequest) (*Response, error)
 	FloodOn(context.Context, *FloodOnRequest) (*Response, error)
 	CoolantOff(context.Context, *CoolantOffRequest) (*Response, error)
+	FeedHold(context.Context, *FeedHoldRequest) (*Response, error)
+	CycleStart(context.Context, *CycleStartRequest) (*Response, error)
+	SoftReset(context.Context, *SoftResetRequest) (*Response, error)
+	Status(context.Context, *StatusRequest) (*Response, error)
+	Jog(context.Context, *JogRequest) (*Response, error)
+	JogCancel(context.Context, *JogCancelRequest) (*Response, error)
+	Override(context.Context, *OverrideRequest) (*Response, error)
+	GetSettings(context.Context, *GetSettingsRequest) (*GetSettingsResponse, error)
+	SetSetting(context.Context, *SetSettingRequest) (*Response, error)
+	DiffSettings(context.Context, *DiffSettingsRequest) (*DiffSettingsResponse, error)
+	ApplyProfile(context.Context, *ApplyProfileRequest) (*ApplyProfileResponse, error)
+	GetParserState(context.Context, *ParserStateRequest) (*ParserState, error)
+	GetBuildInfo(context.Context, *BuildInfoRequest) (*BuildInfo, error)
 	mustEmbedUnimplementedGRBLServer()
 }
 
@@ -173,6 +363,9 @@ func (UnimplementedGRBLServer) Home(context.Context, *HomeRequest) (*Response, e
 func (UnimplementedGRBLServer) StateStream(*StateStreamRequest, GRBL_StateStreamServer) error {
 	return status.Errorf(codes.Unimplemented, "method StateStream not implemented")
 }
+func (UnimplementedGRBLServer) StreamProgram(GRBL_StreamProgramServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamProgram not implemented")
+}
 func (UnimplementedGRBLServer) Move(context.Context, *MoveRequest) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Move not implemented")
 }
@@ -191,6 +384,45 @@ func (UnimplementedGRBLServer) FloodOn(context.Context, *FloodOnRequest) (*Respo
 func (UnimplementedGRBLServer) CoolantOff(context.Context, *CoolantOffRequest) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CoolantOff not implemented")
 }
+func (UnimplementedGRBLServer) FeedHold(context.Context, *FeedHoldRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FeedHold not implemented")
+}
+func (UnimplementedGRBLServer) CycleStart(context.Context, *CycleStartRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CycleStart not implemented")
+}
+func (UnimplementedGRBLServer) SoftReset(context.Context, *SoftResetRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SoftReset not implemented")
+}
+func (UnimplementedGRBLServer) Status(context.Context, *StatusRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
+}
+func (UnimplementedGRBLServer) Jog(context.Context, *JogRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Jog not implemented")
+}
+func (UnimplementedGRBLServer) JogCancel(context.Context, *JogCancelRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JogCancel not implemented")
+}
+func (UnimplementedGRBLServer) Override(context.Context, *OverrideRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Override not implemented")
+}
+func (UnimplementedGRBLServer) GetSettings(context.Context, *GetSettingsRequest) (*GetSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSettings not implemented")
+}
+func (UnimplementedGRBLServer) SetSetting(context.Context, *SetSettingRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSetting not implemented")
+}
+func (UnimplementedGRBLServer) DiffSettings(context.Context, *DiffSettingsRequest) (*DiffSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffSettings not implemented")
+}
+func (UnimplementedGRBLServer) ApplyProfile(context.Context, *ApplyProfileRequest) (*ApplyProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyProfile not implemented")
+}
+func (UnimplementedGRBLServer) GetParserState(context.Context, *ParserStateRequest) (*ParserState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetParserState not implemented")
+}
+func (UnimplementedGRBLServer) GetBuildInfo(context.Context, *BuildInfoRequest) (*BuildInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBuildInfo not implemented")
+}
 func (UnimplementedGRBLServer) mustEmbedUnimplementedGRBLServer() {}
 
 // UnsafeGRBLServer may be embedded to opt out of forward compatibility for this service.
@@ -243,6 +475,32 @@ func (x *gRBLStateStreamServer) Send(m *StateStreamResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _GRBL_StreamProgram_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(GRBLServer).StreamProgram(&gRBLStreamProgramServer{stream})
+}
+
+type GRBL_StreamProgramServer interface {
+	Send(*StreamProgramResponse) error
+	Recv() (*StreamProgramRequest, error)
+	grpc.ServerStream
+}
+
+type gRBLStreamProgramServer struct {
+	grpc.ServerStream
+}
+
+func (x *gRBLStreamProgramServer) Send(m *StreamProgramResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *gRBLStreamProgramServer) Recv() (*StreamProgramRequest, error) {
+	m := new(StreamProgramRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _GRBL_Move_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(MoveRequest)
 	if err := dec(in); err != nil {
@@ -351,6 +609,240 @@ func _GRBL_CoolantOff_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GRBL_FeedHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FeedHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GRBLServer).FeedHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GRBL_FeedHold_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GRBLServer).FeedHold(ctx, req.(*FeedHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GRBL_CycleStart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CycleStartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GRBLServer).CycleStart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GRBL_CycleStart_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GRBLServer).CycleStart(ctx, req.(*CycleStartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GRBL_SoftReset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SoftResetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GRBLServer).SoftReset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GRBL_SoftReset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GRBLServer).SoftReset(ctx, req.(*SoftResetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GRBL_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GRBLServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GRBL_Status_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GRBLServer).Status(ctx, req.(*StatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GRBL_Jog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GRBLServer).Jog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GRBL_Jog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GRBLServer).Jog(ctx, req.(*JogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GRBL_JogCancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JogCancelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GRBLServer).JogCancel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GRBL_JogCancel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GRBLServer).JogCancel(ctx, req.(*JogCancelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GRBL_Override_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OverrideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GRBLServer).Override(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GRBL_Override_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GRBLServer).Override(ctx, req.(*OverrideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GRBL_GetSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GRBLServer).GetSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GRBL_GetSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GRBLServer).GetSettings(ctx, req.(*GetSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GRBL_SetSetting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSettingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GRBLServer).SetSetting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GRBL_SetSetting_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GRBLServer).SetSetting(ctx, req.(*SetSettingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GRBL_DiffSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GRBLServer).DiffSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GRBL_DiffSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GRBLServer).DiffSettings(ctx, req.(*DiffSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GRBL_ApplyProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GRBLServer).ApplyProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GRBL_ApplyProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GRBLServer).ApplyProfile(ctx, req.(*ApplyProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GRBL_GetParserState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ParserStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GRBLServer).GetParserState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GRBL_GetParserState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GRBLServer).GetParserState(ctx, req.(*ParserStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GRBL_GetBuildInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BuildInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GRBLServer).GetBuildInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GRBL_GetBuildInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GRBLServer).GetBuildInfo(ctx, req.(*BuildInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // GRBL_ServiceDesc is the grpc.ServiceDesc for GRBL service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -386,6 +878,58 @@ var GRBL_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CoolantOff",
 			Handler:    _GRBL_CoolantOff_Handler,
 		},
+		{
+			MethodName: "FeedHold",
+			Handler:    _GRBL_FeedHold_Handler,
+		},
+		{
+			MethodName: "CycleStart",
+			Handler:    _GRBL_CycleStart_Handler,
+		},
+		{
+			MethodName: "SoftReset",
+			Handler:    _GRBL_SoftReset_Handler,
+		},
+		{
+			MethodName: "Status",
+			Handler:    _GRBL_Status_Handler,
+		},
+		{
+			MethodName: "Jog",
+			Handler:    _GRBL_Jog_Handler,
+		},
+		{
+			MethodName: "JogCancel",
+			Handler:    _GRBL_JogCancel_Handler,
+		},
+		{
+			MethodName: "Override",
+			Handler:    _GRBL_Override_Handler,
+		},
+		{
+			MethodName: "GetSettings",
+			Handler:    _GRBL_GetSettings_Handler,
+		},
+		{
+			MethodName: "SetSetting",
+			Handler:    _GRBL_SetSetting_Handler,
+		},
+		{
+			MethodName: "DiffSettings",
+			Handler:    _GRBL_DiffSettings_Handler,
+		},
+		{
+			MethodName: "ApplyProfile",
+			Handler:    _GRBL_ApplyProfile_Handler,
+		},
+		{
+			MethodName: "GetParserState",
+			Handler:    _GRBL_GetParserState_Handler,
+		},
+		{
+			MethodName: "GetBuildInfo",
+			Handler:    _GRBL_GetBuildInfo_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -393,6 +937,12 @@ var GRBL_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _GRBL_StateStream_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "StreamProgram",
+			Handler:       _GRBL_StreamProgram_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "grbl.proto",
 }
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"context"
+	"github.com/jt05610/petri/comm/grbl/proto/v1"
+	"github.com/jt05610/petri/comm/program"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+// RXBufferSize is the size of GRBL's serial receive buffer. Lines are sent
+// as long as the ones not yet acknowledged fit in it.
+const RXBufferSize = 128
+
+// CancelTimeout bounds how long a cancelled program waits for the feed hold
+// to bring the machine to a stop before GRBL is reset.
+const CancelTimeout = 5 * time.Second
+
+// firmware streams programs to GRBL.
+type firmware struct {
+	*Server
+}
+
+func (f firmware) Send(ctx context.Context, _ int, b *program.Block) (<-chan error, error) {
+	return f.queue.Send(ctx, []byte(b.Text+"\n"))
+}
+
+func (f firmware) Hold(ctx context.Context) error {
+	return f.realtime(ctx, FeedHold)
+}
+
+func (f firmware) Resume(ctx context.Context) error {
+	return f.realtime(ctx, CycleStart)
+}
+
+// Cancel holds the feed, waits for the machine to stop so GRBL keeps its
+// position, then resets it to discard the blocks it has buffered.
+func (f firmware) Cancel(ctx context.Context) error {
+	if err := f.realtime(ctx, FeedHold); err != nil {
+		return err
+	}
+	stopCtx, cancel := context.WithTimeout(ctx, CancelTimeout)
+	defer cancel()
+	if err := f.awaitStopped(stopCtx); err != nil {
+		f.logger.Warn("Machine did not stop before reset", zap.Error(err))
+	}
+	return f.reset(ctx, status.Error(codes.Canceled, program.ErrCancelled.Error()))
+}
+
+// StreamProgram runs a G-code program using GRBL's character-counting
+// protocol, reporting each block as GRBL acknowledges it.
+func (s *Server) StreamProgram(stream v1.GRBL_StreamProgramServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	prog := req.GetProgram()
+	if prog == nil {
+		return status.Error(codes.InvalidArgument, "the first request must carry the program")
+	}
+	blocks := program.Parse(prog.Gcode)
+	s.logger.Info("Streaming program", zap.String("name", prog.Name), zap.Int("blocks", len(blocks)))
+	recv := func() (program.Control, error) {
+		req, err := stream.Recv()
+		return program.Control(req.GetControl()), err
+	}
+	return program.Serve(stream.Context(), firmware{s}, blocks, recv, func(r *program.Response) error {
+		return stream.Send(&v1.StreamProgramResponse{
+			Event:   v1.ProgramEvent(r.Event),
+			Line:    r.Line,
+			Block:   r.Block,
+			Done:    r.Done,
+			Total:   r.Total,
+			Message: r.Message,
+		})
+	})
+}
@@ -0,0 +1,260 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/jt05610/petri/comm/grbl"
+	"github.com/jt05610/petri/comm/grbl/proto/v1"
+	"github.com/jt05610/petri/comm/queue"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Real-time commands are acted on as soon as GRBL receives them, bypassing
+// its receive buffer, so they are not sent through the command queue.
+const (
+	StatusQuery byte = '?'
+	FeedHold    byte = '!'
+	CycleStart  byte = '~'
+	SoftReset   byte = 0x18
+	JogCancel   byte = 0x85
+)
+
+// Override commands. GRBL only steps overrides, so a target is reached by
+// resetting to 100% and stepping from there.
+const (
+	FeedReset     byte = 0x90
+	FeedUp10      byte = 0x91
+	FeedDown10    byte = 0x92
+	FeedUp1       byte = 0x93
+	FeedDown1     byte = 0x94
+	RapidFull     byte = 0x95
+	RapidHalf     byte = 0x96
+	RapidQuarter  byte = 0x97
+	SpindleReset  byte = 0x99
+	SpindleUp10   byte = 0x9A
+	SpindleDown10 byte = 0x9B
+	SpindleUp1    byte = 0x9C
+	SpindleDown1  byte = 0x9D
+)
+
+// Feed and spindle overrides are limited to this range, in percent.
+const (
+	MinOverride     = 10
+	MaxOverride     = 200
+	defaultOverride = 100
+)
+
+func (s *Server) realtime(ctx context.Context, b ...byte) error {
+	if s.disconnected.Load() {
+		return errDisconnected
+	}
+	s.logger.Debug("Sending real-time command", zap.String("cmd", fmt.Sprintf("%#x", b)))
+	select {
+	case s.TxChan <- b:
+		return nil
+	case <-ctx.Done():
+		return queue.Status(ctx.Err())
+	}
+}
+
+// report asks for a status report and waits for it to arrive.
+func (s *Server) report(ctx context.Context) (*grbl.Status, error) {
+	prev := s.status()
+	if err := s.realtime(ctx, StatusQuery); err != nil {
+		return nil, err
+	}
+	var ret *grbl.Status
+	err := queue.Await(ctx, &s.changed, func() (bool, error) {
+		if s.disconnected.Load() {
+			return false, errDisconnected
+		}
+		ret = s.status()
+		return ret != nil && ret != prev, nil
+	})
+	return ret, queue.Status(err)
+}
+
+// awaitStopped waits until a feed hold has brought the machine to rest.
+func (s *Server) awaitStopped(ctx context.Context) error {
+	for {
+		st, err := s.report(ctx)
+		if err != nil {
+			return err
+		}
+		held := st.State == "hold" && st.SubState != nil && *st.SubState == 0
+		if held || st.State == "idle" || st.State == "alarm" {
+			return nil
+		}
+	}
+}
+
+// reset soft-resets GRBL and answers the commands it discarded with err. It
+// returns once GRBL has restarted, since anything sent before then is lost.
+func (s *Server) reset(ctx context.Context, err error) error {
+	resets := s.resets.Load()
+	s.queue.Flush(err)
+	if err := s.realtime(ctx, SoftReset); err != nil {
+		return err
+	}
+	return queue.Status(queue.Await(ctx, &s.changed, func() (bool, error) {
+		return s.resets.Load() > resets, nil
+	}))
+}
+
+func ok() *v1.Response {
+	return &v1.Response{Message: "ok"}
+}
+
+func (s *Server) FeedHold(ctx context.Context, req *v1.FeedHoldRequest) (*v1.Response, error) {
+	if err := s.realtime(ctx, FeedHold); err != nil {
+		return nil, err
+	}
+	if req.Wait {
+		if err := s.awaitStopped(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return ok(), nil
+}
+
+func (s *Server) CycleStart(ctx context.Context, req *v1.CycleStartRequest) (*v1.Response, error) {
+	if err := s.realtime(ctx, CycleStart); err != nil {
+		return nil, err
+	}
+	return ok(), nil
+}
+
+// SoftReset stops GRBL at once. If the machine was moving, GRBL raises an
+// alarm since its position may be lost.
+func (s *Server) SoftReset(ctx context.Context, req *v1.SoftResetRequest) (*v1.Response, error) {
+	if err := s.reset(ctx, status.Error(codes.Aborted, "soft reset")); err != nil {
+		return nil, err
+	}
+	return ok(), nil
+}
+
+func (s *Server) Status(ctx context.Context, req *v1.StatusRequest) (*v1.Response, error) {
+	if _, err := s.report(ctx); err != nil {
+		return nil, err
+	}
+	return &v1.Response{
+		Message:  "ok",
+		Response: &v1.Response_State{State: s.currentState()},
+	}, nil
+}
+
+func jogMsg(req *v1.JogRequest) ([]byte, error) {
+	if req.X == nil && req.Y == nil && req.Z == nil {
+		return nil, status.Error(codes.InvalidArgument, "jog needs at least one axis")
+	}
+	if req.Feed <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "jog needs a positive feed")
+	}
+	bld := bytes.NewBufferString("$J=G21 G90")
+	if req.Relative {
+		bld = bytes.NewBufferString("$J=G21 G91")
+	}
+	if req.X != nil {
+		bld.WriteString(fmt.Sprintf(" X%.3f", *req.X))
+	}
+	if req.Y != nil {
+		bld.WriteString(fmt.Sprintf(" Y%.3f", *req.Y))
+	}
+	if req.Z != nil {
+		bld.WriteString(fmt.Sprintf(" Z%.3f", *req.Z))
+	}
+	bld.WriteString(fmt.Sprintf(" F%.3f\n", req.Feed))
+	return bld.Bytes(), nil
+}
+
+// Jog starts a jog motion. Jog lines are buffered like any other line, so
+// they go through the command queue; Jog returns once GRBL accepts the line
+// and JogCancel stops it.
+func (s *Server) Jog(ctx context.Context, req *v1.JogRequest) (*v1.Response, error) {
+	cmd, err := jogMsg(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.do(ctx, cmd, nil); err != nil {
+		return nil, err
+	}
+	return ok(), nil
+}
+
+func (s *Server) JogCancel(ctx context.Context, req *v1.JogCancelRequest) (*v1.Response, error) {
+	if err := s.realtime(ctx, JogCancel); err != nil {
+		return nil, err
+	}
+	return ok(), nil
+}
+
+// steps returns the commands that take an override from 100% to target.
+func steps(target int, reset, up10, down10, up1, down1 byte) []byte {
+	ret := []byte{reset}
+	diff := target - defaultOverride
+	tens, ones := up10, up1
+	if diff < 0 {
+		diff = -diff
+		tens, ones = down10, down1
+	}
+	for ; diff >= 10; diff -= 10 {
+		ret = append(ret, tens)
+	}
+	for ; diff > 0; diff-- {
+		ret = append(ret, ones)
+	}
+	return ret
+}
+
+func overrideMsg(req *v1.OverrideRequest) ([]byte, error) {
+	ret := make([]byte, 0)
+	for _, o := range []struct {
+		name  string
+		value *uint32
+		cmds  [5]byte
+	}{
+		{"feed", req.Feed, [5]byte{FeedReset, FeedUp10, FeedDown10, FeedUp1, FeedDown1}},
+		{"spindle", req.Spindle, [5]byte{SpindleReset, SpindleUp10, SpindleDown10, SpindleUp1, SpindleDown1}},
+	} {
+		if o.value == nil {
+			continue
+		}
+		v := int(*o.value)
+		if v < MinOverride || v > MaxOverride {
+			return nil, status.Errorf(codes.InvalidArgument, "%s override must be %d-%d%%", o.name, MinOverride, MaxOverride)
+		}
+		ret = append(ret, steps(v, o.cmds[0], o.cmds[1], o.cmds[2], o.cmds[3], o.cmds[4])...)
+	}
+	if req.Rapid != nil {
+		switch *req.Rapid {
+		case 100:
+			ret = append(ret, RapidFull)
+		case 50:
+			ret = append(ret, RapidHalf)
+		case 25:
+			ret = append(ret, RapidQuarter)
+		default:
+			return nil, status.Error(codes.InvalidArgument, "rapid override must be 25, 50 or 100%")
+		}
+	}
+	return ret, nil
+}
+
+// Override sets the feed, rapid and spindle overrides, taking effect at once
+// even while a program runs.
+func (s *Server) Override(ctx context.Context, req *v1.OverrideRequest) (*v1.Response, error) {
+	cmd, err := overrideMsg(req)
+	if err != nil {
+		return nil, err
+	}
+	if len(cmd) == 0 {
+		return ok(), nil
+	}
+	if err := s.realtime(ctx, cmd...); err != nil {
+		return nil, err
+	}
+	return ok(), nil
+}
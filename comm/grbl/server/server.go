@@ -6,10 +6,15 @@ import (
 	"fmt"
 	"github.com/jt05610/petri/comm/grbl"
 	"github.com/jt05610/petri/comm/grbl/proto/v1"
+	"github.com/jt05610/petri/comm/queue"
 	"github.com/jt05610/petri/comm/serial"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"io"
 	"math"
+	"sync"
 	"sync/atomic"
 	"time"
 )
@@ -18,11 +23,19 @@ var _ v1.GRBLServer = (*Server)(nil)
 
 type Server struct {
 	*grbl.Parser
-	Cts           atomic.Bool
 	logger        *zap.Logger
 	machineStatus *atomic.Pointer[grbl.Status]
 	state         *atomic.Pointer[v1.State]
-	port          *serial.Port
+	alarm         *atomic.Pointer[grbl.Alarm]
+	reported      atomic.Bool
+	resets        atomic.Int64
+	disconnected  atomic.Bool
+	changed       queue.Signal
+	queue         *queue.Queue
+	collectMu     sync.Mutex
+	collectorMu   sync.Mutex // held while the collector runs
+	collector     func(grbl.StatusUpdate)
+	port          serial.Conn
 	rxChan        <-chan io.Reader
 	TxChan        chan []byte
 	listenCancel  context.CancelFunc
@@ -37,89 +50,108 @@ func (s *Server) status() *grbl.Status {
 	return s.machineStatus.Load()
 }
 
-func (s *Server) do(cmd []byte, synchronous bool, check func(state *v1.State) bool) error {
-	for !s.Cts.Load() {
+// firmwareError converts an error:N response to a gRPC status.
+func firmwareError(e grbl.Error) error {
+	code := codes.Internal
+	switch {
+	case e == 8 || e == 9 || e == 13:
+		code = codes.FailedPrecondition
+	case e == 15:
+		code = codes.OutOfRange
+	case e == 20:
+		code = codes.Unimplemented
+	case e <= 4 || e == 16 || e >= 21:
+		code = codes.InvalidArgument
 	}
-	s.logger.Debug("Sending command", zap.String("cmd", string(cmd)))
-	s.TxChan <- cmd
-	if synchronous {
-		for {
-			status := s.currentState()
-			if s.Cts.Load() && check(status) {
-				return nil
-			}
-			if status == nil {
-				continue
-			}
+	return status.Errorf(code, "error:%d %s", int(e), v1.ErrorCode(e))
+}
 
-			if status.Error != nil {
-				if status.Error.Error != nil {
-					if *status.Error.Error != v1.ErrorCode_ErrorCode_NoError {
-						return fmt.Errorf("error: %d", status.Error.Message)
-					}
-				}
-			}
-			if status.Alarm != nil {
-				if status.Alarm.Alarm != nil {
-					if *status.Alarm.Alarm != v1.AlarmCode_AlarmCode_NoAlarm {
-						return fmt.Errorf("alarm: %d", status.Alarm.Message)
-					}
-				}
-			}
+func alarmError(a grbl.Alarm) error {
+	return status.Errorf(codes.Aborted, "ALARM:%d %s", int(a), v1.AlarmCode(a))
+}
+
+var errDisconnected = status.Error(codes.Unavailable, serial.ErrDisconnected.Error())
 
+// Send sends a line of G-code and waits for GRBL to acknowledge it.
+func (s *Server) Send(ctx context.Context, line []byte) error {
+	if s.disconnected.Load() {
+		return errDisconnected
+	}
+	return queue.Status(s.queue.Do(ctx, line))
+}
+
+// do sends each line of cmd in turn. If check is given, it then waits for a
+// status report that satisfies it.
+func (s *Server) do(ctx context.Context, cmd []byte, check func(state *v1.State) bool) error {
+	for _, line := range bytes.SplitAfter(cmd, []byte("\n")) {
+		if len(line) == 0 {
+			continue
 		}
+		s.logger.Debug("Sending command", zap.String("cmd", string(line)))
+		if err := s.Send(ctx, line); err != nil {
+			return err
+		}
+	}
+	if check == nil {
+		return nil
 	}
-	return nil
+	return queue.Status(queue.Await(ctx, &s.changed, func() (bool, error) {
+		if s.disconnected.Load() {
+			return false, errDisconnected
+		}
+		state := s.currentState()
+		if state == nil {
+			return false, nil
+		}
+		if state.Alarm != nil && state.Alarm.Alarm != nil {
+			return false, alarmError(grbl.Alarm(*state.Alarm.Alarm))
+		}
+		return check(state), nil
+	}))
 }
 
 func (s *Server) Home(ctx context.Context, req *v1.HomeRequest) (*v1.Response, error) {
-	if req.Axis == nil {
-		s.TxChan <- []byte("$H\n")
-	} else {
-		if v, ok := req.Axis.(*v1.HomeRequest_X); ok {
-			if v.X {
-				s.TxChan <- []byte("$HX\n")
-			}
-		} else if v, ok := req.Axis.(*v1.HomeRequest_Y); ok {
-			if v.Y {
-				s.TxChan <- []byte("$HY\n")
-			}
-		} else if v, ok := req.Axis.(*v1.HomeRequest_Z); ok {
-			if v.Z {
-				s.TxChan <- []byte("$HZ\n")
-			}
+	// GRBL acknowledges $H once the homing cycle is done
+	cmd := []byte("$H\n")
+	switch v := req.Axis.(type) {
+	case *v1.HomeRequest_X:
+		cmd = nil
+		if v.X {
+			cmd = []byte("$HX\n")
 		}
-	}
-	// wait for state to be homing, then idle again
-	homingSeen := false
-	idleSeen := false
-	for !homingSeen && !idleSeen {
-		time.Sleep(100 * time.Millisecond)
-		status := s.status()
-		if status == nil {
-			continue
+	case *v1.HomeRequest_Y:
+		cmd = nil
+		if v.Y {
+			cmd = []byte("$HY\n")
 		}
-		if status.State == "home" {
-			homingSeen = true
-			if status.State == "idle" {
-				idleSeen = true
-			}
+	case *v1.HomeRequest_Z:
+		cmd = nil
+		if v.Z {
+			cmd = []byte("$HZ\n")
 		}
 	}
-	return &v1.Response{}, nil
+	if err := s.do(ctx, cmd, nil); err != nil {
+		return nil, err
+	}
+	return &v1.Response{Message: "ok"}, nil
 }
 
 const waitFor = " unlock]"
 
-func New(port *serial.Port, logger *zap.Logger) *Server {
+func New(port serial.Conn, logger *zap.Logger) *Server {
 	buf := new(bytes.Buffer)
 	txCh := make(chan []byte, 100)
-	rxCh, err := port.ChannelPort(context.Background(), txCh)
+	// Close cancels ctx, which stops the port's reader and writer as well as
+	// Listen
+	ctx, can := context.WithCancel(context.Background())
+	rxCh, err := port.ChannelPort(ctx, txCh)
 	if err != nil {
+		can()
 		panic(err)
 	}
-	ctx, can := context.WithCancel(context.Background())
-	for waiting := true; waiting; {
+	// wait for the ok to $X too, so it is not taken as the answer to the
+	// first command
+	for unlocked, waiting := false, true; waiting; {
 		select {
 		case <-ctx.Done():
 			panic("context cancelled")
@@ -133,6 +165,9 @@ func New(port *serial.Port, logger *zap.Logger) *Server {
 				txCh <- []byte("$X\n")
 			}
 			if bytes.Contains(msg, []byte("[MSG:Caution: Unlocked]")) {
+				unlocked = true
+			}
+			if unlocked && bytes.Equal(bytes.TrimSpace(msg), []byte("ok")) {
 				waiting = false
 			}
 		}
@@ -142,14 +177,14 @@ func New(port *serial.Port, logger *zap.Logger) *Server {
 		Parser:        grbl.NewParser(buf),
 		port:          port,
 		rxChan:        rxCh,
-		Cts:           atomic.Bool{},
 		logger:        logger,
 		state:         &atomic.Pointer[v1.State]{},
 		machineStatus: &atomic.Pointer[grbl.Status]{},
+		alarm:         &atomic.Pointer[grbl.Alarm]{},
+		queue:         queue.NewCounting(txCh, RXBufferSize),
 		TxChan:        txCh,
 		listenCancel:  can,
 	}
-	s.Cts.Store(false)
 	go func() {
 		err := s.Listen(ctx)
 		if err != nil {
@@ -201,7 +236,6 @@ func goToMsg(pos *v1.MoveRequest) []byte {
 	}
 	bld.WriteString("\n")
 	ret := bld.Bytes()
-	fmt.Println("writing: ", string(ret))
 	return ret
 }
 
@@ -212,23 +246,20 @@ func floatEqual(a, b float32) bool {
 }
 
 func (s *Server) Move(ctx context.Context, req *v1.MoveRequest) (*v1.Response, error) {
-	err := s.do(goToMsg(req), true, func(state *v1.State) bool {
-		if req.X != nil {
-			if floatEqual(state.Position.X, *req.X) && s.machineStatus.Load().State == "idle" {
-				return true
-			}
+	err := s.do(ctx, goToMsg(req), func(state *v1.State) bool {
+		if status := s.status(); status == nil || status.State != "idle" {
+			return false
 		}
-		if req.Y != nil {
-			if floatEqual(state.Position.Y, *req.Y) && s.machineStatus.Load().State == "idle" {
-				return true
-			}
+		if req.X != nil && !floatEqual(state.Position.X, *req.X) {
+			return false
 		}
-		if req.Z != nil {
-			if floatEqual(state.Position.Z, *req.Z) && s.machineStatus.Load().State == "idle" {
-				return true
-			}
+		if req.Y != nil && !floatEqual(state.Position.Y, *req.Y) {
+			return false
+		}
+		if req.Z != nil && !floatEqual(state.Position.Z, *req.Z) {
+			return false
 		}
-		return false
+		return true
 	})
 	if err != nil {
 		return nil, err
@@ -244,9 +275,7 @@ func (s *Server) Move(ctx context.Context, req *v1.MoveRequest) (*v1.Response, e
 }
 
 func (s *Server) SpindleOn(ctx context.Context, req *v1.SpindleOnRequest) (*v1.Response, error) {
-	err := s.do([]byte("M3\n"), true, func(state *v1.State) bool {
-		return true
-	})
+	err := s.do(ctx, []byte("M3\n"), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -257,9 +286,7 @@ func (s *Server) SpindleOn(ctx context.Context, req *v1.SpindleOnRequest) (*v1.R
 }
 
 func (s *Server) SpindleOff(ctx context.Context, req *v1.SpindleOffRequest) (*v1.Response, error) {
-	err := s.do([]byte("M5\n"), true, func(state *v1.State) bool {
-		return true
-	})
+	err := s.do(ctx, []byte("M5\n"), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -270,9 +297,7 @@ func (s *Server) SpindleOff(ctx context.Context, req *v1.SpindleOffRequest) (*v1
 }
 
 func (s *Server) MistOn(ctx context.Context, req *v1.MistOnRequest) (*v1.Response, error) {
-	err := s.do([]byte("M7\n"), true, func(state *v1.State) bool {
-		return true
-	})
+	err := s.do(ctx, []byte("M7\n"), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -287,9 +312,7 @@ func (s *Server) MistOn(ctx context.Context, req *v1.MistOnRequest) (*v1.Respons
 }
 
 func (s *Server) FloodOn(ctx context.Context, req *v1.FloodOnRequest) (*v1.Response, error) {
-	err := s.do([]byte("M8\n"), true, func(state *v1.State) bool {
-		return true
-	})
+	err := s.do(ctx, []byte("M8\n"), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -304,9 +327,7 @@ func (s *Server) FloodOn(ctx context.Context, req *v1.FloodOnRequest) (*v1.Respo
 }
 
 func (s *Server) CoolantOff(ctx context.Context, req *v1.CoolantOffRequest) (*v1.Response, error) {
-	err := s.do([]byte("M9\n"), true, func(state *v1.State) bool {
-		return true
-	})
+	err := s.do(ctx, []byte("M9\n"), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -321,30 +342,65 @@ func (s *Server) CoolantOff(ctx context.Context, req *v1.CoolantOffRequest) (*v1
 }
 
 var (
-	HeartbeatMsg = "?\n"
+	// HeartbeatMsg is GRBL's real-time status query. It is not followed by a
+	// newline, since GRBL acknowledges empty lines with ok.
+	HeartbeatMsg = "?"
 )
 
+var errReset = status.Error(codes.Aborted, "GRBL reset")
+
 func (s *Server) UpdateStatus(status grbl.StatusUpdate) {
-	if _, ok := status.(*grbl.Ack); ok {
-		s.Cts.Store(true)
-		return
-	}
-	if grblErr, ok := status.(grbl.Error); ok {
-		s.logger.Fatal("Received error", zap.Int("msg", int(grblErr)))
-		return
-	}
-	if grblAlarm, ok := status.(grbl.Alarm); ok {
-		s.logger.Fatal("Received alarm", zap.Int("msg", int(grblAlarm)))
-		return
-	}
-	if upd, ok := status.(*grbl.Status); ok {
+	switch upd := status.(type) {
+	case *grbl.Ack:
+		if !s.queue.Resolve(nil) {
+			s.logger.Debug("Received ok with no command outstanding")
+		}
+	case grbl.Error:
+		if !s.queue.Resolve(firmwareError(upd)) {
+			s.logger.Error("Received error with no command outstanding", zap.Int("error", int(upd)))
+		}
+	case grbl.Alarm:
+		s.logger.Error("Received alarm", zap.Int("alarm", int(upd)))
+		s.alarm.Store(&upd)
+		// GRBL drops what it was doing, so nothing outstanding will be answered
+		s.queue.Flush(alarmError(upd))
+		if state := s.currentState(); state != nil {
+			s.state.Store(s.withAlarm(state))
+		}
+		s.changed.Notify()
+	case *grbl.Welcome:
+		s.logger.Info("GRBL reset", zap.String("version", upd.Version))
+		// a reset empties GRBL's buffer, however it was triggered
+		s.queue.Flush(errReset)
+		s.resets.Add(1)
+		s.changed.Notify()
+	case *grbl.Setting, *grbl.ParserState, *grbl.Feedback:
+		s.collectorMu.Lock()
+		if s.collector != nil {
+			s.collector(upd)
+		} else {
+			s.logger.Debug("Received unrequested report", zap.Any("report", upd))
+		}
+		s.collectorMu.Unlock()
+	case grbl.Message:
+		s.logger.Info("Received message", zap.String("msg", string(upd)))
+	case *grbl.Status:
+		s.reported.Store(true)
 		s.machineStatus.Store(upd)
+		pos := upd.MachinePosition
+		if pos == nil {
+			pos = upd.WorkPosition
+		}
+		if pos == nil {
+			pos = new(grbl.Position)
+		}
 		newState := &v1.State{
 			Position: &v1.Position{
-				X: upd.MachinePosition.X,
-				Y: upd.MachinePosition.Y,
-				Z: upd.MachinePosition.Z,
+				X: pos.X,
+				Y: pos.Y,
+				Z: pos.Z,
 			},
+			Feed: upd.Feed,
 		}
 		if upd.Error != nil {
 			ec := v1.ErrorCode(*upd.Error)
@@ -376,15 +432,61 @@ func (s *Server) UpdateStatus(status grbl.StatusUpdate) {
 				newState.Active = append(newState.Active, v1.Peripheral_Spindle)
 			}
 		}
+		if upd.State == "alarm" {
+			newState = s.withAlarm(newState)
+		} else {
+			s.alarm.Store(nil)
+		}
 		s.state.Store(newState)
+		s.changed.Notify()
+	}
+}
+
+// withAlarm returns a copy of state carrying the last alarm GRBL raised.
+func (s *Server) withAlarm(state *v1.State) *v1.State {
+	alarm := s.alarm.Load()
+	if alarm == nil {
+		return state
+	}
+	code := v1.AlarmCode(*alarm)
+	msg := code.String()
+	ret := proto.Clone(state).(*v1.State)
+	ret.Alarm = &v1.Alarm{Alarm: &code, Message: &msg}
+	return ret
+}
+
+// connection turns the port being lost into an alarm, failing whatever was
+// waiting on GRBL. GRBL resets when the port is reopened, and comes back
+// locked if homing is enabled, so it has to be homed or unlocked again.
+func (s *Server) connection(e serial.Event) {
+	switch e.State {
+	case serial.Disconnected:
+		s.logger.Error("Serial port lost", zap.String("port", e.Port), zap.Error(e.Err))
+		s.disconnected.Store(true)
+		s.queue.Flush(errDisconnected)
+		msg := errDisconnected.Error()
+		state := s.currentState()
+		if state == nil {
+			state = &v1.State{Position: &v1.Position{}}
+		}
+		state = proto.Clone(state).(*v1.State)
+		state.Alarm = &v1.Alarm{Message: &msg}
+		s.state.Store(state)
+	case serial.Connected:
+		s.logger.Info("Serial port reconnected", zap.String("port", e.Port))
+		s.disconnected.Store(false)
 	}
+	s.changed.Notify()
 }
 
 func (s *Server) Listen(ctx context.Context) error {
+	defer s.queue.Close()
 	for {
 		select {
 		case <-ctx.Done():
 			return nil
+		case e := <-s.port.Events():
+			s.connection(e)
 		case msg := <-s.rxChan:
 			bb, err := io.ReadAll(msg)
 			if err != nil {
@@ -396,7 +498,6 @@ func (s *Server) Listen(ctx context.Context) error {
 			parser := grbl.NewParser(buf)
 			upd, err := parser.Parse()
 			if err != nil {
-				// write back to the buffer so that we can try again
 				s.logger.Error("Failed to parse message", zap.Error(err))
 			} else {
 				s.UpdateStatus(upd)
@@ -405,24 +506,28 @@ func (s *Server) Listen(ctx context.Context) error {
 	}
 }
 
+// RunHeartbeat asks for a status report every second. Status queries bypass
+// the command queue, so they are answered even while commands are running.
 func (s *Server) RunHeartbeat(ctx context.Context) {
 	ticker := time.NewTicker(1 * time.Second)
-	errCount := 3
+	defer ticker.Stop()
+	missed := 0
+	s.reported.Store(true)
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			errCount = 3
-			if !s.Cts.Load() {
-				errCount--
-				if errCount == 0 {
-					s.logger.Fatal("Failed to receive ack")
-				}
-				continue
+			if s.reported.Swap(false) || s.disconnected.Load() {
+				missed = 0
+			} else if missed++; missed == 3 {
+				s.logger.Fatal("Failed to receive status report")
+			}
+			select {
+			case s.TxChan <- []byte(HeartbeatMsg):
+			case <-ctx.Done():
+				return
 			}
-			s.Cts.Store(false)
-			s.TxChan <- []byte(HeartbeatMsg)
 		}
 	}
 }
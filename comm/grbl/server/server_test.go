@@ -0,0 +1,321 @@
+//go:build linux
+
+package server_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jt05610/petri/comm/grbl/proto/v1"
+	"github.com/jt05610/petri/comm/grbl/server"
+	"github.com/jt05610/petri/comm/grbl/sim"
+	"github.com/jt05610/petri/comm/serial"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func start(t *testing.T) (context.Context, *sim.Simulator, *server.Server) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	t.Cleanup(cancel)
+	dev := sim.New()
+	dev.Speedup = 20
+	pty, err := serial.Simulate(ctx, dev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := serial.OpenPort(pty.Name, 115200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := server.New(port, zap.NewNop())
+	t.Cleanup(func() {
+		_ = s.Close()
+	})
+	go s.RunHeartbeat(ctx)
+	return ctx, dev, s
+}
+
+func float(f float32) *float32 {
+	return &f
+}
+
+func TestServer_Move(t *testing.T) {
+	ctx, dev, s := start(t)
+	if _, err := s.Home(ctx, &v1.HomeRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Move(ctx, &v1.MoveRequest{X: float(-20), Y: float(-5), Speed: float(1000)}); err != nil {
+		t.Fatal(err)
+	}
+	if pos := dev.Position(); pos != [3]float64{-20, -5, 0} {
+		t.Fatalf("expected to end at -20, -5, 0, got %v", pos)
+	}
+	if err := s.Send(ctx, []byte("G5\n")); status.Code(err) != codes.Unimplemented {
+		t.Fatalf("expected an unsupported command to be rejected, got %v", err)
+	}
+}
+
+func TestServer_Alarm(t *testing.T) {
+	ctx, dev, s := start(t)
+	if _, err := s.Home(ctx, &v1.HomeRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error)
+	go func() {
+		_, err := s.Move(ctx, &v1.MoveRequest{X: float(-100), Speed: float(100)})
+		done <- err
+	}()
+	time.Sleep(200 * time.Millisecond)
+	dev.Alarm(1)
+	if err := <-done; status.Code(err) != codes.Aborted {
+		t.Fatalf("expected the move to be aborted, got %v", err)
+	}
+	if _, err := s.Move(ctx, &v1.MoveRequest{X: float(-5), Speed: float(100)}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected moves to be locked out, got %v", err)
+	}
+}
+
+func TestServer_Settings(t *testing.T) {
+	ctx, _, s := start(t)
+	settings, err := s.Settings(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings[100] != 250 || settings[11] != 0.01 {
+		t.Fatalf("unexpected settings %v", settings)
+	}
+	list, err := s.GetSettings(ctx, &v1.GetSettingsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i < len(list.Settings); i++ {
+		if list.Settings[i-1].Number >= list.Settings[i].Number {
+			t.Fatalf("expected settings in order, got $%d before $%d", list.Settings[i-1].Number, list.Settings[i].Number)
+		}
+	}
+	profile := &v1.SettingsProfile{
+		Version:  "1",
+		Settings: []*v1.Setting{{Number: 100, Value: 400}, {Number: 101, Value: 250}},
+	}
+	applied, err := s.ApplyProfile(ctx, &v1.ApplyProfileRequest{Profile: profile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied.Applied) != 1 || applied.Applied[0].Number != 100 {
+		t.Fatalf("expected only $100 to be written, got %v", applied.Applied)
+	}
+	diff, err := s.DiffSettings(ctx, &v1.DiffSettingsRequest{Profile: profile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range diff.Diffs {
+		if d.Want != nil {
+			t.Fatalf("expected the profile to be applied, got %v", d)
+		}
+	}
+	ps, err := s.GetParserState(ctx, &v1.ParserStateRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ps.Modes[1] != "G54" {
+		t.Fatalf("unexpected parser state %v", ps)
+	}
+	info, err := s.GetBuildInfo(ctx, &v1.BuildInfoRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Version != "1.1h.20190825" || info.Options != "V,15,128" {
+		t.Fatalf("unexpected build info %v", info)
+	}
+}
+
+type programStream struct {
+	grpc.ServerStream
+	ctx   context.Context
+	reqs  chan *v1.StreamProgramRequest
+	resps chan *v1.StreamProgramResponse
+}
+
+func newProgramStream(ctx context.Context, gcode string) *programStream {
+	ret := &programStream{
+		ctx:   ctx,
+		reqs:  make(chan *v1.StreamProgramRequest, 1),
+		resps: make(chan *v1.StreamProgramResponse, 1000),
+	}
+	ret.reqs <- &v1.StreamProgramRequest{
+		Request: &v1.StreamProgramRequest_Program{Program: &v1.Program{Name: "test", Gcode: gcode}},
+	}
+	return ret
+}
+
+func (p *programStream) Context() context.Context {
+	return p.ctx
+}
+
+func (p *programStream) Send(resp *v1.StreamProgramResponse) error {
+	p.resps <- resp
+	return nil
+}
+
+func (p *programStream) Recv() (*v1.StreamProgramRequest, error) {
+	select {
+	case req, ok := <-p.reqs:
+		if !ok {
+			return nil, io.EOF
+		}
+		return req, nil
+	case <-p.ctx.Done():
+		return nil, p.ctx.Err()
+	}
+}
+
+func (p *programStream) control(c v1.ProgramControl) {
+	p.reqs <- &v1.StreamProgramRequest{Request: &v1.StreamProgramRequest_Control{Control: c}}
+}
+
+func program(n int) string {
+	var b strings.Builder
+	b.WriteString("G90 G1 F3000 ; absolute moves\n")
+	for i := 1; i <= n; i++ {
+		fmt.Fprintf(&b, "X-%d Y-%d (zig zag)\n", i, i%2)
+	}
+	return b.String()
+}
+
+func TestServer_StreamProgram(t *testing.T) {
+	ctx, dev, s := start(t)
+	if _, err := s.Home(ctx, &v1.HomeRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	stream := newProgramStream(ctx, program(60))
+	if err := s.StreamProgram(stream); err != nil {
+		t.Fatal(err)
+	}
+	close(stream.resps)
+	var last *v1.StreamProgramResponse
+	for resp := range stream.resps {
+		last = resp
+	}
+	if last.Event != v1.ProgramEvent_ProgramEvent_Completed || last.Done != 61 {
+		t.Fatalf("expected the program to complete, got %v", last)
+	}
+	if dev.Overflowed() {
+		t.Fatal("overflowed GRBL's receive buffer")
+	}
+}
+
+func TestServer_StreamProgram_Cancel(t *testing.T) {
+	ctx, dev, s := start(t)
+	if _, err := s.Home(ctx, &v1.HomeRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	stream := newProgramStream(ctx, program(60))
+	done := make(chan error)
+	go func() {
+		done <- s.StreamProgram(stream)
+	}()
+	for resp := range stream.resps {
+		if resp.Event == v1.ProgramEvent_ProgramEvent_Acked && resp.Done == 10 {
+			break
+		}
+	}
+	stream.control(v1.ProgramControl_ProgramControl_Hold)
+	stream.control(v1.ProgramControl_ProgramControl_Cancel)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	held := dev.Position()
+	// the feed hold stopped the machine before the reset, so there is no
+	// alarm and the position holds
+	if _, err := s.Move(ctx, &v1.MoveRequest{X: float(float32(held[0])), Speed: float(1000)}); err != nil {
+		t.Fatalf("expected to move after cancelling, got %v", err)
+	}
+	if _, err := s.Status(ctx, &v1.StatusRequest{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestServer_Jog(t *testing.T) {
+	ctx, _, s := start(t)
+	if _, err := s.Home(ctx, &v1.HomeRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Jog(ctx, &v1.JogRequest{X: float(-50), Feed: 100, Relative: true}); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if _, err := s.JogCancel(ctx, &v1.JogCancelRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := s.Status(ctx, &v1.StatusRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	x := resp.GetState().Position.X
+	if x >= 0 || x <= -50 {
+		t.Fatalf("expected the jog to stop part way, got X %v", x)
+	}
+}
+
+func TestServer_Disconnect(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	var plugged atomic.Bool
+	plugged.Store(true)
+	devices := make(chan net.Conn, 1)
+	port, err := serial.NewPort("pipe", func() (io.ReadWriteCloser, error) {
+		if !plugged.Load() {
+			return nil, errors.New("no such device")
+		}
+		host, dev := net.Pipe()
+		go func() {
+			_ = sim.New().Serve(ctx, dev)
+		}()
+		devices <- dev
+		return host, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	port.MinBackoff = 10 * time.Millisecond
+	s := server.New(port, zap.NewNop())
+	defer func() {
+		_ = s.Close()
+	}()
+	if _, err := s.Status(ctx, &v1.StatusRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	// unplug the controller
+	plugged.Store(false)
+	_ = (<-devices).Close()
+	waitFor := func(code codes.Code) {
+		t.Helper()
+		for {
+			reqCtx, cancel := context.WithTimeout(ctx, time.Second)
+			_, err := s.Status(reqCtx, &v1.StatusRequest{})
+			cancel()
+			if status.Code(err) == code {
+				return
+			}
+			if ctx.Err() != nil {
+				t.Fatalf("expected %v, got %v", code, err)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	waitFor(codes.Unavailable)
+	if _, err := s.Move(ctx, &v1.MoveRequest{X: float(-5)}); status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected moves to fail while disconnected, got %v", err)
+	}
+	plugged.Store(true)
+	waitFor(codes.OK)
+}
@@ -0,0 +1,192 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"github.com/jt05610/petri/comm/grbl"
+	"github.com/jt05610/petri/comm/grbl/proto/v1"
+	"github.com/jt05610/petri/comm/queue"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// collect sends line and passes what GRBL reports before acknowledging it to
+// f. Only one command collects at a time so that reports are not mixed up.
+func (s *Server) collect(ctx context.Context, line string, f func(grbl.StatusUpdate)) error {
+	s.collectMu.Lock()
+	defer s.collectMu.Unlock()
+	s.setCollector(f)
+	// a cancelled command may still be answered, so the results are only
+	// safe to read once the collector is cleared
+	defer s.setCollector(nil)
+	s.logger.Debug("Sending command", zap.String("cmd", line))
+	return queue.Status(s.queue.Do(ctx, []byte(line+"\n")))
+}
+
+func (s *Server) setCollector(f func(grbl.StatusUpdate)) {
+	s.collectorMu.Lock()
+	defer s.collectorMu.Unlock()
+	s.collector = f
+}
+
+// Settings reads the controller's settings with $$.
+func (s *Server) Settings(ctx context.Context) (map[int]float64, error) {
+	ret := make(map[int]float64)
+	err := s.collect(ctx, "$$", func(upd grbl.StatusUpdate) {
+		if setting, ok := upd.(*grbl.Setting); ok {
+			ret[setting.Number] = setting.Value
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (s *Server) writeSetting(ctx context.Context, n int, value float64) error {
+	cmd := fmt.Sprintf("$%d=%s\n", n, strconv.FormatFloat(value, 'f', -1, 64))
+	return s.do(ctx, []byte(cmd), nil)
+}
+
+// Configure writes the settings that differ from the profile and returns
+// them. Settings the profile does not have are left as they are.
+func (s *Server) Configure(ctx context.Context, profile *grbl.Profile) ([]grbl.SettingDiff, error) {
+	settings, err := s.Settings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]grbl.SettingDiff, 0)
+	for _, d := range profile.Diff(settings) {
+		if d.Want == nil {
+			continue
+		}
+		s.logger.Info("Writing setting", zap.Int("setting", d.Number), zap.Float64("value", *d.Want))
+		if err := s.writeSetting(ctx, d.Number, *d.Want); err != nil {
+			return ret, err
+		}
+		ret = append(ret, d)
+	}
+	s.logger.Info("Applied settings profile", zap.String("version", profile.Version), zap.Int("written", len(ret)))
+	return ret, nil
+}
+
+func profileFromProto(p *v1.SettingsProfile) *grbl.Profile {
+	ret := &grbl.Profile{Settings: make(map[int]float64)}
+	if p == nil {
+		return ret
+	}
+	ret.Version = p.Version
+	for _, setting := range p.Settings {
+		ret.Settings[int(setting.Number)] = setting.Value
+	}
+	return ret
+}
+
+func diffsToProto(diffs []grbl.SettingDiff) []*v1.SettingDiff {
+	ret := make([]*v1.SettingDiff, len(diffs))
+	for i, d := range diffs {
+		ret[i] = &v1.SettingDiff{
+			Number: uint32(d.Number),
+			Want:   d.Want,
+			Got:    d.Got,
+		}
+	}
+	return ret
+}
+
+func (s *Server) GetSettings(ctx context.Context, req *v1.GetSettingsRequest) (*v1.GetSettingsResponse, error) {
+	settings, err := s.Settings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	numbers := make([]int, 0, len(settings))
+	for n := range settings {
+		numbers = append(numbers, n)
+	}
+	sort.Ints(numbers)
+	ret := &v1.GetSettingsResponse{Settings: make([]*v1.Setting, 0, len(settings))}
+	for _, n := range numbers {
+		ret.Settings = append(ret.Settings, &v1.Setting{Number: uint32(n), Value: settings[n]})
+	}
+	return ret, nil
+}
+
+func (s *Server) SetSetting(ctx context.Context, req *v1.SetSettingRequest) (*v1.Response, error) {
+	if req.Setting == nil {
+		return nil, status.Error(codes.InvalidArgument, "no setting given")
+	}
+	if err := s.writeSetting(ctx, int(req.Setting.Number), req.Setting.Value); err != nil {
+		return nil, err
+	}
+	return ok(), nil
+}
+
+func (s *Server) DiffSettings(ctx context.Context, req *v1.DiffSettingsRequest) (*v1.DiffSettingsResponse, error) {
+	settings, err := s.Settings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.DiffSettingsResponse{
+		Diffs: diffsToProto(profileFromProto(req.Profile).Diff(settings)),
+	}, nil
+}
+
+func (s *Server) ApplyProfile(ctx context.Context, req *v1.ApplyProfileRequest) (*v1.ApplyProfileResponse, error) {
+	applied, err := s.Configure(ctx, profileFromProto(req.Profile))
+	if err != nil {
+		return nil, err
+	}
+	return &v1.ApplyProfileResponse{Applied: diffsToProto(applied)}, nil
+}
+
+func (s *Server) GetParserState(ctx context.Context, req *v1.ParserStateRequest) (*v1.ParserState, error) {
+	var ret *v1.ParserState
+	err := s.collect(ctx, "$G", func(upd grbl.StatusUpdate) {
+		if ps, ok := upd.(*grbl.ParserState); ok {
+			ret = &v1.ParserState{
+				Modes:   ps.Modes,
+				Tool:    int32(ps.Tool),
+				Feed:    ps.Feed,
+				Spindle: ps.Spindle,
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if ret == nil {
+		return nil, status.Error(codes.Internal, "GRBL did not report its parser state")
+	}
+	return ret, nil
+}
+
+func (s *Server) GetBuildInfo(ctx context.Context, req *v1.BuildInfoRequest) (*v1.BuildInfo, error) {
+	var ret *v1.BuildInfo
+	err := s.collect(ctx, "$I", func(upd grbl.StatusUpdate) {
+		f, ok := upd.(*grbl.Feedback)
+		if !ok {
+			return
+		}
+		if ret == nil {
+			ret = new(v1.BuildInfo)
+		}
+		switch f.Key {
+		case "VER":
+			// [VER:1.1h.20190825:info]
+			ret.Version, ret.Info, _ = strings.Cut(f.Value, ":")
+		case "OPT":
+			ret.Options = f.Value
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if ret == nil {
+		return nil, status.Error(codes.Internal, "GRBL did not report its build info")
+	}
+	return ret, nil
+}
@@ -0,0 +1,1007 @@
+// Package sim simulates a GRBL 1.1 controller so that the servers and the
+// devices built on them can be tested without hardware. Run it behind a
+// pseudo-terminal with serial.Simulate.
+package sim
+
+import (
+	"context"
+	"fmt"
+	"github.com/jt05610/petri/comm/queue"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// RXBufferSize is GRBL's serial receive buffer. A host that sends more
+	// than fits is not counting characters.
+	RXBufferSize = 128
+	// PlannerSize is how many motions GRBL buffers. Motion lines are
+	// acknowledged once they are planned, and wait while the planner is full.
+	PlannerSize = 16
+	// LineSize is GRBL's longest line.
+	LineSize = 80
+	// Tick is how often motion is updated.
+	Tick = 10 * time.Millisecond
+	// Version is reported in the welcome message and by $I.
+	Version = "1.1h"
+)
+
+// DefaultSettings are GRBL's defaults, with homing enabled so that the
+// controller starts locked.
+func DefaultSettings() map[int]float64 {
+	return map[int]float64{
+		0: 10, 1: 25, 2: 0, 3: 0, 4: 0, 5: 0, 6: 0, 10: 1, 11: 0.010, 12: 0.002, 13: 0,
+		20: 0, 21: 0, 22: 1, 23: 0, 24: 25, 25: 500, 26: 250, 27: 1, 30: 1000, 31: 0, 32: 0,
+		100: 250, 101: 250, 102: 250, 110: 500, 111: 500, 112: 500,
+		120: 10, 121: 10, 122: 10, 130: 200, 131: 200, 132: 200,
+	}
+}
+
+var coordinateSystems = []string{"G54", "G55", "G56", "G57", "G58", "G59"}
+
+type line struct {
+	text string
+	gen  context.Context
+}
+
+type motion struct {
+	target [3]float64
+	// rate in mm/min, before overrides
+	rate  float64
+	rapid bool
+	jog   bool
+	epoch int
+}
+
+// Simulator is a simulated GRBL controller.
+type Simulator struct {
+	// Speedup runs motion, dwells and homing this many times faster than
+	// real time.
+	Speedup float64
+
+	writeMu sync.Mutex
+	w       io.Writer
+	lines   chan line
+	changed queue.Signal
+
+	mu       sync.Mutex
+	ctx      context.Context
+	gen      context.Context
+	cancel   context.CancelFunc
+	settings map[int]float64
+	state    string
+	hold     bool
+	alarm    int
+	pos      [3]float64
+	planned  [3]float64
+	plan     []*motion
+	jogEpoch int
+	rxUsed   int
+	overflow bool
+	offsets  map[string][3]float64
+	// parser modes
+	coord     string
+	motion    string
+	absolute  bool
+	inches    bool
+	feed      float64
+	speed     float64
+	spindle   string
+	coolant   map[string]bool
+	ovFeed    int
+	ovRapid   int
+	ovSpindle int
+}
+
+func New() *Simulator {
+	s := &Simulator{
+		Speedup:  1,
+		settings: DefaultSettings(),
+		lines:    make(chan line, RXBufferSize),
+		offsets:  make(map[string][3]float64),
+	}
+	s.defaults()
+	return s
+}
+
+// defaults restores what a reset restores. Callers hold s.mu, or own s.
+func (s *Simulator) defaults() {
+	s.coord = "G54"
+	s.motion = "G0"
+	s.absolute = true
+	s.inches = false
+	s.spindle = "M5"
+	s.speed = 0
+	s.coolant = make(map[string]bool)
+	s.ovFeed, s.ovRapid, s.ovSpindle = 100, 100, 100
+	s.hold = false
+}
+
+// Overflowed reports whether the host ever sent more than fits in the
+// receive buffer.
+func (s *Simulator) Overflowed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.overflow
+}
+
+// Position returns the machine position.
+func (s *Simulator) Position() [3]float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pos
+}
+
+// Alarm raises an alarm as if the controller had detected it, such as 1 for
+// a hard limit.
+func (s *Simulator) Alarm(code int) {
+	s.mu.Lock()
+	s.raise(code)
+	s.mu.Unlock()
+	s.writeln(fmt.Sprintf("ALARM:%d", code))
+}
+
+// raise stops motion and locks the controller. Callers hold s.mu.
+func (s *Simulator) raise(code int) {
+	s.alarm = code
+	s.state = "Alarm"
+	s.plan = nil
+	s.planned = s.pos
+	s.changed.Notify()
+}
+
+func (s *Simulator) writeln(lines ...string) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	for _, l := range lines {
+		_, _ = io.WriteString(s.w, l+"\r\n")
+	}
+}
+
+func (s *Simulator) welcome() {
+	s.mu.Lock()
+	locked := s.state == "Alarm"
+	s.mu.Unlock()
+	s.writeln("", fmt.Sprintf("Grbl %s ['$' for help]", Version))
+	if locked {
+		s.writeln("[MSG:'$H'|'$X' to unlock]")
+	}
+}
+
+// Serve runs the controller, reading from and writing to rw.
+func (s *Simulator) Serve(ctx context.Context, rw io.ReadWriter) error {
+	s.w = rw
+	s.mu.Lock()
+	s.ctx = ctx
+	s.gen, s.cancel = context.WithCancel(ctx)
+	s.state = "Idle"
+	if s.settings[22] == 1 {
+		// homing is required after power up
+		s.state = "Alarm"
+		s.alarm = 0
+	}
+	s.mu.Unlock()
+	s.welcome()
+	go s.execute(ctx)
+	go s.run(ctx)
+	buf := make([]byte, 256)
+	var cur []byte
+	overlong := false
+	for {
+		n, err := rw.Read(buf)
+		if err != nil {
+			return err
+		}
+		for _, b := range buf[:n] {
+			if s.realtime(b) {
+				continue
+			}
+			switch {
+			case b == '\n' || b == '\r':
+				text := string(cur)
+				if overlong {
+					text = "\x00overflow"
+				}
+				s.receive(text)
+				cur, overlong = cur[:0], false
+			case b >= 0x80:
+			case len(cur) >= LineSize-1:
+				overlong = true
+			default:
+				cur = append(cur, b)
+			}
+		}
+	}
+}
+
+func (s *Simulator) receive(text string) {
+	s.mu.Lock()
+	s.rxUsed += len(text) + 1
+	if s.rxUsed > RXBufferSize {
+		s.overflow = true
+	}
+	gen := s.gen
+	s.mu.Unlock()
+	select {
+	case s.lines <- line{text: text, gen: gen}:
+	default:
+		// GRBL would have dropped the bytes
+		s.mu.Lock()
+		s.overflow = true
+		s.mu.Unlock()
+	}
+}
+
+func clamp(v, lo, hi int) int {
+	return max(lo, min(hi, v))
+}
+
+// realtime acts on a real-time command and reports whether b was one.
+func (s *Simulator) realtime(b byte) bool {
+	switch b {
+	case '?':
+		s.writeln(s.report())
+	case '!':
+		s.mu.Lock()
+		switch s.state {
+		case "Jog":
+			s.cancelJog()
+		case "Run", "Idle":
+			s.hold = true
+			s.state = "Hold"
+		}
+		s.mu.Unlock()
+	case '~':
+		s.mu.Lock()
+		if s.state == "Hold" {
+			s.hold = false
+			s.state = "Idle"
+			if len(s.plan) > 0 {
+				s.state = "Run"
+			}
+		}
+		s.mu.Unlock()
+	case 0x18:
+		s.reset()
+	case 0x85:
+		s.mu.Lock()
+		if s.state == "Jog" {
+			s.cancelJog()
+		}
+		s.mu.Unlock()
+	case 0x90, 0x91, 0x92, 0x93, 0x94, 0x95, 0x96, 0x97, 0x99, 0x9A, 0x9B, 0x9C, 0x9D:
+		s.mu.Lock()
+		s.override(b)
+		s.mu.Unlock()
+	default:
+		return false
+	}
+	s.changed.Notify()
+	return true
+}
+
+// cancelJog stops jogging and drops the planned jog motions. Callers hold
+// s.mu.
+func (s *Simulator) cancelJog() {
+	s.jogEpoch++
+	s.plan = nil
+	s.planned = s.pos
+	s.state = "Idle"
+}
+
+// override adjusts an override. Callers hold s.mu.
+func (s *Simulator) override(b byte) {
+	switch b {
+	case 0x90:
+		s.ovFeed = 100
+	case 0x91:
+		s.ovFeed += 10
+	case 0x92:
+		s.ovFeed -= 10
+	case 0x93:
+		s.ovFeed++
+	case 0x94:
+		s.ovFeed--
+	case 0x95:
+		s.ovRapid = 100
+	case 0x96:
+		s.ovRapid = 50
+	case 0x97:
+		s.ovRapid = 25
+	case 0x99:
+		s.ovSpindle = 100
+	case 0x9A:
+		s.ovSpindle += 10
+	case 0x9B:
+		s.ovSpindle -= 10
+	case 0x9C:
+		s.ovSpindle++
+	case 0x9D:
+		s.ovSpindle--
+	}
+	s.ovFeed = clamp(s.ovFeed, 10, 200)
+	s.ovSpindle = clamp(s.ovSpindle, 10, 200)
+}
+
+// reset stops everything and discards what was buffered. Stopping while in
+// motion loses the position, which raises an alarm.
+func (s *Simulator) reset() {
+	s.mu.Lock()
+	alarm := 0
+	switch s.state {
+	case "Run", "Jog":
+		alarm = 3
+	case "Home":
+		alarm = 6
+	}
+	s.cancel()
+	s.gen, s.cancel = context.WithCancel(s.ctx)
+	for drained := false; !drained; {
+		select {
+		case <-s.lines:
+		default:
+			drained = true
+		}
+	}
+	s.rxUsed = 0
+	s.plan = nil
+	s.planned = s.pos
+	s.defaults()
+	switch {
+	case alarm != 0:
+		s.raise(alarm)
+	case s.state != "Alarm":
+		s.state = "Idle"
+	}
+	s.mu.Unlock()
+	if alarm != 0 {
+		s.writeln(fmt.Sprintf("ALARM:%d", alarm))
+	}
+	s.welcome()
+}
+
+func format(f float64) string {
+	return strconv.FormatFloat(f, 'f', 3, 64)
+}
+
+func (s *Simulator) report() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state := s.state
+	if state == "Hold" {
+		state = "Hold:0"
+	}
+	wco := s.offsets[s.coord]
+	rate := 0.0
+	if len(s.plan) > 0 && !s.hold {
+		rate = s.rate(s.plan[0])
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "<%s|MPos:%s,%s,%s|FS:%.0f,%.0f", state,
+		format(s.pos[0]), format(s.pos[1]), format(s.pos[2]), rate, s.speed)
+	fmt.Fprintf(&b, "|Ov:%d,%d,%d", s.ovFeed, s.ovRapid, s.ovSpindle)
+	fmt.Fprintf(&b, "|WCO:%s,%s,%s", format(wco[0]), format(wco[1]), format(wco[2]))
+	active := ""
+	switch s.spindle {
+	case "M3":
+		active += "S"
+	case "M4":
+		active += "C"
+	}
+	if s.coolant["M8"] {
+		active += "F"
+	}
+	if s.coolant["M7"] {
+		active += "M"
+	}
+	if active != "" {
+		fmt.Fprintf(&b, "|A:%s", active)
+	}
+	b.WriteString(">")
+	return b.String()
+}
+
+// rate returns the feed of m in mm/min with the overrides applied. Callers
+// hold s.mu.
+func (s *Simulator) rate(m *motion) float64 {
+	if m.rapid {
+		return m.rate * float64(s.ovRapid) / 100
+	}
+	if m.jog {
+		return m.rate
+	}
+	return m.rate * float64(s.ovFeed) / 100
+}
+
+// run moves the machine along the planned motions.
+func (s *Simulator) run(ctx context.Context) {
+	ticker := time.NewTicker(Tick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		s.mu.Lock()
+		s.step(Tick.Seconds() * s.Speedup)
+		s.mu.Unlock()
+		s.changed.Notify()
+	}
+}
+
+// step advances motion by dt seconds. Callers hold s.mu.
+func (s *Simulator) step(dt float64) {
+	if s.hold || s.state == "Alarm" || s.state == "Home" {
+		return
+	}
+	if len(s.plan) == 0 {
+		if s.state == "Run" || s.state == "Jog" {
+			s.state = "Idle"
+		}
+		return
+	}
+	m := s.plan[0]
+	s.state = "Run"
+	if m.jog {
+		s.state = "Jog"
+	}
+	dist := 0.0
+	for i := range s.pos {
+		dist += (m.target[i] - s.pos[i]) * (m.target[i] - s.pos[i])
+	}
+	dist = math.Sqrt(dist)
+	travel := s.rate(m) / 60 * dt
+	if travel >= dist {
+		s.pos = m.target
+		s.plan = s.plan[1:]
+		return
+	}
+	for i := range s.pos {
+		s.pos[i] += (m.target[i] - s.pos[i]) * travel / dist
+	}
+}
+
+// wait blocks until done reports true, giving up when the line's generation
+// is reset.
+func (s *Simulator) wait(gen context.Context, done func() bool) error {
+	return queue.Await(gen, &s.changed, func() (bool, error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return done(), nil
+	})
+}
+
+// sync waits for the planned motions to finish, as GRBL does before
+// commands that must not overlap motion.
+func (s *Simulator) sync(gen context.Context) error {
+	return s.wait(gen, func() bool {
+		return len(s.plan) == 0 && s.state != "Hold"
+	})
+}
+
+func (s *Simulator) sleep(gen context.Context, seconds float64) error {
+	select {
+	case <-time.After(time.Duration(seconds / s.Speedup * float64(time.Second))):
+		return nil
+	case <-gen.Done():
+		return gen.Err()
+	}
+}
+
+// enqueue plans a motion, waiting while the planner is full.
+func (s *Simulator) enqueue(gen context.Context, m *motion) error {
+	err := s.wait(gen, func() bool {
+		return len(s.plan) < PlannerSize
+	})
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.state == "Alarm" || (m.jog && m.epoch != s.jogEpoch) {
+		return nil
+	}
+	s.plan = append(s.plan, m)
+	s.planned = m.target
+	if s.state == "Idle" {
+		s.state = "Run"
+		if m.jog {
+			s.state = "Jog"
+		}
+	}
+	return nil
+}
+
+// execute runs received lines in order.
+func (s *Simulator) execute(ctx context.Context) {
+	for {
+		var l line
+		select {
+		case <-ctx.Done():
+			return
+		case l = <-s.lines:
+		}
+		s.mu.Lock()
+		s.rxUsed -= len(l.text) + 1
+		s.mu.Unlock()
+		out, code := s.exec(l.gen, l.text)
+		if l.gen.Err() != nil {
+			// reset while running; GRBL answers nothing
+			continue
+		}
+		if code == alarmed {
+			s.writeln(out...)
+			continue
+		}
+		if code != 0 {
+			out = append(out, fmt.Sprintf("error:%d", code))
+		} else {
+			out = append(out, "ok")
+		}
+		s.writeln(out...)
+	}
+}
+
+// alarmed is returned by exec when the line raised an alarm, which GRBL
+// reports instead of answering the line.
+const alarmed = -1
+
+// clean strips whitespace and comments and upper-cases text, as GRBL does
+// before parsing a line.
+func clean(text string) string {
+	var b strings.Builder
+	depth := 0
+	for _, r := range text {
+		switch {
+		case r == ';' && depth == 0:
+			return b.String()
+		case r == '(':
+			depth++
+		case r == ')' && depth > 0:
+			depth--
+		case depth > 0 || r == ' ' || r == '\t':
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return strings.ToUpper(b.String())
+}
+
+func (s *Simulator) exec(gen context.Context, text string) ([]string, int) {
+	if text == "\x00overflow" {
+		return nil, 11
+	}
+	text = clean(text)
+	if strings.HasPrefix(text, "$") {
+		return s.system(gen, text)
+	}
+	if text == "" {
+		return nil, 0
+	}
+	s.mu.Lock()
+	locked := s.state == "Alarm" || s.state == "Jog"
+	s.mu.Unlock()
+	if locked {
+		return nil, 9
+	}
+	return s.gcode(gen, text)
+}
+
+func (s *Simulator) system(gen context.Context, cmd string) ([]string, int) {
+	s.mu.Lock()
+	state := s.state
+	s.mu.Unlock()
+	switch {
+	case cmd == "$":
+		return []string{"[HLP:$$ $# $G $I $N $x=val $Nx=line $J=line $SLP $C $X $H ~ ! ? ctrl-x]"}, 0
+	case cmd == "$$":
+		return s.listSettings(), 0
+	case cmd == "$#":
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		ret := make([]string, 0, len(coordinateSystems))
+		for _, c := range coordinateSystems {
+			o := s.offsets[c]
+			ret = append(ret, fmt.Sprintf("[%s:%s,%s,%s]", c, format(o[0]), format(o[1]), format(o[2])))
+		}
+		return ret, 0
+	case cmd == "$G":
+		return []string{s.parserState()}, 0
+	case cmd == "$I":
+		return []string{"[VER:" + Version + ".20190825:]", "[OPT:V,15,128]"}, 0
+	case cmd == "$N":
+		return []string{"$N0=", "$N1="}, 0
+	case cmd == "$X":
+		if state != "Alarm" {
+			return nil, 0
+		}
+		s.mu.Lock()
+		s.state = "Idle"
+		s.alarm = 0
+		s.mu.Unlock()
+		s.changed.Notify()
+		return []string{"[MSG:Caution: Unlocked]"}, 0
+	case strings.HasPrefix(cmd, "$H"):
+		return s.home(gen, cmd[2:])
+	case strings.HasPrefix(cmd, "$J="):
+		return s.jog(gen, cmd[3:])
+	}
+	n, value, found := strings.Cut(cmd[1:], "=")
+	if !found {
+		return nil, 3
+	}
+	num, err := strconv.Atoi(n)
+	if err != nil {
+		return nil, 3
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, 2
+	}
+	if v < 0 {
+		return nil, 4
+	}
+	if state != "Idle" && state != "Alarm" {
+		return nil, 8
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, found := s.settings[num]; !found {
+		return nil, 3
+	}
+	s.settings[num] = v
+	return nil, 0
+}
+
+func (s *Simulator) listSettings() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	nums := make([]int, 0, len(s.settings))
+	for n := range s.settings {
+		nums = append(nums, n)
+	}
+	sort.Ints(nums)
+	ret := make([]string, len(nums))
+	for i, n := range nums {
+		v := s.settings[n]
+		if n < 100 && v == math.Trunc(v) {
+			ret[i] = fmt.Sprintf("$%d=%d", n, int(v))
+		} else {
+			ret[i] = fmt.Sprintf("$%d=%s", n, format(v))
+		}
+	}
+	return ret
+}
+
+func (s *Simulator) parserState() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	distance, units := "G90", "G21"
+	if !s.absolute {
+		distance = "G91"
+	}
+	if s.inches {
+		units = "G20"
+	}
+	coolant := "M9"
+	if s.coolant["M7"] {
+		coolant = "M7"
+	}
+	if s.coolant["M8"] {
+		coolant = "M8"
+	}
+	return fmt.Sprintf("[GC:%s %s G17 %s %s G94 %s %s T0 F%g S%g]",
+		s.motion, s.coord, units, distance, s.spindle, coolant, s.feed, s.speed)
+}
+
+var axes = "XYZ"
+
+// home runs the homing cycle, leaving the homed axes at zero.
+func (s *Simulator) home(gen context.Context, which string) ([]string, int) {
+	s.mu.Lock()
+	if s.settings[22] != 1 {
+		s.mu.Unlock()
+		return nil, 5
+	}
+	if s.state != "Idle" && s.state != "Alarm" {
+		s.mu.Unlock()
+		return nil, 8
+	}
+	if which == "" {
+		which = axes
+	}
+	far := 0.0
+	for _, a := range which {
+		i := strings.IndexRune(axes, a)
+		if i < 0 {
+			s.mu.Unlock()
+			return nil, 3
+		}
+		far = math.Max(far, math.Abs(s.pos[i]))
+	}
+	s.state = "Home"
+	seek := s.settings[25]
+	s.mu.Unlock()
+	s.changed.Notify()
+	if err := s.sleep(gen, far/seek*60); err != nil {
+		return nil, 0
+	}
+	s.mu.Lock()
+	for _, a := range which {
+		s.pos[strings.IndexRune(axes, a)] = 0
+	}
+	s.planned = s.pos
+	s.state = "Idle"
+	s.alarm = 0
+	s.mu.Unlock()
+	s.changed.Notify()
+	return nil, 0
+}
+
+type word struct {
+	letter byte
+	value  float64
+}
+
+func parseWords(text string) ([]word, int) {
+	ret := make([]word, 0)
+	for i := 0; i < len(text); {
+		letter := text[i]
+		if letter < 'A' || letter > 'Z' {
+			return nil, 1
+		}
+		j := i + 1
+		for j < len(text) && (text[j] == '.' || text[j] == '-' || text[j] == '+' || (text[j] >= '0' && text[j] <= '9')) {
+			j++
+		}
+		v, err := strconv.ParseFloat(text[i+1:j], 64)
+		if err != nil {
+			return nil, 2
+		}
+		ret = append(ret, word{letter: letter, value: v})
+		i = j
+	}
+	return ret, 0
+}
+
+// target converts axis words to a machine position. Callers hold s.mu.
+func (s *Simulator) target(words map[byte]float64, absolute, machine bool) [3]float64 {
+	ret := s.planned
+	wco := s.offsets[s.coord]
+	scale := 1.0
+	if s.inches {
+		scale = 25.4
+	}
+	for i := range axes {
+		v, found := words[axes[i]]
+		if !found {
+			continue
+		}
+		v *= scale
+		switch {
+		case !absolute:
+			ret[i] += v
+		case machine:
+			ret[i] = v
+		default:
+			ret[i] = v + wco[i]
+		}
+	}
+	return ret
+}
+
+// withinTravel reports whether target is inside the soft limits, if they are
+// enabled. Callers hold s.mu.
+func (s *Simulator) withinTravel(target [3]float64) bool {
+	if s.settings[20] != 1 {
+		return true
+	}
+	for i, v := range target {
+		if v > 0 || v < -s.settings[130+i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Simulator) jog(gen context.Context, text string) ([]string, int) {
+	words, code := parseWords(clean(text))
+	if code != 0 {
+		return nil, code
+	}
+	s.mu.Lock()
+	if s.state != "Idle" && s.state != "Jog" {
+		s.mu.Unlock()
+		return nil, 8
+	}
+	absolute, machine := s.absolute, false
+	axisWords := make(map[byte]float64)
+	feed := -1.0
+	for _, w := range words {
+		switch {
+		case w.letter == 'G' && w.value == 90:
+			absolute = true
+		case w.letter == 'G' && w.value == 91:
+			absolute = false
+		case w.letter == 'G' && w.value == 53:
+			machine = true
+		case w.letter == 'G' && (w.value == 20 || w.value == 21):
+			s.inches = w.value == 20
+		case w.letter == 'F':
+			feed = w.value
+		case strings.IndexByte(axes, w.letter) >= 0:
+			axisWords[w.letter] = w.value
+		default:
+			s.mu.Unlock()
+			return nil, 16
+		}
+	}
+	if feed <= 0 {
+		s.mu.Unlock()
+		return nil, 22
+	}
+	target := s.target(axisWords, absolute, machine)
+	if !s.withinTravel(target) {
+		s.mu.Unlock()
+		return nil, 15
+	}
+	m := &motion{target: target, rate: feed, jog: true, epoch: s.jogEpoch}
+	s.mu.Unlock()
+	if err := s.enqueue(gen, m); err != nil {
+		return nil, 0
+	}
+	return nil, 0
+}
+
+func (s *Simulator) gcode(gen context.Context, text string) ([]string, int) {
+	words, code := parseWords(text)
+	if code != 0 {
+		return nil, code
+	}
+	s.mu.Lock()
+	motionMode := s.motion
+	absolute := s.absolute
+	machine := false
+	dwell, pause := -1.0, false
+	setOffset := 0
+	offsetIndex := 1
+	spindle, coolant := "", ""
+	axisWords := make(map[byte]float64)
+	for _, w := range words {
+		switch w.letter {
+		case 'G':
+			switch w.value {
+			case 0:
+				motionMode = "G0"
+			case 1:
+				motionMode = "G1"
+			case 4:
+				dwell = 0
+			case 10:
+				setOffset = -1
+			case 17, 40, 49, 80, 94:
+			case 20, 21:
+				s.inches = w.value == 20
+			case 53:
+				machine = true
+			case 54, 55, 56, 57, 58, 59:
+				s.coord = fmt.Sprintf("G%d", int(w.value))
+			case 90:
+				absolute = true
+			case 91:
+				absolute = false
+			default:
+				s.mu.Unlock()
+				return nil, 20
+			}
+		case 'M':
+			switch w.value {
+			case 0:
+				pause = true
+			case 2, 30:
+				spindle, coolant = "M5", "M9"
+			case 3, 4, 5:
+				spindle = fmt.Sprintf("M%d", int(w.value))
+			case 7, 8, 9:
+				coolant = fmt.Sprintf("M%d", int(w.value))
+			default:
+				s.mu.Unlock()
+				return nil, 20
+			}
+		case 'L':
+			setOffset = int(w.value)
+		case 'P':
+			if dwell >= 0 {
+				dwell = w.value
+			}
+			offsetIndex = int(w.value)
+		case 'F':
+			s.feed = w.value
+		case 'S':
+			s.speed = w.value
+		case 'T', 'N':
+		case 'X', 'Y', 'Z':
+			axisWords[w.letter] = w.value
+		default:
+			s.mu.Unlock()
+			return nil, 20
+		}
+	}
+	s.absolute = absolute
+	s.motion = motionMode
+	if setOffset != 0 {
+		defer s.mu.Unlock()
+		if (setOffset != 2 && setOffset != 20) || offsetIndex < 1 || offsetIndex > len(coordinateSystems) {
+			return nil, 28
+		}
+		c := coordinateSystems[offsetIndex-1]
+		o := s.offsets[c]
+		for i := range axes {
+			if v, found := axisWords[axes[i]]; found {
+				if setOffset == 2 {
+					o[i] = v
+				} else {
+					o[i] = s.planned[i] - v
+				}
+			}
+		}
+		s.offsets[c] = o
+		return nil, 0
+	}
+	var m *motion
+	if len(axisWords) > 0 {
+		if motionMode == "G1" && s.feed <= 0 {
+			s.mu.Unlock()
+			return nil, 22
+		}
+		target := s.target(axisWords, absolute, machine)
+		if !s.withinTravel(target) {
+			s.raise(2)
+			s.mu.Unlock()
+			return []string{"ALARM:2"}, alarmed
+		}
+		m = &motion{target: target, rate: s.feed, rapid: motionMode == "G0"}
+		if m.rapid {
+			m.rate = s.settings[110]
+		}
+	}
+	s.mu.Unlock()
+	if dwell >= 0 || pause || spindle != "" || coolant != "" {
+		if err := s.sync(gen); err != nil {
+			return nil, 0
+		}
+	}
+	if dwell > 0 {
+		if err := s.sleep(gen, dwell); err != nil {
+			return nil, 0
+		}
+	}
+	s.mu.Lock()
+	if pause {
+		s.hold = true
+		s.state = "Hold"
+	}
+	if spindle != "" {
+		s.spindle = spindle
+	}
+	switch coolant {
+	case "M9":
+		s.coolant = make(map[string]bool)
+	case "M7", "M8":
+		s.coolant[coolant] = true
+	}
+	s.mu.Unlock()
+	if m != nil {
+		if err := s.enqueue(gen, m); err != nil {
+			return nil, 0
+		}
+	}
+	s.changed.Notify()
+	return nil, 0
+}
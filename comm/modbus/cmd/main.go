@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/joho/godotenv"
+	"github.com/jt05610/petri/amqp"
+	amqpServer "github.com/jt05610/petri/amqp/server"
+	"github.com/jt05610/petri/comm/modbus"
+	"github.com/jt05610/petri/comm/modbus/device"
+	"github.com/jt05610/petri/comm/modbus/server"
+	"github.com/jt05610/petri/comm/modbus/sim"
+	"github.com/jt05610/petri/comm/serial"
+	proto "github.com/jt05610/petri/proto/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+)
+
+type Environment struct {
+	// Port serves the registers over gRPC. It is 0 if PORT is not set.
+	Port int
+	// Address is a Modbus TCP device such as 10.0.0.5:502. Without one,
+	// the device is reached over RTU on SerialPort.
+	Address    string
+	SerialPort string
+	Baud       int
+	// DeviceConfig and Net, when set, run the device as a petri device bound
+	// to the net by the config, talking to the controller over AMQP.
+	DeviceConfig string
+	Net          string
+	URI          string
+	Exchange     string
+	DeviceID     string
+	InstanceID   string
+}
+
+// lookup reads the variables keys point at, failing on the first one missing.
+func lookup(keys map[string]*string) error {
+	for k, into := range keys {
+		v, found := os.LookupEnv(k)
+		if !found {
+			return fmt.Errorf("environment variable %s not found", k)
+		}
+		*into = v
+	}
+	return nil
+}
+
+func load() (*Environment, error) {
+	ret := new(Environment)
+	if config, found := os.LookupEnv("DEVICE_CONFIG"); found {
+		ret.DeviceConfig = config
+		err := lookup(map[string]*string{
+			"NET":           &ret.Net,
+			"RABBITMQ_URI":  &ret.URI,
+			"AMQP_EXCHANGE": &ret.Exchange,
+			"DEVICE_ID":     &ret.DeviceID,
+			"INSTANCE_ID":   &ret.InstanceID,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	port, found := os.LookupEnv("PORT")
+	if !found && ret.DeviceConfig == "" {
+		return nil, fmt.Errorf("set PORT or DEVICE_CONFIG")
+	}
+	if found {
+		p, err := strconv.Atoi(port)
+		if err != nil {
+			return nil, err
+		}
+		ret.Port = p
+	}
+	if addr, found := os.LookupEnv("MODBUS_ADDRESS"); found {
+		ret.Address = addr
+		return ret, nil
+	}
+	serialPort, found := os.LookupEnv("SERIAL_PORT")
+	if !found {
+		return nil, fmt.Errorf("set MODBUS_ADDRESS or SERIAL_PORT")
+	}
+	ret.SerialPort = serialPort
+	ret.Baud = 9600
+	if baud, found := os.LookupEnv("SERIAL_BAUD"); found {
+		var err error
+		if ret.Baud, err = strconv.Atoi(baud); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// runDevice runs the petri device the config binds to the net until ctx is
+// cancelled.
+func runDevice(ctx context.Context, environ *Environment, client *modbus.Client, logger *zap.Logger) error {
+	config, err := device.LoadFile(environ.DeviceConfig)
+	if err != nil {
+		return err
+	}
+	net, err := device.LoadNetFile(environ.Net)
+	if err != nil {
+		return err
+	}
+	dev, err := device.New(client, net, config, logger)
+	if err != nil {
+		return err
+	}
+	link, err := amqp.Connect(environ.URI, logger)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = link.Close()
+	}()
+	t, err := amqp.NewTransport(ctx, link, amqp.DefaultTopology(environ.Exchange))
+	if err != nil {
+		return err
+	}
+	srv, err := amqpServer.New(net, t, environ.DeviceID, environ.InstanceID, dev.EventMap(), dev.Handlers(), logger)
+	if err != nil {
+		return err
+	}
+	go dev.Run(ctx, srv.Raise)
+	logger.Info("Started device", zap.String("device", environ.DeviceID), zap.String("instance", environ.InstanceID))
+	return srv.Listen(ctx)
+}
+
+func main() {
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		panic(err)
+	}
+	// the environment may also be set without a .env file
+	_ = godotenv.Load()
+	environ, err := load()
+	if err != nil {
+		logger.Fatal("Failed to load environment", zap.Error(err))
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var transport modbus.Transport
+	if environ.Address != "" {
+		transport, err = modbus.DialTCP(ctx, environ.Address)
+	} else {
+		// a SERIAL_PORT of sim runs against a simulated slave instead of hardware
+		environ.SerialPort, err = serial.SimulateSpec(ctx, environ.SerialPort, sim.New(1024))
+		if err != nil {
+			logger.Fatal("Failed to start simulator", zap.Error(err))
+		}
+		transport, err = modbus.OpenRTU(environ.SerialPort, environ.Baud)
+	}
+	if err != nil {
+		logger.Fatal("Failed to connect to device", zap.Error(err))
+	}
+	client := modbus.NewClient(transport)
+	s := server.New(client, logger)
+	defer func() {
+		if err := s.Close(); err != nil {
+			logger.Error("Failed to close device", zap.Error(err))
+		}
+	}()
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt)
+	go func() {
+		<-c
+		cancel()
+	}()
+	if environ.DeviceConfig != "" {
+		go func() {
+			defer cancel()
+			if err := runDevice(ctx, environ, client, logger); err != nil {
+				logger.Error("Device stopped", zap.Error(err))
+			}
+		}()
+	}
+	if environ.Port == 0 {
+		<-ctx.Done()
+		return
+	}
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", environ.Port))
+	if err != nil {
+		logger.Fatal("Failed to listen", zap.Error(err))
+	}
+	grpcServer := grpc.NewServer()
+	proto.RegisterModbusServer(grpcServer, s)
+	logger.Info("Starting grpc server", zap.Int("port", environ.Port))
+	go func() {
+		if err := grpcServer.Serve(lis); err != nil {
+			logger.Fatal("Failed to serve grpc", zap.Error(err))
+		}
+	}()
+	<-ctx.Done()
+	grpcServer.GracefulStop()
+}
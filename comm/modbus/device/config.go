@@ -0,0 +1,116 @@
+package device
+
+import (
+	"encoding/json"
+	"fmt"
+	proto "github.com/jt05610/petri/proto/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"io"
+	"os"
+	"time"
+)
+
+// DefaultPoll is how often inputs are read when the config does not say.
+const DefaultPoll = 250 * time.Millisecond
+
+// Config binds a register map to a net. A config file looks like
+//
+//	{
+//	  "unit": 1,
+//	  "poll": "100ms",
+//	  "register_map": {"coils": [{"name": "valve", "address": 0}], ...},
+//	  "commands": [{"event": "open", "writes": [{"register": "valve", "value": 1}]}],
+//	  "triggers": [{"event": "filled", "register": "level", "op": ">=", "value": 800}]
+//	}
+type Config struct {
+	// Unit is the device's Modbus address.
+	Unit byte
+	// Poll is how often the registers the triggers watch are read.
+	Poll        time.Duration
+	RegisterMap *proto.RegisterMap
+	Commands    []*Command
+	Triggers    []*Trigger
+}
+
+// Command turns a command for an event into writes, made in order.
+type Command struct {
+	Event string `json:"event"`
+	// Transition fires when the writes succeed. It defaults to the transition
+	// named after the event.
+	Transition string   `json:"transition,omitempty"`
+	Writes     []*Write `json:"writes"`
+}
+
+// Write sets a coil or holding register to a fixed value, or to a field of the
+// command's data multiplied by Scale.
+type Write struct {
+	Register string   `json:"register"`
+	Value    *float64 `json:"value,omitempty"`
+	Field    string   `json:"field,omitempty"`
+	// Scale converts the field to the register's units, such as 10 for a
+	// temperature kept in tenths of a degree. Zero means 1.
+	Scale float64 `json:"scale,omitempty"`
+}
+
+// Trigger raises an event when the condition Register Op Value becomes true.
+// Coils and discrete inputs read as 0 or 1.
+type Trigger struct {
+	Event string `json:"event"`
+	// ID is the event's ID in the manifest.
+	ID string `json:"id,omitempty"`
+	// Transition fires when the event is raised. It defaults to the
+	// transition named after the event.
+	Transition string  `json:"transition,omitempty"`
+	Register   string  `json:"register"`
+	Op         Op      `json:"op,omitempty"`
+	Value      float64 `json:"value"`
+}
+
+// file is a Config as it is written down.
+type file struct {
+	Unit        byte            `json:"unit"`
+	Poll        string          `json:"poll,omitempty"`
+	RegisterMap json.RawMessage `json:"register_map"`
+	Commands    []*Command      `json:"commands"`
+	Triggers    []*Trigger      `json:"triggers"`
+}
+
+// Load reads a config. The register map is written as the RegisterMap message
+// encodes to JSON.
+func Load(r io.Reader) (*Config, error) {
+	var f file
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return nil, fmt.Errorf("decode device config: %w", err)
+	}
+	c := &Config{
+		Unit:        f.Unit,
+		Poll:        DefaultPoll,
+		RegisterMap: new(proto.RegisterMap),
+		Commands:    f.Commands,
+		Triggers:    f.Triggers,
+	}
+	if f.Poll != "" {
+		poll, err := time.ParseDuration(f.Poll)
+		if err != nil {
+			return nil, fmt.Errorf("poll: %w", err)
+		}
+		c.Poll = poll
+	}
+	if len(f.RegisterMap) > 0 {
+		if err := protojson.Unmarshal(f.RegisterMap, c.RegisterMap); err != nil {
+			return nil, fmt.Errorf("register_map: %w", err)
+		}
+	}
+	return c, nil
+}
+
+func LoadFile(path string) (*Config, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	return Load(f)
+}
@@ -0,0 +1,435 @@
+// Package device runs a Modbus device, such as a PLC, as a net from a Config
+// instead of code. Commands become coil and register writes, and inputs are
+// polled so that events are raised when conditions on them become true.
+package device
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jt05610/petri"
+	"github.com/jt05610/petri/comm/modbus"
+	"github.com/jt05610/petri/control"
+	"github.com/jt05610/petri/labeled"
+	"go.uber.org/zap"
+	"math"
+	"time"
+)
+
+var ErrRange = errors.New("value does not fit in the register")
+
+// Op compares a register's value with a trigger's.
+type Op string
+
+const (
+	Equal          Op = "=="
+	NotEqual       Op = "!="
+	Less           Op = "<"
+	LessOrEqual    Op = "<="
+	Greater        Op = ">"
+	GreaterOrEqual Op = ">="
+)
+
+func (o Op) valid() bool {
+	switch o {
+	case Equal, NotEqual, Less, LessOrEqual, Greater, GreaterOrEqual:
+		return true
+	}
+	return false
+}
+
+func (o Op) holds(a, b float64) bool {
+	switch o {
+	case NotEqual:
+		return a != b
+	case Less:
+		return a < b
+	case LessOrEqual:
+		return a <= b
+	case Greater:
+		return a > b
+	case GreaterOrEqual:
+		return a >= b
+	}
+	return a == b
+}
+
+type kind int
+
+const (
+	coil kind = iota
+	discreteInput
+	holdingRegister
+	inputRegister
+)
+
+func (k kind) String() string {
+	return [...]string{"coil", "discrete input", "holding register", "input register"}[k]
+}
+
+// register is an entry of the register map. Registers of size 2 hold a 32-bit
+// value, high word first.
+type register struct {
+	name    string
+	kind    kind
+	address uint16
+	size    uint16
+}
+
+func (r *register) bit() bool {
+	return r.kind == coil || r.kind == discreteInput
+}
+
+func (r *register) max() float64 {
+	if r.size == 2 {
+		return math.MaxUint32
+	}
+	return math.MaxUint16
+}
+
+type write struct {
+	*Write
+	register *register
+}
+
+type command struct {
+	*Command
+	transition *petri.Transition
+	writes     []*write
+}
+
+type trigger struct {
+	*Trigger
+	register *register
+	// armed is set while the condition is false, so the event is raised when
+	// it becomes true rather than on every poll that finds it true. It stays
+	// set until the event is raised or dropped.
+	armed bool
+}
+
+// Raiser publishes an event the device raised, such as (*server.Server).Raise.
+type Raiser func(ctx context.Context, event *labeled.Event) error
+
+type Device struct {
+	client    *modbus.Client
+	unit      byte
+	poll      time.Duration
+	logger    *zap.Logger
+	registers map[string]*register
+	commands  []*command
+	triggers  []*trigger
+}
+
+func (d *Device) add(name string, k kind, address, size uint32) error {
+	if _, found := d.registers[name]; found {
+		return fmt.Errorf("register %q is mapped twice", name)
+	}
+	if size == 0 {
+		size = 1
+	}
+	if size > 2 {
+		return fmt.Errorf("register %q: size %d is not 1 or 2", name, size)
+	}
+	if address+size > math.MaxUint16+1 {
+		return fmt.Errorf("register %q: address %d is beyond the last address", name, address)
+	}
+	d.registers[name] = &register{name: name, kind: k, address: uint16(address), size: uint16(size)}
+	return nil
+}
+
+func (d *Device) register(name string) (*register, error) {
+	r, found := d.registers[name]
+	if !found {
+		return nil, fmt.Errorf("register %q is not in the register map", name)
+	}
+	return r, nil
+}
+
+func transition(net *labeled.Net, name string) (*petri.Transition, error) {
+	for _, t := range net.Transitions {
+		if t.Name == name {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("net has no transition %q", name)
+}
+
+// New binds the config to the net. The triggers' events are added to the net;
+// the commands are added when a server is made with Handlers and EventMap.
+func New(client *modbus.Client, net *labeled.Net, config *Config, logger *zap.Logger) (*Device, error) {
+	d := &Device{
+		client:    client,
+		unit:      config.Unit,
+		poll:      config.Poll,
+		logger:    logger,
+		registers: make(map[string]*register),
+	}
+	if d.poll <= 0 {
+		d.poll = DefaultPoll
+	}
+	m := config.RegisterMap
+	var errs []error
+	for _, r := range m.GetCoils() {
+		errs = append(errs, d.add(r.Name, coil, r.Address, 1))
+	}
+	for _, r := range m.GetDiscreteInputs() {
+		errs = append(errs, d.add(r.Name, discreteInput, r.Address, 1))
+	}
+	for _, r := range m.GetHoldingRegisters() {
+		errs = append(errs, d.add(r.Name, holdingRegister, r.Address, r.Size))
+	}
+	for _, r := range m.GetInputRegisters() {
+		errs = append(errs, d.add(r.Name, inputRegister, r.Address, r.Size))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	commands := make(map[string]bool)
+	for _, c := range config.Commands {
+		cmd, err := d.command(net, c)
+		if err != nil {
+			return nil, fmt.Errorf("command %q: %w", c.Event, err)
+		}
+		commands[c.Event] = true
+		d.commands = append(d.commands, cmd)
+	}
+	// several triggers may raise the same event, but it always fires the same
+	// transition
+	raised := make(map[string]*petri.Transition)
+	for _, t := range config.Triggers {
+		if commands[t.Event] {
+			return nil, fmt.Errorf("trigger %q: the event is also a command", t.Event)
+		}
+		trig, tr, err := d.trigger(net, t)
+		if err != nil {
+			return nil, fmt.Errorf("trigger %q: %w", t.Event, err)
+		}
+		if prev, found := raised[t.Event]; !found {
+			net.AddEvent(&labeled.Event{ID: t.ID, Name: t.Event}, tr)
+			raised[t.Event] = tr
+		} else if prev != tr {
+			return nil, fmt.Errorf("trigger %q: the event already fires %q, not %q", t.Event, prev.Name, tr.Name)
+		}
+		d.triggers = append(d.triggers, trig)
+	}
+	return d, nil
+}
+
+func (d *Device) command(net *labeled.Net, c *Command) (*command, error) {
+	name := c.Transition
+	if name == "" {
+		name = c.Event
+	}
+	t, err := transition(net, name)
+	if err != nil {
+		return nil, err
+	}
+	ret := &command{Command: c, transition: t}
+	for _, w := range c.Writes {
+		r, err := d.register(w.Register)
+		if err != nil {
+			return nil, err
+		}
+		if r.kind != coil && r.kind != holdingRegister {
+			return nil, fmt.Errorf("register %q is a read-only %s", r.name, r.kind)
+		}
+		if (w.Value == nil) == (w.Field == "") {
+			return nil, fmt.Errorf("write to %q needs one of a value or a field", r.name)
+		}
+		ret.writes = append(ret.writes, &write{Write: w, register: r})
+	}
+	return ret, nil
+}
+
+func (d *Device) trigger(net *labeled.Net, t *Trigger) (*trigger, *petri.Transition, error) {
+	if t.Op == "" {
+		t.Op = Equal
+	}
+	if !t.Op.valid() {
+		return nil, nil, fmt.Errorf("unknown op %q", t.Op)
+	}
+	r, err := d.register(t.Register)
+	if err != nil {
+		return nil, nil, err
+	}
+	name := t.Transition
+	if name == "" {
+		name = t.Event
+	}
+	tr, err := transition(net, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &trigger{Trigger: t, register: r}, tr, nil
+}
+
+// Handlers makes the writes for each command.
+func (d *Device) Handlers() control.Handlers {
+	ret := make(control.Handlers, len(d.commands))
+	for _, c := range d.commands {
+		c := c
+		ret[c.Event] = func(ctx context.Context, ev *labeled.Event) (*labeled.Event, error) {
+			for _, w := range c.writes {
+				if err := d.write(ctx, w, ev.Data); err != nil {
+					return nil, fmt.Errorf("write %s: %w", w.Register, err)
+				}
+			}
+			return &labeled.Event{Name: ev.Name, Data: ev.Data}, nil
+		}
+	}
+	return ret
+}
+
+// EventMap maps each command to the transition it fires.
+func (d *Device) EventMap() map[string]*petri.Transition {
+	ret := make(map[string]*petri.Transition, len(d.commands))
+	for _, c := range d.commands {
+		ret[c.Event] = c.transition
+	}
+	return ret
+}
+
+func (w *write) value(data map[string]interface{}) (float64, error) {
+	if w.Value != nil {
+		return *w.Value, nil
+	}
+	scale := w.Scale
+	if scale == 0 {
+		scale = 1
+	}
+	switch v := data[w.Field].(type) {
+	case float64:
+		return v * scale, nil
+	case int:
+		return float64(v) * scale, nil
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	case nil:
+		return 0, fmt.Errorf("missing field %q", w.Field)
+	default:
+		return 0, fmt.Errorf("field %q is a %T, not a number", w.Field, v)
+	}
+}
+
+func (d *Device) write(ctx context.Context, w *write, data map[string]interface{}) error {
+	v, err := w.value(data)
+	if err != nil {
+		return err
+	}
+	r := w.register
+	if r.kind == coil {
+		return d.client.WriteSingleCoil(ctx, d.unit, r.address, v != 0)
+	}
+	raw := math.Round(v)
+	if raw < 0 || raw > r.max() {
+		return fmt.Errorf("%w: %g in %s", ErrRange, raw, r.name)
+	}
+	if r.size == 1 {
+		return d.client.WriteSingleRegister(ctx, d.unit, r.address, uint16(raw))
+	}
+	return d.client.WriteMultipleRegisters(ctx, d.unit, r.address, []uint16{uint16(uint32(raw) >> 16), uint16(raw)})
+}
+
+func (d *Device) read(ctx context.Context, r *register) (float64, error) {
+	var bits []bool
+	var regs []uint16
+	var err error
+	switch r.kind {
+	case coil:
+		bits, err = d.client.ReadCoils(ctx, d.unit, r.address, 1)
+	case discreteInput:
+		bits, err = d.client.ReadDiscreteInputs(ctx, d.unit, r.address, 1)
+	case holdingRegister:
+		regs, err = d.client.ReadHoldingRegisters(ctx, d.unit, r.address, r.size)
+	case inputRegister:
+		regs, err = d.client.ReadInputRegisters(ctx, d.unit, r.address, r.size)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if r.bit() {
+		if bits[0] {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	if r.size == 2 {
+		return float64(uint32(regs[0])<<16 | uint32(regs[1])), nil
+	}
+	return float64(regs[0]), nil
+}
+
+// Read reads a register by its name in the register map.
+func (d *Device) Read(ctx context.Context, name string) (float64, error) {
+	r, err := d.register(name)
+	if err != nil {
+		return 0, err
+	}
+	return d.read(ctx, r)
+}
+
+// Poll reads the registers the triggers watch once, and raises the events
+// whose conditions have become true since the last poll. A condition that is
+// already true at the first poll does not raise its event. Events the net
+// does not allow in its current marking are dropped; events that fail to be
+// raised for any other reason are tried again at the next poll.
+func (d *Device) Poll(ctx context.Context, raise Raiser) error {
+	values := make(map[*register]float64)
+	var errs []error
+	for _, t := range d.triggers {
+		v, found := values[t.register]
+		if !found {
+			var err error
+			if v, err = d.read(ctx, t.register); err != nil {
+				errs = append(errs, fmt.Errorf("read %s: %w", t.Register, err))
+				continue
+			}
+			values[t.register] = v
+		}
+		holds := t.Op.holds(v, t.Value)
+		if !holds {
+			t.armed = true
+		}
+		if !holds || !t.armed {
+			continue
+		}
+		var value interface{} = v
+		if t.register.bit() {
+			value = v != 0
+		}
+		ev := &labeled.Event{Name: t.Event, Data: map[string]interface{}{t.Register: value}}
+		err := raise(ctx, ev)
+		if errors.Is(err, labeled.ErrNotEnabled) {
+			d.logger.Info("Dropped event the net does not allow", zap.String("event", t.Event))
+			t.armed = false
+			continue
+		}
+		if err != nil {
+			// stay armed so the event is raised again at the next poll
+			errs = append(errs, fmt.Errorf("raise %s: %w", t.Event, err))
+			continue
+		}
+		t.armed = false
+	}
+	return errors.Join(errs...)
+}
+
+// Run polls until ctx is cancelled. Failed polls are logged and tried again
+// at the next interval.
+func (d *Device) Run(ctx context.Context, raise Raiser) {
+	ticker := time.NewTicker(d.poll)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := d.Poll(ctx, raise); err != nil && ctx.Err() == nil {
+				d.logger.Warn("Failed to poll device", zap.Error(err))
+			}
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package device_test
+
+import (
+	"context"
+	"github.com/jt05610/petri"
+	"github.com/jt05610/petri/amqp/client"
+	"github.com/jt05610/petri/amqp/server"
+	"github.com/jt05610/petri/comm/modbus"
+	"github.com/jt05610/petri/comm/modbus/device"
+	"github.com/jt05610/petri/comm/modbus/sim"
+	"github.com/jt05610/petri/control"
+	"github.com/jt05610/petri/labeled"
+	"github.com/jt05610/petri/marked"
+	"github.com/jt05610/petri/transport"
+	"go.uber.org/zap"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+const tankConfig = `{
+  "unit": 1,
+  "poll": "10ms",
+  "register_map": {
+    "coils": [{"name": "inlet", "address": 0}],
+    "holding_registers": [{"name": "setpoint", "address": 2, "size": 2}],
+    "input_registers": [{"name": "level", "address": 5}]
+  },
+  "commands": [{
+    "event": "fill",
+    "writes": [
+      {"register": "inlet", "value": 1},
+      {"register": "setpoint", "field": "setpoint", "scale": 10}
+    ]
+  }],
+  "triggers": [{"event": "filled", "register": "level", "op": ">=", "value": 800}]
+}`
+
+// tank is filled on command and reports when its level is reached.
+func tank() (*labeled.Net, *petri.Place) {
+	empty, filling, full := petri.NewPlace("empty", 1), petri.NewPlace("filling", 1), petri.NewPlace("full", 1)
+	fill, filled := petri.NewTransition("fill"), petri.NewTransition("filled")
+	n := petri.NewNet("tank").
+		WithPlaces(empty, filling, full).
+		WithTransitions(fill, filled).
+		WithArcs(
+			petri.NewArc(empty, fill, "", nil),
+			petri.NewArc(fill, filling, "", nil),
+			petri.NewArc(filling, filled, "", nil),
+			petri.NewArc(filled, full, "", nil),
+		)
+	return labeled.New(marked.New(n, marked.Marking{1, 0, 0})), full
+}
+
+func slave(t *testing.T, ctx context.Context) (*sim.Slave, *modbus.Client) {
+	t.Helper()
+	dev := sim.New(32)
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		_ = dev.ServeTCP(ctx, l)
+	}()
+	tcp, err := modbus.DialTCP(ctx, l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := modbus.NewClient(tcp)
+	t.Cleanup(func() {
+		_ = c.Close()
+	})
+	return dev, c
+}
+
+func TestDevice(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	plc, c := slave(t, ctx)
+	config, err := device.Load(strings.NewReader(tankConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln, full := tank()
+	dev, err := device.New(c, ln, config, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	broker := transport.NewBroker()
+	defer func() {
+		_ = broker.Close()
+	}()
+	srv, err := server.New(ln, broker, "tank-device", "tank-1", dev.EventMap(), dev.Handlers(), zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error, 1)
+	listenCtx, stop := context.WithCancel(ctx)
+	go func() {
+		done <- srv.Listen(listenCtx)
+	}()
+	defer func() {
+		stop()
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+	}()
+	events, err := broker.Subscribe(ctx, &transport.Queue{Keys: []string{"tank-1.events.filled"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the tank is not filling, so reaching the level is not an event yet
+	for _, level := range []uint16{0, 900, 0} {
+		plc.SetInputRegister(5, level)
+		if err := dev.Poll(ctx, srv.Raise); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctl := client.NewController(zap.NewNop(), broker)
+	defer ctl.Close()
+	if err := ctl.Listen(ctx); err != nil {
+		t.Fatal(err)
+	}
+	_, err = ctl.Call(ctx, &control.Command{
+		Event: &labeled.Event{Name: "fill", Data: map[string]interface{}{"setpoint": 61.5}},
+		To:    "tank-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plc.Coil(0) || plc.HoldingRegister(2) != 0 || plc.HoldingRegister(3) != 615 {
+		t.Fatalf("expected the inlet open with a setpoint of 615, got %v %d %d", plc.Coil(0), plc.HoldingRegister(2), plc.HoldingRegister(3))
+	}
+
+	plc.SetInputRegister(5, 850)
+	if err := dev.Poll(ctx, srv.Raise); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case m := <-events.Messages():
+		ev, err := (&transport.EventService{}).Load(ctx, m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ev.Data["level"] != float64(850) || ev.Marking[full.ID] != 1 {
+			t.Fatalf("expected the tank to be full at 850, got %v %v", ev.Data, ev.Marking)
+		}
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for the filled event")
+	}
+	// the level staying up is not raised again
+	if err := dev.Poll(ctx, srv.Raise); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case m := <-events.Messages():
+		t.Fatalf("expected one filled event, got another on %s", m.Key)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestDevice_Retry(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	plc, c := slave(t, ctx)
+	config, err := device.Load(strings.NewReader(tankConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln, _ := tank()
+	dev, err := device.New(c, ln, config, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var raised []error
+	raise := func(context.Context, *labeled.Event) error {
+		// the first attempt is refused, as while an operator is awaited
+		err := error(&control.Error{Kind: control.Unconfirmed})
+		if len(raised) > 0 {
+			err = nil
+		}
+		raised = append(raised, err)
+		return err
+	}
+	if err := dev.Poll(ctx, raise); err != nil {
+		t.Fatal(err)
+	}
+	plc.SetInputRegister(5, 850)
+	if err := dev.Poll(ctx, raise); err == nil {
+		t.Fatal("expected the refused event to be reported")
+	}
+	for i := 0; i < 2; i++ {
+		if err := dev.Poll(ctx, raise); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(raised) != 2 || raised[1] != nil {
+		t.Fatalf("expected the event to be raised again once, got %v", raised)
+	}
+}
+
+func TestNew(t *testing.T) {
+	for name, config := range map[string]string{
+		"unknown register": `{"commands": [{"event": "fill", "writes": [{"register": "drain", "value": 1}]}]}`,
+		"read-only":        `{"register_map": {"input_registers": [{"name": "level", "address": 5}]}, "commands": [{"event": "fill", "writes": [{"register": "level", "value": 1}]}]}`,
+		"no value":         `{"register_map": {"coils": [{"name": "inlet"}]}, "commands": [{"event": "fill", "writes": [{"register": "inlet"}]}]}`,
+		"unknown op":       `{"register_map": {"coils": [{"name": "inlet"}]}, "triggers": [{"event": "filled", "register": "inlet", "op": "=>"}]}`,
+		"no transition":    `{"register_map": {"coils": [{"name": "inlet"}]}, "triggers": [{"event": "drained", "register": "inlet"}]}`,
+		"too large":        `{"register_map": {"holding_registers": [{"name": "setpoint", "size": 4}]}}`,
+		"two transitions":  `{"register_map": {"coils": [{"name": "inlet"}]}, "triggers": [{"event": "filled", "register": "inlet"}, {"event": "filled", "transition": "fill", "register": "inlet"}]}`,
+	} {
+		c, err := device.Load(strings.NewReader(config))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		ln, _ := tank()
+		if _, err := device.New(nil, ln, c, zap.NewNop()); err == nil {
+			t.Errorf("%s: expected the config to be rejected", name)
+		}
+	}
+}
+
+const tankNet = `{
+  "name": "tank",
+  "places": [{"name": "empty", "tokens": 1}, {"name": "filling"}, {"name": "full", "id": "full-id"}],
+  "transitions": [{"name": "fill"}, {"name": "filled"}],
+  "arcs": [
+    {"from": "empty", "to": "fill"},
+    {"from": "fill", "to": "filling"},
+    {"from": "filling", "to": "filled"},
+    {"from": "filled", "to": "full"}
+  ]
+}`
+
+func TestLoadNet(t *testing.T) {
+	ln, err := device.LoadNet(strings.NewReader(tankNet))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := ln.MarkingMap(); m["empty"] != 1 || m["filling"] != 0 || m["full-id"] != 0 {
+		t.Fatalf("expected the tank to start empty, got %v", m)
+	}
+	config, err := device.Load(strings.NewReader(tankConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dev, err := device.New(nil, ln, config, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr := dev.EventMap()["fill"]; tr == nil || tr.ID != "fill" {
+		t.Fatalf("expected fill to fire the fill transition, got %v", tr)
+	}
+	for name, net := range map[string]string{
+		"unknown node":   `{"places": [{"name": "empty"}], "arcs": [{"from": "empty", "to": "fill"}]}`,
+		"place to place": `{"places": [{"name": "empty"}, {"name": "full"}], "arcs": [{"from": "empty", "to": "full"}]}`,
+		"reused name":    `{"places": [{"name": "fill"}], "transitions": [{"name": "fill"}]}`,
+	} {
+		if _, err := device.LoadNet(strings.NewReader(net)); err == nil {
+			t.Errorf("%s: expected the net to be rejected", name)
+		}
+	}
+}
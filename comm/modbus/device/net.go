@@ -0,0 +1,127 @@
+package device
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/jt05610/petri"
+	"github.com/jt05610/petri/labeled"
+	"github.com/jt05610/petri/marked"
+	"io"
+	"os"
+)
+
+// Net describes the device's net. A net file looks like
+//
+//	{
+//	  "name": "tank",
+//	  "places": [{"name": "empty", "tokens": 1}, {"name": "full"}],
+//	  "transitions": [{"name": "fill"}],
+//	  "arcs": [{"from": "empty", "to": "fill"}, {"from": "fill", "to": "full"}]
+//	}
+//
+// Arcs join places and transitions by name. IDs default to the names, so a
+// snapshot of the marking still applies after the device restarts.
+type Net struct {
+	ID          string      `json:"id,omitempty"`
+	Name        string      `json:"name"`
+	Places      []*NetPlace `json:"places"`
+	Transitions []*NetNode  `json:"transitions"`
+	Arcs        []*NetArc   `json:"arcs"`
+}
+
+// NetNode is a place or transition of a Net.
+type NetNode struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+// NetPlace is a place holding Tokens when the device starts. A Bound of zero
+// means 1.
+type NetPlace struct {
+	NetNode
+	Bound  int `json:"bound,omitempty"`
+	Tokens int `json:"tokens,omitempty"`
+}
+
+// NetArc joins a place and a transition by name.
+type NetArc struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+func id(n *NetNode) string {
+	if n.ID != "" {
+		return n.ID
+	}
+	return n.Name
+}
+
+// Build makes the labeled net the config describes.
+func (n *Net) Build() (*labeled.Net, error) {
+	nodes := make(map[string]petri.Node)
+	places := make([]*petri.Place, len(n.Places))
+	marking := make(marked.Marking, len(n.Places))
+	for i, p := range n.Places {
+		if _, found := nodes[p.Name]; found {
+			return nil, fmt.Errorf("place %q: the name is already used", p.Name)
+		}
+		bound := p.Bound
+		if bound == 0 {
+			bound = 1
+		}
+		places[i] = petri.NewPlace(p.Name, bound)
+		places[i].ID = id(&p.NetNode)
+		marking[i] = p.Tokens
+		nodes[p.Name] = places[i]
+	}
+	transitions := make([]*petri.Transition, len(n.Transitions))
+	for i, t := range n.Transitions {
+		if _, found := nodes[t.Name]; found {
+			return nil, fmt.Errorf("transition %q: the name is already used", t.Name)
+		}
+		transitions[i] = petri.NewTransition(t.Name)
+		transitions[i].ID = id(t)
+		nodes[t.Name] = transitions[i]
+	}
+	arcs := make([]*petri.Arc, len(n.Arcs))
+	for i, a := range n.Arcs {
+		from, found := nodes[a.From]
+		if !found {
+			return nil, fmt.Errorf("arc %d: no place or transition %q", i, a.From)
+		}
+		to, found := nodes[a.To]
+		if !found {
+			return nil, fmt.Errorf("arc %d: no place or transition %q", i, a.To)
+		}
+		if from.Kind() == to.Kind() {
+			return nil, fmt.Errorf("arc %d: %q and %q are both %ss", i, a.From, a.To, from.Kind())
+		}
+		arcs[i] = petri.NewArc(from, to, "", nil)
+	}
+	net := petri.NewNet(n.Name).WithPlaces(places...).WithTransitions(transitions...).WithArcs(arcs...)
+	if n.ID != "" {
+		net.ID = n.ID
+	}
+	return labeled.New(marked.New(net, marking)), nil
+}
+
+// LoadNet reads a net file.
+func LoadNet(r io.Reader) (*labeled.Net, error) {
+	var n Net
+	if err := json.NewDecoder(r).Decode(&n); err != nil {
+		return nil, fmt.Errorf("decode net: %w", err)
+	}
+	return n.Build()
+}
+
+// LoadNetFile reads the net file at path.
+func LoadNetFile(path string) (*labeled.Net, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	return LoadNet(f)
+}
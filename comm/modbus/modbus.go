@@ -0,0 +1,278 @@
+// Package modbus is a Modbus client for PLCs and other devices, over TCP or
+// over a serial line with RTU framing.
+package modbus
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+type FunctionCode byte
+
+const (
+	ReadCoils              FunctionCode = 1
+	ReadDiscreteInputs     FunctionCode = 2
+	ReadHoldingRegisters   FunctionCode = 3
+	ReadInputRegisters     FunctionCode = 4
+	WriteSingleCoil        FunctionCode = 5
+	WriteSingleRegister    FunctionCode = 6
+	WriteMultipleCoils     FunctionCode = 15
+	WriteMultipleRegisters FunctionCode = 16
+)
+
+// exceptionFlag is set in the function code of a response carrying an
+// exception.
+const exceptionFlag = 0x80
+
+// The most that one request can read or write.
+const (
+	MaxReadBits       = 2000
+	MaxReadRegisters  = 125
+	MaxWriteBits      = 1968
+	MaxWriteRegisters = 123
+)
+
+// coilOn is how a coil that is set is written.
+const coilOn = 0xFF00
+
+// Exception is the error a device answers with when it cannot carry out a
+// request.
+type Exception byte
+
+const (
+	IllegalFunction        Exception = 1
+	IllegalDataAddress     Exception = 2
+	IllegalDataValue       Exception = 3
+	ServerDeviceFailure    Exception = 4
+	Acknowledge            Exception = 5
+	ServerDeviceBusy       Exception = 6
+	GatewayPathUnavailable Exception = 10
+	GatewayTargetFailed    Exception = 11
+)
+
+var exceptions = map[Exception]string{
+	IllegalFunction:        "illegal function",
+	IllegalDataAddress:     "illegal data address",
+	IllegalDataValue:       "illegal data value",
+	ServerDeviceFailure:    "server device failure",
+	Acknowledge:            "acknowledge",
+	ServerDeviceBusy:       "server device busy",
+	GatewayPathUnavailable: "gateway path unavailable",
+	GatewayTargetFailed:    "gateway target device failed to respond",
+}
+
+func (e Exception) Error() string {
+	if msg, found := exceptions[e]; found {
+		return "modbus: " + msg
+	}
+	return fmt.Sprintf("modbus: exception %d", byte(e))
+}
+
+var (
+	ErrTimeout  = errors.New("modbus: no response")
+	ErrResponse = errors.New("modbus: malformed response")
+)
+
+// PDU is a protocol data unit: a function code and its data, which are
+// framed differently by TCP and RTU.
+type PDU struct {
+	Function FunctionCode
+	Data     []byte
+}
+
+// Exception returns the exception the PDU carries, if it is an exception
+// response.
+func (p PDU) Exception() (Exception, bool) {
+	if p.Function&exceptionFlag == 0 {
+		return 0, false
+	}
+	if len(p.Data) != 1 {
+		return ServerDeviceFailure, true
+	}
+	return Exception(p.Data[0]), true
+}
+
+// ExceptionPDU answers a request with an exception.
+func ExceptionPDU(fc FunctionCode, e Exception) PDU {
+	return PDU{Function: fc | exceptionFlag, Data: []byte{byte(e)}}
+}
+
+// Transport frames requests for a unit and returns its responses.
+type Transport interface {
+	Send(ctx context.Context, unit byte, req PDU) (PDU, error)
+	Close() error
+}
+
+// PackBits packs bits into bytes, least significant bit first.
+func PackBits(bits []bool) []byte {
+	ret := make([]byte, (len(bits)+7)/8)
+	for i, b := range bits {
+		if b {
+			ret[i/8] |= 1 << (i % 8)
+		}
+	}
+	return ret
+}
+
+// UnpackBits returns the first n bits packed in b.
+func UnpackBits(b []byte, n int) []bool {
+	ret := make([]bool, n)
+	for i := range ret {
+		ret[i] = b[i/8]&(1<<(i%8)) != 0
+	}
+	return ret
+}
+
+// PackRegisters encodes registers big-endian, as Modbus sends them.
+func PackRegisters(regs []uint16) []byte {
+	ret := make([]byte, 2*len(regs))
+	for i, r := range regs {
+		binary.BigEndian.PutUint16(ret[2*i:], r)
+	}
+	return ret
+}
+
+// UnpackRegisters decodes big-endian registers.
+func UnpackRegisters(b []byte) []uint16 {
+	ret := make([]uint16, len(b)/2)
+	for i := range ret {
+		ret[i] = binary.BigEndian.Uint16(b[2*i:])
+	}
+	return ret
+}
+
+// words encodes each value as a big-endian 16-bit word.
+func words(vals ...uint16) []byte {
+	return PackRegisters(vals)
+}
+
+// Client reads and writes a device's data through a transport.
+type Client struct {
+	t Transport
+}
+
+func NewClient(t Transport) *Client {
+	return &Client{t: t}
+}
+
+func (c *Client) Close() error {
+	return c.t.Close()
+}
+
+// Send sends a request and returns the response, or the exception the
+// device answered with.
+func (c *Client) Send(ctx context.Context, unit byte, req PDU) (PDU, error) {
+	resp, err := c.t.Send(ctx, unit, req)
+	if err != nil {
+		return PDU{}, err
+	}
+	if e, ok := resp.Exception(); ok {
+		return PDU{}, e
+	}
+	if resp.Function != req.Function {
+		return PDU{}, fmt.Errorf("%w: function %d in answer to %d", ErrResponse, resp.Function, req.Function)
+	}
+	return resp, nil
+}
+
+func checkQuantity(qty, max int) error {
+	if qty < 1 || qty > max {
+		return fmt.Errorf("%w: quantity %d is not between 1 and %d", IllegalDataValue, qty, max)
+	}
+	return nil
+}
+
+// read sends a read request and returns the data after the byte count.
+func (c *Client) read(ctx context.Context, unit byte, fc FunctionCode, addr, qty uint16, size int) ([]byte, error) {
+	resp, err := c.Send(ctx, unit, PDU{Function: fc, Data: words(addr, qty)})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Data) != 1+size || int(resp.Data[0]) != size {
+		return nil, fmt.Errorf("%w: expected %d bytes, got %d", ErrResponse, size, len(resp.Data)-1)
+	}
+	return resp.Data[1:], nil
+}
+
+func (c *Client) readBits(ctx context.Context, unit byte, fc FunctionCode, addr, qty uint16) ([]bool, error) {
+	if err := checkQuantity(int(qty), MaxReadBits); err != nil {
+		return nil, err
+	}
+	data, err := c.read(ctx, unit, fc, addr, qty, (int(qty)+7)/8)
+	if err != nil {
+		return nil, err
+	}
+	return UnpackBits(data, int(qty)), nil
+}
+
+func (c *Client) readRegisters(ctx context.Context, unit byte, fc FunctionCode, addr, qty uint16) ([]uint16, error) {
+	if err := checkQuantity(int(qty), MaxReadRegisters); err != nil {
+		return nil, err
+	}
+	data, err := c.read(ctx, unit, fc, addr, qty, 2*int(qty))
+	if err != nil {
+		return nil, err
+	}
+	return UnpackRegisters(data), nil
+}
+
+func (c *Client) ReadCoils(ctx context.Context, unit byte, addr, qty uint16) ([]bool, error) {
+	return c.readBits(ctx, unit, ReadCoils, addr, qty)
+}
+
+func (c *Client) ReadDiscreteInputs(ctx context.Context, unit byte, addr, qty uint16) ([]bool, error) {
+	return c.readBits(ctx, unit, ReadDiscreteInputs, addr, qty)
+}
+
+func (c *Client) ReadHoldingRegisters(ctx context.Context, unit byte, addr, qty uint16) ([]uint16, error) {
+	return c.readRegisters(ctx, unit, ReadHoldingRegisters, addr, qty)
+}
+
+func (c *Client) ReadInputRegisters(ctx context.Context, unit byte, addr, qty uint16) ([]uint16, error) {
+	return c.readRegisters(ctx, unit, ReadInputRegisters, addr, qty)
+}
+
+// write sends a write request, which devices answer by echoing its first
+// four bytes.
+func (c *Client) write(ctx context.Context, unit byte, req PDU) error {
+	resp, err := c.Send(ctx, unit, req)
+	if err != nil {
+		return err
+	}
+	if len(resp.Data) != 4 || string(resp.Data) != string(req.Data[:4]) {
+		return fmt.Errorf("%w: write not echoed", ErrResponse)
+	}
+	return nil
+}
+
+func (c *Client) WriteSingleCoil(ctx context.Context, unit byte, addr uint16, value bool) error {
+	var v uint16
+	if value {
+		v = coilOn
+	}
+	return c.write(ctx, unit, PDU{Function: WriteSingleCoil, Data: words(addr, v)})
+}
+
+func (c *Client) WriteSingleRegister(ctx context.Context, unit byte, addr, value uint16) error {
+	return c.write(ctx, unit, PDU{Function: WriteSingleRegister, Data: words(addr, value)})
+}
+
+func (c *Client) WriteMultipleCoils(ctx context.Context, unit byte, addr uint16, values []bool) error {
+	if err := checkQuantity(len(values), MaxWriteBits); err != nil {
+		return err
+	}
+	bits := PackBits(values)
+	data := append(words(addr, uint16(len(values))), byte(len(bits)))
+	return c.write(ctx, unit, PDU{Function: WriteMultipleCoils, Data: append(data, bits...)})
+}
+
+func (c *Client) WriteMultipleRegisters(ctx context.Context, unit byte, addr uint16, values []uint16) error {
+	if err := checkQuantity(len(values), MaxWriteRegisters); err != nil {
+		return err
+	}
+	regs := PackRegisters(values)
+	data := append(words(addr, uint16(len(values))), byte(len(regs)))
+	return c.write(ctx, unit, PDU{Function: WriteMultipleRegisters, Data: append(data, regs...)})
+}
@@ -0,0 +1,111 @@
+package modbus_test
+
+import (
+	"context"
+	"errors"
+	"github.com/jt05610/petri/comm/modbus"
+	"github.com/jt05610/petri/comm/modbus/sim"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCRC(t *testing.T) {
+	// read 10 holding registers from unit 1, CRC sent as C5 CD
+	if crc := modbus.CRC([]byte{0x01, 0x03, 0x00, 0x00, 0x00, 0x0A}); crc != 0xCDC5 {
+		t.Fatalf("expected 0xCDC5, got %#x", crc)
+	}
+}
+
+func TestPackBits(t *testing.T) {
+	bits := []bool{true, false, true, true, false, false, true, true, true, true}
+	packed := modbus.PackBits(bits)
+	if !reflect.DeepEqual(packed, []byte{0xCD, 0x03}) {
+		t.Fatalf("expected CD 03, got % X", packed)
+	}
+	if got := modbus.UnpackBits(packed, len(bits)); !reflect.DeepEqual(got, bits) {
+		t.Fatalf("expected %v, got %v", bits, got)
+	}
+}
+
+// exercise reads and writes every kind of data through a client.
+func exercise(t *testing.T, ctx context.Context, c *modbus.Client, dev *sim.Slave) {
+	t.Helper()
+	if err := c.WriteMultipleRegisters(ctx, 1, 10, []uint16{1, 2, 0xBEEF}); err != nil {
+		t.Fatal(err)
+	}
+	regs, err := c.ReadHoldingRegisters(ctx, 1, 10, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(regs, []uint16{1, 2, 0xBEEF}) {
+		t.Fatalf("expected 1 2 BEEF, got %v", regs)
+	}
+	coils := []bool{true, false, false, true, true, false, true, false, true}
+	if err := c.WriteMultipleCoils(ctx, 1, 3, coils); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.WriteSingleCoil(ctx, 1, 4, true); err != nil {
+		t.Fatal(err)
+	}
+	coils[1] = true
+	got, err := c.ReadCoils(ctx, 1, 3, uint16(len(coils)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, coils) {
+		t.Fatalf("expected %v, got %v", coils, got)
+	}
+	if err := c.WriteSingleRegister(ctx, 1, 0, 42); err != nil {
+		t.Fatal(err)
+	}
+	if dev.HoldingRegister(0) != 42 {
+		t.Fatalf("expected register 0 to be written")
+	}
+	dev.SetDiscreteInput(7, true)
+	dev.SetInputRegister(2, 300)
+	inputs, err := c.ReadDiscreteInputs(ctx, 1, 6, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(inputs, []bool{false, true}) {
+		t.Fatalf("expected input 7 to be set, got %v", inputs)
+	}
+	regs, err = c.ReadInputRegisters(ctx, 1, 2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if regs[0] != 300 {
+		t.Fatalf("expected 300, got %v", regs)
+	}
+	_, err = c.ReadHoldingRegisters(ctx, 1, 60, 10)
+	if !errors.Is(err, modbus.IllegalDataAddress) {
+		t.Fatalf("expected an illegal address, got %v", err)
+	}
+}
+
+func TestTCP(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	dev := sim.New(64)
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		_ = dev.ServeTCP(ctx, l)
+	}()
+	tcp, err := modbus.DialTCP(ctx, l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := modbus.NewClient(tcp)
+	defer func() {
+		_ = c.Close()
+	}()
+	exercise(t, ctx, c, dev)
+	if _, err := c.ReadCoils(ctx, 2, 0, 1); !errors.Is(err, modbus.GatewayTargetFailed) {
+		t.Fatalf("expected unit 2 to be missing, got %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+package modbus
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"github.com/jt05610/petri/comm/serial"
+	"io"
+	"sync"
+	"time"
+)
+
+// DefaultGap is the silence that separates RTU frames: 3.5 characters at
+// 9600 baud.
+const DefaultGap = 4 * time.Millisecond
+
+// CRC is the Modbus RTU checksum.
+func CRC(b []byte) uint16 {
+	crc := uint16(0xFFFF)
+	for _, v := range b {
+		crc ^= uint16(v)
+		for i := 0; i < 8; i++ {
+			if crc&1 != 0 {
+				crc = crc>>1 ^ 0xA001
+			} else {
+				crc >>= 1
+			}
+		}
+	}
+	return crc
+}
+
+// WriteRTU writes a frame to send over a serial line.
+func WriteRTU(w io.Writer, unit byte, p PDU) error {
+	frame := make([]byte, 0, 4+len(p.Data))
+	frame = append(frame, unit, byte(p.Function))
+	frame = append(frame, p.Data...)
+	frame = binary.LittleEndian.AppendUint16(frame, CRC(frame))
+	_, err := w.Write(frame)
+	return err
+}
+
+// ReadRTU reads a frame from a serial line. RTU frames carry no length, so it
+// is worked out from the function code, which means knowing whether a request
+// or a response is expected.
+func ReadRTU(r io.Reader, request bool) (byte, PDU, error) {
+	frame := make([]byte, 2, 8)
+	if _, err := io.ReadFull(r, frame); err != nil {
+		return 0, PDU{}, err
+	}
+	fc := FunctionCode(frame[1])
+	// the fixed part of the data, and whether its last byte counts the bytes
+	// that follow
+	size, counted := 0, false
+	switch {
+	case fc&exceptionFlag != 0 && !request:
+		size = 1
+	case request && fc >= ReadCoils && fc <= WriteSingleRegister:
+		size = 4
+	case request && (fc == WriteMultipleCoils || fc == WriteMultipleRegisters):
+		size, counted = 5, true
+	case !request && fc >= ReadCoils && fc <= ReadInputRegisters:
+		size, counted = 1, true
+	case !request && (fc == WriteSingleCoil || fc == WriteSingleRegister || fc == WriteMultipleCoils || fc == WriteMultipleRegisters):
+		size = 4
+	default:
+		return 0, PDU{}, fmt.Errorf("%w: unknown function %d", ErrFrame, fc)
+	}
+	frame = append(frame, make([]byte, size)...)
+	if _, err := io.ReadFull(r, frame[2:]); err != nil {
+		return 0, PDU{}, err
+	}
+	if counted {
+		n := int(frame[len(frame)-1])
+		frame = append(frame, make([]byte, n)...)
+		if _, err := io.ReadFull(r, frame[len(frame)-n:]); err != nil {
+			return 0, PDU{}, err
+		}
+	}
+	var sum [2]byte
+	if _, err := io.ReadFull(r, sum[:]); err != nil {
+		return 0, PDU{}, err
+	}
+	if binary.LittleEndian.Uint16(sum[:]) != CRC(frame) {
+		return 0, PDU{}, fmt.Errorf("%w: bad CRC", ErrFrame)
+	}
+	return frame[0], PDU{Function: fc, Data: frame[2:]}, nil
+}
+
+// Port is the serial port RTU frames are sent over, such as a *serial.Port.
+type Port interface {
+	ReadPort(b []byte) (int, error)
+	WritePort(b []byte) (int, error)
+}
+
+// portReader reads from a port until the deadline. Serial ports return no
+// data when their read timeout passes.
+type portReader struct {
+	port     Port
+	deadline time.Time
+}
+
+func (r *portReader) Read(b []byte) (int, error) {
+	for {
+		n, err := r.port.ReadPort(b)
+		if n > 0 || err != nil {
+			return n, err
+		}
+		if time.Now().After(r.deadline) {
+			return 0, ErrTimeout
+		}
+	}
+}
+
+type portWriter struct {
+	port Port
+}
+
+func (w portWriter) Write(b []byte) (int, error) {
+	return w.port.WritePort(b)
+}
+
+// RTU is Modbus RTU over a serial line, which carries one request at a time.
+type RTU struct {
+	// Timeout bounds how long to wait for a response.
+	Timeout time.Duration
+	// Gap is the silence kept between frames.
+	Gap time.Duration
+
+	port Port
+	mu   sync.Mutex
+	last time.Time
+}
+
+var _ Transport = (*RTU)(nil)
+
+func NewRTU(port Port) *RTU {
+	return &RTU{Timeout: DefaultTimeout, Gap: DefaultGap, port: port}
+}
+
+// OpenRTU opens a serial port, given as serial.Open takes it, for RTU.
+func OpenRTU(spec string, baud int) (*RTU, error) {
+	port, err := serial.Open(spec, baud)
+	if err != nil {
+		return nil, err
+	}
+	return NewRTU(port), nil
+}
+
+func (t *RTU) Send(ctx context.Context, unit byte, req PDU) (PDU, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if wait := time.Until(t.last.Add(t.Gap)); wait > 0 {
+		time.Sleep(wait)
+	}
+	defer func() {
+		t.last = time.Now()
+	}()
+	if err := WriteRTU(portWriter{t.port}, unit, req); err != nil {
+		return PDU{}, err
+	}
+	got, resp, err := ReadRTU(&portReader{port: t.port, deadline: deadline(ctx, t.Timeout)}, false)
+	if err != nil {
+		// drop whatever is left of the frame, so it is not read as the
+		// start of the next one
+		if f, ok := t.port.(interface{ Flush() error }); ok {
+			_ = f.Flush()
+		}
+		if err == ErrTimeout && ctx.Err() != nil {
+			return PDU{}, ctx.Err()
+		}
+		return PDU{}, err
+	}
+	if got != unit {
+		return PDU{}, fmt.Errorf("%w: answer from unit %d to unit %d", ErrResponse, got, unit)
+	}
+	return resp, nil
+}
+
+func (t *RTU) Close() error {
+	if c, ok := t.port.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
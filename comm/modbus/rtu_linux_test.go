@@ -0,0 +1,41 @@
+//go:build linux
+
+package modbus_test
+
+import (
+	"context"
+	"errors"
+	"github.com/jt05610/petri/comm/modbus"
+	"github.com/jt05610/petri/comm/modbus/sim"
+	"github.com/jt05610/petri/comm/serial"
+	"testing"
+	"time"
+)
+
+func TestRTU(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+	dev := sim.New(64)
+	pty, err := serial.Simulate(ctx, dev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rtu, err := modbus.OpenRTU(pty.Name, 9600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := modbus.NewClient(rtu)
+	defer func() {
+		_ = c.Close()
+	}()
+	exercise(t, ctx, c, dev)
+	// other units stay silent
+	rtu.Timeout = 100 * time.Millisecond
+	if _, err := c.ReadCoils(ctx, 2, 0, 1); !errors.Is(err, modbus.ErrTimeout) {
+		t.Fatalf("expected unit 2 not to answer, got %v", err)
+	}
+	rtu.Timeout = modbus.DefaultTimeout
+	if _, err := c.ReadCoils(ctx, 1, 0, 1); err != nil {
+		t.Fatalf("expected unit 1 to answer after the timeout, got %v", err)
+	}
+}
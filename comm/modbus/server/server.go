@@ -0,0 +1,180 @@
+// Package server serves a Modbus device over gRPC, so PLCs can be driven the
+// same way as the other devices.
+package server
+
+import (
+	"context"
+	"errors"
+	"github.com/jt05610/petri/comm/modbus"
+	proto "github.com/jt05610/petri/proto/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"math"
+)
+
+var _ proto.ModbusServer = (*Server)(nil)
+
+type Server struct {
+	client *modbus.Client
+	logger *zap.Logger
+	proto.UnimplementedModbusServer
+}
+
+func New(client *modbus.Client, logger *zap.Logger) *Server {
+	return &Server{client: client, logger: logger}
+}
+
+func (s *Server) Close() error {
+	return s.client.Close()
+}
+
+// toStatus converts an error from the client to a gRPC status.
+func toStatus(err error) error {
+	var exc modbus.Exception
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &exc):
+		code := codes.Internal
+		switch exc {
+		case modbus.IllegalFunction:
+			code = codes.Unimplemented
+		case modbus.IllegalDataAddress:
+			code = codes.OutOfRange
+		case modbus.IllegalDataValue:
+			code = codes.InvalidArgument
+		case modbus.ServerDeviceBusy, modbus.GatewayPathUnavailable, modbus.GatewayTargetFailed:
+			code = codes.Unavailable
+		}
+		return status.Error(code, err.Error())
+	case errors.Is(err, modbus.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	}
+	return status.Error(codes.Unavailable, err.Error())
+}
+
+// fail logs an error from the device and converts it to a gRPC status.
+func (s *Server) fail(err error) error {
+	s.logger.Warn("Modbus request failed", zap.Error(err))
+	return toStatus(err)
+}
+
+func unit(id uint32) (byte, error) {
+	if id > math.MaxUint8 {
+		return 0, status.Errorf(codes.InvalidArgument, "unit %d is above 255", id)
+	}
+	return byte(id), nil
+}
+
+// span checks a request's addresses before they are narrowed to 16 bits.
+func span(unitID, addr, qty uint32) (byte, uint16, uint16, error) {
+	u, err := unit(unitID)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	if addr > math.MaxUint16 || qty > math.MaxUint16 || addr+qty > math.MaxUint16+1 {
+		return 0, 0, 0, status.Errorf(codes.OutOfRange, "%d items from %d are beyond the last address", qty, addr)
+	}
+	return u, uint16(addr), uint16(qty), nil
+}
+
+func (s *Server) ReadCoils(ctx context.Context, req *proto.ReadCoilsRequest) (*proto.ReadCoilsResponse, error) {
+	u, addr, qty, err := span(req.UnitId, req.StartAddress, req.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.client.ReadCoils(ctx, u, addr, qty)
+	if err != nil {
+		return nil, s.fail(err)
+	}
+	return &proto.ReadCoilsResponse{Data: data}, nil
+}
+
+func (s *Server) ReadDiscreteInputs(ctx context.Context, req *proto.ReadDiscreteInputsRequest) (*proto.ReadDiscreteInputsResponse, error) {
+	u, addr, qty, err := span(req.UnitId, req.StartAddress, req.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.client.ReadDiscreteInputs(ctx, u, addr, qty)
+	if err != nil {
+		return nil, s.fail(err)
+	}
+	return &proto.ReadDiscreteInputsResponse{Data: data}, nil
+}
+
+func (s *Server) ReadHoldingRegisters(ctx context.Context, req *proto.ReadHoldingRegistersRequest) (*proto.ReadHoldingRegistersResponse, error) {
+	u, addr, qty, err := span(req.UnitId, req.StartAddress, req.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.client.ReadHoldingRegisters(ctx, u, addr, qty)
+	if err != nil {
+		return nil, s.fail(err)
+	}
+	return &proto.ReadHoldingRegistersResponse{Data: modbus.PackRegisters(data)}, nil
+}
+
+func (s *Server) ReadInputRegisters(ctx context.Context, req *proto.ReadInputRegistersRequest) (*proto.ReadInputRegistersResponse, error) {
+	u, addr, qty, err := span(req.UnitId, req.StartAddress, req.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.client.ReadInputRegisters(ctx, u, addr, qty)
+	if err != nil {
+		return nil, s.fail(err)
+	}
+	return &proto.ReadInputRegistersResponse{Data: modbus.PackRegisters(data)}, nil
+}
+
+func (s *Server) WriteSingleCoil(ctx context.Context, req *proto.WriteSingleCoilRequest) (*proto.WriteSingleCoilResponse, error) {
+	u, addr, _, err := span(req.UnitId, req.Address, 1)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.client.WriteSingleCoil(ctx, u, addr, req.Value); err != nil {
+		return nil, s.fail(err)
+	}
+	return &proto.WriteSingleCoilResponse{Address: req.Address, Value: req.Value}, nil
+}
+
+func (s *Server) WriteSingleRegister(ctx context.Context, req *proto.WriteSingleRegisterRequest) (*proto.WriteSingleRegisterResponse, error) {
+	u, addr, _, err := span(req.UnitId, req.Address, 1)
+	if err != nil {
+		return nil, err
+	}
+	if req.Value > math.MaxUint16 {
+		return nil, status.Errorf(codes.InvalidArgument, "value %d does not fit in a register", req.Value)
+	}
+	if err := s.client.WriteSingleRegister(ctx, u, addr, uint16(req.Value)); err != nil {
+		return nil, s.fail(err)
+	}
+	return &proto.WriteSingleRegisterResponse{Address: req.Address, Value: req.Value}, nil
+}
+
+func (s *Server) WriteMultipleCoils(ctx context.Context, req *proto.WriteMultipleCoilsRequest) (*proto.WriteMultipleCoilsResponse, error) {
+	u, addr, qty, err := span(req.UnitId, req.StartAddress, uint32(len(req.Data)))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.client.WriteMultipleCoils(ctx, u, addr, req.Data); err != nil {
+		return nil, s.fail(err)
+	}
+	return &proto.WriteMultipleCoilsResponse{StartAddress: req.StartAddress, Quantity: uint32(qty)}, nil
+}
+
+func (s *Server) WriteMultipleRegisters(ctx context.Context, req *proto.WriteMultipleRegistersRequest) (*proto.WriteMultipleRegistersResponse, error) {
+	if len(req.Data)%2 != 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "%d bytes is not a whole number of registers", len(req.Data))
+	}
+	u, addr, qty, err := span(req.UnitId, req.StartAddress, uint32(len(req.Data)/2))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.client.WriteMultipleRegisters(ctx, u, addr, modbus.UnpackRegisters(req.Data)); err != nil {
+		return nil, s.fail(err)
+	}
+	return &proto.WriteMultipleRegistersResponse{StartAddress: req.StartAddress, Quantity: uint32(qty)}, nil
+}
@@ -0,0 +1,83 @@
+package server_test
+
+import (
+	"context"
+	"github.com/jt05610/petri/comm/modbus"
+	"github.com/jt05610/petri/comm/modbus/server"
+	"github.com/jt05610/petri/comm/modbus/sim"
+	proto "github.com/jt05610/petri/proto/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net"
+	"testing"
+	"time"
+)
+
+func start(t *testing.T) (context.Context, *sim.Slave, *server.Server) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+	dev := sim.New(32)
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		_ = dev.ServeTCP(ctx, l)
+	}()
+	tcp, err := modbus.DialTCP(ctx, l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := server.New(modbus.NewClient(tcp), zap.NewNop())
+	t.Cleanup(func() {
+		_ = s.Close()
+	})
+	return ctx, dev, s
+}
+
+func TestServer_WriteMultiple(t *testing.T) {
+	ctx, dev, s := start(t)
+	coils, err := s.WriteMultipleCoils(ctx, &proto.WriteMultipleCoilsRequest{UnitId: 1, StartAddress: 4, Data: []bool{true, false, true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if coils.Quantity != 3 || !dev.Coil(4) || dev.Coil(5) || !dev.Coil(6) {
+		t.Fatalf("expected coils 4 and 6 to be set, got %v", coils)
+	}
+	regs, err := s.WriteMultipleRegisters(ctx, &proto.WriteMultipleRegistersRequest{UnitId: 1, StartAddress: 1, Data: []byte{0x01, 0x02, 0x03, 0x04}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if regs.Quantity != 2 || dev.HoldingRegister(1) != 0x0102 || dev.HoldingRegister(2) != 0x0304 {
+		t.Fatalf("expected registers 1 and 2 to be written, got %v", regs)
+	}
+	read, err := s.ReadHoldingRegisters(ctx, &proto.ReadHoldingRegistersRequest{UnitId: 1, StartAddress: 1, Quantity: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(read.Data) != "\x01\x02\x03\x04" {
+		t.Fatalf("expected the registers back, got % X", read.Data)
+	}
+}
+
+func TestServer_Errors(t *testing.T) {
+	ctx, _, s := start(t)
+	_, err := s.ReadCoils(ctx, &proto.ReadCoilsRequest{UnitId: 1, StartAddress: 30, Quantity: 5})
+	if status.Code(err) != codes.OutOfRange {
+		t.Fatalf("expected reading past the end to be out of range, got %v", err)
+	}
+	_, err = s.ReadInputRegisters(ctx, &proto.ReadInputRegistersRequest{UnitId: 1, Quantity: 200})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected too many registers to be rejected, got %v", err)
+	}
+	_, err = s.WriteSingleRegister(ctx, &proto.WriteSingleRegisterRequest{UnitId: 1, Value: 1 << 16})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected a value too large for a register to be rejected, got %v", err)
+	}
+	_, err = s.ReadCoils(ctx, &proto.ReadCoilsRequest{UnitId: 7, Quantity: 1})
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected a missing unit to be unavailable, got %v", err)
+	}
+}
@@ -0,0 +1,235 @@
+// Package sim simulates a Modbus slave, such as a PLC, holding its coils,
+// inputs and registers in memory, so that the server and the devices built
+// on it can be tested without hardware. Serve it over RTU behind a
+// pseudo-terminal with serial.Simulate, or over TCP with ServeTCP.
+package sim
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"github.com/jt05610/petri/comm/modbus"
+	"io"
+	"net"
+	"sync"
+)
+
+// Slave is a simulated Modbus slave.
+type Slave struct {
+	// Unit is the slave's address. RTU requests for other units are
+	// ignored, and TCP ones answered with a gateway exception.
+	Unit byte
+
+	mu        sync.Mutex
+	coils     []bool
+	discrete  []bool
+	holding   []uint16
+	input     []uint16
+	requested []modbus.FunctionCode
+}
+
+// New returns a slave at unit 1 with size of each kind of data, all zero.
+func New(size int) *Slave {
+	return &Slave{
+		Unit:     1,
+		coils:    make([]bool, size),
+		discrete: make([]bool, size),
+		holding:  make([]uint16, size),
+		input:    make([]uint16, size),
+	}
+}
+
+func (s *Slave) Coil(addr int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.coils[addr]
+}
+
+func (s *Slave) HoldingRegister(addr int) uint16 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.holding[addr]
+}
+
+// SetDiscreteInput sets an input, as a sensor wired to the PLC would.
+func (s *Slave) SetDiscreteInput(addr int, v bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.discrete[addr] = v
+}
+
+// SetInputRegister sets an input register, as a measurement would.
+func (s *Slave) SetInputRegister(addr int, v uint16) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.input[addr] = v
+}
+
+// Requested returns the function codes of the requests handled so far.
+func (s *Slave) Requested() []modbus.FunctionCode {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]modbus.FunctionCode(nil), s.requested...)
+}
+
+// span checks that quantity items from addr fit in a table of size items.
+func span(data []byte, size, max int) (addr, qty int, exc modbus.Exception) {
+	addr = int(binary.BigEndian.Uint16(data))
+	qty = int(binary.BigEndian.Uint16(data[2:]))
+	if qty < 1 || qty > max {
+		return 0, 0, modbus.IllegalDataValue
+	}
+	if addr+qty > size {
+		return 0, 0, modbus.IllegalDataAddress
+	}
+	return addr, qty, 0
+}
+
+func readBits(data []byte, table []bool) ([]byte, modbus.Exception) {
+	addr, qty, exc := span(data, len(table), modbus.MaxReadBits)
+	if exc != 0 {
+		return nil, exc
+	}
+	bits := modbus.PackBits(table[addr : addr+qty])
+	return append([]byte{byte(len(bits))}, bits...), 0
+}
+
+func readRegisters(data []byte, table []uint16) ([]byte, modbus.Exception) {
+	addr, qty, exc := span(data, len(table), modbus.MaxReadRegisters)
+	if exc != 0 {
+		return nil, exc
+	}
+	regs := modbus.PackRegisters(table[addr : addr+qty])
+	return append([]byte{byte(len(regs))}, regs...), 0
+}
+
+// Handle carries out a request and returns the response.
+func (s *Slave) Handle(req modbus.PDU) modbus.PDU {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requested = append(s.requested, req.Function)
+	data, exc := s.handle(req)
+	if exc != 0 {
+		return modbus.ExceptionPDU(req.Function, exc)
+	}
+	return modbus.PDU{Function: req.Function, Data: data}
+}
+
+// handle carries out a request. Callers hold s.mu.
+func (s *Slave) handle(req modbus.PDU) ([]byte, modbus.Exception) {
+	if len(req.Data) < 4 {
+		return nil, modbus.IllegalDataValue
+	}
+	switch req.Function {
+	case modbus.ReadCoils:
+		return readBits(req.Data, s.coils)
+	case modbus.ReadDiscreteInputs:
+		return readBits(req.Data, s.discrete)
+	case modbus.ReadHoldingRegisters:
+		return readRegisters(req.Data, s.holding)
+	case modbus.ReadInputRegisters:
+		return readRegisters(req.Data, s.input)
+	case modbus.WriteSingleCoil:
+		addr := int(binary.BigEndian.Uint16(req.Data))
+		v := binary.BigEndian.Uint16(req.Data[2:])
+		if v != 0 && v != 0xFF00 {
+			return nil, modbus.IllegalDataValue
+		}
+		if addr >= len(s.coils) {
+			return nil, modbus.IllegalDataAddress
+		}
+		s.coils[addr] = v != 0
+		return req.Data[:4], 0
+	case modbus.WriteSingleRegister:
+		addr := int(binary.BigEndian.Uint16(req.Data))
+		if addr >= len(s.holding) {
+			return nil, modbus.IllegalDataAddress
+		}
+		s.holding[addr] = binary.BigEndian.Uint16(req.Data[2:])
+		return req.Data[:4], 0
+	case modbus.WriteMultipleCoils:
+		addr, qty, exc := span(req.Data, len(s.coils), modbus.MaxWriteBits)
+		if exc != 0 {
+			return nil, exc
+		}
+		if len(req.Data) < 5 || int(req.Data[4]) != (qty+7)/8 || len(req.Data) != 5+(qty+7)/8 {
+			return nil, modbus.IllegalDataValue
+		}
+		copy(s.coils[addr:], modbus.UnpackBits(req.Data[5:], qty))
+		return req.Data[:4], 0
+	case modbus.WriteMultipleRegisters:
+		addr, qty, exc := span(req.Data, len(s.holding), modbus.MaxWriteRegisters)
+		if exc != 0 {
+			return nil, exc
+		}
+		if len(req.Data) < 5 || int(req.Data[4]) != 2*qty || len(req.Data) != 5+2*qty {
+			return nil, modbus.IllegalDataValue
+		}
+		copy(s.holding[addr:], modbus.UnpackRegisters(req.Data[5:]))
+		return req.Data[:4], 0
+	}
+	return nil, modbus.IllegalFunction
+}
+
+// Serve answers RTU requests read from rw until it is closed.
+func (s *Slave) Serve(ctx context.Context, rw io.ReadWriter) error {
+	for ctx.Err() == nil {
+		unit, req, err := modbus.ReadRTU(rw, true)
+		if errors.Is(err, modbus.ErrFrame) {
+			// a real slave waits for the line to go quiet before listening
+			// again; here the rest of the frame is read as noise
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if unit != s.Unit {
+			continue
+		}
+		if err := modbus.WriteRTU(rw, unit, s.Handle(req)); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// ServeTCP answers Modbus TCP connections accepted from l until ctx is done.
+func (s *Slave) ServeTCP(ctx context.Context, l net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		_ = l.Close()
+	}()
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		go s.serveConn(ctx, conn)
+	}
+}
+
+func (s *Slave) serveConn(ctx context.Context, conn net.Conn) {
+	defer func() {
+		_ = conn.Close()
+	}()
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+	for {
+		h, req, err := modbus.ReadTCP(conn)
+		if err != nil {
+			return
+		}
+		resp := modbus.ExceptionPDU(req.Function, modbus.GatewayTargetFailed)
+		if h.Unit == s.Unit {
+			resp = s.Handle(req)
+		}
+		if err := modbus.WriteTCP(conn, h, resp); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package modbus
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// DefaultTimeout is how long to wait for a response when the request's
+// context has no deadline.
+const DefaultTimeout = time.Second
+
+// maxPDU is the largest PDU a frame can carry.
+const maxPDU = 253
+
+var ErrFrame = errors.New("modbus: malformed frame")
+
+// deadline returns when a request sent now must be answered by.
+func deadline(ctx context.Context, timeout time.Duration) time.Time {
+	ret := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(ret) {
+		return d
+	}
+	return ret
+}
+
+// Header is the MBAP header that frames a PDU over TCP.
+type Header struct {
+	Transaction uint16
+	Unit        byte
+}
+
+// ReadTCP reads a frame sent over TCP.
+func ReadTCP(r io.Reader) (Header, PDU, error) {
+	var buf [7]byte
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return Header{}, PDU{}, err
+	}
+	protocol := binary.BigEndian.Uint16(buf[2:])
+	length := int(binary.BigEndian.Uint16(buf[4:]))
+	if protocol != 0 || length < 2 || length > maxPDU+1 {
+		return Header{}, PDU{}, fmt.Errorf("%w: protocol %d, length %d", ErrFrame, protocol, length)
+	}
+	body := make([]byte, length-1)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return Header{}, PDU{}, err
+	}
+	h := Header{Transaction: binary.BigEndian.Uint16(buf[:]), Unit: buf[6]}
+	return h, PDU{Function: FunctionCode(body[0]), Data: body[1:]}, nil
+}
+
+// WriteTCP writes a frame to send over TCP.
+func WriteTCP(w io.Writer, h Header, p PDU) error {
+	frame := make([]byte, 7, 8+len(p.Data))
+	binary.BigEndian.PutUint16(frame, h.Transaction)
+	binary.BigEndian.PutUint16(frame[4:], uint16(len(p.Data)+2))
+	frame[6] = h.Unit
+	frame = append(frame, byte(p.Function))
+	_, err := w.Write(append(frame, p.Data...))
+	return err
+}
+
+// TCP is Modbus TCP. The connection is dialed again after it fails.
+type TCP struct {
+	// Timeout bounds how long to wait for a response.
+	Timeout time.Duration
+
+	addr string
+	mu   sync.Mutex
+	conn net.Conn
+	tid  uint16
+}
+
+var _ Transport = (*TCP)(nil)
+
+// DialTCP connects to a device or gateway at addr, such as 10.0.0.5:502.
+func DialTCP(ctx context.Context, addr string) (*TCP, error) {
+	t := &TCP{Timeout: DefaultTimeout, addr: addr}
+	if err := t.dial(ctx); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *TCP) dial(ctx context.Context) error {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", t.addr)
+	if err != nil {
+		return err
+	}
+	t.conn = conn
+	return nil
+}
+
+// Send sends a request and waits for the response with the same
+// transaction ID, skipping late responses to requests that timed out.
+func (t *TCP) Send(ctx context.Context, unit byte, req PDU) (PDU, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.conn == nil {
+		if err := t.dial(ctx); err != nil {
+			return PDU{}, err
+		}
+	}
+	t.tid++
+	h := Header{Transaction: t.tid, Unit: unit}
+	resp, err := t.roundTrip(ctx, h, req)
+	if err != nil {
+		_ = t.conn.Close()
+		t.conn = nil
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			if ctx.Err() != nil {
+				return PDU{}, ctx.Err()
+			}
+			return PDU{}, ErrTimeout
+		}
+		return PDU{}, err
+	}
+	return resp, nil
+}
+
+func (t *TCP) roundTrip(ctx context.Context, h Header, req PDU) (PDU, error) {
+	if err := t.conn.SetDeadline(deadline(ctx, t.Timeout)); err != nil {
+		return PDU{}, err
+	}
+	if err := WriteTCP(t.conn, h, req); err != nil {
+		return PDU{}, err
+	}
+	for {
+		got, resp, err := ReadTCP(t.conn)
+		if err != nil {
+			return PDU{}, err
+		}
+		if got == h {
+			return resp, nil
+		}
+	}
+}
+
+func (t *TCP) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.conn == nil {
+		return nil
+	}
+	err := t.conn.Close()
+	t.conn = nil
+	return err
+}
@@ -0,0 +1,376 @@
+// Package program streams G-code programs to firmware, reporting progress as
+// the firmware acknowledges each block.
+package program
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jt05610/petri/comm/queue"
+	"strings"
+	"sync"
+)
+
+// Block is one line of a program to send.
+type Block struct {
+	// Line is the line of the source it came from, counting from 1.
+	Line int
+	Text string
+}
+
+// Parse splits gcode into blocks, dropping comments and blank lines.
+func Parse(gcode string) []*Block {
+	ret := make([]*Block, 0)
+	for i, line := range strings.Split(gcode, "\n") {
+		if j := strings.IndexByte(line, ';'); j >= 0 {
+			line = line[:j]
+		}
+		var b strings.Builder
+		depth := 0
+		for _, r := range line {
+			switch {
+			case r == '(':
+				depth++
+			case r == ')' && depth > 0:
+				depth--
+			case depth == 0:
+				b.WriteRune(r)
+			}
+		}
+		text := strings.TrimSpace(b.String())
+		if text == "" || text == "%" {
+			continue
+		}
+		ret = append(ret, &Block{Line: i + 1, Text: text})
+	}
+	return ret
+}
+
+// Control is an operator's request while a program runs. Its values are
+// those of the servers' ProgramControl enums, where 0 is no request.
+type Control int
+
+const (
+	Hold Control = iota + 1
+	Resume
+	Cancel
+)
+
+// Event says what a Progress report is about. Its values are those of the
+// servers' ProgramEvent enums.
+type Event int
+
+const (
+	Started Event = iota
+	Acked
+	Held
+	Resumed
+	Completed
+	Cancelled
+	Failed
+)
+
+// Progress is reported as the program runs.
+type Progress struct {
+	Event Event
+	// Block is the block acknowledged, for Acked and Failed.
+	Block *Block
+	// Done is the number of blocks acknowledged.
+	Done  int
+	Total int
+	Err   error
+}
+
+// Firmware is what a server provides to stream a program.
+type Firmware interface {
+	// Send writes the block with index i and returns a channel that receives
+	// the firmware's answer to it. Send may block while the firmware's buffer
+	// is full.
+	Send(ctx context.Context, i int, b *Block) (<-chan error, error)
+	// Hold pauses motion.
+	Hold(ctx context.Context) error
+	// Resume continues after Hold.
+	Resume(ctx context.Context) error
+	// Cancel stops the firmware and answers the blocks it had not run.
+	Cancel(ctx context.Context) error
+}
+
+var ErrCancelled = errors.New("program cancelled")
+
+type run struct {
+	fw       Firmware
+	blocks   []*Block
+	report   func(*Progress) error
+	mu       sync.Mutex
+	held     bool
+	canceled bool
+	changed  queue.Signal
+	// cancel stops the sender, which sends its error on sent once it has sent
+	// its last block.
+	cancel  context.CancelFunc
+	sent    chan error
+	sendErr error
+	stopped bool
+}
+
+func (r *run) progress(p *Progress) error {
+	p.Total = len(r.blocks)
+	return r.report(p)
+}
+
+func (r *run) set(f func()) {
+	r.mu.Lock()
+	f()
+	r.mu.Unlock()
+	r.changed.Notify()
+}
+
+func (r *run) isHeld() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.held
+}
+
+// stop stops sending and returns the sender's error once nothing more will be
+// sent, so the firmware is only cancelled after the last block went out.
+func (r *run) stop() error {
+	if !r.stopped {
+		r.cancel()
+		r.sendErr = <-r.sent
+		r.stopped = true
+	}
+	return r.sendErr
+}
+
+// wait blocks while the program is held and reports whether to keep sending.
+func (r *run) wait(ctx context.Context) bool {
+	for {
+		changed := r.changed.Wait()
+		r.mu.Lock()
+		held, canceled := r.held, r.canceled
+		r.mu.Unlock()
+		if canceled || ctx.Err() != nil {
+			return false
+		}
+		if !held {
+			return true
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+type flight struct {
+	block *Block
+	done  <-chan error
+}
+
+// send sends the blocks that are not held back, and closes inflight once it
+// stops.
+func (r *run) send(ctx context.Context, inflight chan<- *flight) error {
+	defer close(inflight)
+	for i, b := range r.blocks {
+		if !r.wait(ctx) {
+			return nil
+		}
+		done, err := r.fw.Send(ctx, i, b)
+		if err != nil {
+			return err
+		}
+		inflight <- &flight{block: b, done: done}
+	}
+	return nil
+}
+
+type answer struct {
+	block *Block
+	err   error
+}
+
+// answers passes on the firmware's answers to the blocks in flight in the
+// order they were sent.
+func answers(ctx context.Context, inflight <-chan *flight, answered chan<- *answer) {
+	defer close(answered)
+	for f := range inflight {
+		a := &answer{block: f.block}
+		select {
+		case a.err = <-f.done:
+		case <-ctx.Done():
+			return
+		}
+		select {
+		case answered <- a:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// apply carries out an operator's request. It returns ErrCancelled once the
+// program is cancelled.
+func (r *run) apply(ctx context.Context, c Control) error {
+	switch c {
+	case Hold:
+		// held is set before the firmware is told so that no block is sent
+		// after the hold, while a Send waiting for room does not delay it
+		r.set(func() { r.held = true })
+		if err := r.fw.Hold(ctx); err != nil {
+			return err
+		}
+		return r.progress(&Progress{Event: Held})
+	case Resume:
+		if err := r.fw.Resume(ctx); err != nil {
+			return err
+		}
+		r.set(func() { r.held = false })
+		return r.progress(&Progress{Event: Resumed})
+	case Cancel:
+		r.set(func() { r.canceled = true })
+		_ = r.stop()
+		if err := r.fw.Cancel(context.WithoutCancel(ctx)); err != nil {
+			return err
+		}
+		return ErrCancelled
+	}
+	return nil
+}
+
+// loop applies controls and reports answers until every block is answered. A
+// control that has arrived is applied before the next answer is reported, and
+// no answers are reported while the program is held.
+func (r *run) loop(ctx context.Context, controls <-chan Control, answered <-chan *answer) error {
+	done := 0
+	for {
+		select {
+		case c, ok := <-controls:
+			if !ok {
+				controls = nil
+				continue
+			}
+			if err := r.apply(ctx, c); err != nil {
+				return err
+			}
+			continue
+		default:
+		}
+		next := answered
+		if r.isHeld() {
+			next = nil
+		}
+		select {
+		case c, ok := <-controls:
+			if !ok {
+				controls = nil
+				continue
+			}
+			if err := r.apply(ctx, c); err != nil {
+				return err
+			}
+		case a, ok := <-next:
+			if !ok {
+				if err := r.stop(); err != nil {
+					return err
+				}
+				return ctx.Err()
+			}
+			if a.err != nil {
+				return fmt.Errorf("line %d %q: %w", a.block.Line, a.block.Text, a.err)
+			}
+			done++
+			if err := r.progress(&Progress{Event: Acked, Block: a.block, Done: done}); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Run sends blocks to fw, applying controls as they arrive and calling report
+// with progress. It returns ErrCancelled if the program was cancelled.
+func Run(ctx context.Context, fw Firmware, blocks []*Block, controls <-chan Control, report func(*Progress) error) error {
+	sendCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	r := &run{fw: fw, blocks: blocks, report: report, cancel: cancel, sent: make(chan error, 1)}
+	if err := r.progress(&Progress{Event: Started}); err != nil {
+		return err
+	}
+	inflight := make(chan *flight, len(blocks))
+	go func() {
+		r.sent <- r.send(sendCtx, inflight)
+	}()
+	answered := make(chan *answer)
+	go answers(sendCtx, inflight, answered)
+	err := r.loop(ctx, controls, answered)
+	if errors.Is(err, ErrCancelled) {
+		_ = r.progress(&Progress{Event: Cancelled})
+		return ErrCancelled
+	}
+	if err != nil {
+		_ = r.stop()
+		// stop the firmware working through what was already sent
+		if cErr := fw.Cancel(context.WithoutCancel(ctx)); cErr != nil {
+			err = errors.Join(err, cErr)
+		}
+		_ = r.progress(&Progress{Event: Failed, Err: err})
+		return err
+	}
+	return r.progress(&Progress{Event: Completed, Done: len(blocks)})
+}
+
+// Response is a progress report laid out as the servers' StreamProgramResponse
+// messages carry it.
+type Response struct {
+	Event   Event
+	Line    int32
+	Block   string
+	Done    int32
+	Total   int32
+	Message string
+}
+
+func (p *Progress) response() *Response {
+	ret := &Response{Event: p.Event, Done: int32(p.Done), Total: int32(p.Total)}
+	if p.Block != nil {
+		ret.Line = int32(p.Block.Line)
+		ret.Block = p.Block.Text
+	}
+	if p.Err != nil {
+		ret.Message = p.Err.Error()
+	}
+	return ret
+}
+
+// Serve runs blocks for a StreamProgram call whose program has been received.
+// recv returns the next control from the stream and send writes a response to
+// it. A cancelled program is not an error; other errors are gRPC statuses.
+func Serve(ctx context.Context, fw Firmware, blocks []*Block, recv func() (Control, error), send func(*Response) error) error {
+	controls := make(chan Control)
+	go func() {
+		defer close(controls)
+		for {
+			c, err := recv()
+			if err != nil {
+				return
+			}
+			if c < Hold || c > Cancel {
+				continue
+			}
+			select {
+			case controls <- c:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	err := Run(ctx, fw, blocks, controls, func(p *Progress) error {
+		return send(p.response())
+	})
+	if errors.Is(err, ErrCancelled) {
+		return nil
+	}
+	return queue.Status(err)
+}
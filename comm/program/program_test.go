@@ -0,0 +1,217 @@
+package program_test
+
+import (
+	"context"
+	"errors"
+	"github.com/jt05610/petri/comm/program"
+	"github.com/jt05610/petri/comm/queue"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// firmware acknowledges lines as they arrive unless it is held, and rejects
+// lines containing BAD. A firmware that stalls holds itself after that many
+// lines, as a slow machine would still be running them.
+type firmware struct {
+	q         *queue.Queue
+	mu        sync.Mutex
+	held      bool
+	cancelled bool
+	resumed   queue.Signal
+}
+
+func newFirmware(ctx context.Context, stall int) *firmware {
+	tx := make(chan []byte, 16)
+	f := &firmware{q: queue.NewCounting(tx, 32)}
+	go func() {
+		for n := 0; ; n++ {
+			select {
+			case <-ctx.Done():
+				return
+			case line := <-tx:
+				if stall > 0 && n == stall {
+					_ = f.Hold(ctx)
+				}
+				_ = queue.Await(ctx, &f.resumed, func() (bool, error) {
+					f.mu.Lock()
+					defer f.mu.Unlock()
+					return !f.held, nil
+				})
+				var err error
+				if strings.Contains(string(line), "BAD") {
+					err = errors.New("error:20")
+				}
+				f.q.Resolve(err)
+			}
+		}
+	}()
+	return f
+}
+
+func (f *firmware) Send(ctx context.Context, _ int, b *program.Block) (<-chan error, error) {
+	return f.q.Send(ctx, []byte(b.Text+"\n"))
+}
+
+func (f *firmware) Hold(context.Context) error {
+	f.mu.Lock()
+	f.held = true
+	f.mu.Unlock()
+	return nil
+}
+
+func (f *firmware) Resume(context.Context) error {
+	f.mu.Lock()
+	f.held = false
+	f.mu.Unlock()
+	f.resumed.Notify()
+	return nil
+}
+
+func (f *firmware) Cancel(context.Context) error {
+	f.mu.Lock()
+	f.cancelled = true
+	f.mu.Unlock()
+	f.q.Flush(errors.New("reset"))
+	return nil
+}
+
+const gcode = `; prime the pumps
+G21 (mm)
+G90
+
+G1 X10 F500
+G1 X20
+G1 X30 ; last move
+%
+`
+
+func TestParse(t *testing.T) {
+	blocks := program.Parse(gcode)
+	want := []program.Block{{Line: 2, Text: "G21"}, {Line: 3, Text: "G90"}, {Line: 5, Text: "G1 X10 F500"}, {Line: 6, Text: "G1 X20"}, {Line: 7, Text: "G1 X30"}}
+	if len(blocks) != len(want) {
+		t.Fatalf("expected %d blocks, got %d", len(want), len(blocks))
+	}
+	for i, b := range blocks {
+		if *b != want[i] {
+			t.Fatalf("expected %v, got %v", want[i], *b)
+		}
+	}
+}
+
+func events(ps []*program.Progress) []program.Event {
+	ret := make([]program.Event, len(ps))
+	for i, p := range ps {
+		ret[i] = p.Event
+	}
+	return ret
+}
+
+func TestRun(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	fw := newFirmware(ctx, 0)
+	var got []*program.Progress
+	err := program.Run(ctx, fw, program.Parse(gcode), nil, func(p *program.Progress) error {
+		got = append(got, p)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 7 || got[0].Event != program.Started || got[6].Event != program.Completed || got[6].Done != 5 {
+		t.Fatalf("unexpected progress %v", events(got))
+	}
+	for i, p := range got[1:6] {
+		if p.Event != program.Acked || p.Done != i+1 || p.Total != 5 {
+			t.Fatalf("expected block %d to be acknowledged, got %+v", i+1, p)
+		}
+	}
+}
+
+func TestRun_Failed(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	fw := newFirmware(ctx, 0)
+	var last *program.Progress
+	err := program.Run(ctx, fw, program.Parse("G21\nG1 BAD\nG1 X10\n"), nil, func(p *program.Progress) error {
+		last = p
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Fatalf("expected line 2 to fail, got %v", err)
+	}
+	if last.Event != program.Failed || !fw.cancelled {
+		t.Fatalf("expected the firmware to be stopped after the failure, got %v", last.Event)
+	}
+}
+
+func TestRun_HoldCancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	fw := newFirmware(ctx, 0)
+	controls := make(chan program.Control, 2)
+	var got []*program.Progress
+	err := program.Run(ctx, fw, program.Parse(strings.Repeat("G1 X10\nG1 X0\n", 20)), controls, func(p *program.Progress) error {
+		got = append(got, p)
+		switch {
+		case p.Event == program.Acked && p.Done == 1:
+			controls <- program.Hold
+		case p.Event == program.Held:
+			controls <- program.Cancel
+		}
+		return nil
+	})
+	if !errors.Is(err, program.ErrCancelled) {
+		t.Fatalf("expected the program to be cancelled, got %v", err)
+	}
+	last := got[len(got)-1]
+	if last.Event != program.Cancelled || !fw.cancelled {
+		t.Fatalf("expected the program to end cancelled, got %v", events(got))
+	}
+	for _, p := range got {
+		if p.Event == program.Acked && p.Done == 40 {
+			t.Fatal("expected the held program not to finish")
+		}
+	}
+}
+
+func TestServe(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	// controls reach Serve as the stream delivers them, so the machine is
+	// still running the second block when the hold arrives
+	fw := newFirmware(ctx, 1)
+	// requests without a control are skipped
+	requests := make(chan program.Control, 3)
+	requests <- 0
+	recv := func() (program.Control, error) {
+		select {
+		case c := <-requests:
+			return c, nil
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+	var got []*program.Response
+	err := program.Serve(ctx, fw, program.Parse(strings.Repeat("G1 X10\nG1 X0\n", 20)), recv, func(r *program.Response) error {
+		got = append(got, r)
+		switch {
+		case r.Event == program.Acked && r.Done == 1:
+			if r.Line != 1 || r.Block != "G1 X10" || r.Total != 40 {
+				t.Errorf("unexpected response %+v", r)
+			}
+			requests <- program.Hold
+		case r.Event == program.Held:
+			requests <- program.Cancel
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected a cancelled program not to be an error, got %v", err)
+	}
+	if last := got[len(got)-1]; last.Event != program.Cancelled {
+		t.Fatalf("expected the program to end cancelled, got %+v", last)
+	}
+}
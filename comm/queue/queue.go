@@ -0,0 +1,231 @@
+// Package queue tracks the commands sent to line-oriented firmware such as
+// GRBL and Marlin, which acknowledge every line with ok or an error.
+package queue
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sync"
+)
+
+var ErrClosed = errors.New("command queue closed")
+
+type command struct {
+	line []byte
+	done chan error
+}
+
+// Queue sends commands to the firmware and resolves them in order as the
+// firmware acknowledges them. Callers wait while the queue is full.
+type Queue struct {
+	tx      chan<- []byte
+	depth   int
+	size    int
+	sendMu  sync.Mutex
+	mu      sync.Mutex
+	pending []*command
+	used    int
+	closed  error
+	freed   Signal
+}
+
+// New returns a queue that writes lines to tx and keeps at most depth
+// commands outstanding.
+func New(tx chan<- []byte, depth int) *Queue {
+	return &Queue{
+		tx:      tx,
+		depth:   depth,
+		pending: make([]*command, 0, depth),
+	}
+}
+
+// NewCounting returns a queue that keeps at most size bytes outstanding, for
+// firmware that buffers received lines, such as GRBL's character-counting
+// protocol.
+func NewCounting(tx chan<- []byte, size int) *Queue {
+	return &Queue{
+		tx:      tx,
+		size:    size,
+		pending: make([]*command, 0),
+	}
+}
+
+// fits reports whether line can be sent now. Callers hold q.mu.
+func (q *Queue) fits(line []byte) bool {
+	if len(q.pending) == 0 {
+		return true
+	}
+	if q.depth > 0 && len(q.pending) >= q.depth {
+		return false
+	}
+	return q.size == 0 || q.used+len(line) <= q.size
+}
+
+// Send waits until line fits, sends it and returns a channel that receives
+// the firmware's answer. Lines are sent in the order Send is called. A line
+// is only outstanding once it is on tx, so an answer that arrives before then
+// is not taken for its own.
+func (q *Queue) Send(ctx context.Context, line []byte) (<-chan error, error) {
+	q.sendMu.Lock()
+	defer q.sendMu.Unlock()
+	for {
+		freed := q.freed.Wait()
+		q.mu.Lock()
+		if q.closed != nil {
+			q.mu.Unlock()
+			return nil, q.closed
+		}
+		// answers only ever free room, so the line still fits once sent
+		fits := q.fits(line)
+		q.mu.Unlock()
+		if fits {
+			break
+		}
+		select {
+		case <-freed:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	select {
+	case q.tx <- line:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	c := &command{line: line, done: make(chan error, 1)}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed != nil {
+		return nil, q.closed
+	}
+	q.pending = append(q.pending, c)
+	q.used += len(line)
+	return c.done, nil
+}
+
+// Do sends line and waits until the firmware acknowledges it. If ctx is done
+// after the line was sent, Do returns but the command stays outstanding until
+// the firmware answers, so later answers are not matched to the wrong command.
+func (q *Queue) Do(ctx context.Context, line []byte) error {
+	done, err := q.Send(ctx, line)
+	if err != nil {
+		return err
+	}
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Resolve answers the oldest outstanding command with err, nil for ok. It
+// reports false if nothing was outstanding.
+func (q *Queue) Resolve(err error) bool {
+	q.mu.Lock()
+	if len(q.pending) == 0 {
+		q.mu.Unlock()
+		return false
+	}
+	c := q.pending[0]
+	q.pending = q.pending[1:]
+	q.used -= len(c.line)
+	q.mu.Unlock()
+	c.done <- err
+	q.freed.Notify()
+	return true
+}
+
+// Flush answers every outstanding command with err, for when the firmware
+// drops its buffer, such as on an alarm or a reset.
+func (q *Queue) Flush(err error) int {
+	n := 0
+	for q.Resolve(err) {
+		n++
+	}
+	return n
+}
+
+// Len returns the number of outstanding commands.
+func (q *Queue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.pending)
+}
+
+// Close fails the outstanding commands and any sent later with ErrClosed.
+func (q *Queue) Close() {
+	q.mu.Lock()
+	if q.closed == nil {
+		q.closed = ErrClosed
+	}
+	q.mu.Unlock()
+	q.Flush(ErrClosed)
+	q.freed.Notify()
+}
+
+// Signal wakes everything waiting on it each time it is notified.
+type Signal struct {
+	mu sync.Mutex
+	ch chan struct{}
+}
+
+func (s *Signal) Notify() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ch != nil {
+		close(s.ch)
+		s.ch = nil
+	}
+}
+
+// Wait returns a channel that is closed at the next Notify.
+func (s *Signal) Wait() <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ch == nil {
+		s.ch = make(chan struct{})
+	}
+	return s.ch
+}
+
+// Await checks done each time sig is notified until it reports true or an
+// error, or ctx is done.
+func Await(ctx context.Context, sig *Signal, done func() (bool, error)) error {
+	for {
+		wait := sig.Wait()
+		ok, err := done()
+		if err != nil || ok {
+			return err
+		}
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Status converts an error from the queue to a gRPC status error. Errors that
+// already carry a status, such as firmware errors, are returned as they are.
+func Status(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, ErrClosed):
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return status.Error(codes.Unknown, err.Error())
+}
@@ -0,0 +1,152 @@
+package queue_test
+
+import (
+	"context"
+	"errors"
+	"github.com/jt05610/petri/comm/queue"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+func sent(t *testing.T, tx <-chan []byte, want string) {
+	t.Helper()
+	select {
+	case line := <-tx:
+		if string(line) != want {
+			t.Fatalf("expected %q to be sent, got %q", want, line)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for %q", want)
+	}
+}
+
+func TestQueue_Do(t *testing.T) {
+	tx := make(chan []byte, 8)
+	q := queue.New(tx, 1)
+	ctx := context.Background()
+	first, second := make(chan error, 1), make(chan error, 1)
+	go func() {
+		first <- q.Do(ctx, []byte("G0 X1\n"))
+	}()
+	sent(t, tx, "G0 X1\n")
+	go func() {
+		second <- q.Do(ctx, []byte("G0 X2\n"))
+	}()
+	select {
+	case line := <-tx:
+		t.Fatalf("expected %q to wait for the first ack", line)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if !q.Resolve(nil) {
+		t.Fatal("expected a command to be outstanding")
+	}
+	if err := <-first; err != nil {
+		t.Fatal(err)
+	}
+	sent(t, tx, "G0 X2\n")
+	fwErr := status.Error(codes.InvalidArgument, "error:20")
+	q.Resolve(fwErr)
+	if err := <-second; !errors.Is(err, fwErr) {
+		t.Fatalf("expected the firmware error, got %v", err)
+	}
+	if q.Resolve(nil) {
+		t.Fatal("expected nothing to be outstanding")
+	}
+}
+
+func TestQueue_Cancel(t *testing.T) {
+	tx := make(chan []byte, 8)
+	q := queue.New(tx, 1)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := q.Do(ctx, []byte("G28\n"))
+	if st := status.Convert(queue.Status(err)); st.Code() != codes.DeadlineExceeded {
+		t.Fatalf("expected the deadline to be exceeded, got %v", err)
+	}
+	sent(t, tx, "G28\n")
+	// the abandoned command keeps its place until the firmware answers it
+	if q.Len() != 1 {
+		t.Fatalf("expected the command to stay outstanding, got %d", q.Len())
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- q.Do(context.Background(), []byte("M5\n"))
+	}()
+	q.Resolve(nil)
+	sent(t, tx, "M5\n")
+	q.Resolve(nil)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	q.Close()
+	if err := q.Do(context.Background(), []byte("M5\n")); status.Code(queue.Status(err)) != codes.Unavailable {
+		t.Fatalf("expected a closed queue to be unavailable, got %v", err)
+	}
+}
+
+func TestQueue_Unsent(t *testing.T) {
+	tx := make(chan []byte)
+	q := queue.New(tx, 1)
+	type sendResult struct {
+		done <-chan error
+		err  error
+	}
+	res := make(chan sendResult, 1)
+	go func() {
+		done, err := q.Send(context.Background(), []byte("G28\n"))
+		res <- sendResult{done, err}
+	}()
+	// nobody has taken the line yet, so a stray ok is not its answer
+	time.Sleep(20 * time.Millisecond)
+	if q.Resolve(nil) {
+		t.Fatal("expected an answer before the line was sent to be ignored")
+	}
+	sent(t, tx, "G28\n")
+	r := <-res
+	if r.err != nil {
+		t.Fatal(r.err)
+	}
+	select {
+	case err := <-r.done:
+		t.Fatalf("expected the line to wait for its own answer, got %v", err)
+	default:
+	}
+	if !q.Resolve(nil) {
+		t.Fatal("expected the sent line to be outstanding")
+	}
+	if err := <-r.done; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAwait(t *testing.T) {
+	var sig queue.Signal
+	var n int
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	done := make(chan error, 1)
+	// Await waits on the signal before checking, so a notification sent once
+	// a check has been seen always wakes the next one
+	checked := make(chan struct{}, 1)
+	go func() {
+		done <- queue.Await(ctx, &sig, func() (bool, error) {
+			n++
+			checked <- struct{}{}
+			return n == 3, nil
+		})
+	}()
+	for i := 0; i < 2; i++ {
+		<-checked
+		sig.Notify()
+	}
+	<-checked
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 {
+		t.Fatalf("expected three checks, got %d", n)
+	}
+}
@@ -0,0 +1,186 @@
+package serial_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"github.com/jt05610/petri/comm/serial"
+	"io"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func readLine(t *testing.T, rx <-chan io.Reader) string {
+	t.Helper()
+	select {
+	case r := <-rx:
+		got, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(got)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a line")
+	}
+	return ""
+}
+
+func nextEvent(t *testing.T, port *serial.Port) serial.Event {
+	t.Helper()
+	select {
+	case e := <-port.Events():
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for an event")
+	}
+	return serial.Event{}
+}
+
+func TestPort_Reconnect(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	devices := make(chan net.Conn, 1)
+	attempts := 0
+	port, err := serial.NewPort("fake", func() (io.ReadWriteCloser, error) {
+		// the device is still gone on the first attempt to reopen it
+		if attempts++; attempts == 2 {
+			return nil, errors.New("no such device")
+		}
+		host, dev := net.Pipe()
+		devices <- dev
+		return host, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	port.MinBackoff = time.Millisecond
+	defer func() {
+		_ = port.Close()
+	}()
+	tx := make(chan []byte)
+	rx, err := port.ChannelPort(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dev := <-devices
+	go func() {
+		_, _ = io.WriteString(dev, "hello\n")
+		_ = dev.Close()
+	}()
+	if got := readLine(t, rx); got != "hello" {
+		t.Fatalf("expected hello, got %q", got)
+	}
+	if e := nextEvent(t, port); e.State != serial.Disconnected || e.Err == nil {
+		t.Fatalf("expected the port to be lost, got %v", e)
+	}
+	if e := nextEvent(t, port); e.State != serial.Connected {
+		t.Fatalf("expected the port to be reopened, got %v", e)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts to open the port, got %d", attempts)
+	}
+	dev = <-devices
+	tx <- []byte("ping\n")
+	line, err := bufio.NewReader(dev).ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	if line != "ping\n" {
+		t.Fatalf("expected ping, got %q", line)
+	}
+	go func() {
+		_, _ = io.WriteString(dev, "pong\n")
+	}()
+	if got := readLine(t, rx); got != "pong" {
+		t.Fatalf("expected pong, got %q", got)
+	}
+}
+
+func TestPort_CloseWhileLost(t *testing.T) {
+	var attempts atomic.Int32
+	devices := make(chan net.Conn, 1)
+	port, err := serial.NewPort("fake", func() (io.ReadWriteCloser, error) {
+		// the device never comes back once unplugged
+		if attempts.Add(1) > 1 {
+			return nil, errors.New("no such device")
+		}
+		host, dev := net.Pipe()
+		devices <- dev
+		return host, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	port.MinBackoff, port.MaxBackoff = time.Millisecond, time.Millisecond
+	if _, err := port.ChannelPort(context.Background(), make(chan []byte)); err != nil {
+		t.Fatal(err)
+	}
+	_ = (<-devices).Close()
+	if e := nextEvent(t, port); e.State != serial.Disconnected {
+		t.Fatalf("expected the port to be lost, got %v", e)
+	}
+	if err := port.Close(); err != nil {
+		t.Fatal(err)
+	}
+	// an attempt may be under way as the port closes
+	time.Sleep(20 * time.Millisecond)
+	n := attempts.Load()
+	time.Sleep(50 * time.Millisecond)
+	if got := attempts.Load(); got != n {
+		t.Fatalf("expected no attempts to reopen a closed port, got %d more", got-n)
+	}
+}
+
+func TestPort_WriteWhileLost(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	devices := make(chan net.Conn, 1)
+	var attempts atomic.Int32
+	port, err := serial.NewPort("fake", func() (io.ReadWriteCloser, error) {
+		if attempts.Add(1) > 1 {
+			return nil, errors.New("no such device")
+		}
+		host, dev := net.Pipe()
+		devices <- dev
+		return host, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	port.MinBackoff, port.MaxBackoff = time.Millisecond, time.Millisecond
+	defer func() {
+		_ = port.Close()
+	}()
+	tx := make(chan []byte)
+	if _, err := port.ChannelPort(ctx, tx); err != nil {
+		t.Fatal(err)
+	}
+	_ = (<-devices).Close()
+	if e := nextEvent(t, port); e.State != serial.Disconnected {
+		t.Fatalf("expected the port to be lost, got %v", e)
+	}
+	tx <- []byte("G28\n")
+	if e := nextEvent(t, port); e.State != serial.Disconnected || !errors.Is(e.Err, serial.ErrDisconnected) {
+		t.Fatalf("expected the dropped write to be reported, got %v", e)
+	}
+}
+
+func TestParseMatch(t *testing.T) {
+	m, err := serial.ParseMatch("usb:0403:6001:A10KZP4S")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m != (serial.Match{VID: "0403", PID: "6001", SerialNumber: "A10KZP4S"}) {
+		t.Fatalf("unexpected match %+v", m)
+	}
+	if m.String() != "usb:0403:6001:A10KZP4S" {
+		t.Fatalf("unexpected string %q", m)
+	}
+	for _, bad := range []string{"/dev/ttyUSB0", "usb:0403", "usb::6001"} {
+		if _, err := serial.ParseMatch(bad); err == nil {
+			t.Fatalf("expected %q to be rejected", bad)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+//go:build linux
+
+package serial
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// PTY is a pseudo-terminal. Programs open Name as if it were a serial port
+// while a simulator reads and writes the other end.
+type PTY struct {
+	*os.File
+	Name string
+	// tty is held open so that reads on the other end do not fail while no
+	// program has the port open.
+	tty *os.File
+}
+
+func ioctl(fd uintptr, req uintptr, arg unsafe.Pointer) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, req, uintptr(arg)); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// OpenPTY creates a pseudo-terminal in raw mode, so bytes pass through it
+// unchanged and nothing is echoed.
+func OpenPTY() (*PTY, error) {
+	f, err := os.OpenFile("/dev/ptmx", os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		return nil, err
+	}
+	var unlock int32
+	if err := ioctl(f.Fd(), syscall.TIOCSPTLCK, unsafe.Pointer(&unlock)); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("unlock pty: %w", err)
+	}
+	var n uint32
+	if err := ioctl(f.Fd(), syscall.TIOCGPTN, unsafe.Pointer(&n)); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("get pty number: %w", err)
+	}
+	name := fmt.Sprintf("/dev/pts/%d", n)
+	tty, err := os.OpenFile(name, os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	var t syscall.Termios
+	if err := ioctl(tty.Fd(), syscall.TCGETS, unsafe.Pointer(&t)); err != nil {
+		_ = f.Close()
+		_ = tty.Close()
+		return nil, err
+	}
+	t.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP | syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	t.Oflag &^= syscall.OPOST
+	t.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	t.Cflag &^= syscall.CSIZE | syscall.PARENB
+	t.Cflag |= syscall.CS8
+	if err := ioctl(tty.Fd(), syscall.TCSETS, unsafe.Pointer(&t)); err != nil {
+		_ = f.Close()
+		_ = tty.Close()
+		return nil, err
+	}
+	return &PTY{File: f, Name: name, tty: tty}, nil
+}
+
+func (p *PTY) Close() error {
+	err := p.File.Close()
+	if tErr := p.tty.Close(); err == nil {
+		err = tErr
+	}
+	return err
+}
+
+// Device is firmware simulated behind a pseudo-terminal.
+type Device interface {
+	// Serve talks to the host over rw until ctx is done.
+	Serve(ctx context.Context, rw io.ReadWriter) error
+}
+
+// Simulate runs dev behind a new pseudo-terminal, which is closed once ctx is
+// done. Open its Name with OpenPort to talk to the device.
+func Simulate(ctx context.Context, dev Device) (*PTY, error) {
+	p, err := OpenPTY()
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		<-ctx.Done()
+		_ = p.Close()
+	}()
+	go func() {
+		_ = dev.Serve(ctx, p)
+	}()
+	return p, nil
+}
+
+// Sim is the port spec that runs a simulator instead of opening hardware.
+const Sim = "sim"
+
+// SimulateSpec returns spec unchanged, or when it is Sim, runs dev as with
+// Simulate and returns the name of its pseudo-terminal in its place.
+func SimulateSpec(ctx context.Context, spec string, dev Device) (string, error) {
+	if spec != Sim {
+		return spec, nil
+	}
+	p, err := Simulate(ctx, dev)
+	if err != nil {
+		return "", err
+	}
+	return p.Name, nil
+}
@@ -0,0 +1,69 @@
+//go:build linux
+
+package serial_test
+
+import (
+	"bufio"
+	"context"
+	"github.com/jt05610/petri/comm/serial"
+	"io"
+	"strings"
+	"testing"
+)
+
+type upper struct{}
+
+func (upper) Serve(ctx context.Context, rw io.ReadWriter) error {
+	scanner := bufio.NewScanner(rw)
+	for scanner.Scan() {
+		if _, err := io.WriteString(rw, strings.ToUpper(scanner.Text())+"\r\n"); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+func TestSimulate(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pty, err := serial.Simulate(ctx, upper{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := serial.OpenPort(pty.Name, 115200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = port.Close()
+	}()
+	tx := make(chan []byte, 1)
+	rx, err := port.ChannelPort(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx <- []byte("ok\n")
+	got, err := io.ReadAll(<-rx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "OK" {
+		t.Fatalf("expected OK, got %q", got)
+	}
+}
+
+func TestSimulateSpec(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	name, err := serial.SimulateSpec(ctx, "/dev/ttyUSB0", upper{})
+	if err != nil || name != "/dev/ttyUSB0" {
+		t.Fatalf("expected a port to be kept, got %q %v", name, err)
+	}
+	name, err = serial.SimulateSpec(ctx, serial.Sim, upper{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(name, "/dev/pts/") {
+		t.Fatalf("expected a pseudo-terminal, got %q", name)
+	}
+}
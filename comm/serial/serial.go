@@ -4,18 +4,78 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"go.bug.st/serial"
 	"io"
+	"strings"
 	"sync"
 	"time"
 )
 
+const (
+	// DefaultMinBackoff and DefaultMaxBackoff bound the wait between attempts
+	// to reopen a lost port.
+	DefaultMinBackoff = 100 * time.Millisecond
+	DefaultMaxBackoff = 5 * time.Second
+)
+
+// ErrDisconnected is returned by reads and writes while the port is lost.
+var ErrDisconnected = errors.New("serial port disconnected")
+
+// State is whether a port is connected to its device.
+type State int
+
+const (
+	Disconnected State = iota
+	Connected
+)
+
+func (s State) String() string {
+	if s == Connected {
+		return "connected"
+	}
+	return "disconnected"
+}
+
+// Event reports that a port was lost or reopened. Err is why it was lost.
+type Event struct {
+	State State
+	Port  string
+	Err   error
+}
+
+// Conn is the part of a Port that drivers use, so they can be tested against
+// fakes.
+type Conn interface {
+	// ChannelPort sends each line read to the returned channel and writes
+	// everything sent on writeCh.
+	ChannelPort(ctx context.Context, writeCh <-chan []byte, split ...byte) (<-chan io.Reader, error)
+	// Events reports when the connection is lost and when it comes back.
+	Events() <-chan Event
+	Close() error
+}
+
+var _ Conn = (*Port)(nil)
+
+// Port is a serial connection that is reopened with backoff when the device
+// goes away, such as when it is unplugged or resets.
 type Port struct {
-	port serial.Port
-	mu   sync.RWMutex
-	rxCh chan io.Reader
-	txCh chan []byte
+	// MinBackoff and MaxBackoff bound the wait between attempts to reopen the
+	// port. The wait doubles after each failed attempt.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	name   string
+	open   func() (io.ReadWriteCloser, error)
+	events chan Event
+
+	mu     sync.RWMutex
+	port   io.ReadWriteCloser
+	closed bool
+	done   chan struct{} // closed by Close
+	rxCh   chan io.Reader
+	txCh   chan []byte
 }
 
 func ListPorts() ([]string, error) {
@@ -26,8 +86,25 @@ func ListPorts() ([]string, error) {
 	return ports, nil
 }
 
-func OpenPort(port string, baud int) (*Port, error) {
+// NewPort opens a port using open, which is called again to reopen it after
+// it is lost. name identifies the port in events.
+func NewPort(name string, open func() (io.ReadWriteCloser, error)) (*Port, error) {
+	p, err := open()
+	if err != nil {
+		return nil, err
+	}
+	return &Port{
+		MinBackoff: DefaultMinBackoff,
+		MaxBackoff: DefaultMaxBackoff,
+		name:       name,
+		open:       open,
+		events:     make(chan Event, 16),
+		port:       p,
+		done:       make(chan struct{}),
+	}, nil
+}
 
+func openPath(port string, baud int) (io.ReadWriteCloser, error) {
 	p, err := serial.Open(port, &serial.Mode{
 		BaudRate: baud,
 		Parity:   serial.NoParity,
@@ -40,20 +117,121 @@ func OpenPort(port string, baud int) (*Port, error) {
 	}
 	err = p.SetReadTimeout(time.Duration(1000) * time.Millisecond)
 	if err != nil {
+		_ = p.Close()
 		return nil, err
 	}
 	err = p.ResetOutputBuffer()
 	if err != nil {
+		_ = p.Close()
 		return nil, err
 	}
-	ret := &Port{port: p}
-	return ret, nil
+	return p, nil
+}
+
+// OpenPort opens the serial port at a fixed path.
+func OpenPort(port string, baud int) (*Port, error) {
+	return NewPort(port, func() (io.ReadWriteCloser, error) {
+		return openPath(port, baud)
+	})
+}
+
+// OpenMatch opens the USB serial device that m selects. The device is looked
+// up again on every reconnect, so it is found wherever it is plugged in.
+func OpenMatch(m Match, baud int) (*Port, error) {
+	return NewPort(m.String(), func() (io.ReadWriteCloser, error) {
+		path, err := m.Find()
+		if err != nil {
+			return nil, err
+		}
+		return openPath(path, baud)
+	})
+}
+
+// Open opens a port given either as a path or as a USB match such as
+// usb:0403:6001:A10KZP4S. See ParseMatch.
+func Open(spec string, baud int) (*Port, error) {
+	if strings.HasPrefix(spec, matchPrefix) {
+		m, err := ParseMatch(spec)
+		if err != nil {
+			return nil, err
+		}
+		return OpenMatch(m, baud)
+	}
+	return OpenPort(spec, baud)
+}
+
+// Events reports when the port is lost and when it is reopened. Events are
+// dropped if nobody keeps up with them.
+func (p *Port) Events() <-chan Event {
+	return p.events
+}
+
+func (p *Port) emit(e Event) {
+	select {
+	case p.events <- e:
+	default:
+	}
+}
+
+func (p *Port) current() io.ReadWriteCloser {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.port
+}
+
+// lost closes conn after it failed with err, unless it was already replaced.
+func (p *Port) lost(conn io.ReadWriteCloser, err error) {
+	p.mu.Lock()
+	if p.port != conn || p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.port = nil
+	p.mu.Unlock()
+	_ = conn.Close()
+	p.emit(Event{State: Disconnected, Port: p.name, Err: err})
+}
+
+// reconnect reopens the port, waiting longer after each failed attempt,
+// until it succeeds, ctx is done or the port is closed.
+func (p *Port) reconnect(ctx context.Context) (io.ReadWriteCloser, error) {
+	wait := p.MinBackoff
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-p.done:
+			return nil, ErrDisconnected
+		case <-time.After(wait):
+		}
+		if wait *= 2; wait > p.MaxBackoff {
+			wait = p.MaxBackoff
+		}
+		conn, err := p.open()
+		if err != nil {
+			continue
+		}
+		p.mu.Lock()
+		if p.closed {
+			p.mu.Unlock()
+			_ = conn.Close()
+			return nil, ErrDisconnected
+		}
+		p.port = conn
+		p.mu.Unlock()
+		p.emit(Event{State: Connected, Port: p.name})
+		return conn, nil
+	}
 }
 
 func (p *Port) Flush() error {
+	conn := p.current()
+	if conn == nil {
+		return ErrDisconnected
+	}
 	buf := make([]byte, 1024)
 	for {
-		n, err := p.port.Read(buf)
+		n, err := conn.Read(buf)
 		if err != nil {
 			return err
 		}
@@ -63,16 +241,34 @@ func (p *Port) Flush() error {
 	}
 	return nil
 }
+
 func (p *Port) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.closed {
+		close(p.done)
+	}
+	p.closed = true
+	if p.port == nil {
+		return nil
+	}
 	return p.port.Close()
 }
 
 func (p *Port) WritePort(data []byte) (int, error) {
-	return p.port.Write(data)
+	conn := p.current()
+	if conn == nil {
+		return 0, ErrDisconnected
+	}
+	return conn.Write(data)
 }
 
 func (p *Port) ReadPort(data []byte) (int, error) {
-	return p.port.Read(data)
+	conn := p.current()
+	if conn == nil {
+		return 0, ErrDisconnected
+	}
+	return conn.Read(data)
 }
 
 func equalToOneOf(b byte, bb []byte) bool {
@@ -97,23 +293,55 @@ func MultiSplit(split []byte) bufio.SplitFunc {
 	}
 }
 
+// ChannelPort reads lines and writes until ctx is done or the port is closed,
+// reopening the port whenever it is lost. Data sent while the port is lost is
+// dropped and reported as another Disconnected event, so whatever was waiting
+// on it can be failed even if it was sent after the port was lost.
 func (p *Port) ChannelPort(ctx context.Context, writeCh <-chan []byte, split ...byte) (<-chan io.Reader, error) {
 	p.rxCh = make(chan io.Reader, 100) // Buffer size can be adjusted as per your requirement
 	go func() {
-		scanner := bufio.NewScanner(p.port)
-		if len(split) > 0 {
-			scanner.Split(MultiSplit(split))
-		}
+		conn := p.current()
 		for {
-			select {
-			case <-ctx.Done():
-				fmt.Println("closing listen port ")
-				return
-			default:
-				if scanner.Scan() {
-					p.rxCh <- bytes.NewBuffer(scanner.Bytes())
+			if conn == nil {
+				var err error
+				if conn, err = p.reconnect(ctx); err != nil {
+					return
+				}
+			}
+			scanner := bufio.NewScanner(conn)
+			if len(split) > 0 {
+				scanner.Split(MultiSplit(split))
+			}
+			for scanner.Scan() {
+				select {
+				// the scanner reuses its buffer for the next line
+				case p.rxCh <- bytes.NewBuffer(bytes.Clone(scanner.Bytes())):
+				case <-ctx.Done():
+					return
+				case <-p.done:
+					return
 				}
 			}
+			err := scanner.Err()
+			// reads time out while the device is quiet, which the scanner
+			// gives up on after a while
+			if errors.Is(err, io.ErrNoProgress) {
+				continue
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			if err == nil {
+				err = io.EOF
+			}
+			p.lost(conn, err)
+			p.mu.RLock()
+			closed := p.closed
+			p.mu.RUnlock()
+			if closed {
+				return
+			}
+			conn = nil
 		}
 	}()
 
@@ -122,10 +350,21 @@ func (p *Port) ChannelPort(ctx context.Context, writeCh <-chan []byte, split ...
 			select {
 			case <-ctx.Done():
 				return
+			case <-p.done:
+				return
 			case data := <-writeCh:
-				if _, err := p.port.Write(data); err != nil {
-					// handle the error
-					return
+				conn := p.current()
+				if conn == nil {
+					p.emit(Event{
+						State: Disconnected,
+						Port:  p.name,
+						Err:   fmt.Errorf("dropped %q: %w", data, ErrDisconnected),
+					})
+					continue
+				}
+				if _, err := conn.Write(data); err != nil {
+					// closing the port makes the reader reopen it
+					p.lost(conn, err)
 				}
 			}
 		}
@@ -0,0 +1,77 @@
+package serial
+
+import (
+	"errors"
+	"fmt"
+	"go.bug.st/serial/enumerator"
+	"strings"
+)
+
+const matchPrefix = "usb:"
+
+// ErrNotFound is returned when no connected device matches.
+var ErrNotFound = errors.New("no matching serial device")
+
+// Match selects a USB serial device by its vendor and product IDs, and by its
+// serial number when several identical devices are plugged in. Unlike
+// /dev/ttyUSBn, these stay the same wherever and in whatever order devices
+// are plugged in.
+type Match struct {
+	VID          string
+	PID          string
+	SerialNumber string
+}
+
+// ParseMatch parses usb:VID:PID or usb:VID:PID:SERIAL, with the IDs in hex
+// as lsusb prints them.
+func ParseMatch(s string) (Match, error) {
+	rest, found := strings.CutPrefix(s, matchPrefix)
+	parts := strings.SplitN(rest, ":", 3)
+	if !found || len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return Match{}, fmt.Errorf("invalid usb device %q, expected usb:VID:PID[:SERIAL]", s)
+	}
+	m := Match{VID: parts[0], PID: parts[1]}
+	if len(parts) == 3 {
+		m.SerialNumber = parts[2]
+	}
+	return m, nil
+}
+
+func (m Match) String() string {
+	ret := matchPrefix + m.VID + ":" + m.PID
+	if m.SerialNumber != "" {
+		ret += ":" + m.SerialNumber
+	}
+	return ret
+}
+
+// Matches reports whether a port is the device m selects.
+func (m Match) Matches(d *enumerator.PortDetails) bool {
+	if !d.IsUSB || !strings.EqualFold(d.VID, m.VID) || !strings.EqualFold(d.PID, m.PID) {
+		return false
+	}
+	return m.SerialNumber == "" || d.SerialNumber == m.SerialNumber
+}
+
+// Find returns the path of the device m selects. It fails if several devices
+// match, since it could not tell which one is meant.
+func (m Match) Find() (string, error) {
+	ports, err := enumerator.GetDetailedPortsList()
+	if err != nil {
+		return "", err
+	}
+	var found []string
+	for _, d := range ports {
+		if m.Matches(d) {
+			found = append(found, d.Name)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return "", fmt.Errorf("%w: %s", ErrNotFound, m)
+	case 1:
+		return found[0], nil
+	default:
+		return "", fmt.Errorf("%s matches %s, add a serial number", m, strings.Join(found, ", "))
+	}
+}
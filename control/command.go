@@ -9,6 +9,11 @@ type Command struct {
 	*labeled.Event
 	Topic string
 	To    string
+	// CorrelationID is copied onto the event that answers the command.
+	CorrelationID string
+	// ReplyTo names the queue the answer should be sent to. When empty the
+	// answer is only published on the exchange.
+	ReplyTo string
 }
 
 func (c *Command) snakeCaseName() string {
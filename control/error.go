@@ -0,0 +1,31 @@
+package control
+
+import "fmt"
+
+// ErrorKind says why a device could not carry out a command.
+type ErrorKind string
+
+const (
+	// MalformedCommand means the command could not be decoded. Malformed
+	// commands are dead-lettered rather than retried.
+	MalformedCommand ErrorKind = "malformed"
+	// UnknownCommand means the device has no handler for the command.
+	UnknownCommand ErrorKind = "unknown_command"
+	// HandlerFailed means the handler for the command returned an error.
+	HandlerFailed ErrorKind = "handler"
+	// Unconfirmed means the device restarted and is waiting for an operator
+	// to confirm its state before it runs commands.
+	Unconfirmed ErrorKind = "unconfirmed"
+)
+
+// Error is sent back to the controller in place of the event a command would
+// have produced.
+type Error struct {
+	Kind    ErrorKind
+	Command string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s: %s", e.Kind, e.Command, e.Message)
+}
@@ -25,6 +25,10 @@ type Event struct {
 	Topic   string
 	From    string
 	Marking Marking
+	// Error is set when the event reports a failed command.
+	Error *Error
+	// CorrelationID is the correlation ID of the command this event answers.
+	CorrelationID string
 }
 
 func (e *Event) snakeCaseName() string {
@@ -32,5 +36,8 @@ func (e *Event) snakeCaseName() string {
 }
 
 func (e *Event) RoutingKey() string {
+	if e.Error != nil {
+		return e.From + ".errors." + e.snakeCaseName()
+	}
 	return e.From + ".events." + e.snakeCaseName()
 }
@@ -0,0 +1,155 @@
+package control
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jt05610/petri"
+	"github.com/jt05610/petri/labeled"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var ErrIncompatible = errors.New("incompatible instance")
+
+// Capability is an event an instance handles and the fields it expects.
+type Capability struct {
+	ID     string           `json:"id"`
+	Name   string           `json:"name"`
+	Fields []*labeled.Field `json:"fields,omitempty"`
+}
+
+// Manifest describes what a device instance implements. Servers send it with
+// their beacons so the controller only binds steps to instances that can run
+// them.
+type Manifest struct {
+	// NetHash identifies the structure of the net the instance runs.
+	NetHash string `json:"net_hash"`
+	// NetVersion is the ID of the stored net version the instance's net was
+	// built from, if it was built from one.
+	NetVersion string `json:"net_version,omitempty"`
+	// Version is the instance's software version.
+	Version string        `json:"version,omitempty"`
+	Events  []*Capability `json:"events"`
+}
+
+func snakeCase(s string) string {
+	return strings.ReplaceAll(strings.ToLower(s), " ", "_")
+}
+
+func nodeName(n petri.Node) string {
+	switch n := n.(type) {
+	case *petri.Place:
+		return "p:" + n.Name
+	case *petri.Transition:
+		return "t:" + n.Name
+	}
+	return n.Identifier()
+}
+
+// NetHash hashes the places, transitions and arcs of a net by name, so two
+// copies of the same net hash alike whatever their IDs.
+func NetHash(n *petri.Net) string {
+	lines := make([]string, 0, len(n.Places)+len(n.Transitions)+len(n.Arcs))
+	for _, p := range n.Places {
+		lines = append(lines, "p:"+p.Name+":"+strconv.Itoa(p.Bound))
+	}
+	for _, t := range n.Transitions {
+		lines = append(lines, "t:"+t.Name)
+	}
+	for _, a := range n.Arcs {
+		lines = append(lines, "a:"+nodeName(a.Src)+"->"+nodeName(a.Dest))
+	}
+	sort.Strings(lines)
+	h := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	return hex.EncodeToString(h[:])
+}
+
+// NewManifest describes the events of net that have handlers.
+func NewManifest(net *labeled.Net, version string) *Manifest {
+	m := &Manifest{
+		NetHash: NetHash(net.Net.Net),
+		Version: version,
+		Events:  make([]*Capability, 0, len(net.EventMap)),
+	}
+	for _, ev := range net.Events {
+		name := snakeCase(ev.Name)
+		if _, handled := net.EventMap[name]; !handled || m.capability(name) != nil {
+			continue
+		}
+		m.Events = append(m.Events, &Capability{
+			ID:     ev.ID,
+			Name:   name,
+			Fields: ev.Fields,
+		})
+	}
+	return m
+}
+
+// ManifestFrom reads the manifest from beacon data.
+func ManifestFrom(data map[string]interface{}) (*Manifest, error) {
+	raw, found := data["manifest"]
+	if !found {
+		return nil, nil
+	}
+	bytes, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	m := new(Manifest)
+	return m, json.Unmarshal(bytes, m)
+}
+
+func (m *Manifest) capability(name string) *Capability {
+	for _, c := range m.Events {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// Supports checks that the instance handles ev and expects the same fields
+// with the same types.
+func (m *Manifest) Supports(ev *labeled.Event) error {
+	c := m.capability(snakeCase(ev.Name))
+	if c == nil {
+		return fmt.Errorf("%w: %s is not handled", ErrIncompatible, ev.Name)
+	}
+	if ev.ID != "" && c.ID != "" && ev.ID != c.ID {
+		return fmt.Errorf("%w: %s has id %s, expected %s", ErrIncompatible, ev.Name, c.ID, ev.ID)
+	}
+	for _, f := range ev.Fields {
+		var found *labeled.Field
+		for _, cf := range c.Fields {
+			if cf.Name == f.Name {
+				found = cf
+				break
+			}
+		}
+		if found == nil {
+			return fmt.Errorf("%w: %s does not take %s", ErrIncompatible, ev.Name, f.Name)
+		}
+		if found.Type != f.Type {
+			return fmt.Errorf("%w: %s takes %s as a %s, expected a %s", ErrIncompatible, ev.Name, f.Name, found.Type, f.Type)
+		}
+	}
+	return nil
+}
+
+// Runs checks that the instance runs one of nets, when the controller knows
+// which nets the device should have.
+func (m *Manifest) Runs(nets ...*labeled.Net) error {
+	if len(nets) == 0 {
+		return nil
+	}
+	for _, n := range nets {
+		if n != nil && n.Net != nil && NetHash(n.Net.Net) == m.NetHash {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: net %s is not one of the device's nets", ErrIncompatible, m.NetHash)
+}
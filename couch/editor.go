@@ -0,0 +1,308 @@
+package couch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jt05610/petri"
+)
+
+var _ petri.NetEditor = (*NetEditor)(nil)
+var _ petri.NetVersioner = (*NetEditor)(nil)
+
+// NetEditor applies petri.NetEdits across the places, transitions, arcs and
+// nets databases, undoing every write it made if any of them fails. Every
+// successful edit is recorded as a new version of the net.
+type NetEditor struct {
+	Places      *Service[*petri.Place, *petri.PlaceInput, *petri.PlaceFilter, *petri.PlaceUpdate]
+	Transitions *Service[*petri.Transition, *petri.TransitionInput, *petri.TransitionFilter, *petri.TransitionUpdate]
+	Arcs        *Service[*petri.Arc, *petri.ArcInput, *petri.ArcFilter, *petri.ArcUpdate]
+	Nets        *Service[*petri.Net, *petri.NetInput, *petri.NetFilter, *petri.NetUpdate]
+	Versions    *Service[*petri.NetVersion, *petri.NetVersionInput, *petri.NetVersionFilter, *petri.NetVersionUpdate]
+}
+
+func ids[T petri.Object](objs []T) []string {
+	ret := make([]string, len(objs))
+	for i, o := range objs {
+		ret[i] = o.Identifier()
+	}
+	return ret
+}
+
+// Load returns the net with its places, transitions and arcs filled in from
+// their own databases.
+func (e *NetEditor) Load(ctx context.Context, netID string) (*petri.Net, error) {
+	n, err := e.Nets.Get(ctx, netID)
+	if err != nil {
+		return nil, err
+	}
+	places, err := e.Places.List(ctx, &petri.PlaceFilter{ID: &petri.StringSelector{In: ids(n.Places)}})
+	if err != nil {
+		return nil, err
+	}
+	transitions, err := e.Transitions.List(ctx, &petri.TransitionFilter{ID: &petri.StringSelector{In: ids(n.Transitions)}})
+	if err != nil {
+		return nil, err
+	}
+	arcs, err := e.Arcs.List(ctx, &petri.ArcFilter{ID: &petri.StringSelector{In: ids(n.Arcs)}})
+	if err != nil {
+		return nil, err
+	}
+	ret := petri.LoadNet(places, transitions, arcs)
+	ret.ID = n.ID
+	ret.Name = n.Name
+	ret.TokenSchemas = n.TokenSchemas
+	ret.Nets = n.Nets
+	return ret, nil
+}
+
+func (e *NetEditor) add(ctx context.Context, tx *Tx, o petri.Object) error {
+	var err error
+	switch obj := o.(type) {
+	case *petri.Place:
+		_, err = TxInsert(ctx, tx, e.Places, obj)
+	case *petri.Transition:
+		_, err = TxInsert(ctx, tx, e.Transitions, obj)
+	case *petri.Arc:
+		_, err = TxInsert(ctx, tx, e.Arcs, obj)
+	default:
+		err = fmt.Errorf("cannot add %T", o)
+	}
+	return err
+}
+
+func (e *NetEditor) remove(ctx context.Context, tx *Tx, o petri.Object) error {
+	var err error
+	switch o.(type) {
+	case *petri.Place:
+		_, err = TxRemove(ctx, tx, e.Places, o.Identifier())
+	case *petri.Transition:
+		_, err = TxRemove(ctx, tx, e.Transitions, o.Identifier())
+	case *petri.Arc:
+		_, err = TxRemove(ctx, tx, e.Arcs, o.Identifier())
+	default:
+		err = fmt.Errorf("cannot remove %T", o)
+	}
+	return err
+}
+
+// ApplyNetEdit validates the edited net before writing anything, then stores
+// new nodes before the arcs that use them and removes arcs before their nodes.
+// If any write fails the ones before it are undone.
+func (e *NetEditor) ApplyNetEdit(ctx context.Context, netID string, edit *petri.NetEdit) (*petri.Net, error) {
+	n, err := e.Load(ctx, netID)
+	if err != nil {
+		return nil, err
+	}
+	res, err := petri.ApplyEdit(n, edit)
+	if err != nil {
+		return nil, err
+	}
+	tx := Begin()
+	apply := func() error {
+		for _, o := range res.Added {
+			if o.Kind() == petri.ArcObject {
+				continue
+			}
+			if err := e.add(ctx, tx, o); err != nil {
+				return err
+			}
+		}
+		for _, o := range res.Added {
+			if o.Kind() != petri.ArcObject {
+				continue
+			}
+			if err := e.add(ctx, tx, o); err != nil {
+				return err
+			}
+		}
+		for _, o := range res.Removed {
+			if o.Kind() != petri.ArcObject {
+				continue
+			}
+			if err := e.remove(ctx, tx, o); err != nil {
+				return err
+			}
+		}
+		for _, o := range res.Removed {
+			if o.Kind() == petri.ArcObject {
+				continue
+			}
+			if err := e.remove(ctx, tx, o); err != nil {
+				return err
+			}
+		}
+		if err := e.updateNet(ctx, tx, res.Net); err != nil {
+			return err
+		}
+		_, err := e.commit(ctx, tx, res.Net, "")
+		return err
+	}
+	if err := apply(); err != nil {
+		if rbErr := tx.Rollback(ctx); rbErr != nil {
+			return nil, errors.Join(err, fmt.Errorf("rollback: %w", rbErr))
+		}
+		return nil, err
+	}
+	tx.Commit()
+	return res.Net, nil
+}
+
+func (e *NetEditor) updateNet(ctx context.Context, tx *Tx, n *petri.Net) error {
+	_, err := TxUpdate(ctx, tx, e.Nets, n.ID, &petri.NetUpdate{
+		Input: &petri.NetInput{
+			Places:      n.Places,
+			Transitions: n.Transitions,
+			Arcs:        n.Arcs,
+		},
+		Mask: &petri.NetMask{
+			Places:      true,
+			Transitions: true,
+			Arcs:        true,
+		},
+	})
+	return err
+}
+
+func (e *NetEditor) head(ctx context.Context, netID string) (*petri.NetVersion, error) {
+	history, err := e.History(ctx, netID)
+	if err != nil {
+		return nil, err
+	}
+	if len(history) == 0 {
+		return nil, nil
+	}
+	return history[len(history)-1], nil
+}
+
+func (e *NetEditor) commit(ctx context.Context, tx *Tx, n *petri.Net, message string) (*petri.NetVersion, error) {
+	parent, err := e.head(ctx, n.ID)
+	if err != nil {
+		return nil, err
+	}
+	v := (&petri.NetVersionInput{
+		Net:     n,
+		Parent:  parent,
+		Message: message,
+	}).Object().(*petri.NetVersion)
+	return TxInsert(ctx, tx, e.Versions, v)
+}
+
+// Commit records the stored state of the net as a new version.
+func (e *NetEditor) Commit(ctx context.Context, netID string, message string) (*petri.NetVersion, error) {
+	n, err := e.Load(ctx, netID)
+	if err != nil {
+		return nil, err
+	}
+	return e.commit(ctx, Begin(), n, message)
+}
+
+func (e *NetEditor) History(ctx context.Context, netID string) ([]*petri.NetVersion, error) {
+	history, err := e.Versions.List(ctx, &petri.NetVersionFilter{NetID: &petri.StringSelector{Equals: netID}})
+	if err != nil {
+		return nil, err
+	}
+	petri.SortVersions(history)
+	return history, nil
+}
+
+func (e *NetEditor) Version(ctx context.Context, id string) (*petri.NetVersion, error) {
+	return e.Versions.Get(ctx, id)
+}
+
+func (e *NetEditor) restore(ctx context.Context, tx *Tx, o petri.Object) error {
+	var err error
+	switch obj := o.(type) {
+	case *petri.Place:
+		err = TxPut(ctx, tx, e.Places, obj)
+	case *petri.Transition:
+		err = TxPut(ctx, tx, e.Transitions, obj)
+	case *petri.Arc:
+		err = TxPut(ctx, tx, e.Arcs, obj)
+	default:
+		err = fmt.Errorf("cannot restore %T", o)
+	}
+	return err
+}
+
+// Revert puts back the places, transitions and arcs of the version, removes
+// the ones that were added after it, and records the result as a new version
+// whose parent is the current head.
+func (e *NetEditor) Revert(ctx context.Context, netID string, versionID string) (*petri.Net, error) {
+	v, err := e.Version(ctx, versionID)
+	if err != nil {
+		return nil, err
+	}
+	if v.NetID != netID {
+		return nil, fmt.Errorf("version %s does not belong to net %s", versionID, netID)
+	}
+	current, err := e.Load(ctx, netID)
+	if err != nil {
+		return nil, err
+	}
+	target := v.Net()
+	diff := petri.Diff(current, target)
+	objects := make(map[string]petri.Object)
+	for _, p := range target.Places {
+		objects[p.ID] = p
+	}
+	for _, t := range target.Transitions {
+		objects[t.ID] = t
+	}
+	for _, a := range target.Arcs {
+		objects[a.ID] = a
+	}
+	for _, p := range current.Places {
+		if _, ok := objects[p.ID]; !ok {
+			objects[p.ID] = p
+		}
+	}
+	for _, t := range current.Transitions {
+		if _, ok := objects[t.ID]; !ok {
+			objects[t.ID] = t
+		}
+	}
+	for _, a := range current.Arcs {
+		if _, ok := objects[a.ID]; !ok {
+			objects[a.ID] = a
+		}
+	}
+	tx := Begin()
+	apply := func() error {
+		// nodes first so restored arcs never point at missing nodes, and
+		// arcs are dropped before the nodes they join
+		for _, changes := range [][]*petri.Change{diff.Places, diff.Transitions, diff.Arcs} {
+			for _, c := range changes {
+				if c.Type == petri.Removed {
+					continue
+				}
+				if err := e.restore(ctx, tx, objects[c.ID]); err != nil {
+					return err
+				}
+			}
+		}
+		for _, changes := range [][]*petri.Change{diff.Arcs, diff.Transitions, diff.Places} {
+			for _, c := range changes {
+				if c.Type != petri.Removed {
+					continue
+				}
+				if err := e.remove(ctx, tx, objects[c.ID]); err != nil {
+					return err
+				}
+			}
+		}
+		if err := e.updateNet(ctx, tx, target); err != nil {
+			return err
+		}
+		_, err := e.commit(ctx, tx, target, fmt.Sprintf("revert to version %d", v.Version))
+		return err
+	}
+	if err := apply(); err != nil {
+		if rbErr := tx.Rollback(ctx); rbErr != nil {
+			return nil, errors.Join(err, fmt.Errorf("rollback: %w", rbErr))
+		}
+		return nil, err
+	}
+	tx.Commit()
+	return target, nil
+}
@@ -234,3 +234,11 @@ func EventService(uri string) *Service[*petri.EventSchema, *petri.EventInput, *p
 	}
 	return s
 }
+
+func VersionService(uri string) *Service[*petri.NetVersion, *petri.NetVersionInput, *petri.NetVersionFilter, *petri.NetVersionUpdate] {
+	s, err := Open[*petri.NetVersion, *petri.NetVersionInput, *petri.NetVersionFilter, *petri.NetVersionUpdate](uri, "netVersions")
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
@@ -0,0 +1,142 @@
+package couch
+
+import (
+	"context"
+	"errors"
+	"github.com/go-kivik/kivik/v3"
+	"github.com/jt05610/petri"
+	"net/http"
+)
+
+// Tx records a compensating write for every change made through it so that a
+// group of writes spread over several databases can be undone if a later write
+// fails. CouchDB has no multi-document transactions, so this is the best we can
+// do.
+type Tx struct {
+	undo []func(ctx context.Context) error
+}
+
+func Begin() *Tx {
+	return &Tx{
+		undo: make([]func(ctx context.Context) error, 0),
+	}
+}
+
+// Rollback runs the compensating writes in the reverse order of the changes
+// they undo.
+func (tx *Tx) Rollback(ctx context.Context) error {
+	var errs []error
+	for i := len(tx.undo) - 1; i >= 0; i-- {
+		if err := tx.undo[i](ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	tx.undo = tx.undo[:0]
+	return errors.Join(errs...)
+}
+
+// Commit forgets the compensating writes.
+func (tx *Tx) Commit() {
+	tx.undo = tx.undo[:0]
+}
+
+func (s *Service[T, U, V, W]) put(ctx context.Context, id string, doc petri.Document) error {
+	if rev, ok := s.revMap[id]; ok {
+		doc["_rev"] = rev
+	}
+	rev, err := s.db.Put(ctx, id, doc)
+	if err != nil {
+		return err
+	}
+	s.revMap[id] = rev
+	return nil
+}
+
+// Insert stores an object that was built outside the service, such as one
+// created while applying a petri.NetEdit.
+func (s *Service[T, U, V, W]) Insert(ctx context.Context, o T) (T, error) {
+	var zero T
+	doc := o.Document()
+	doc["_id"] = o.Identifier()
+	rev, err := s.db.Put(ctx, o.Identifier(), doc)
+	if err != nil {
+		return zero, err
+	}
+	s.revMap[o.Identifier()] = rev
+	return o, nil
+}
+
+// TxInsert inserts o and records its removal on tx.
+func TxInsert[T petri.Object, U petri.Input, V petri.Filter, W petri.Update](ctx context.Context, tx *Tx, s *Service[T, U, V, W], o T) (T, error) {
+	ret, err := s.Insert(ctx, o)
+	if err != nil {
+		return ret, err
+	}
+	id := ret.Identifier()
+	tx.undo = append(tx.undo, func(ctx context.Context) error {
+		_, err := s.Remove(ctx, id)
+		return err
+	})
+	return ret, nil
+}
+
+// TxRemove removes the object with the given id and records its restoration on
+// tx.
+func TxRemove[T petri.Object, U petri.Input, V petri.Filter, W petri.Update](ctx context.Context, tx *Tx, s *Service[T, U, V, W], id string) (T, error) {
+	ret, err := s.Remove(ctx, id)
+	if err != nil {
+		return ret, err
+	}
+	doc := ret.Document()
+	tx.undo = append(tx.undo, func(ctx context.Context) error {
+		delete(s.revMap, id)
+		return s.put(ctx, id, doc)
+	})
+	return ret, nil
+}
+
+// TxUpdate updates the object with the given id and records the write that
+// puts back its previous contents on tx.
+func TxUpdate[T petri.Object, U petri.Input, V petri.Filter, W petri.Update](ctx context.Context, tx *Tx, s *Service[T, U, V, W], id string, update W) (T, error) {
+	old, err := s.Get(ctx, id)
+	if err != nil {
+		return old, err
+	}
+	doc := old.Document()
+	ret, err := s.Update(ctx, id, update)
+	if err != nil {
+		return ret, err
+	}
+	tx.undo = append(tx.undo, func(ctx context.Context) error {
+		return s.put(ctx, id, doc)
+	})
+	return ret, nil
+}
+
+// TxPut writes o over whatever is stored under its ID, creating it if it does
+// not exist, and records the write that puts back the previous contents on tx.
+func TxPut[T petri.Object, U petri.Input, V petri.Filter, W petri.Update](ctx context.Context, tx *Tx, s *Service[T, U, V, W], o T) error {
+	id := o.Identifier()
+	old, err := s.Get(ctx, id)
+	if err != nil {
+		if kivik.StatusCode(err) != http.StatusNotFound {
+			return err
+		}
+		if err := s.put(ctx, id, o.Document()); err != nil {
+			return err
+		}
+		tx.undo = append(tx.undo, func(ctx context.Context) error {
+			_, err := s.Remove(ctx, id)
+			return err
+		})
+		return nil
+	}
+	doc := old.Document()
+	if err := s.put(ctx, id, o.Document()); err != nil {
+		return err
+	}
+	tx.undo = append(tx.undo, func(ctx context.Context) error {
+		return s.put(ctx, id, doc)
+	})
+	return nil
+}
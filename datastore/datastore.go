@@ -1,6 +1,17 @@
 package datastore
 
-import "context"
+import (
+	"context"
+	"errors"
+	"github.com/jt05610/petri"
+	"time"
+)
+
+var (
+	ErrNotFound    = errors.New("not found")
+	ErrExists      = errors.New("already exists")
+	ErrInvalidRole = errors.New("invalid role")
+)
 
 type Initializer interface {
 	CreateDb(ctx context.Context, name string) error
@@ -13,6 +24,10 @@ const (
 	UserRole  Role = "user"
 )
 
+func (r Role) Valid() bool {
+	return r == AdminRole || r == UserRole
+}
+
 type User interface {
 	CreateUser(ctx context.Context, name, password string, role Role) error
 	DeleteUser(ctx context.Context, name string) error
@@ -20,15 +35,140 @@ type User interface {
 
 type Administrator interface {
 	DeleteDb(ctx context.Context, name string) error
-	AddUserToDb(ctx context.Context, db string, user User) error
+	AddUserToDb(ctx context.Context, db, user string, role Role) error
 	RemoveUserFromDb(ctx context.Context, db, user string) error
-	ChangeUserDbRole(ctx context.Context, db, user, role string) error
+	ChangeUserDbRole(ctx context.Context, db, user string, role Role) error
 }
 
 type Loader interface {
 	Load(ctx context.Context, name string) (DataStore, error)
 }
 
+type RunState string
+
+const (
+	RunRunning   RunState = "running"
+	RunCompleted RunState = "completed"
+	RunFailed    RunState = "failed"
+	RunAborted   RunState = "aborted"
+)
+
+// Run is one execution of a sequence within a session. It records the net and
+// net version the sequence ran against so results can be traced back to the
+// protocol that produced them.
+type Run struct {
+	ID           string
+	SessionID    string
+	SequenceID   string
+	NetID        string
+	NetVersionID string
+	State        RunState
+	StartedAt    time.Time
+	EndedAt      time.Time
+}
+
+// StepEvent is an event received from a device while a run was on a given
+// step.
+type StepEvent struct {
+	RunID      string
+	SessionID  string
+	Step       int
+	DeviceID   string
+	InstanceID string
+	Event      string
+	Data       map[string]interface{}
+	Marking    map[string]int
+	Timestamp  time.Time
+}
+
+// Telemetry is a measurement a device reported outside the normal event flow,
+// such as a temperature or a position.
+type Telemetry struct {
+	SessionID  string
+	DeviceID   string
+	InstanceID string
+	Name       string
+	Value      map[string]interface{}
+	Timestamp  time.Time
+}
+
+// Query selects events or telemetry. Empty fields match everything; a zero
+// From or To leaves that end of the time range open.
+type Query struct {
+	SessionID string
+	RunID     string
+	DeviceID  string
+	From      time.Time
+	To        time.Time
+	Limit     int
+}
+
 // DataStore is an interface for storing event data and device configuration data.
 type DataStore interface {
+	AddRun(ctx context.Context, run *Run) error
+	FinishRun(ctx context.Context, id string, state RunState, at time.Time) error
+	Run(ctx context.Context, id string) (*Run, error)
+	Runs(ctx context.Context, sessionID string) ([]*Run, error)
+	AppendEvents(ctx context.Context, events ...*StepEvent) error
+	Events(ctx context.Context, q *Query) ([]*StepEvent, error)
+	AppendTelemetry(ctx context.Context, telemetry ...*Telemetry) error
+	Telemetry(ctx context.Context, q *Query) ([]*Telemetry, error)
+	Close() error
+}
+
+// Outstanding is a command that was sent but had not been answered when a
+// checkpoint was taken.
+type Outstanding struct {
+	DeviceID      string
+	InstanceID    string
+	Event         string
+	CorrelationID string
+	SentAt        time.Time
+}
+
+// Checkpoint is the state of a session, saved after every transition so a
+// session interrupted by a controller restart can be resumed or aborted.
+type Checkpoint struct {
+	SessionID  string
+	SequenceID string
+	// NetVersionID is the version of the net the session was started with.
+	NetVersionID string
+	// RunID is the run the session's events were stored under.
+	RunID string
+	State string
+	// Step is the index of the step in flight, or of the next step to send.
+	Step        int
+	Outstanding *Outstanding
+	Parameters  map[string]interface{}
+	// Routes maps each device to the instance its steps were sent to.
+	Routes map[string]string
+	// Markings are the device markings last reported to the controller.
+	Markings  map[string]map[string]int
+	Events    []*StepEvent
+	UpdatedAt time.Time
+}
+
+// Journal keeps the latest checkpoint of each session.
+type Journal interface {
+	SaveCheckpoint(ctx context.Context, cp *Checkpoint) error
+	Checkpoint(ctx context.Context, sessionID string) (*Checkpoint, error)
+	// Interrupted lists the checkpoints of sessions that were running, paused
+	// or stopping, oldest first.
+	Interrupted(ctx context.Context) ([]*Checkpoint, error)
+}
+
+// NetVersion is a version of a net together with the marking runs on it start
+// from.
+type NetVersion struct {
+	*petri.NetVersion
+	Marking map[string]int
+}
+
+// NetVersions keeps the versions of nets that sessions were started with, so
+// a session resumed after the net was edited still runs on its own version.
+type NetVersions interface {
+	AddNetVersion(ctx context.Context, v *NetVersion) error
+	NetVersion(ctx context.Context, id string) (*NetVersion, error)
+	// LatestNetVersion returns ErrNotFound if the net has no versions.
+	LatestNetVersion(ctx context.Context, netID string) (*NetVersion, error)
 }
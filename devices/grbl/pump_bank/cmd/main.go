@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"github.com/joho/godotenv"
 	"github.com/jt05610/petri/amqp"
+	"github.com/jt05610/petri/comm/grbl"
 	proto "github.com/jt05610/petri/comm/grbl/proto/v1"
 	"github.com/jt05610/petri/comm/grbl/server"
+	"github.com/jt05610/petri/comm/grbl/sim"
 	"github.com/jt05610/petri/comm/serial"
 	"github.com/jt05610/petri/devices/grbl/pump_bank"
 	"github.com/jt05610/petri/env"
@@ -82,7 +84,12 @@ func main() {
 		logger.Fatal("Failed to load .env", zap.Error(err))
 	}
 	environ := load()
-	port, err := serial.OpenPort(environ.SerialPort, environ.Baud)
+	// a SERIAL_PORT of sim runs against a simulated controller instead of hardware
+	environ.SerialPort, err = serial.SimulateSpec(context.Background(), environ.SerialPort, sim.New())
+	if err != nil {
+		logger.Fatal("Failed to start simulator", zap.Error(err))
+	}
+	port, err := serial.Open(environ.SerialPort, environ.Baud)
 	if err != nil {
 		logger.Fatal("Failed to open port", zap.Error(err))
 	}
@@ -97,6 +104,15 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	go s.RunHeartbeat(ctx)
+	if path, found := os.LookupEnv("SETTINGS_PROFILE"); found {
+		profile, err := grbl.LoadProfileFile(path)
+		if err != nil {
+			logger.Fatal("Failed to load settings profile", zap.Error(err))
+		}
+		if _, err := s.Configure(ctx, profile); err != nil {
+			logger.Fatal("Failed to apply settings profile", zap.Error(err))
+		}
+	}
 
 	_, err = s.SpindleOff(ctx, &proto.SpindleOffRequest{})
 	if err != nil {
@@ -118,11 +134,11 @@ func main() {
 	}
 
 	_, err = s.Home(ctx, &proto.HomeRequest{})
-	s.TxChan <- []byte("G55\n")
 	if err != nil {
 		logger.Fatal("Failed to home", zap.Error(err))
 	}
-	for !s.Cts.Load() {
+	if err := s.Send(ctx, []byte("G55\n")); err != nil {
+		logger.Fatal("Failed to select work coordinates", zap.Error(err))
 	}
 	// lis, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", environ.Port))
 	if err != nil {
@@ -138,7 +154,7 @@ func main() {
 	//	logger.Fatal("Failed to serve grpc", zap.Error(err))
 	//	}
 	//}()
-	conn, err := amqp.Dial(amqpEnv())
+	link, err := amqp.Dial(amqpEnv(), logger)
 	if err != nil {
 		logger.Fatal("Failed to dial amqp", zap.Error(err))
 	}
@@ -149,12 +165,12 @@ func main() {
 		cancel()
 	}()
 	defer func() {
-		err := conn.Close()
+		err := link.Close()
 		if err != nil {
 			logger.Error("Failed to close amqp connection", zap.Error(err))
 		}
 	}()
 
-	go pump_bank.Run(ctx, conn, s)
+	go pump_bank.Run(ctx, link, s)
 	<-ctx.Done()
 }
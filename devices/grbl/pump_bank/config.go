@@ -0,0 +1,10 @@
+package pump_bank
+
+import "github.com/jt05610/petri/env"
+
+var environ = env.Environment{
+	Exchange:   "topic_devices",
+	DeviceID:   "clo35zqxg0001jgwoh4b8sm1a",
+	InstanceID: "pump_bank",
+	RPCAddress: "localhost:55055",
+}
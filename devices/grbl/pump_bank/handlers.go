@@ -0,0 +1,116 @@
+package pump_bank
+
+import (
+	"context"
+	"fmt"
+	proto "github.com/jt05610/petri/comm/grbl/proto/v1"
+	"go.uber.org/zap"
+	"math"
+)
+
+// Initialize checks the pumps' settings. The plungers are taken to be where
+// the settings say, which is 0 after homing.
+func (d *PumpBank) Initialize(_ context.Context, bank *BankSettings) error {
+	if bank == nil {
+		return fmt.Errorf("missing pump settings")
+	}
+	if err := bank.Aqueous.validate(); err != nil {
+		return fmt.Errorf("aqueous: %w", err)
+	}
+	if err := bank.Organic.validate(); err != nil {
+		return fmt.Errorf("organic: %w", err)
+	}
+	d.mu.Lock()
+	d.bank = bank
+	d.mu.Unlock()
+	return nil
+}
+
+// makeRequest is the move that pumps req, with both plungers reaching their
+// targets together.
+func (d *PumpBank) makeRequest(req *StartPumpRequest) (*proto.MoveRequest, error) {
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.bank == nil {
+		return nil, fmt.Errorf("pump bank is not initialized")
+	}
+	aq, org := req.aqueousOrganicRate()
+	x, err := d.bank.Aqueous.target(req.Volume * aq / req.TFR)
+	if err != nil {
+		return nil, fmt.Errorf("aqueous: %w", err)
+	}
+	y, err := d.bank.Organic.target(req.Volume * org / req.TFR)
+	if err != nil {
+		return nil, fmt.Errorf("organic: %w", err)
+	}
+	aqFeed, orgFeed := d.bank.Aqueous.distance(aq), d.bank.Organic.distance(org)
+	if aqFeed > d.bank.Aqueous.MaxFeedRate {
+		return nil, fmt.Errorf("aqueous: %g mL/min needs %g mm/min, above %g", aq, aqFeed, d.bank.Aqueous.MaxFeedRate)
+	}
+	if orgFeed > d.bank.Organic.MaxFeedRate {
+		return nil, fmt.Errorf("organic: %g mL/min needs %g mm/min, above %g", org, orgFeed, d.bank.Organic.MaxFeedRate)
+	}
+	// GRBL feeds along the path, so each axis runs at its share of it
+	feed := math.Hypot(aqFeed, orgFeed)
+	return makeMove(float32(x), float32(y), float32(feed)), nil
+}
+
+func makeMove(x, y, speed float32) *proto.MoveRequest {
+	return &proto.MoveRequest{X: &x, Y: &y, Speed: &speed}
+}
+
+// positionOrganicValve opens the organic valve to the outlet to dispense, or to
+// the reservoir to fill the syringe.
+func (d *PumpBank) positionOrganicValve(ctx context.Context, req *StartPumpRequest) error {
+	dispense := req.Volume > 0
+	if d.valveCanDispense.Load() == dispense {
+		return nil
+	}
+	var err error
+	if dispense {
+		_, err = d.client.FloodOn(ctx, &proto.FloodOnRequest{})
+	} else {
+		_, err = d.client.CoolantOff(ctx, &proto.CoolantOffRequest{})
+	}
+	if err != nil {
+		return fmt.Errorf("position organic valve: %w", err)
+	}
+	d.valveCanDispense.Store(dispense)
+	return nil
+}
+
+// StartPump starts the move and returns; PumpFinished is raised once the pumps
+// stop.
+func (d *PumpBank) StartPump(ctx context.Context, req *StartPumpRequest) (*StartPumpResponse, error) {
+	move, err := d.makeRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.positionOrganicValve(ctx, req); err != nil {
+		return nil, err
+	}
+	go d.pump(context.WithoutCancel(ctx), move)
+	return &StartPumpResponse{Volume: req.Volume, TFR: req.TFR, FRR: req.FRR}, nil
+}
+
+// pump makes the move and raises PumpFinished.
+func (d *PumpBank) pump(ctx context.Context, move *proto.MoveRequest) {
+	resp := &PumpFinishedResponse{Message: "done"}
+	if _, err := d.client.Move(ctx, move); err != nil {
+		resp.Message = err.Error()
+	} else {
+		d.mu.Lock()
+		d.bank.Aqueous.currentPosition = float64(move.GetX())
+		d.bank.Organic.currentPosition = float64(move.GetY())
+		d.mu.Unlock()
+	}
+	if d.raise == nil {
+		return
+	}
+	if err := d.raise(ctx, resp.Event()); err != nil {
+		d.logger.Error("Failed to raise pump_finished", zap.Error(err))
+	}
+}
@@ -0,0 +1,116 @@
+package pump_bank
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	proto "github.com/jt05610/petri/comm/grbl/proto/v1"
+	"github.com/jt05610/petri/labeled"
+	"go.uber.org/zap"
+	"math"
+	"sync"
+	"sync/atomic"
+)
+
+// PumpBank drives an aqueous syringe pump on the X axis and an organic syringe
+// pump on the Y axis of a GRBL controller. The organic line has a valve, driven
+// by the flood coolant output, that connects the syringe to the outlet or to
+// its reservoir.
+type PumpBank struct {
+	client proto.GRBLServer
+	mu     sync.Mutex
+	bank   *BankSettings
+	// valveCanDispense is set while the organic valve is open to the outlet.
+	valveCanDispense atomic.Bool
+	// raise publishes the events the bank raises, such as (*server.Server).Raise.
+	raise  func(ctx context.Context, event *labeled.Event) error
+	logger *zap.Logger
+}
+
+// Settings describe one syringe pump. Distances are in mm along the axis,
+// volumes in mL and feed rates in mm/min.
+type Settings struct {
+	SyringeDiameter float64
+	SyringeVolume   float64
+	// MaxDistance is how far the plunger travels from 0, the empty syringe,
+	// which is toward negative positions.
+	MaxDistance float64
+	MaxFeedRate float64
+	// currentPosition is where the plunger is after the last move.
+	currentPosition float64
+}
+
+type BankSettings struct {
+	Aqueous *Settings
+	Organic *Settings
+}
+
+func (s *Settings) validate() error {
+	switch {
+	case s == nil:
+		return errors.New("missing settings")
+	case s.SyringeDiameter <= 0:
+		return errors.New("syringe diameter must be positive")
+	case s.MaxDistance <= 0:
+		return errors.New("max distance must be positive")
+	case s.MaxFeedRate <= 0:
+		return errors.New("max feed rate must be positive")
+	}
+	return nil
+}
+
+// resolution is how finely GRBL places an axis, in mm. Positions read back
+// from a move are only this close to the ends of travel.
+const resolution = 0.001
+
+// distance is how far the plunger moves to displace volume mL.
+func (s *Settings) distance(volume float64) float64 {
+	r := s.SyringeDiameter / 2
+	return volume * 1000 / (math.Pi * r * r)
+}
+
+// target is where the plunger ends after displacing volume mL, which is
+// negative to fill the syringe.
+func (s *Settings) target(volume float64) (float64, error) {
+	ret := s.currentPosition + s.distance(volume)
+	if ret > resolution || ret < -s.MaxDistance-resolution {
+		return 0, fmt.Errorf("%g mL moves the plunger to %g mm, outside 0 to %g mm", volume, ret, -s.MaxDistance)
+	}
+	return math.Max(math.Min(ret, 0), -s.MaxDistance), nil
+}
+
+// StartPumpRequest pumps Volume mL at a total flow rate of TFR mL/min, split
+// between the pumps at FRR parts aqueous to one part organic. A negative volume
+// fills the syringes.
+type StartPumpRequest struct {
+	Volume float64 `json:"volume"`
+	TFR    float64 `json:"tfr"`
+	FRR    float64 `json:"frr"`
+}
+
+type StartPumpResponse struct {
+	Volume float64 `json:"volume"`
+	TFR    float64 `json:"tfr"`
+	FRR    float64 `json:"frr"`
+}
+
+// PumpFinishedResponse is raised once the pumps stop, with a message saying
+// why.
+type PumpFinishedResponse struct {
+	Message string `json:"message"`
+}
+
+func (r *StartPumpRequest) validate() error {
+	if r.TFR <= 0 {
+		return fmt.Errorf("total flow rate %g must be positive", r.TFR)
+	}
+	if r.FRR < 0 {
+		return fmt.Errorf("flow rate ratio %g must not be negative", r.FRR)
+	}
+	return nil
+}
+
+// aqueousOrganicRate splits the total flow rate between the pumps.
+func (r *StartPumpRequest) aqueousOrganicRate() (aq, org float64) {
+	return r.TFR * r.FRR / (r.FRR + 1), r.TFR / (r.FRR + 1)
+}
@@ -0,0 +1,125 @@
+package pump_bank
+
+import (
+	"context"
+	"fmt"
+	"github.com/jt05610/petri"
+	proto "github.com/jt05610/petri/comm/grbl/proto/v1"
+	"github.com/jt05610/petri/control"
+	"github.com/jt05610/petri/device"
+	"github.com/jt05610/petri/labeled"
+	"github.com/jt05610/petri/marked"
+	"go.uber.org/zap"
+	"strconv"
+)
+
+func NewPumpBank(client proto.GRBLServer) *PumpBank {
+	return &PumpBank{client: client, logger: zap.NewNop()}
+}
+
+// load builds the net described in device.yaml, keeping its IDs so snapshots
+// and sequences refer to the same places and events across restarts.
+func (d *PumpBank) load() *device.Device {
+	idle, pumping := petri.NewPlace("Idle", 1), petri.NewPlace("Pumping", 1)
+	idle.ID, pumping.ID = "clo35k9zl0002jgp40q9shhvy", "clo35kjzx0003jgp4xeqru6ty"
+	started, finished := petri.NewTransition("PumpStarted"), petri.NewTransition("PumpFinished")
+	started.ID, finished.ID = "clo35lwi70004jgp4szjduuex", "clo35m29j0005jgp4ses0pvnl"
+	n := petri.NewNet("pump_bank").
+		WithPlaces(idle, pumping).
+		WithTransitions(started, finished).
+		WithArcs(
+			petri.NewArc(idle, started, "", nil),
+			petri.NewArc(started, pumping, "", nil),
+			petri.NewArc(pumping, finished, "", nil),
+			petri.NewArc(finished, idle, "", nil),
+		)
+	n.ID = "clo35jjx20001jgp4ay4yvvwe"
+	ln := labeled.New(marked.New(n, marked.Marking{1, 0}))
+	_ = ln.AddEventHandler((&StartPumpRequest{}).Event(), started, d.Handlers()["start_pump"])
+	ln.AddEvent((&PumpFinishedResponse{}).Event(), finished)
+	return device.New("clo35zqxg0001jgwoh4b8sm1a", "pump_bank", []*labeled.Net{ln})
+}
+
+// number reads a number field, which arrives as a number from JSON or as a
+// string from a form.
+func number(data map[string]interface{}, name string) (float64, error) {
+	switch v := data[name].(type) {
+	case float64:
+		return v, nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	case nil:
+		return 0, fmt.Errorf("missing field %q", name)
+	default:
+		return 0, fmt.Errorf("field %q is a %T, not a number", name, v)
+	}
+}
+
+func (r *StartPumpRequest) Event() *labeled.Event {
+	return &labeled.Event{
+		Name: "start_pump",
+		ID:   "clo35r5wk000ajgp4j3hj1p6r",
+		Fields: []*labeled.Field{
+			{Name: "tfr", Type: labeled.Number},
+			{Name: "frr", Type: labeled.Number},
+			{Name: "volume", Type: labeled.Number},
+		},
+	}
+}
+
+func (r *StartPumpRequest) FromEvent(event *labeled.Event) error {
+	if event.Name != "start_pump" {
+		return fmt.Errorf("expected event name start_pump, got %s", event.Name)
+	}
+	var err error
+	if r.TFR, err = number(event.Data, "tfr"); err != nil {
+		return err
+	}
+	if r.FRR, err = number(event.Data, "frr"); err != nil {
+		return err
+	}
+	r.Volume, err = number(event.Data, "volume")
+	return err
+}
+
+func (r *StartPumpResponse) Event() *labeled.Event {
+	return &labeled.Event{
+		Name: "start_pump",
+		ID:   "clo35r5wk000ajgp4j3hj1p6r",
+		Data: map[string]interface{}{
+			"tfr":    r.TFR,
+			"frr":    r.FRR,
+			"volume": r.Volume,
+		},
+	}
+}
+
+func (r *PumpFinishedResponse) Event() *labeled.Event {
+	return &labeled.Event{
+		Name: "pump_finished",
+		ID:   "clo35rgso000cjgp4ego9v4ue",
+		Fields: []*labeled.Field{
+			{Name: "message", Type: labeled.String},
+		},
+		Data: map[string]interface{}{
+			"message": r.Message,
+		},
+	}
+}
+
+func (d *PumpBank) Handlers() control.Handlers {
+	return control.Handlers{
+		"start_pump": func(ctx context.Context, data *labeled.Event) (*labeled.Event, error) {
+			req := new(StartPumpRequest)
+			err := req.FromEvent(data)
+			if err != nil {
+				return nil, err
+			}
+			resp, err := d.StartPump(ctx, req)
+			if err != nil {
+				return nil, err
+			}
+			return resp.Event(), nil
+		},
+	}
+}
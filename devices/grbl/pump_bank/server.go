@@ -2,7 +2,6 @@ package pump_bank
 
 import (
 	"context"
-	"embed"
 	"encoding/json"
 	"github.com/jt05610/petri/amqp"
 	"github.com/jt05610/petri/amqp/server"
@@ -11,11 +10,9 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"syscall"
 )
 
-//go:embed device.yaml
-var deviceYaml embed.FS
-
 func loadPumpParams() *BankSettings {
 	f, err := os.Open("pump_params.json")
 	if err != nil {
@@ -70,11 +67,12 @@ func loadPumpParams() *BankSettings {
 	return &bs
 }
 
-func Run(ctx context.Context, conn *amqp.Connection, client proto.GRBLServer) {
+func Run(ctx context.Context, link *amqp.Link, client proto.GRBLServer) {
 	req := loadPumpParams()
 	logger, err := zap.NewProduction()
 	failOnError(err, "Error creating logger")
 	d := NewPumpBank(client)
+	d.logger = logger
 	d.valveCanDispense.Store(true)
 	dev := d.load()
 	err = d.Initialize(context.Background(), req)
@@ -85,7 +83,15 @@ func Run(ctx context.Context, conn *amqp.Connection, client proto.GRBLServer) {
 	if err != nil {
 		log.Fatal(err)
 	}
-	srv := server.New(dev.Nets[0], conn.Channel, environ.Exchange, environ.DeviceID, environ.InstanceID, dev.EventMap(), d.Handlers(), logger)
+	t, err := amqp.NewTransport(ctx, link, amqp.DefaultTopology(environ.Exchange))
+	failOnError(err, "Failed to declare topology")
+	srv, err := server.New(dev.Nets[0], t, environ.DeviceID, environ.InstanceID, dev.EventMap(), d.Handlers(), logger)
+	failOnError(err, "Failed to create server")
+	d.raise = srv.Raise
+	if environ.SnapshotPath != "" {
+		srv.Snapshots = server.FileStore(environ.SnapshotPath)
+		srv.Startup = server.StartupPolicy(environ.StartupPolicy)
+	}
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	c := make(chan os.Signal, 1)
@@ -94,6 +100,17 @@ func Run(ctx context.Context, conn *amqp.Connection, client proto.GRBLServer) {
 		<-c // Wait for SIGINT
 		cancel()
 	}()
+	// with the confirm startup policy an operator settles a restarted device
+	// with SIGUSR1 to restore its snapshot or SIGUSR2 to reset it
+	confirm := make(chan os.Signal, 1)
+	signal.Notify(confirm, syscall.SIGUSR1, syscall.SIGUSR2)
+	go func() {
+		for sig := range confirm {
+			if err := srv.Confirm(ctx, sig == syscall.SIGUSR1); err != nil {
+				logger.Error("Failed to confirm device state", zap.Error(err))
+			}
+		}
+	}()
 	err = d.positionOrganicValve(ctx, &StartPumpRequest{
 		Volume: -5,
 	})
@@ -101,8 +118,9 @@ func Run(ctx context.Context, conn *amqp.Connection, client proto.GRBLServer) {
 		log.Fatal(err)
 	}
 	logger.Info("Started 🐰 server")
-	srv.Listen(ctx)
-	<-ctx.Done()
+	if err := srv.Listen(ctx); err != nil {
+		logger.Error("Server stopped", zap.Error(err))
+	}
 	logger.Info("Shutting down 🐰 server")
 }
 
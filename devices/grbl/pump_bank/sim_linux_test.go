@@ -0,0 +1,173 @@
+//go:build linux
+
+package pump_bank
+
+import (
+	"context"
+	"errors"
+	"github.com/jt05610/petri/amqp/client"
+	"github.com/jt05610/petri/amqp/server"
+	proto "github.com/jt05610/petri/comm/grbl/proto/v1"
+	grbl "github.com/jt05610/petri/comm/grbl/server"
+	"github.com/jt05610/petri/comm/grbl/sim"
+	"github.com/jt05610/petri/comm/serial"
+	"github.com/jt05610/petri/control"
+	"github.com/jt05610/petri/device"
+	"github.com/jt05610/petri/labeled"
+	"github.com/jt05610/petri/sequence"
+	"github.com/jt05610/petri/transport"
+	"go.uber.org/zap"
+	"math"
+	"testing"
+	"time"
+)
+
+// simulated runs the bank against a simulated controller.
+func simulated(ctx context.Context, t *testing.T) (*sim.Simulator, *PumpBank) {
+	t.Helper()
+	dev := sim.New()
+	dev.Speedup = 100
+	pty, err := serial.Simulate(ctx, dev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := serial.OpenPort(pty.Name, 115200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grbl.New(port, zap.NewNop())
+	t.Cleanup(func() {
+		_ = s.Close()
+	})
+	go s.RunHeartbeat(ctx)
+	if _, err := s.Home(ctx, &proto.HomeRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	b := NewPumpBank(s)
+	err = b.Initialize(ctx, &BankSettings{
+		Aqueous: &Settings{
+			SyringeDiameter: 26.7,
+			SyringeVolume:   60,
+			MaxDistance:     50,
+			MaxFeedRate:     50,
+		},
+		Organic: &Settings{
+			SyringeDiameter: 12.06,
+			SyringeVolume:   5,
+			MaxDistance:     50,
+			MaxFeedRate:     50,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dev, b
+}
+
+// bind waits for the instance's beacon and binds the device to it.
+func bind(t *testing.T, c *client.Controller, deviceID, instanceID string) {
+	t.Helper()
+	deadline := time.After(5 * time.Second)
+	var err error
+	for err = c.Bind(deviceID, instanceID); errors.Is(err, client.ErrUnknownInstance); err = c.Bind(deviceID, instanceID) {
+		if dErr := c.Discover(); dErr != nil {
+			t.Fatal(dErr)
+		}
+		select {
+		case <-deadline:
+			t.Fatalf("timed out waiting for the beacon from %s", instanceID)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestSequence runs a sequence that fills the syringes from a controller,
+// through the transport and the device server, to a simulated controller.
+func TestSequence(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	machine, b := simulated(ctx, t)
+	dev := b.load()
+	broker := transport.NewBroker()
+	defer func() {
+		_ = broker.Close()
+	}()
+	srv, err := server.New(dev.Nets[0], broker, dev.ID, "pump-1", dev.EventMap(), b.Handlers(), zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.raise = srv.Raise
+	go func() {
+		_ = srv.Listen(ctx)
+	}()
+
+	c := client.NewController(zap.NewNop(), broker)
+	defer c.Close()
+	if err := c.Listen(ctx); err != nil {
+		t.Fatal(err)
+	}
+	fill := (&StartPumpRequest{}).Event()
+	fill.Data = map[string]interface{}{"volume": -5.0, "tfr": 5.0, "frr": 3.0}
+	c.Sequence = &sequence.Sequence{
+		Name: "fill",
+		Steps: []*sequence.Step{{Action: &sequence.Action{
+			Device: &device.Device{ID: dev.ID, Name: dev.Name},
+			Event:  fill,
+		}}},
+	}
+	bind(t, c, dev.ID, "pump-1")
+	data := make(chan *control.Event, 16)
+	c.ChannelData(ctx, data)
+	if err := c.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	started := false
+	for finished := false; !finished; {
+		select {
+		case ev := <-data:
+			switch ev.Name {
+			case "start_pump":
+				started = true
+			case "pump_finished":
+				if !started || ev.Data["message"] != "done" {
+					t.Fatalf("expected the pumps to finish after starting, got %v", ev.Data)
+				}
+				finished = true
+			}
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for the pumps to finish")
+		}
+	}
+
+	// 3.75 mL of aqueous and 1.25 mL of organic were drawn in
+	pos := machine.Position()
+	if math.Abs(pos[0]+6.6976) > 0.001 || math.Abs(pos[1]+10.9427) > 0.001 {
+		t.Fatalf("expected the plungers at -6.698, -10.943, got %v", pos)
+	}
+	if b.valveCanDispense.Load() {
+		t.Fatal("expected the organic valve to be open to the reservoir")
+	}
+	// the plungers cannot be drawn past their travel
+	over := &StartPumpRequest{Volume: -50, TFR: 5, FRR: 3}
+	if _, err := b.makeRequest(over); err == nil {
+		t.Fatal("expected a fill beyond the syringes' travel to be refused")
+	}
+	_, err = c.Call(ctx, &control.Command{
+		Event: &labeled.Event{Name: "start_pump", Data: map[string]interface{}{"volume": 5.0, "tfr": 5.0, "frr": 3.0}},
+		To:    "pump-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.After(10 * time.Second)
+	for pos = machine.Position(); math.Abs(pos[0]) > 0.001 || math.Abs(pos[1]) > 0.001; pos = machine.Position() {
+		select {
+		case <-deadline:
+			t.Fatalf("expected the plungers to return to 0, got %v", pos)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
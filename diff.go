@@ -0,0 +1,183 @@
+package petri
+
+import (
+	"fmt"
+	"sort"
+)
+
+type ChangeType string
+
+const (
+	Added   ChangeType = "added"
+	Removed ChangeType = "removed"
+	Changed ChangeType = "changed"
+)
+
+// Change describes how a single place, transition or arc differs between two
+// nets. Fields lists the fields that differ when the object was changed.
+type Change struct {
+	Type   ChangeType `json:"type"`
+	Kind   Kind       `json:"kind"`
+	ID     string     `json:"id"`
+	Name   string     `json:"name"`
+	Fields []string   `json:"fields,omitempty"`
+}
+
+// ExpressionChange is a guard or arc expression that differs between two nets.
+// From is empty for expressions that were added and To is empty for ones that
+// were removed.
+type ExpressionChange struct {
+	Kind Kind   `json:"kind"`
+	ID   string `json:"id"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// NetDiff is the structural difference between two nets. Objects are matched
+// by ID.
+type NetDiff struct {
+	Places      []*Change           `json:"places"`
+	Transitions []*Change           `json:"transitions"`
+	Arcs        []*Change           `json:"arcs"`
+	Expressions []*ExpressionChange `json:"expressions"`
+}
+
+// Empty reports whether the two nets are structurally identical.
+func (d *NetDiff) Empty() bool {
+	return len(d.Places) == 0 && len(d.Transitions) == 0 && len(d.Arcs) == 0 && len(d.Expressions) == 0
+}
+
+func tokenSchemaIDs(schemas []*TokenSchema) string {
+	names := make([]string, len(schemas))
+	for i, s := range schemas {
+		names[i] = s.ID
+	}
+	sort.Strings(names)
+	return fmt.Sprint(names)
+}
+
+func placeFields(a, b *Place) []string {
+	var fields []string
+	if a.Name != b.Name {
+		fields = append(fields, "name")
+	}
+	if a.Bound != b.Bound {
+		fields = append(fields, "bound")
+	}
+	if tokenSchemaIDs(a.AcceptedTokens) != tokenSchemaIDs(b.AcceptedTokens) {
+		fields = append(fields, "acceptedTokens")
+	}
+	return fields
+}
+
+func eventID(e *EventSchema) string {
+	if e == nil {
+		return ""
+	}
+	return e.ID
+}
+
+func transitionFields(a, b *Transition) []string {
+	var fields []string
+	if a.Name != b.Name {
+		fields = append(fields, "name")
+	}
+	if a.Expression != b.Expression {
+		fields = append(fields, "expression")
+	}
+	if a.Cold != b.Cold {
+		fields = append(fields, "cold")
+	}
+	if eventID(a.Event) != eventID(b.Event) {
+		fields = append(fields, "event")
+	}
+	return fields
+}
+
+func schemaID(t *TokenSchema) string {
+	if t == nil {
+		return ""
+	}
+	return t.ID
+}
+
+func arcFields(a, b *Arc) []string {
+	var fields []string
+	if a.Src.Identifier() != b.Src.Identifier() {
+		fields = append(fields, "src")
+	}
+	if a.Dest.Identifier() != b.Dest.Identifier() {
+		fields = append(fields, "dest")
+	}
+	if a.Expression != b.Expression {
+		fields = append(fields, "expression")
+	}
+	if schemaID(a.OutputSchema) != schemaID(b.OutputSchema) {
+		fields = append(fields, "outputSchema")
+	}
+	return fields
+}
+
+func diffObjects[T Object](kind Kind, from, to []T, fields func(a, b T) []string) []*Change {
+	changes := make([]*Change, 0)
+	toIndex := make(map[string]T)
+	for _, o := range to {
+		toIndex[o.Identifier()] = o
+	}
+	fromIndex := make(map[string]T)
+	for _, o := range from {
+		fromIndex[o.Identifier()] = o
+		other, ok := toIndex[o.Identifier()]
+		if !ok {
+			changes = append(changes, &Change{Type: Removed, Kind: kind, ID: o.Identifier(), Name: o.String()})
+			continue
+		}
+		if f := fields(o, other); len(f) > 0 {
+			changes = append(changes, &Change{Type: Changed, Kind: kind, ID: o.Identifier(), Name: other.String(), Fields: f})
+		}
+	}
+	for _, o := range to {
+		if _, ok := fromIndex[o.Identifier()]; !ok {
+			changes = append(changes, &Change{Type: Added, Kind: kind, ID: o.Identifier(), Name: o.String()})
+		}
+	}
+	return changes
+}
+
+func diffExpressions[T Object](kind Kind, from, to []T, expression func(T) string) []*ExpressionChange {
+	changes := make([]*ExpressionChange, 0)
+	before := make(map[string]string)
+	for _, o := range from {
+		before[o.Identifier()] = expression(o)
+	}
+	after := make(map[string]string)
+	for _, o := range to {
+		after[o.Identifier()] = expression(o)
+	}
+	for _, o := range from {
+		id := o.Identifier()
+		if before[id] != after[id] {
+			changes = append(changes, &ExpressionChange{Kind: kind, ID: id, From: before[id], To: after[id]})
+		}
+	}
+	for _, o := range to {
+		id := o.Identifier()
+		if _, ok := before[id]; !ok && after[id] != "" {
+			changes = append(changes, &ExpressionChange{Kind: kind, ID: id, To: after[id]})
+		}
+	}
+	return changes
+}
+
+// Diff returns the changes that turn from into to.
+func Diff(from, to *Net) *NetDiff {
+	return &NetDiff{
+		Places:      diffObjects(PlaceObject, from.Places, to.Places, placeFields),
+		Transitions: diffObjects(TransitionObject, from.Transitions, to.Transitions, transitionFields),
+		Arcs:        diffObjects(ArcObject, from.Arcs, to.Arcs, arcFields),
+		Expressions: append(
+			diffExpressions(TransitionObject, from.Transitions, to.Transitions, func(t *Transition) string { return t.Expression }),
+			diffExpressions(ArcObject, from.Arcs, to.Arcs, func(a *Arc) string { return a.Expression })...,
+		),
+	}
+}
@@ -0,0 +1,75 @@
+package petri_test
+
+import (
+	"encoding/json"
+	"github.com/jt05610/petri"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	from, p, tr, a := editTestNet()
+	res, err := petri.ApplyEdit(from, &petri.NetEdit{
+		Ops: []*petri.EditOp{
+			{Kind: petri.RemoveArcEdit, ID: a.ID},
+			{Kind: petri.AddPlaceEdit, Ref: "q", Place: &petri.PlaceInput{Name: "q", Bound: 1}},
+			{Kind: petri.AddArcEdit, Arc: &petri.ArcEditInput{Src: "q", Dest: tr.ID, Expression: "Signal + 1"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	to := res.Net
+	changed := *tr
+	changed.Expression = "Signal > 0"
+	to.Transitions = []*petri.Transition{&changed}
+
+	d := petri.Diff(from, to)
+	if len(d.Places) != 1 || d.Places[0].Type != petri.Added || d.Places[0].Name != "q" {
+		t.Fatalf("unexpected place changes %+v", d.Places)
+	}
+	if len(d.Transitions) != 1 || d.Transitions[0].Type != petri.Changed || d.Transitions[0].Fields[0] != "expression" {
+		t.Fatalf("unexpected transition changes %+v", d.Transitions)
+	}
+	if len(d.Arcs) != 2 {
+		t.Fatalf("expected 2 arc changes, got %d", len(d.Arcs))
+	}
+	if len(d.Expressions) != 3 {
+		t.Fatalf("expected 3 expression changes, got %d", len(d.Expressions))
+	}
+	if !petri.Diff(from, from).Empty() {
+		t.Fatal("expected net to have no changes from itself")
+	}
+	if p.Name != "p" {
+		t.Fatal("diff modified the net")
+	}
+}
+
+func TestNetVersion_RoundTrip(t *testing.T) {
+	n, _, _, _ := editTestNet()
+	v1 := (&petri.NetVersionInput{Net: n}).Object().(*petri.NetVersion)
+	v2 := (&petri.NetVersionInput{Net: n, Parent: v1}).Object().(*petri.NetVersion)
+	if v2.Parent != v1.ID || v2.Version != 2 {
+		t.Fatalf("expected version 2 with parent %s, got %d with parent %s", v1.ID, v2.Version, v2.Parent)
+	}
+	if err := v2.Update(&petri.NetVersionUpdate{}); err != petri.ErrImmutable {
+		t.Fatalf("expected versions to be immutable, got %v", err)
+	}
+	bytes, err := json.Marshal(v2.Document())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var loaded petri.NetVersion
+	if err := json.Unmarshal(bytes, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	if err := loaded.PostInit(); err != nil {
+		t.Fatal(err)
+	}
+	restored := loaded.Net()
+	if err := restored.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if d := petri.Diff(n, restored); !d.Empty() {
+		t.Fatalf("expected restored net to match, got %+v", d)
+	}
+}
@@ -0,0 +1,235 @@
+package petri
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/expr-lang/expr"
+)
+
+var ErrInvalidNet = errors.New("invalid net")
+
+// EditKind is the kind of change an EditOp makes to a net.
+type EditKind string
+
+const (
+	AddPlaceEdit         EditKind = "addPlace"
+	AddTransitionEdit    EditKind = "addTransition"
+	AddArcEdit           EditKind = "addArc"
+	RemovePlaceEdit      EditKind = "removePlace"
+	RemoveTransitionEdit EditKind = "removeTransition"
+	RemoveArcEdit        EditKind = "removeArc"
+)
+
+// ArcEditInput describes an arc added by an edit. Src and Dest are either the
+// ID of a node already in the net or the Ref of a node added earlier in the
+// same edit.
+type ArcEditInput struct {
+	Src          string       `json:"src"`
+	Dest         string       `json:"dest"`
+	Expression   string       `json:"expression,omitempty"`
+	OutputSchema *TokenSchema `json:"outputSchema,omitempty"`
+}
+
+// EditOp is a single change in a NetEdit.
+type EditOp struct {
+	Kind EditKind `json:"kind"`
+	// Ref names the object created by an add so later operations in the same
+	// edit can refer to it before it has been given an ID.
+	Ref string `json:"ref,omitempty"`
+	// ID is the object removed by a remove.
+	ID         string           `json:"id,omitempty"`
+	Place      *PlaceInput      `json:"place,omitempty"`
+	Transition *TransitionInput `json:"transition,omitempty"`
+	Arc        *ArcEditInput    `json:"arc,omitempty"`
+}
+
+// NetEdit is an ordered list of operations that are applied to a net together.
+type NetEdit struct {
+	Ops []*EditOp `json:"ops"`
+}
+
+// EditResult is the outcome of applying a NetEdit to a net in memory. Added and
+// Removed hold the objects that must be written to storage, in the order the
+// operations were given.
+type EditResult struct {
+	Net     *Net
+	Added   []Object
+	Removed []Object
+}
+
+// NetEditor applies a NetEdit to a stored net, writing every change or none.
+type NetEditor interface {
+	ApplyNetEdit(ctx context.Context, netID string, edit *NetEdit) (*Net, error)
+}
+
+func editErr(i int, op *EditOp, format string, args ...interface{}) error {
+	return fmt.Errorf("edit %d (%s): %s", i, op.Kind, fmt.Sprintf(format, args...))
+}
+
+// ApplyEdit applies the edit to a copy of the net and validates the result. The
+// original net is not modified.
+func ApplyEdit(net *Net, edit *NetEdit) (*EditResult, error) {
+	places := append([]*Place{}, net.Places...)
+	transitions := append([]*Transition{}, net.Transitions...)
+	arcs := append([]*Arc{}, net.Arcs...)
+	refs := make(map[string]Node)
+	res := &EditResult{
+		Added:   make([]Object, 0),
+		Removed: make([]Object, 0),
+	}
+
+	node := func(id string) Node {
+		if n, ok := refs[id]; ok {
+			return n
+		}
+		for _, p := range places {
+			if p.ID == id {
+				return p
+			}
+		}
+		for _, t := range transitions {
+			if t.ID == id {
+				return t
+			}
+		}
+		return nil
+	}
+
+	for i, op := range edit.Ops {
+		switch op.Kind {
+		case AddPlaceEdit:
+			if op.Place == nil {
+				return nil, editErr(i, op, "missing place")
+			}
+			p := op.Place.Object().(*Place)
+			places = append(places, p)
+			if op.Ref != "" {
+				refs[op.Ref] = p
+			}
+			res.Added = append(res.Added, p)
+		case AddTransitionEdit:
+			if op.Transition == nil {
+				return nil, editErr(i, op, "missing transition")
+			}
+			t := op.Transition.Object().(*Transition)
+			transitions = append(transitions, t)
+			if op.Ref != "" {
+				refs[op.Ref] = t
+			}
+			res.Added = append(res.Added, t)
+		case AddArcEdit:
+			if op.Arc == nil {
+				return nil, editErr(i, op, "missing arc")
+			}
+			src := node(op.Arc.Src)
+			if src == nil {
+				return nil, editErr(i, op, "unknown source %s", op.Arc.Src)
+			}
+			dest := node(op.Arc.Dest)
+			if dest == nil {
+				return nil, editErr(i, op, "unknown destination %s", op.Arc.Dest)
+			}
+			a := NewArc(src, dest, op.Arc.Expression, op.Arc.OutputSchema)
+			arcs = append(arcs, a)
+			res.Added = append(res.Added, a)
+		case RemovePlaceEdit:
+			found := false
+			for j, p := range places {
+				if p.ID == op.ID {
+					places = append(places[:j], places[j+1:]...)
+					res.Removed = append(res.Removed, p)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, editErr(i, op, "place %s not found", op.ID)
+			}
+		case RemoveTransitionEdit:
+			found := false
+			for j, t := range transitions {
+				if t.ID == op.ID {
+					transitions = append(transitions[:j], transitions[j+1:]...)
+					res.Removed = append(res.Removed, t)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, editErr(i, op, "transition %s not found", op.ID)
+			}
+		case RemoveArcEdit:
+			found := false
+			for j, a := range arcs {
+				if a.ID == op.ID {
+					arcs = append(arcs[:j], arcs[j+1:]...)
+					res.Removed = append(res.Removed, a)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, editErr(i, op, "arc %s not found", op.ID)
+			}
+		default:
+			return nil, editErr(i, op, "unknown operation")
+		}
+	}
+
+	res.Net = LoadNet(places, transitions, arcs)
+	res.Net.ID = net.ID
+	res.Net.Name = net.Name
+	res.Net.TokenSchemas = net.TokenSchemas
+	res.Net.Nets = net.Nets
+	if err := res.Net.Validate(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Validate checks that every arc joins a place and a transition that are in the
+// net, that no two arcs join the same nodes, and that all expressions compile.
+func (p *Net) Validate() error {
+	nodes := make(map[string]Kind)
+	for _, pl := range p.Places {
+		nodes[pl.ID] = PlaceObject
+	}
+	for _, t := range p.Transitions {
+		nodes[t.ID] = TransitionObject
+		if t.Expression == "" {
+			continue
+		}
+		if _, err := expr.Compile(t.Expression); err != nil {
+			return fmt.Errorf("%w: transition %s: %v", ErrInvalidNet, t.Name, err)
+		}
+	}
+	seen := make(map[string]bool)
+	for _, a := range p.Arcs {
+		if a.Src == nil || a.Dest == nil {
+			return fmt.Errorf("%w: arc %s is missing an endpoint", ErrInvalidNet, a.ID)
+		}
+		src, ok := nodes[a.Src.Identifier()]
+		if !ok {
+			return fmt.Errorf("%w: arc %s source %s is not in the net", ErrInvalidNet, a.ID, a.Src.Identifier())
+		}
+		dest, ok := nodes[a.Dest.Identifier()]
+		if !ok {
+			return fmt.Errorf("%w: arc %s destination %s is not in the net", ErrInvalidNet, a.ID, a.Dest.Identifier())
+		}
+		if src == dest {
+			return fmt.Errorf("%w: arc %s connects two places or two transitions", ErrInvalidNet, a.ID)
+		}
+		if seen[a.String()] {
+			return fmt.Errorf("%w: arc %s duplicates %s", ErrInvalidNet, a.ID, a.String())
+		}
+		seen[a.String()] = true
+		if a.Expression == "" {
+			continue
+		}
+		if _, err := expr.Compile(a.Expression); err != nil {
+			return fmt.Errorf("%w: arc %s: %v", ErrInvalidNet, a.ID, err)
+		}
+	}
+	return nil
+}
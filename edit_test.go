@@ -0,0 +1,98 @@
+package petri_test
+
+import (
+	"errors"
+	"github.com/jt05610/petri"
+	"testing"
+)
+
+func editTestNet() (*petri.Net, *petri.Place, *petri.Transition, *petri.Arc) {
+	signal := petri.Signal()
+	p := petri.NewPlace("p", 1, signal)
+	t := petri.NewTransition("t")
+	a := petri.NewArc(p, t, "Signal", signal)
+	n := petri.NewNet("edit").WithPlaces(p).WithTransitions(t).WithArcs(a)
+	n.ID = petri.ID()
+	return n, p, t, a
+}
+
+func TestApplyEdit(t *testing.T) {
+	n, p, tr, a := editTestNet()
+	signal := petri.Signal()
+	res, err := petri.ApplyEdit(n, &petri.NetEdit{
+		Ops: []*petri.EditOp{
+			{Kind: petri.AddPlaceEdit, Ref: "q", Place: &petri.PlaceInput{Name: "q", Bound: 1, AcceptedTokens: []*petri.TokenSchema{signal}}},
+			{Kind: petri.AddTransitionEdit, Ref: "u", Transition: &petri.TransitionInput{Name: "u"}},
+			{Kind: petri.AddArcEdit, Arc: &petri.ArcEditInput{Src: tr.ID, Dest: "q", Expression: "Signal", OutputSchema: signal}},
+			{Kind: petri.AddArcEdit, Arc: &petri.ArcEditInput{Src: "q", Dest: "u", Expression: "Signal", OutputSchema: signal}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Added) != 4 {
+		t.Fatalf("expected 4 added objects, got %d", len(res.Added))
+	}
+	if len(res.Net.Places) != 2 || len(res.Net.Transitions) != 2 || len(res.Net.Arcs) != 3 {
+		t.Fatalf("unexpected net size %d/%d/%d", len(res.Net.Places), len(res.Net.Transitions), len(res.Net.Arcs))
+	}
+	if res.Net.ID != n.ID {
+		t.Fatalf("expected net id %s, got %s", n.ID, res.Net.ID)
+	}
+	if len(n.Places) != 1 || len(n.Arcs) != 1 {
+		t.Fatal("original net was modified")
+	}
+
+	res, err = petri.ApplyEdit(n, &petri.NetEdit{
+		Ops: []*petri.EditOp{
+			{Kind: petri.RemoveArcEdit, ID: a.ID},
+			{Kind: petri.RemovePlaceEdit, ID: p.ID},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Removed) != 2 || len(res.Net.Places) != 0 || len(res.Net.Arcs) != 0 {
+		t.Fatalf("unexpected removal result %+v", res)
+	}
+}
+
+func TestApplyEdit_Invalid(t *testing.T) {
+	n, p, tr, _ := editTestNet()
+	testCases := []struct {
+		name string
+		ops  []*petri.EditOp
+	}{
+		{
+			name: "dangling arc",
+			ops:  []*petri.EditOp{{Kind: petri.RemovePlaceEdit, ID: p.ID}},
+		},
+		{
+			name: "duplicate arc",
+			ops:  []*petri.EditOp{{Kind: petri.AddArcEdit, Arc: &petri.ArcEditInput{Src: p.ID, Dest: tr.ID, Expression: "Signal"}}},
+		},
+		{
+			name: "place to place",
+			ops: []*petri.EditOp{
+				{Kind: petri.AddPlaceEdit, Ref: "q", Place: &petri.PlaceInput{Name: "q"}},
+				{Kind: petri.AddArcEdit, Arc: &petri.ArcEditInput{Src: p.ID, Dest: "q"}},
+			},
+		},
+		{
+			name: "bad expression",
+			ops:  []*petri.EditOp{{Kind: petri.AddTransitionEdit, Transition: &petri.TransitionInput{Name: "u", Expression: "Signal >"}}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := petri.ApplyEdit(n, &petri.NetEdit{Ops: tc.ops})
+			if !errors.Is(err, petri.ErrInvalidNet) {
+				t.Fatalf("expected invalid net error, got %v", err)
+			}
+		})
+	}
+	_, err := petri.ApplyEdit(n, &petri.NetEdit{Ops: []*petri.EditOp{{Kind: petri.AddArcEdit, Arc: &petri.ArcEditInput{Src: "missing", Dest: tr.ID}}}})
+	if err == nil {
+		t.Fatal("expected error for unknown arc source")
+	}
+}
@@ -13,6 +13,11 @@ type Environment struct {
 	DeviceID   string
 	InstanceID string
 	RPCAddress string
+	// SnapshotPath is where the device keeps its marking across restarts. It
+	// is empty if SNAPSHOT_PATH is not set.
+	SnapshotPath string
+	// StartupPolicy is what the device does with a snapshot when it starts.
+	StartupPolicy string
 }
 
 func LoadEnv(logger *zap.Logger) *Environment {
@@ -41,12 +46,19 @@ func LoadEnv(logger *zap.Logger) *Environment {
 	if !found {
 		logger.Fatal("RPC_ADDRESS not set")
 	}
+	snapshotPath, _ := os.LookupEnv("SNAPSHOT_PATH")
+	startupPolicy, found := os.LookupEnv("STARTUP_POLICY")
+	if !found {
+		startupPolicy = "confirm"
+	}
 	return &Environment{
-		URI:        uri,
-		Exchange:   exchange,
-		DeviceID:   deviceID,
-		InstanceID: instanceID,
-		RPCAddress: rpcAddr,
+		URI:           uri,
+		Exchange:      exchange,
+		DeviceID:      deviceID,
+		InstanceID:    instanceID,
+		RPCAddress:    rpcAddr,
+		SnapshotPath:  snapshotPath,
+		StartupPolicy: startupPolicy,
 	}
 }
 
@@ -128,6 +128,7 @@ type Net struct {
 	EventIDs      map[string]string
 	Events        []*Event
 	eventCh       chan *Event
+	raised        map[string]*petri.Transition
 }
 
 func (n *Net) Hot() []*petri.Transition {
@@ -148,6 +149,7 @@ func New(net *marked.Net) *Net {
 		notifications: make(map[string][]*Notification),
 		hot:           make(map[string]bool),
 		eventCh:       make(chan *Event),
+		raised:        make(map[string]*petri.Transition),
 	}
 	for _, t := range net.Transitions {
 		n.hot[t.Name] = true
@@ -241,6 +243,20 @@ func (n *Net) Handle(ctx context.Context, event *Event) error {
 	if err != nil {
 		return err
 	}
+	notes, err := n.fireHot(ctx, event.ID)
+	if err != nil {
+		return err
+	}
+	for _, ev := range append(newEvents, notes...) {
+		n.eventCh <- ev
+	}
+	return nil
+}
+
+// fireHot fires the hot transitions the last event enabled and returns the
+// notifications they raise.
+func (n *Net) fireHot(ctx context.Context, id string) ([]*Event, error) {
+	newEvents := make([]*Event, 0)
 	av := n.Available()
 	nCold := 0
 	for _, hot := range n.hot {
@@ -253,33 +269,69 @@ func (n *Net) Handle(ctx context.Context, event *Event) error {
 			if !n.hot[t.Name] {
 				continue
 			}
-			err = n.Fire(t)
-			if err != nil {
-				return err
+			if err := n.Fire(t); err != nil {
+				return nil, err
 			}
 			if nn, ok := n.notifications[t.Name]; ok {
 				for _, h := range nn {
 					d, err := h.Getter(ctx)
 					if err != nil {
-						return err
+						return nil, err
 					}
 					newEvents = append(newEvents, &Event{
-						ID:   event.ID,
+						ID:   id,
 						Name: h.Name,
 						Data: d,
 					})
 				}
 			}
 		}
-		if err != nil {
-			return err
-		}
 		av = n.Available()
 	}
-	for _, ev := range newEvents {
-		n.eventCh <- ev
+	return newEvents, nil
+}
+
+var ErrNotEnabled = errors.New("transition is not enabled")
+
+// AddEvent adds an event that the device raises on its own, such as a sensor
+// tripping, rather than in answer to a command. Its transition is cold, so it
+// only fires when the event is raised.
+func (n *Net) AddEvent(event *Event, transition *petri.Transition) {
+	name := sentenceCaseToSnakeCase(event.Name)
+	if n.raised == nil {
+		n.raised = make(map[string]*petri.Transition)
 	}
-	return nil
+	n.raised[name] = transition
+	if n.hot == nil {
+		n.hot = make(map[string]bool)
+	}
+	n.hot[transition.Name] = false
+	n.Events = append(n.Events, event)
+	n.EventIDs[name] = event.ID
+}
+
+// Raise fires the transition of an event added with AddEvent and the hot
+// transitions that follow it. It returns the event, with its ID, followed by
+// any notifications. Nothing fires if the marking does not enable the event's
+// transition.
+func (n *Net) Raise(ctx context.Context, event *Event) ([]*Event, error) {
+	name := sentenceCaseToSnakeCase(event.Name)
+	t, found := n.raised[name]
+	if !found {
+		return nil, fmt.Errorf("event %q is not raised by the device", event.Name)
+	}
+	if !n.Enabled(t) {
+		return nil, fmt.Errorf("%w: %s", ErrNotEnabled, t.Name)
+	}
+	if err := n.Fire(t); err != nil {
+		return nil, err
+	}
+	ev := &Event{ID: n.EventIDs[name], Name: event.Name, Data: event.Data}
+	notes, err := n.fireHot(ctx, ev.ID)
+	if err != nil {
+		return nil, err
+	}
+	return append([]*Event{ev}, notes...), nil
 }
 
 func ValidSequence(net *Net, seq []*Event) bool {
@@ -11,6 +11,7 @@ type Marking []int
 type Net struct {
 	*petri.Net
 	marking      Marking
+	initial      Marking
 	index        map[string]int
 	joinedPlaces map[string]string
 }
@@ -19,6 +20,7 @@ func (n *Net) Copy() *Net {
 	ret := &Net{
 		Net:     n.Net,
 		marking: make(Marking, len(n.marking)),
+		initial: append(Marking(nil), n.initial...),
 		index:   make(map[string]int),
 	}
 	for k, v := range n.index {
@@ -45,6 +47,26 @@ func (n *Net) Marking() Marking {
 	return n.marking
 }
 
+// Reset puts the net back to the marking it was created with.
+func (n *Net) Reset() {
+	copy(n.marking, n.initial)
+}
+
+// Restore sets the marking from a map of place IDs to tokens such as the one
+// MarkingMap returns. Every place must be present.
+func (n *Net) Restore(marking map[string]int) error {
+	restored := make(Marking, len(n.marking))
+	for id, i := range n.index {
+		v, found := marking[id]
+		if !found {
+			return fmt.Errorf("no marking for place %s", id)
+		}
+		restored[i] = v
+	}
+	copy(n.marking, restored)
+	return nil
+}
+
 // Enabled returns true if the transition is enabled
 func (n *Net) Enabled(t *petri.Transition) bool {
 	for _, arc := range n.Inputs(t) {
@@ -113,6 +135,7 @@ func New(n *petri.Net, initial Marking, joinedIDs ...map[string]string) *Net {
 	net := &Net{
 		Net:     n,
 		marking: initial,
+		initial: append(Marking(nil), initial...),
 	}
 	net.index = make(map[string]int)
 	for i, p := range n.Places {
@@ -8,6 +8,7 @@ import (
 	fracCollector "github.com/jt05610/petri/devices/fraction_collector"
 	"github.com/jt05610/petri/marlin"
 	proto "github.com/jt05610/petri/marlin/proto/v1"
+	"github.com/jt05610/petri/marlin/sim"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"net"
@@ -66,7 +67,12 @@ func main() {
 		logger.Fatal("Failed to load .env", zap.Error(err))
 	}
 	environ := load()
-	port, err := serial.OpenPort(environ.SerialPort, environ.Baud)
+	// a SERIAL_PORT of sim runs against a simulated controller instead of hardware
+	environ.SerialPort, err = serial.SimulateSpec(context.Background(), environ.SerialPort, sim.New())
+	if err != nil {
+		logger.Fatal("Failed to start simulator", zap.Error(err))
+	}
+	port, err := serial.Open(environ.SerialPort, environ.Baud)
 	if err != nil {
 		logger.Fatal("Failed to open port", zap.Error(err))
 	}
@@ -341,6 +341,28 @@ type Alarm int
 
 func (a Alarm) IsStatusUpdate() {}
 
+// Fault is the text of an Error: message. Marlin stops what it was doing when
+// it sends one.
+type Fault string
+
+func (f Fault) IsStatusUpdate() {}
+
+// Recoverable reports whether the fault is a line number or checksum error,
+// which Marlin follows with a Resend request instead of stopping.
+func (f Fault) Recoverable() bool {
+	return strings.Contains(string(f), "Line Number") || strings.Contains(strings.ToLower(string(f)), "checksum")
+}
+
+// Started is sent when Marlin boots, such as after it was killed and reset.
+type Started struct{}
+
+func (s *Started) IsStatusUpdate() {}
+
+// Resend asks for the lines from N on to be sent again.
+type Resend int
+
+func (r Resend) IsStatusUpdate() {}
+
 func (p *Parser) parseError() (Error, error) {
 	return Error(p.parseInt()), nil
 }
@@ -366,6 +388,36 @@ func (p *Parser) Parse() (ret StatusUpdate, err error) {
 				return &Ack{}, p.discard()
 			case "echo":
 				return &Processing{}, p.discard()
+			case "start":
+				return &Started{}, p.discard()
+			case "T":
+				rest, err := io.ReadAll(p.lexer.rdr)
+				if err != nil {
+					return nil, err
+				}
+				return parseTemperature("T" + string(rest))
+			case "Resend":
+				for {
+					pos, tok, lit := p.lexer.Lex()
+					switch tok {
+					case Colon, Space:
+						continue
+					case Float:
+						n, err := strconv.Atoi(lit)
+						if err != nil {
+							return nil, p.errorf(pos, "expected line number, got %q", lit)
+						}
+						return Resend(n), p.discard()
+					}
+					_ = p.discard()
+					return nil, p.errorf(pos, "expected line number, got %q", lit)
+				}
+			case "Error":
+				msg, err := io.ReadAll(p.lexer.rdr)
+				if err != nil {
+					return nil, err
+				}
+				return Fault(strings.TrimSpace(strings.TrimPrefix(string(msg), ":"))), nil
 			case "alarm":
 				ret, err := p.parseAlarm()
 				if err != nil {
@@ -377,6 +429,9 @@ func (p *Parser) Parse() (ret StatusUpdate, err error) {
 				_ = p.discard()
 				return nil, err
 			}
+		case Space, Return:
+			// temperature reports start with a space
+			continue
 		default:
 			err = p.errorf(pos, "expected identifier, got %q", lit)
 			_ = p.discard()
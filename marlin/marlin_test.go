@@ -33,6 +33,29 @@ var testCases = []struct {
 		buffer: []byte("ok"),
 		expect: &marlin.Ack{},
 	},
+	{
+		name:   "error",
+		buffer: []byte("Error:Printer halted. kill() called!\r\n"),
+		expect: marlin.Fault("Printer halted. kill() called!"),
+	},
+	{
+		name:   "temperature report",
+		buffer: []byte(" T:59.51 /60.00 B:24.91 /0.00 @:127 B@:0 W:?\r\n"),
+		expect: &marlin.Temperature{
+			Hotend: &marlin.Heater{Actual: 59.51, Target: 60},
+			Bed:    &marlin.Heater{Actual: 24.91},
+		},
+	},
+	{
+		name:   "start",
+		buffer: []byte("start\r\n"),
+		expect: &marlin.Started{},
+	},
+	{
+		name:   "resend",
+		buffer: []byte("Resend: 6\r\n"),
+		expect: marlin.Resend(6),
+	},
 }
 
 func TestParse(t *testing.T) {
@@ -70,6 +93,27 @@ func TestParse(t *testing.T) {
 				t.Fatalf("expected ack, got %T", tc.expect)
 			}
 		}
+		if f, ok := u.(marlin.Fault); ok {
+			if e, ok := tc.expect.(marlin.Fault); !ok || f != e {
+				t.Fatalf("expected %v, got %q", tc.expect, f)
+			}
+		}
+		if r, ok := u.(marlin.Resend); ok {
+			if e, ok := tc.expect.(marlin.Resend); !ok || r != e {
+				t.Fatalf("expected %v, got %v", tc.expect, r)
+			}
+		}
+		if temp, ok := u.(*marlin.Temperature); ok {
+			e, ok := tc.expect.(*marlin.Temperature)
+			if !ok || *temp.Hotend != *e.Hotend || *temp.Bed != *e.Bed {
+				t.Fatalf("%s: expected %v, got %v", tc.name, tc.expect, temp)
+			}
+		}
+		if _, ok := u.(*marlin.Started); ok {
+			if _, ok := tc.expect.(*marlin.Started); !ok {
+				t.Fatalf("expected start, got %T", tc.expect)
+			}
+		}
 		if _, ok := u.(*marlin.Processing); ok {
 			if _, ok := tc.expect.(*marlin.Processing); !ok {
 				t.Fatalf("expected processing, got %T", tc.expect)
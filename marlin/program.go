@@ -0,0 +1,98 @@
+package marlin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jt05610/petri/comm/program"
+	proto "github.com/jt05610/petri/marlin/proto/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// MaxResends is how many times a line Marlin rejects is sent again.
+const MaxResends = 3
+
+var errResend = errors.New("resend requested")
+
+// numbered adds a line number and checksum to a block, so Marlin can detect
+// lines garbled or lost on the way.
+func numbered(n int, text string) []byte {
+	line := fmt.Sprintf("N%d %s", n, text)
+	var sum byte
+	for i := 0; i < len(line); i++ {
+		sum ^= line[i]
+	}
+	return []byte(fmt.Sprintf("%s*%d\n", line, sum))
+}
+
+// firmware streams programs to Marlin one line at a time, resending lines it
+// rejects.
+type firmware struct {
+	*Server
+}
+
+func (f firmware) Send(ctx context.Context, i int, b *program.Block) (<-chan error, error) {
+	line := numbered(i+1, b.Text)
+	ret := make(chan error, 1)
+	for tries := 0; ; tries++ {
+		err := f.queue.Do(ctx, line)
+		if errors.Is(err, errResend) && tries < MaxResends {
+			f.logger.Warn("Resending line", zap.Int("line", i+1))
+			continue
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		ret <- err
+		return ret, nil
+	}
+}
+
+// Hold stops sending; Marlin finishes the moves it has already planned.
+func (f firmware) Hold(context.Context) error {
+	return nil
+}
+
+func (f firmware) Resume(context.Context) error {
+	return nil
+}
+
+// Cancel stops the moves Marlin has planned.
+func (f firmware) Cancel(ctx context.Context) error {
+	return f.queue.Do(ctx, []byte("M410\n"))
+}
+
+// StreamProgram runs a G-code program with line numbers and checksums,
+// reporting each line as Marlin acknowledges it.
+func (s *Server) StreamProgram(stream proto.Marlin_StreamProgramServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	prog := req.GetProgram()
+	if prog == nil {
+		return grpcstatus.Error(codes.InvalidArgument, "the first request must carry the program")
+	}
+	blocks := program.Parse(prog.Gcode)
+	s.logger.Info("Streaming program", zap.String("name", prog.Name), zap.Int("blocks", len(blocks)))
+	// number the program's lines from 1
+	if err := s.send(stream.Context(), []byte("M110 N0\n")); err != nil {
+		return err
+	}
+	recv := func() (program.Control, error) {
+		req, err := stream.Recv()
+		return program.Control(req.GetControl()), err
+	}
+	return program.Serve(stream.Context(), firmware{s}, blocks, recv, func(r *program.Response) error {
+		return stream.Send(&proto.StreamProgramResponse{
+			Event:   proto.ProgramEvent(r.Event),
+			Line:    r.Line,
+			Block:   r.Block,
+			Done:    r.Done,
+			Total:   r.Total,
+			Message: r.Message,
+		})
+	})
+}
@@ -2,8 +2,8 @@
 
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.28.1
-// 	protoc        v4.23.4
+// 	protoc-gen-go v1.31.0
+// 	protoc        (unknown)
 // source: v1/marlin.proto
 
 package marlin
@@ -22,6 +22,177 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type AlarmCode int32
+
+const (
+	AlarmCode_AlarmCode_None           AlarmCode = 0
+	AlarmCode_AlarmCode_ThermalRunaway AlarmCode = 1 // a heater stopped tracking its target
+	AlarmCode_AlarmCode_MinTemp        AlarmCode = 2 // a thermistor reads below its minimum, usually disconnected
+	AlarmCode_AlarmCode_MaxTemp        AlarmCode = 3 // a thermistor reads above its maximum
+	AlarmCode_AlarmCode_HeatingFailed  AlarmCode = 4 // a heater did not warm up in time
+	AlarmCode_AlarmCode_Halted         AlarmCode = 5 // Marlin was killed for another reason
+)
+
+// Enum value maps for AlarmCode.
+var (
+	AlarmCode_name = map[int32]string{
+		0: "AlarmCode_None",
+		1: "AlarmCode_ThermalRunaway",
+		2: "AlarmCode_MinTemp",
+		3: "AlarmCode_MaxTemp",
+		4: "AlarmCode_HeatingFailed",
+		5: "AlarmCode_Halted",
+	}
+	AlarmCode_value = map[string]int32{
+		"AlarmCode_None":           0,
+		"AlarmCode_ThermalRunaway": 1,
+		"AlarmCode_MinTemp":        2,
+		"AlarmCode_MaxTemp":        3,
+		"AlarmCode_HeatingFailed":  4,
+		"AlarmCode_Halted":         5,
+	}
+)
+
+func (x AlarmCode) Enum() *AlarmCode {
+	p := new(AlarmCode)
+	*p = x
+	return p
+}
+
+func (x AlarmCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AlarmCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_marlin_proto_enumTypes[0].Descriptor()
+}
+
+func (AlarmCode) Type() protoreflect.EnumType {
+	return &file_v1_marlin_proto_enumTypes[0]
+}
+
+func (x AlarmCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AlarmCode.Descriptor instead.
+func (AlarmCode) EnumDescriptor() ([]byte, []int) {
+	return file_v1_marlin_proto_rawDescGZIP(), []int{0}
+}
+
+type ProgramControl int32
+
+const (
+	ProgramControl_ProgramControl_None   ProgramControl = 0
+	ProgramControl_ProgramControl_Hold   ProgramControl = 1 // stop sending and pause motion
+	ProgramControl_ProgramControl_Resume ProgramControl = 2 // continue after a hold
+	ProgramControl_ProgramControl_Cancel ProgramControl = 3 // stop and discard the rest of the program
+)
+
+// Enum value maps for ProgramControl.
+var (
+	ProgramControl_name = map[int32]string{
+		0: "ProgramControl_None",
+		1: "ProgramControl_Hold",
+		2: "ProgramControl_Resume",
+		3: "ProgramControl_Cancel",
+	}
+	ProgramControl_value = map[string]int32{
+		"ProgramControl_None":   0,
+		"ProgramControl_Hold":   1,
+		"ProgramControl_Resume": 2,
+		"ProgramControl_Cancel": 3,
+	}
+)
+
+func (x ProgramControl) Enum() *ProgramControl {
+	p := new(ProgramControl)
+	*p = x
+	return p
+}
+
+func (x ProgramControl) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProgramControl) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_marlin_proto_enumTypes[1].Descriptor()
+}
+
+func (ProgramControl) Type() protoreflect.EnumType {
+	return &file_v1_marlin_proto_enumTypes[1]
+}
+
+func (x ProgramControl) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProgramControl.Descriptor instead.
+func (ProgramControl) EnumDescriptor() ([]byte, []int) {
+	return file_v1_marlin_proto_rawDescGZIP(), []int{1}
+}
+
+type ProgramEvent int32
+
+const (
+	ProgramEvent_ProgramEvent_Started   ProgramEvent = 0
+	ProgramEvent_ProgramEvent_Acked     ProgramEvent = 1 // a block was acknowledged
+	ProgramEvent_ProgramEvent_Held      ProgramEvent = 2
+	ProgramEvent_ProgramEvent_Resumed   ProgramEvent = 3
+	ProgramEvent_ProgramEvent_Completed ProgramEvent = 4
+	ProgramEvent_ProgramEvent_Cancelled ProgramEvent = 5
+	ProgramEvent_ProgramEvent_Failed    ProgramEvent = 6
+)
+
+// Enum value maps for ProgramEvent.
+var (
+	ProgramEvent_name = map[int32]string{
+		0: "ProgramEvent_Started",
+		1: "ProgramEvent_Acked",
+		2: "ProgramEvent_Held",
+		3: "ProgramEvent_Resumed",
+		4: "ProgramEvent_Completed",
+		5: "ProgramEvent_Cancelled",
+		6: "ProgramEvent_Failed",
+	}
+	ProgramEvent_value = map[string]int32{
+		"ProgramEvent_Started":   0,
+		"ProgramEvent_Acked":     1,
+		"ProgramEvent_Held":      2,
+		"ProgramEvent_Resumed":   3,
+		"ProgramEvent_Completed": 4,
+		"ProgramEvent_Cancelled": 5,
+		"ProgramEvent_Failed":    6,
+	}
+)
+
+func (x ProgramEvent) Enum() *ProgramEvent {
+	p := new(ProgramEvent)
+	*p = x
+	return p
+}
+
+func (x ProgramEvent) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProgramEvent) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_marlin_proto_enumTypes[2].Descriptor()
+}
+
+func (ProgramEvent) Type() protoreflect.EnumType {
+	return &file_v1_marlin_proto_enumTypes[2]
+}
+
+func (x ProgramEvent) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProgramEvent.Descriptor instead.
+func (ProgramEvent) EnumDescriptor() ([]byte, []int) {
+	return file_v1_marlin_proto_rawDescGZIP(), []int{2}
+}
+
 type Position struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -97,19 +268,198 @@ func (x *Position) GetE() float32 {
 	return 0
 }
 
+// temperatures in °C
+type Heater struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Actual float32 `protobuf:"fixed32,1,opt,name=actual,proto3" json:"actual,omitempty"`
+	// 0 when the heater is off
+	Target float32 `protobuf:"fixed32,2,opt,name=target,proto3" json:"target,omitempty"`
+}
+
+func (x *Heater) Reset() {
+	*x = Heater{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_marlin_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Heater) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Heater) ProtoMessage() {}
+
+func (x *Heater) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marlin_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Heater.ProtoReflect.Descriptor instead.
+func (*Heater) Descriptor() ([]byte, []int) {
+	return file_v1_marlin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Heater) GetActual() float32 {
+	if x != nil {
+		return x.Actual
+	}
+	return 0
+}
+
+func (x *Heater) GetTarget() float32 {
+	if x != nil {
+		return x.Target
+	}
+	return 0
+}
+
+type Temperatures struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hotend *Heater `protobuf:"bytes,1,opt,name=hotend,proto3,oneof" json:"hotend,omitempty"`
+	Bed    *Heater `protobuf:"bytes,2,opt,name=bed,proto3,oneof" json:"bed,omitempty"`
+}
+
+func (x *Temperatures) Reset() {
+	*x = Temperatures{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_marlin_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Temperatures) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Temperatures) ProtoMessage() {}
+
+func (x *Temperatures) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marlin_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Temperatures.ProtoReflect.Descriptor instead.
+func (*Temperatures) Descriptor() ([]byte, []int) {
+	return file_v1_marlin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Temperatures) GetHotend() *Heater {
+	if x != nil {
+		return x.Hotend
+	}
+	return nil
+}
+
+func (x *Temperatures) GetBed() *Heater {
+	if x != nil {
+		return x.Bed
+	}
+	return nil
+}
+
+// Marlin stops and ignores commands after an alarm until it is restarted.
+type Alarm struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code    AlarmCode `protobuf:"varint,1,opt,name=code,proto3,enum=AlarmCode" json:"code,omitempty"`
+	Message string    `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// the heater that raised the alarm, such as 0 or bed
+	Heater *string `protobuf:"bytes,3,opt,name=heater,proto3,oneof" json:"heater,omitempty"`
+}
+
+func (x *Alarm) Reset() {
+	*x = Alarm{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_marlin_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Alarm) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Alarm) ProtoMessage() {}
+
+func (x *Alarm) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marlin_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Alarm.ProtoReflect.Descriptor instead.
+func (*Alarm) Descriptor() ([]byte, []int) {
+	return file_v1_marlin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Alarm) GetCode() AlarmCode {
+	if x != nil {
+		return x.Code
+	}
+	return AlarmCode_AlarmCode_None
+}
+
+func (x *Alarm) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Alarm) GetHeater() string {
+	if x != nil && x.Heater != nil {
+		return *x.Heater
+	}
+	return ""
+}
+
 type State struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Position *Position `protobuf:"bytes,1,opt,name=position,proto3" json:"position,omitempty"`
-	Speed    float32   `protobuf:"fixed32,2,opt,name=speed,proto3" json:"speed,omitempty"`
+	Position     *Position     `protobuf:"bytes,1,opt,name=position,proto3" json:"position,omitempty"`
+	Speed        float32       `protobuf:"fixed32,2,opt,name=speed,proto3" json:"speed,omitempty"`
+	Temperatures *Temperatures `protobuf:"bytes,3,opt,name=temperatures,proto3,oneof" json:"temperatures,omitempty"`
+	Alarm        *Alarm        `protobuf:"bytes,4,opt,name=alarm,proto3,oneof" json:"alarm,omitempty"`
 }
 
 func (x *State) Reset() {
 	*x = State{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_marlin_proto_msgTypes[1]
+		mi := &file_v1_marlin_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -122,7 +472,7 @@ func (x *State) String() string {
 func (*State) ProtoMessage() {}
 
 func (x *State) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_marlin_proto_msgTypes[1]
+	mi := &file_v1_marlin_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -135,7 +485,7 @@ func (x *State) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use State.ProtoReflect.Descriptor instead.
 func (*State) Descriptor() ([]byte, []int) {
-	return file_v1_marlin_proto_rawDescGZIP(), []int{1}
+	return file_v1_marlin_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *State) GetPosition() *Position {
@@ -152,6 +502,20 @@ func (x *State) GetSpeed() float32 {
 	return 0
 }
 
+func (x *State) GetTemperatures() *Temperatures {
+	if x != nil {
+		return x.Temperatures
+	}
+	return nil
+}
+
+func (x *State) GetAlarm() *Alarm {
+	if x != nil {
+		return x.Alarm
+	}
+	return nil
+}
+
 type StateStreamRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -161,7 +525,7 @@ type StateStreamRequest struct {
 func (x *StateStreamRequest) Reset() {
 	*x = StateStreamRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_marlin_proto_msgTypes[2]
+		mi := &file_v1_marlin_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -174,7 +538,7 @@ func (x *StateStreamRequest) String() string {
 func (*StateStreamRequest) ProtoMessage() {}
 
 func (x *StateStreamRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_marlin_proto_msgTypes[2]
+	mi := &file_v1_marlin_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -187,7 +551,7 @@ func (x *StateStreamRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StateStreamRequest.ProtoReflect.Descriptor instead.
 func (*StateStreamRequest) Descriptor() ([]byte, []int) {
-	return file_v1_marlin_proto_rawDescGZIP(), []int{2}
+	return file_v1_marlin_proto_rawDescGZIP(), []int{5}
 }
 
 type StateStreamResponse struct {
@@ -202,7 +566,7 @@ type StateStreamResponse struct {
 func (x *StateStreamResponse) Reset() {
 	*x = StateStreamResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_marlin_proto_msgTypes[3]
+		mi := &file_v1_marlin_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -215,7 +579,7 @@ func (x *StateStreamResponse) String() string {
 func (*StateStreamResponse) ProtoMessage() {}
 
 func (x *StateStreamResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_marlin_proto_msgTypes[3]
+	mi := &file_v1_marlin_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -228,7 +592,7 @@ func (x *StateStreamResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StateStreamResponse.ProtoReflect.Descriptor instead.
 func (*StateStreamResponse) Descriptor() ([]byte, []int) {
-	return file_v1_marlin_proto_rawDescGZIP(), []int{3}
+	return file_v1_marlin_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *StateStreamResponse) GetState() *State {
@@ -251,7 +615,6 @@ type HomeRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	// Types that are assignable to Axis:
-	//
 	//	*HomeRequest_All
 	//	*HomeRequest_X
 	//	*HomeRequest_Y
@@ -262,7 +625,7 @@ type HomeRequest struct {
 func (x *HomeRequest) Reset() {
 	*x = HomeRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_marlin_proto_msgTypes[4]
+		mi := &file_v1_marlin_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -275,7 +638,7 @@ func (x *HomeRequest) String() string {
 func (*HomeRequest) ProtoMessage() {}
 
 func (x *HomeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_marlin_proto_msgTypes[4]
+	mi := &file_v1_marlin_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -288,7 +651,7 @@ func (x *HomeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HomeRequest.ProtoReflect.Descriptor instead.
 func (*HomeRequest) Descriptor() ([]byte, []int) {
-	return file_v1_marlin_proto_rawDescGZIP(), []int{4}
+	return file_v1_marlin_proto_rawDescGZIP(), []int{7}
 }
 
 func (m *HomeRequest) GetAxis() isHomeRequest_Axis {
@@ -374,7 +737,7 @@ type MoveRequest struct {
 func (x *MoveRequest) Reset() {
 	*x = MoveRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_marlin_proto_msgTypes[5]
+		mi := &file_v1_marlin_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -387,7 +750,7 @@ func (x *MoveRequest) String() string {
 func (*MoveRequest) ProtoMessage() {}
 
 func (x *MoveRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_marlin_proto_msgTypes[5]
+	mi := &file_v1_marlin_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -400,7 +763,7 @@ func (x *MoveRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MoveRequest.ProtoReflect.Descriptor instead.
 func (*MoveRequest) Descriptor() ([]byte, []int) {
-	return file_v1_marlin_proto_rawDescGZIP(), []int{5}
+	return file_v1_marlin_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *MoveRequest) GetX() float32 {
@@ -449,7 +812,7 @@ type MoveResponse struct {
 func (x *MoveResponse) Reset() {
 	*x = MoveResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_marlin_proto_msgTypes[6]
+		mi := &file_v1_marlin_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -462,7 +825,7 @@ func (x *MoveResponse) String() string {
 func (*MoveResponse) ProtoMessage() {}
 
 func (x *MoveResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_marlin_proto_msgTypes[6]
+	mi := &file_v1_marlin_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -475,7 +838,7 @@ func (x *MoveResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MoveResponse.ProtoReflect.Descriptor instead.
 func (*MoveResponse) Descriptor() ([]byte, []int) {
-	return file_v1_marlin_proto_rawDescGZIP(), []int{6}
+	return file_v1_marlin_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *MoveResponse) GetMessage() string {
@@ -494,7 +857,7 @@ type FanOnRequest struct {
 func (x *FanOnRequest) Reset() {
 	*x = FanOnRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_marlin_proto_msgTypes[7]
+		mi := &file_v1_marlin_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -507,7 +870,7 @@ func (x *FanOnRequest) String() string {
 func (*FanOnRequest) ProtoMessage() {}
 
 func (x *FanOnRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_marlin_proto_msgTypes[7]
+	mi := &file_v1_marlin_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -520,7 +883,7 @@ func (x *FanOnRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FanOnRequest.ProtoReflect.Descriptor instead.
 func (*FanOnRequest) Descriptor() ([]byte, []int) {
-	return file_v1_marlin_proto_rawDescGZIP(), []int{7}
+	return file_v1_marlin_proto_rawDescGZIP(), []int{10}
 }
 
 type FanOnResponse struct {
@@ -534,7 +897,7 @@ type FanOnResponse struct {
 func (x *FanOnResponse) Reset() {
 	*x = FanOnResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_marlin_proto_msgTypes[8]
+		mi := &file_v1_marlin_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -547,7 +910,7 @@ func (x *FanOnResponse) String() string {
 func (*FanOnResponse) ProtoMessage() {}
 
 func (x *FanOnResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_marlin_proto_msgTypes[8]
+	mi := &file_v1_marlin_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -560,7 +923,7 @@ func (x *FanOnResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FanOnResponse.ProtoReflect.Descriptor instead.
 func (*FanOnResponse) Descriptor() ([]byte, []int) {
-	return file_v1_marlin_proto_rawDescGZIP(), []int{8}
+	return file_v1_marlin_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *FanOnResponse) GetMessage() string {
@@ -579,7 +942,7 @@ type FanOffRequest struct {
 func (x *FanOffRequest) Reset() {
 	*x = FanOffRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_marlin_proto_msgTypes[9]
+		mi := &file_v1_marlin_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -592,7 +955,7 @@ func (x *FanOffRequest) String() string {
 func (*FanOffRequest) ProtoMessage() {}
 
 func (x *FanOffRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_marlin_proto_msgTypes[9]
+	mi := &file_v1_marlin_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -605,7 +968,7 @@ func (x *FanOffRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FanOffRequest.ProtoReflect.Descriptor instead.
 func (*FanOffRequest) Descriptor() ([]byte, []int) {
-	return file_v1_marlin_proto_rawDescGZIP(), []int{9}
+	return file_v1_marlin_proto_rawDescGZIP(), []int{12}
 }
 
 type FanOffResponse struct {
@@ -619,7 +982,7 @@ type FanOffResponse struct {
 func (x *FanOffResponse) Reset() {
 	*x = FanOffResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_marlin_proto_msgTypes[10]
+		mi := &file_v1_marlin_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -632,7 +995,7 @@ func (x *FanOffResponse) String() string {
 func (*FanOffResponse) ProtoMessage() {}
 
 func (x *FanOffResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_marlin_proto_msgTypes[10]
+	mi := &file_v1_marlin_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -645,7 +1008,7 @@ func (x *FanOffResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FanOffResponse.ProtoReflect.Descriptor instead.
 func (*FanOffResponse) Descriptor() ([]byte, []int) {
-	return file_v1_marlin_proto_rawDescGZIP(), []int{10}
+	return file_v1_marlin_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *FanOffResponse) GetMessage() string {
@@ -655,6 +1018,63 @@ func (x *FanOffResponse) GetMessage() string {
 	return ""
 }
 
+type SetTemperatureRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// target in °C, 0 to turn the heater off
+	Target float32 `protobuf:"fixed32,1,opt,name=target,proto3" json:"target,omitempty"`
+	// wait until the heater has warmed up to target
+	Wait bool `protobuf:"varint,2,opt,name=wait,proto3" json:"wait,omitempty"`
+}
+
+func (x *SetTemperatureRequest) Reset() {
+	*x = SetTemperatureRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_marlin_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetTemperatureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTemperatureRequest) ProtoMessage() {}
+
+func (x *SetTemperatureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marlin_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTemperatureRequest.ProtoReflect.Descriptor instead.
+func (*SetTemperatureRequest) Descriptor() ([]byte, []int) {
+	return file_v1_marlin_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SetTemperatureRequest) GetTarget() float32 {
+	if x != nil {
+		return x.Target
+	}
+	return 0
+}
+
+func (x *SetTemperatureRequest) GetWait() bool {
+	if x != nil {
+		return x.Wait
+	}
+	return false
+}
+
 type Response struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -662,7 +1082,6 @@ type Response struct {
 
 	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
 	// Types that are assignable to Response:
-	//
 	//	*Response_State
 	//	*Response_Move
 	Response isResponse_Response `protobuf_oneof:"response"`
@@ -671,7 +1090,7 @@ type Response struct {
 func (x *Response) Reset() {
 	*x = Response{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_marlin_proto_msgTypes[11]
+		mi := &file_v1_marlin_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -684,7 +1103,7 @@ func (x *Response) String() string {
 func (*Response) ProtoMessage() {}
 
 func (x *Response) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_marlin_proto_msgTypes[11]
+	mi := &file_v1_marlin_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -697,7 +1116,7 @@ func (x *Response) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Response.ProtoReflect.Descriptor instead.
 func (*Response) Descriptor() ([]byte, []int) {
-	return file_v1_marlin_proto_rawDescGZIP(), []int{11}
+	return file_v1_marlin_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *Response) GetMessage() string {
@@ -744,6 +1163,233 @@ func (*Response_State) isResponse_Response() {}
 
 func (*Response_Move) isResponse_Response() {}
 
+type Program struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// name shown in logs
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// G-code, one block per line
+	Gcode string `protobuf:"bytes,2,opt,name=gcode,proto3" json:"gcode,omitempty"`
+}
+
+func (x *Program) Reset() {
+	*x = Program{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_marlin_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Program) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Program) ProtoMessage() {}
+
+func (x *Program) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marlin_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Program.ProtoReflect.Descriptor instead.
+func (*Program) Descriptor() ([]byte, []int) {
+	return file_v1_marlin_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Program) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Program) GetGcode() string {
+	if x != nil {
+		return x.Gcode
+	}
+	return ""
+}
+
+// The first request carries the program; later ones control it.
+type StreamProgramRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Request:
+	//	*StreamProgramRequest_Program
+	//	*StreamProgramRequest_Control
+	Request isStreamProgramRequest_Request `protobuf_oneof:"request"`
+}
+
+func (x *StreamProgramRequest) Reset() {
+	*x = StreamProgramRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_marlin_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamProgramRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamProgramRequest) ProtoMessage() {}
+
+func (x *StreamProgramRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marlin_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamProgramRequest.ProtoReflect.Descriptor instead.
+func (*StreamProgramRequest) Descriptor() ([]byte, []int) {
+	return file_v1_marlin_proto_rawDescGZIP(), []int{17}
+}
+
+func (m *StreamProgramRequest) GetRequest() isStreamProgramRequest_Request {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (x *StreamProgramRequest) GetProgram() *Program {
+	if x, ok := x.GetRequest().(*StreamProgramRequest_Program); ok {
+		return x.Program
+	}
+	return nil
+}
+
+func (x *StreamProgramRequest) GetControl() ProgramControl {
+	if x, ok := x.GetRequest().(*StreamProgramRequest_Control); ok {
+		return x.Control
+	}
+	return ProgramControl_ProgramControl_None
+}
+
+type isStreamProgramRequest_Request interface {
+	isStreamProgramRequest_Request()
+}
+
+type StreamProgramRequest_Program struct {
+	Program *Program `protobuf:"bytes,1,opt,name=program,proto3,oneof"`
+}
+
+type StreamProgramRequest_Control struct {
+	Control ProgramControl `protobuf:"varint,2,opt,name=control,proto3,enum=ProgramControl,oneof"`
+}
+
+func (*StreamProgramRequest_Program) isStreamProgramRequest_Request() {}
+
+func (*StreamProgramRequest_Control) isStreamProgramRequest_Request() {}
+
+type StreamProgramResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Event ProgramEvent `protobuf:"varint,1,opt,name=event,proto3,enum=ProgramEvent" json:"event,omitempty"`
+	// line of the program the block came from, counting from 1
+	Line  int32  `protobuf:"varint,2,opt,name=line,proto3" json:"line,omitempty"`
+	Block string `protobuf:"bytes,3,opt,name=block,proto3" json:"block,omitempty"`
+	// blocks acknowledged so far
+	Done    int32  `protobuf:"varint,4,opt,name=done,proto3" json:"done,omitempty"`
+	Total   int32  `protobuf:"varint,5,opt,name=total,proto3" json:"total,omitempty"`
+	Message string `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *StreamProgramResponse) Reset() {
+	*x = StreamProgramResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_marlin_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamProgramResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamProgramResponse) ProtoMessage() {}
+
+func (x *StreamProgramResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marlin_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamProgramResponse.ProtoReflect.Descriptor instead.
+func (*StreamProgramResponse) Descriptor() ([]byte, []int) {
+	return file_v1_marlin_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *StreamProgramResponse) GetEvent() ProgramEvent {
+	if x != nil {
+		return x.Event
+	}
+	return ProgramEvent_ProgramEvent_Started
+}
+
+func (x *StreamProgramResponse) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *StreamProgramResponse) GetBlock() string {
+	if x != nil {
+		return x.Block
+	}
+	return ""
+}
+
+func (x *StreamProgramResponse) GetDone() int32 {
+	if x != nil {
+		return x.Done
+	}
+	return 0
+}
+
+func (x *StreamProgramResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *StreamProgramResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 var File_v1_marlin_proto protoreflect.FileDescriptor
 
 var file_v1_marlin_proto_rawDesc = []byte{
@@ -752,66 +1398,157 @@ var file_v1_marlin_proto_rawDesc = []byte{
 	0x01, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79,
 	0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x01, 0x79, 0x12, 0x0c, 0x0a, 0x01, 0x7a, 0x18, 0x03,
 	0x20, 0x01, 0x28, 0x02, 0x52, 0x01, 0x7a, 0x12, 0x0c, 0x0a, 0x01, 0x65, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x02, 0x52, 0x01, 0x65, 0x22, 0x44, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x25,
-	0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x09, 0x2e, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x70, 0x6f, 0x73,
-	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x22, 0x14, 0x0a, 0x12, 0x53,
-	0x74, 0x61, 0x74, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x22, 0x51, 0x0a, 0x13, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52,
-	0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
-	0x74, 0x61, 0x6d, 0x70, 0x22, 0x59, 0x0a, 0x0b, 0x48, 0x6f, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x03, 0x61, 0x6c, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
-	0x48, 0x00, 0x52, 0x03, 0x61, 0x6c, 0x6c, 0x12, 0x0e, 0x0a, 0x01, 0x58, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x08, 0x48, 0x00, 0x52, 0x01, 0x58, 0x12, 0x0e, 0x0a, 0x01, 0x59, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x08, 0x48, 0x00, 0x52, 0x01, 0x59, 0x12, 0x0e, 0x0a, 0x01, 0x5a, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x08, 0x48, 0x00, 0x52, 0x01, 0x5a, 0x42, 0x06, 0x0a, 0x04, 0x61, 0x78, 0x69, 0x73, 0x22,
-	0x96, 0x01, 0x0a, 0x0b, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x11, 0x0a, 0x01, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x48, 0x00, 0x52, 0x01, 0x78, 0x88,
-	0x01, 0x01, 0x12, 0x11, 0x0a, 0x01, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x48, 0x01, 0x52,
-	0x01, 0x79, 0x88, 0x01, 0x01, 0x12, 0x11, 0x0a, 0x01, 0x7a, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02,
-	0x48, 0x02, 0x52, 0x01, 0x7a, 0x88, 0x01, 0x01, 0x12, 0x11, 0x0a, 0x01, 0x65, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x02, 0x48, 0x03, 0x52, 0x01, 0x65, 0x88, 0x01, 0x01, 0x12, 0x19, 0x0a, 0x05, 0x73,
-	0x70, 0x65, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x02, 0x48, 0x04, 0x52, 0x05, 0x73, 0x70,
-	0x65, 0x65, 0x64, 0x88, 0x01, 0x01, 0x42, 0x04, 0x0a, 0x02, 0x5f, 0x78, 0x42, 0x04, 0x0a, 0x02,
-	0x5f, 0x79, 0x42, 0x04, 0x0a, 0x02, 0x5f, 0x7a, 0x42, 0x04, 0x0a, 0x02, 0x5f, 0x65, 0x42, 0x08,
-	0x0a, 0x06, 0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x22, 0x28, 0x0a, 0x0c, 0x4d, 0x6f, 0x76, 0x65,
+	0x28, 0x02, 0x52, 0x01, 0x65, 0x22, 0x38, 0x0a, 0x06, 0x48, 0x65, 0x61, 0x74, 0x65, 0x72, 0x12,
+	0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x52,
+	0x06, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22,
+	0x67, 0x0a, 0x0c, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x12,
+	0x24, 0x0a, 0x06, 0x68, 0x6f, 0x74, 0x65, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x07, 0x2e, 0x48, 0x65, 0x61, 0x74, 0x65, 0x72, 0x48, 0x00, 0x52, 0x06, 0x68, 0x6f, 0x74, 0x65,
+	0x6e, 0x64, 0x88, 0x01, 0x01, 0x12, 0x1e, 0x0a, 0x03, 0x62, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x07, 0x2e, 0x48, 0x65, 0x61, 0x74, 0x65, 0x72, 0x48, 0x01, 0x52, 0x03, 0x62,
+	0x65, 0x64, 0x88, 0x01, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x68, 0x6f, 0x74, 0x65, 0x6e, 0x64,
+	0x42, 0x06, 0x0a, 0x04, 0x5f, 0x62, 0x65, 0x64, 0x22, 0x69, 0x0a, 0x05, 0x41, 0x6c, 0x61, 0x72,
+	0x6d, 0x12, 0x1e, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x0a, 0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x63, 0x6f, 0x64,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x06, 0x68,
+	0x65, 0x61, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x68,
+	0x65, 0x61, 0x74, 0x65, 0x72, 0x88, 0x01, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x68, 0x65, 0x61,
+	0x74, 0x65, 0x72, 0x22, 0xba, 0x01, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x25, 0x0a,
+	0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x09, 0x2e, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x02, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x12, 0x36, 0x0a, 0x0c, 0x74, 0x65,
+	0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x48,
+	0x00, 0x52, 0x0c, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x88,
+	0x01, 0x01, 0x12, 0x21, 0x0a, 0x05, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x06, 0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x48, 0x01, 0x52, 0x05, 0x61, 0x6c, 0x61,
+	0x72, 0x6d, 0x88, 0x01, 0x01, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x61, 0x6c, 0x61, 0x72, 0x6d,
+	0x22, 0x14, 0x0a, 0x12, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x51, 0x0a, 0x13, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a,
+	0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x59, 0x0a, 0x0b, 0x48, 0x6f, 0x6d,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x03, 0x61, 0x6c, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x03, 0x61, 0x6c, 0x6c, 0x12, 0x0e, 0x0a, 0x01,
+	0x58, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x01, 0x58, 0x12, 0x0e, 0x0a, 0x01,
+	0x59, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x01, 0x59, 0x12, 0x0e, 0x0a, 0x01,
+	0x5a, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x01, 0x5a, 0x42, 0x06, 0x0a, 0x04,
+	0x61, 0x78, 0x69, 0x73, 0x22, 0x96, 0x01, 0x0a, 0x0b, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x11, 0x0a, 0x01, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x48,
+	0x00, 0x52, 0x01, 0x78, 0x88, 0x01, 0x01, 0x12, 0x11, 0x0a, 0x01, 0x79, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x02, 0x48, 0x01, 0x52, 0x01, 0x79, 0x88, 0x01, 0x01, 0x12, 0x11, 0x0a, 0x01, 0x7a, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x02, 0x48, 0x02, 0x52, 0x01, 0x7a, 0x88, 0x01, 0x01, 0x12, 0x11, 0x0a,
+	0x01, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x48, 0x03, 0x52, 0x01, 0x65, 0x88, 0x01, 0x01,
+	0x12, 0x19, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x02, 0x48,
+	0x04, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x88, 0x01, 0x01, 0x42, 0x04, 0x0a, 0x02, 0x5f,
+	0x78, 0x42, 0x04, 0x0a, 0x02, 0x5f, 0x79, 0x42, 0x04, 0x0a, 0x02, 0x5f, 0x7a, 0x42, 0x04, 0x0a,
+	0x02, 0x5f, 0x65, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x22, 0x28, 0x0a,
+	0x0c, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x0e, 0x0a, 0x0c, 0x46, 0x61, 0x6e, 0x4f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x29, 0x0a, 0x0d, 0x46, 0x61, 0x6e, 0x4f, 0x6e,
 	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
 	0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x22, 0x0e, 0x0a, 0x0c, 0x46, 0x61, 0x6e, 0x4f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x22, 0x29, 0x0a, 0x0d, 0x46, 0x61, 0x6e, 0x4f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x0f, 0x0a,
-	0x0d, 0x46, 0x61, 0x6e, 0x4f, 0x66, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x2a,
-	0x0a, 0x0e, 0x46, 0x61, 0x6e, 0x4f, 0x66, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x67, 0x65, 0x22, 0x0f, 0x0a, 0x0d, 0x46, 0x61, 0x6e, 0x4f, 0x66, 0x66, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0x2a, 0x0a, 0x0e, 0x46, 0x61, 0x6e, 0x4f, 0x66, 0x66, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
+	0x43, 0x0a, 0x15, 0x53, 0x65, 0x74, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x77, 0x61, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04,
+	0x77, 0x61, 0x69, 0x74, 0x22, 0x75, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
 	0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x75, 0x0a, 0x08, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x12, 0x1e, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x06, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65,
-	0x12, 0x23, 0x0a, 0x04, 0x6d, 0x6f, 0x76, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d,
-	0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52,
-	0x04, 0x6d, 0x6f, 0x76, 0x65, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x32, 0xd8, 0x01, 0x0a, 0x06, 0x4d, 0x61, 0x72, 0x6c, 0x69, 0x6e, 0x12, 0x21, 0x0a, 0x04,
-	0x48, 0x6f, 0x6d, 0x65, 0x12, 0x0c, 0x2e, 0x48, 0x6f, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x09, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x3c, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x13,
-	0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61,
-	0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x21, 0x0a,
-	0x04, 0x4d, 0x6f, 0x76, 0x65, 0x12, 0x0c, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x12, 0x23, 0x0a, 0x05, 0x46, 0x61, 0x6e, 0x4f, 0x6e, 0x12, 0x0d, 0x2e, 0x46, 0x61, 0x6e, 0x4f,
-	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x25, 0x0a, 0x06, 0x46, 0x61, 0x6e, 0x4f, 0x66, 0x66, 0x12,
-	0x0e, 0x2e, 0x46, 0x61, 0x6e, 0x4f, 0x66, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x09, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x0b, 0x5a, 0x09,
-	0x76, 0x31, 0x2f, 0x6d, 0x61, 0x72, 0x6c, 0x69, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1e, 0x0a, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x48, 0x00, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x23, 0x0a, 0x04, 0x6d, 0x6f,
+	0x76, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x04, 0x6d, 0x6f, 0x76, 0x65, 0x42,
+	0x0a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x33, 0x0a, 0x07, 0x50,
+	0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x63,
+	0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x63, 0x6f, 0x64, 0x65,
+	0x22, 0x74, 0x0a, 0x14, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61,
+	0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x67,
+	0x72, 0x61, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x50, 0x72, 0x6f, 0x67,
+	0x72, 0x61, 0x6d, 0x48, 0x00, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x12, 0x2b,
+	0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x0f, 0x2e, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x48, 0x00, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x42, 0x09, 0x0a, 0x07, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xaa, 0x01, 0x0a, 0x15, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x23, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x0d, 0x2e, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x05,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x12,
+	0x12, 0x0a, 0x04, 0x64, 0x6f, 0x6e, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x64,
+	0x6f, 0x6e, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x2a, 0x9e, 0x01, 0x0a, 0x09, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x43, 0x6f, 0x64,
+	0x65, 0x12, 0x12, 0x0a, 0x0e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x4e,
+	0x6f, 0x6e, 0x65, 0x10, 0x00, 0x12, 0x1c, 0x0a, 0x18, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x43, 0x6f,
+	0x64, 0x65, 0x5f, 0x54, 0x68, 0x65, 0x72, 0x6d, 0x61, 0x6c, 0x52, 0x75, 0x6e, 0x61, 0x77, 0x61,
+	0x79, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x43, 0x6f, 0x64, 0x65,
+	0x5f, 0x4d, 0x69, 0x6e, 0x54, 0x65, 0x6d, 0x70, 0x10, 0x02, 0x12, 0x15, 0x0a, 0x11, 0x41, 0x6c,
+	0x61, 0x72, 0x6d, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x4d, 0x61, 0x78, 0x54, 0x65, 0x6d, 0x70, 0x10,
+	0x03, 0x12, 0x1b, 0x0a, 0x17, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x48,
+	0x65, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0x04, 0x12, 0x14,
+	0x0a, 0x10, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x43, 0x6f, 0x64, 0x65, 0x5f, 0x48, 0x61, 0x6c, 0x74,
+	0x65, 0x64, 0x10, 0x05, 0x2a, 0x78, 0x0a, 0x0e, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x43,
+	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x12, 0x17, 0x0a, 0x13, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61,
+	0x6d, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x4e, 0x6f, 0x6e, 0x65, 0x10, 0x00, 0x12,
+	0x17, 0x0a, 0x13, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
+	0x6c, 0x5f, 0x48, 0x6f, 0x6c, 0x64, 0x10, 0x01, 0x12, 0x19, 0x0a, 0x15, 0x50, 0x72, 0x6f, 0x67,
+	0x72, 0x61, 0x6d, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x52, 0x65, 0x73, 0x75, 0x6d,
+	0x65, 0x10, 0x02, 0x12, 0x19, 0x0a, 0x15, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x43, 0x6f,
+	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x10, 0x03, 0x2a, 0xc2,
+	0x01, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12,
+	0x18, 0x0a, 0x14, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x5f,
+	0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x50, 0x72, 0x6f,
+	0x67, 0x72, 0x61, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x41, 0x63, 0x6b, 0x65, 0x64, 0x10,
+	0x01, 0x12, 0x15, 0x0a, 0x11, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x5f, 0x48, 0x65, 0x6c, 0x64, 0x10, 0x02, 0x12, 0x18, 0x0a, 0x14, 0x50, 0x72, 0x6f, 0x67,
+	0x72, 0x61, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x64,
+	0x10, 0x03, 0x12, 0x1a, 0x0a, 0x16, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x5f, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x10, 0x04, 0x12, 0x1a,
+	0x0a, 0x16, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x43,
+	0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x65, 0x64, 0x10, 0x05, 0x12, 0x17, 0x0a, 0x13, 0x50, 0x72,
+	0x6f, 0x67, 0x72, 0x61, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x46, 0x61, 0x69, 0x6c, 0x65,
+	0x64, 0x10, 0x06, 0x32, 0x95, 0x03, 0x0a, 0x06, 0x4d, 0x61, 0x72, 0x6c, 0x69, 0x6e, 0x12, 0x21,
+	0x0a, 0x04, 0x48, 0x6f, 0x6d, 0x65, 0x12, 0x0c, 0x2e, 0x48, 0x6f, 0x6d, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x3c, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x12, 0x13, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12,
+	0x44, 0x0a, 0x0d, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d,
+	0x12, 0x15, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x21, 0x0a, 0x04, 0x4d, 0x6f, 0x76, 0x65, 0x12, 0x0c, 0x2e,
+	0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x23, 0x0a, 0x05, 0x46, 0x61, 0x6e, 0x4f,
+	0x6e, 0x12, 0x0d, 0x2e, 0x46, 0x61, 0x6e, 0x4f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x09, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x25, 0x0a,
+	0x06, 0x46, 0x61, 0x6e, 0x4f, 0x66, 0x66, 0x12, 0x0e, 0x2e, 0x46, 0x61, 0x6e, 0x4f, 0x66, 0x66,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x14, 0x53, 0x65, 0x74, 0x48, 0x6f, 0x74, 0x65, 0x6e,
+	0x64, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x16, 0x2e, 0x53,
+	0x65, 0x74, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x38, 0x0a, 0x11, 0x53, 0x65, 0x74, 0x42, 0x65, 0x64, 0x54, 0x65, 0x6d, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x16, 0x2e, 0x53, 0x65, 0x74, 0x54, 0x65, 0x6d, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x09,
+	0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x0b, 0x5a, 0x09, 0x76,
+	0x31, 0x2f, 0x6d, 0x61, 0x72, 0x6c, 0x69, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -826,41 +1563,66 @@ func file_v1_marlin_proto_rawDescGZIP() []byte {
 	return file_v1_marlin_proto_rawDescData
 }
 
-var file_v1_marlin_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_v1_marlin_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_v1_marlin_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_v1_marlin_proto_goTypes = []interface{}{
-	(*Position)(nil),            // 0: Position
-	(*State)(nil),               // 1: State
-	(*StateStreamRequest)(nil),  // 2: StateStreamRequest
-	(*StateStreamResponse)(nil), // 3: StateStreamResponse
-	(*HomeRequest)(nil),         // 4: HomeRequest
-	(*MoveRequest)(nil),         // 5: MoveRequest
-	(*MoveResponse)(nil),        // 6: MoveResponse
-	(*FanOnRequest)(nil),        // 7: FanOnRequest
-	(*FanOnResponse)(nil),       // 8: FanOnResponse
-	(*FanOffRequest)(nil),       // 9: FanOffRequest
-	(*FanOffResponse)(nil),      // 10: FanOffResponse
-	(*Response)(nil),            // 11: Response
+	(AlarmCode)(0),                // 0: AlarmCode
+	(ProgramControl)(0),           // 1: ProgramControl
+	(ProgramEvent)(0),             // 2: ProgramEvent
+	(*Position)(nil),              // 3: Position
+	(*Heater)(nil),                // 4: Heater
+	(*Temperatures)(nil),          // 5: Temperatures
+	(*Alarm)(nil),                 // 6: Alarm
+	(*State)(nil),                 // 7: State
+	(*StateStreamRequest)(nil),    // 8: StateStreamRequest
+	(*StateStreamResponse)(nil),   // 9: StateStreamResponse
+	(*HomeRequest)(nil),           // 10: HomeRequest
+	(*MoveRequest)(nil),           // 11: MoveRequest
+	(*MoveResponse)(nil),          // 12: MoveResponse
+	(*FanOnRequest)(nil),          // 13: FanOnRequest
+	(*FanOnResponse)(nil),         // 14: FanOnResponse
+	(*FanOffRequest)(nil),         // 15: FanOffRequest
+	(*FanOffResponse)(nil),        // 16: FanOffResponse
+	(*SetTemperatureRequest)(nil), // 17: SetTemperatureRequest
+	(*Response)(nil),              // 18: Response
+	(*Program)(nil),               // 19: Program
+	(*StreamProgramRequest)(nil),  // 20: StreamProgramRequest
+	(*StreamProgramResponse)(nil), // 21: StreamProgramResponse
 }
 var file_v1_marlin_proto_depIdxs = []int32{
-	0,  // 0: State.position:type_name -> Position
-	1,  // 1: StateStreamResponse.state:type_name -> State
-	1,  // 2: Response.state:type_name -> State
-	6,  // 3: Response.move:type_name -> MoveResponse
-	4,  // 4: Marlin.Home:input_type -> HomeRequest
-	2,  // 5: Marlin.StateStream:input_type -> StateStreamRequest
-	5,  // 6: Marlin.Move:input_type -> MoveRequest
-	7,  // 7: Marlin.FanOn:input_type -> FanOnRequest
-	9,  // 8: Marlin.FanOff:input_type -> FanOffRequest
-	11, // 9: Marlin.Home:output_type -> Response
-	3,  // 10: Marlin.StateStream:output_type -> StateStreamResponse
-	11, // 11: Marlin.Move:output_type -> Response
-	11, // 12: Marlin.FanOn:output_type -> Response
-	11, // 13: Marlin.FanOff:output_type -> Response
-	9,  // [9:14] is the sub-list for method output_type
-	4,  // [4:9] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	4,  // 0: Temperatures.hotend:type_name -> Heater
+	4,  // 1: Temperatures.bed:type_name -> Heater
+	0,  // 2: Alarm.code:type_name -> AlarmCode
+	3,  // 3: State.position:type_name -> Position
+	5,  // 4: State.temperatures:type_name -> Temperatures
+	6,  // 5: State.alarm:type_name -> Alarm
+	7,  // 6: StateStreamResponse.state:type_name -> State
+	7,  // 7: Response.state:type_name -> State
+	12, // 8: Response.move:type_name -> MoveResponse
+	19, // 9: StreamProgramRequest.program:type_name -> Program
+	1,  // 10: StreamProgramRequest.control:type_name -> ProgramControl
+	2,  // 11: StreamProgramResponse.event:type_name -> ProgramEvent
+	10, // 12: Marlin.Home:input_type -> HomeRequest
+	8,  // 13: Marlin.StateStream:input_type -> StateStreamRequest
+	20, // 14: Marlin.StreamProgram:input_type -> StreamProgramRequest
+	11, // 15: Marlin.Move:input_type -> MoveRequest
+	13, // 16: Marlin.FanOn:input_type -> FanOnRequest
+	15, // 17: Marlin.FanOff:input_type -> FanOffRequest
+	17, // 18: Marlin.SetHotendTemperature:input_type -> SetTemperatureRequest
+	17, // 19: Marlin.SetBedTemperature:input_type -> SetTemperatureRequest
+	18, // 20: Marlin.Home:output_type -> Response
+	9,  // 21: Marlin.StateStream:output_type -> StateStreamResponse
+	21, // 22: Marlin.StreamProgram:output_type -> StreamProgramResponse
+	18, // 23: Marlin.Move:output_type -> Response
+	18, // 24: Marlin.FanOn:output_type -> Response
+	18, // 25: Marlin.FanOff:output_type -> Response
+	18, // 26: Marlin.SetHotendTemperature:output_type -> Response
+	18, // 27: Marlin.SetBedTemperature:output_type -> Response
+	20, // [20:28] is the sub-list for method output_type
+	12, // [12:20] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_v1_marlin_proto_init() }
@@ -882,7 +1644,7 @@ func file_v1_marlin_proto_init() {
 			}
 		}
 		file_v1_marlin_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*State); i {
+			switch v := v.(*Heater); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -894,7 +1656,7 @@ func file_v1_marlin_proto_init() {
 			}
 		}
 		file_v1_marlin_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StateStreamRequest); i {
+			switch v := v.(*Temperatures); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -906,7 +1668,7 @@ func file_v1_marlin_proto_init() {
 			}
 		}
 		file_v1_marlin_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StateStreamResponse); i {
+			switch v := v.(*Alarm); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -918,7 +1680,7 @@ func file_v1_marlin_proto_init() {
 			}
 		}
 		file_v1_marlin_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HomeRequest); i {
+			switch v := v.(*State); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -930,7 +1692,7 @@ func file_v1_marlin_proto_init() {
 			}
 		}
 		file_v1_marlin_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MoveRequest); i {
+			switch v := v.(*StateStreamRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -942,7 +1704,7 @@ func file_v1_marlin_proto_init() {
 			}
 		}
 		file_v1_marlin_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MoveResponse); i {
+			switch v := v.(*StateStreamResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -954,7 +1716,7 @@ func file_v1_marlin_proto_init() {
 			}
 		}
 		file_v1_marlin_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FanOnRequest); i {
+			switch v := v.(*HomeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -966,7 +1728,7 @@ func file_v1_marlin_proto_init() {
 			}
 		}
 		file_v1_marlin_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FanOnResponse); i {
+			switch v := v.(*MoveRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -978,7 +1740,7 @@ func file_v1_marlin_proto_init() {
 			}
 		}
 		file_v1_marlin_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FanOffRequest); i {
+			switch v := v.(*MoveResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -990,7 +1752,7 @@ func file_v1_marlin_proto_init() {
 			}
 		}
 		file_v1_marlin_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FanOffResponse); i {
+			switch v := v.(*FanOnRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1002,6 +1764,54 @@ func file_v1_marlin_proto_init() {
 			}
 		}
 		file_v1_marlin_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FanOnResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_marlin_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FanOffRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_marlin_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FanOffResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_marlin_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetTemperatureRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_marlin_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Response); i {
 			case 0:
 				return &v.state
@@ -1013,30 +1823,74 @@ func file_v1_marlin_proto_init() {
 				return nil
 			}
 		}
+		file_v1_marlin_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Program); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_marlin_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamProgramRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_marlin_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamProgramResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
-	file_v1_marlin_proto_msgTypes[4].OneofWrappers = []interface{}{
+	file_v1_marlin_proto_msgTypes[2].OneofWrappers = []interface{}{}
+	file_v1_marlin_proto_msgTypes[3].OneofWrappers = []interface{}{}
+	file_v1_marlin_proto_msgTypes[4].OneofWrappers = []interface{}{}
+	file_v1_marlin_proto_msgTypes[7].OneofWrappers = []interface{}{
 		(*HomeRequest_All)(nil),
 		(*HomeRequest_X)(nil),
 		(*HomeRequest_Y)(nil),
 		(*HomeRequest_Z)(nil),
 	}
-	file_v1_marlin_proto_msgTypes[5].OneofWrappers = []interface{}{}
-	file_v1_marlin_proto_msgTypes[11].OneofWrappers = []interface{}{
+	file_v1_marlin_proto_msgTypes[8].OneofWrappers = []interface{}{}
+	file_v1_marlin_proto_msgTypes[15].OneofWrappers = []interface{}{
 		(*Response_State)(nil),
 		(*Response_Move)(nil),
 	}
+	file_v1_marlin_proto_msgTypes[17].OneofWrappers = []interface{}{
+		(*StreamProgramRequest_Program)(nil),
+		(*StreamProgramRequest_Control)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_v1_marlin_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   12,
+			NumEnums:      3,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_v1_marlin_proto_goTypes,
 		DependencyIndexes: file_v1_marlin_proto_depIdxs,
+		EnumInfos:         file_v1_marlin_proto_enumTypes,
 		MessageInfos:      file_v1_marlin_proto_msgTypes,
 	}.Build()
 	File_v1_marlin_proto = out.File
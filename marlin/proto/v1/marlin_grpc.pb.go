@@ -1,7 +1,9 @@
+// messages we need to send
+
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
-// - protoc-gen-go-grpc v1.2.0
-// - protoc             v4.23.4
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
 // source: v1/marlin.proto
 
 package marlin
@@ -18,15 +20,29 @@ import (
 // Requires gRPC-Go v1.32.0 or later.
 const _ = grpc.SupportPackageIsVersion7
 
+const (
+	Marlin_Home_FullMethodName                 = "/Marlin/Home"
+	Marlin_StateStream_FullMethodName          = "/Marlin/StateStream"
+	Marlin_StreamProgram_FullMethodName        = "/Marlin/StreamProgram"
+	Marlin_Move_FullMethodName                 = "/Marlin/Move"
+	Marlin_FanOn_FullMethodName                = "/Marlin/FanOn"
+	Marlin_FanOff_FullMethodName               = "/Marlin/FanOff"
+	Marlin_SetHotendTemperature_FullMethodName = "/Marlin/SetHotendTemperature"
+	Marlin_SetBedTemperature_FullMethodName    = "/Marlin/SetBedTemperature"
+)
+
 // MarlinClient is the client API for Marlin service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type MarlinClient interface {
 	Home(ctx context.Context, in *HomeRequest, opts ...grpc.CallOption) (*Response, error)
 	StateStream(ctx context.Context, in *StateStreamRequest, opts ...grpc.CallOption) (Marlin_StateStreamClient, error)
+	StreamProgram(ctx context.Context, opts ...grpc.CallOption) (Marlin_StreamProgramClient, error)
 	Move(ctx context.Context, in *MoveRequest, opts ...grpc.CallOption) (*Response, error)
 	FanOn(ctx context.Context, in *FanOnRequest, opts ...grpc.CallOption) (*Response, error)
 	FanOff(ctx context.Context, in *FanOffRequest, opts ...grpc.CallOption) (*Response, error)
+	SetHotendTemperature(ctx context.Context, in *SetTemperatureRequest, opts ...grpc.CallOption) (*Response, error)
+	SetBedTemperature(ctx context.Context, in *SetTemperatureRequest, opts ...grpc.CallOption) (*Response, error)
 }
 
 type marlinClient struct {
@@ -39,7 +55,7 @@ func NewMarlinClient(cc grpc.ClientConnInterface) MarlinClient {
 
 func (c *marlinClient) Home(ctx context.Context, in *HomeRequest, opts ...grpc.CallOption) (*Response, error) {
 	out := new(Response)
-	err := c.cc.Invoke(ctx, "/Marlin/Home", in, out, opts...)
+	err := c.cc.Invoke(ctx, Marlin_Home_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -47,7 +63,7 @@ func (c *marlinClient) Home(ctx context.Context, in *HomeRequest, opts ...grpc.C
 }
 
 func (c *marlinClient) StateStream(ctx context.Context, in *StateStreamRequest, opts ...grpc.CallOption) (Marlin_StateStreamClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Marlin_ServiceDesc.Streams[0], "/Marlin/StateStream", opts...)
+	stream, err := c.cc.NewStream(ctx, &Marlin_ServiceDesc.Streams[0], Marlin_StateStream_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -78,9 +94,40 @@ func (x *marlinStateStreamClient) Recv() (*StateStreamResponse, error) {
 	return m, nil
 }
 
+func (c *marlinClient) StreamProgram(ctx context.Context, opts ...grpc.CallOption) (Marlin_StreamProgramClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Marlin_ServiceDesc.Streams[1], Marlin_StreamProgram_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &marlinStreamProgramClient{stream}
+	return x, nil
+}
+
+type Marlin_StreamProgramClient interface {
+	Send(*StreamProgramRequest) error
+	Recv() (*StreamProgramResponse, error)
+	grpc.ClientStream
+}
+
+type marlinStreamProgramClient struct {
+	grpc.ClientStream
+}
+
+func (x *marlinStreamProgramClient) Send(m *StreamProgramRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *marlinStreamProgramClient) Recv() (*StreamProgramResponse, error) {
+	m := new(StreamProgramResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *marlinClient) Move(ctx context.Context, in *MoveRequest, opts ...grpc.CallOption) (*Response, error) {
 	out := new(Response)
-	err := c.cc.Invoke(ctx, "/Marlin/Move", in, out, opts...)
+	err := c.cc.Invoke(ctx, Marlin_Move_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -89,7 +136,7 @@ func (c *marlinClient) Move(ctx context.Context, in *MoveRequest, opts ...grpc.C
 
 func (c *marlinClient) FanOn(ctx context.Context, in *FanOnRequest, opts ...grpc.CallOption) (*Response, error) {
 	out := new(Response)
-	err := c.cc.Invoke(ctx, "/Marlin/FanOn", in, out, opts...)
+	err := c.cc.Invoke(ctx, Marlin_FanOn_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -98,7 +145,25 @@ func (c *marlinClient) FanOn(ctx context.Context, in *FanOnRequest, opts ...grpc
 
 func (c *marlinClient) FanOff(ctx context.Context, in *FanOffRequest, opts ...grpc.CallOption) (*Response, error) {
 	out := new(Response)
-	err := c.cc.Invoke(ctx, "/Marlin/FanOff", in, out, opts...)
+	err := c.cc.Invoke(ctx, Marlin_FanOff_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marlinClient) SetHotendTemperature(ctx context.Context, in *SetTemperatureRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, Marlin_SetHotendTemperature_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marlinClient) SetBedTemperature(ctx context.Context, in *SetTemperatureRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, Marlin_SetBedTemperature_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -111,9 +176,12 @@ func (c *marlinClient) FanOff(ctx context.Context, in *FanOffRequest, opts ...gr
 type MarlinServer interface {
 	Home(context.Context, *HomeRequest) (*Response, error)
 	StateStream(*StateStreamRequest, Marlin_StateStreamServer) error
+	StreamProgram(Marlin_StreamProgramServer) error
 	Move(context.Context, *MoveRequest) (*Response, error)
 	FanOn(context.Context, *FanOnRequest) (*Response, error)
 	FanOff(context.Context, *FanOffRequest) (*Response, error)
+	SetHotendTemperature(context.Context, *SetTemperatureRequest) (*Response, error)
+	SetBedTemperature(context.Context, *SetTemperatureRequest) (*Response, error)
 	mustEmbedUnimplementedMarlinServer()
 }
 
@@ -127,6 +195,9 @@ func (UnimplementedMarlinServer) Home(context.Context, *HomeRequest) (*Response,
 func (UnimplementedMarlinServer) StateStream(*StateStreamRequest, Marlin_StateStreamServer) error {
 	return status.Errorf(codes.Unimplemented, "method StateStream not implemented")
 }
+func (UnimplementedMarlinServer) StreamProgram(Marlin_StreamProgramServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamProgram not implemented")
+}
 func (UnimplementedMarlinServer) Move(context.Context, *MoveRequest) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Move not implemented")
 }
@@ -136,6 +207,12 @@ func (UnimplementedMarlinServer) FanOn(context.Context, *FanOnRequest) (*Respons
 func (UnimplementedMarlinServer) FanOff(context.Context, *FanOffRequest) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FanOff not implemented")
 }
+func (UnimplementedMarlinServer) SetHotendTemperature(context.Context, *SetTemperatureRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetHotendTemperature not implemented")
+}
+func (UnimplementedMarlinServer) SetBedTemperature(context.Context, *SetTemperatureRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBedTemperature not implemented")
+}
 func (UnimplementedMarlinServer) mustEmbedUnimplementedMarlinServer() {}
 
 // UnsafeMarlinServer may be embedded to opt out of forward compatibility for this service.
@@ -159,7 +236,7 @@ func _Marlin_Home_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Marlin/Home",
+		FullMethod: Marlin_Home_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(MarlinServer).Home(ctx, req.(*HomeRequest))
@@ -188,6 +265,32 @@ func (x *marlinStateStreamServer) Send(m *StateStreamResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Marlin_StreamProgram_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MarlinServer).StreamProgram(&marlinStreamProgramServer{stream})
+}
+
+type Marlin_StreamProgramServer interface {
+	Send(*StreamProgramResponse) error
+	Recv() (*StreamProgramRequest, error)
+	grpc.ServerStream
+}
+
+type marlinStreamProgramServer struct {
+	grpc.ServerStream
+}
+
+func (x *marlinStreamProgramServer) Send(m *StreamProgramResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *marlinStreamProgramServer) Recv() (*StreamProgramRequest, error) {
+	m := new(StreamProgramRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _Marlin_Move_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(MoveRequest)
 	if err := dec(in); err != nil {
@@ -198,7 +301,7 @@ func _Marlin_Move_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Marlin/Move",
+		FullMethod: Marlin_Move_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(MarlinServer).Move(ctx, req.(*MoveRequest))
@@ -216,7 +319,7 @@ func _Marlin_FanOn_Handler(srv interface{}, ctx context.Context, dec func(interf
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Marlin/FanOn",
+		FullMethod: Marlin_FanOn_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(MarlinServer).FanOn(ctx, req.(*FanOnRequest))
@@ -234,7 +337,7 @@ func _Marlin_FanOff_Handler(srv interface{}, ctx context.Context, dec func(inter
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Marlin/FanOff",
+		FullMethod: Marlin_FanOff_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(MarlinServer).FanOff(ctx, req.(*FanOffRequest))
@@ -242,6 +345,42 @@ func _Marlin_FanOff_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Marlin_SetHotendTemperature_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTemperatureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarlinServer).SetHotendTemperature(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Marlin_SetHotendTemperature_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarlinServer).SetHotendTemperature(ctx, req.(*SetTemperatureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Marlin_SetBedTemperature_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTemperatureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarlinServer).SetBedTemperature(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Marlin_SetBedTemperature_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarlinServer).SetBedTemperature(ctx, req.(*SetTemperatureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Marlin_ServiceDesc is the grpc.ServiceDesc for Marlin service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -265,6 +404,14 @@ var Marlin_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "FanOff",
 			Handler:    _Marlin_FanOff_Handler,
 		},
+		{
+			MethodName: "SetHotendTemperature",
+			Handler:    _Marlin_SetHotendTemperature_Handler,
+		},
+		{
+			MethodName: "SetBedTemperature",
+			Handler:    _Marlin_SetBedTemperature_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -272,6 +419,12 @@ var Marlin_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Marlin_StateStream_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "StreamProgram",
+			Handler:       _Marlin_StreamProgram_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "v1/marlin.proto",
 }
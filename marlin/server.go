@@ -4,9 +4,13 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"github.com/jt05610/petri/comm/queue"
 	"github.com/jt05610/petri/comm/serial"
 	proto "github.com/jt05610/petri/marlin/proto/v1"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+	pb "google.golang.org/protobuf/proto"
 	"io"
 	"math"
 	"sync/atomic"
@@ -17,11 +21,16 @@ var _ proto.MarlinServer = (*Server)(nil)
 
 type Server struct {
 	*Parser
-	cts           atomic.Bool
 	logger        *zap.Logger
 	machineStatus *atomic.Pointer[Status]
 	state         *atomic.Pointer[proto.State]
-	port          *serial.Port
+	changed       queue.Signal
+	queue         *queue.Queue
+	alarm         atomic.Pointer[proto.Alarm]
+	resend        atomic.Bool
+	reporting     atomic.Bool
+	disconnected  atomic.Bool
+	port          serial.Conn
 	rxChan        <-chan io.Reader
 	TxChan        chan []byte
 	listenCancel  context.CancelFunc
@@ -29,9 +38,7 @@ type Server struct {
 }
 
 func (s *Server) FanOn(ctx context.Context, request *proto.FanOnRequest) (*proto.Response, error) {
-	err := s.do([]byte("M106 S255\n"), true, func(state *proto.State) bool {
-		return true
-	})
+	err := s.do(ctx, []byte("M106 S255\n"), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -41,9 +48,7 @@ func (s *Server) FanOn(ctx context.Context, request *proto.FanOnRequest) (*proto
 }
 
 func (s *Server) FanOff(ctx context.Context, request *proto.FanOffRequest) (*proto.Response, error) {
-	err := s.do([]byte("M106 S0\n"), true, func(state *proto.State) bool {
-		return true
-	})
+	err := s.do(ctx, []byte("M106 S0\n"), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -56,63 +61,90 @@ func (s *Server) currentState() *proto.State {
 	return s.state.Load()
 }
 
+// setState stores a copy of the current state changed by f. It is only
+// called from Listen, so updates are not lost.
+func (s *Server) setState(f func(state *proto.State)) {
+	state := &proto.State{Position: &proto.Position{}}
+	if cur := s.currentState(); cur != nil {
+		state = pb.Clone(cur).(*proto.State)
+	}
+	f(state)
+	s.state.Store(state)
+	s.changed.Notify()
+}
+
 func (s *Server) status() *Status {
 	return s.machineStatus.Load()
 }
 
-func (s *Server) do(cmd []byte, synchronous bool, check func(state *proto.State) bool) error {
-	for !s.cts.Load() {
+var errDisconnected = grpcstatus.Error(codes.Unavailable, serial.ErrDisconnected.Error())
+
+// send sends a line and waits for Marlin to acknowledge it.
+func (s *Server) send(ctx context.Context, line []byte) error {
+	if s.disconnected.Load() {
+		return errDisconnected
 	}
-	s.TxChan <- cmd
-	s.cts.Store(false)
-	if synchronous {
-		for {
-			status := s.currentState()
-			if status == nil {
-				continue
-			}
-			if s.cts.Load() && check(status) {
-				return nil
-			}
+	if alarm := s.alarm.Load(); alarm != nil {
+		return grpcstatus.Errorf(codes.FailedPrecondition, "%s: %s", alarm.Code, alarm.Message)
+	}
+	return queue.Status(s.queue.Do(ctx, line))
+}
+
+// do sends each line of cmd in turn. If check is given, it then waits for a
+// position report that satisfies it.
+func (s *Server) do(ctx context.Context, cmd []byte, check func(state *proto.State) bool) error {
+	for _, line := range bytes.SplitAfter(cmd, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		if err := s.send(ctx, line); err != nil {
+			return err
 		}
 	}
-	return nil
+	if check == nil {
+		return nil
+	}
+	return queue.Status(queue.Await(ctx, &s.changed, func() (bool, error) {
+		if s.disconnected.Load() {
+			return false, errDisconnected
+		}
+		if alarm := s.alarm.Load(); alarm != nil {
+			return false, alarmError(alarm)
+		}
+		state := s.currentState()
+		return state != nil && check(state), nil
+	}))
 }
 
 func (s *Server) Home(ctx context.Context, req *proto.HomeRequest) (*proto.Response, error) {
-	if req.Axis == nil {
-		s.TxChan <- []byte("G28\n")
-	} else {
-		if v, ok := req.Axis.(*proto.HomeRequest_X); ok {
-			if v.X {
-				s.TxChan <- []byte("G28 X\n")
-			}
-		} else if v, ok := req.Axis.(*proto.HomeRequest_Y); ok {
-			if v.Y {
-				s.TxChan <- []byte("G28 Y\n")
-			}
-		} else if v, ok := req.Axis.(*proto.HomeRequest_Z); ok {
-			if v.Z {
-				s.TxChan <- []byte("G28 Z\n")
-			}
+	// Marlin acknowledges G28 once homing is done
+	cmd := []byte("G28\n")
+	switch v := req.Axis.(type) {
+	case *proto.HomeRequest_X:
+		cmd = nil
+		if v.X {
+			cmd = []byte("G28 X\n")
 		}
-	}
-	// wait until we see all positions at 0
-	for {
-		time.Sleep(100 * time.Millisecond)
-		status := s.status()
-		if status == nil {
-			continue
+	case *proto.HomeRequest_Y:
+		cmd = nil
+		if v.Y {
+			cmd = []byte("G28 Y\n")
 		}
-		if status.Position.X == 0 && status.Position.Y == 0 && status.Position.Z == 0 {
-			return &proto.Response{
-				Message: "ok",
-			}, nil
+	case *proto.HomeRequest_Z:
+		cmd = nil
+		if v.Z {
+			cmd = []byte("G28 Z\n")
 		}
 	}
+	if err := s.do(ctx, cmd, nil); err != nil {
+		return nil, err
+	}
+	return &proto.Response{
+		Message: "ok",
+	}, nil
 }
 
-func New(ctx context.Context, port *serial.Port, logger *zap.Logger) *Server {
+func New(ctx context.Context, port serial.Conn, logger *zap.Logger) *Server {
 	buf := new(bytes.Buffer)
 	txCh := make(chan []byte, 100)
 	rxCh, err := port.ChannelPort(ctx, txCh)
@@ -124,10 +156,10 @@ func New(ctx context.Context, port *serial.Port, logger *zap.Logger) *Server {
 		Parser:        NewParser(buf),
 		port:          port,
 		rxChan:        rxCh,
-		cts:           atomic.Bool{},
 		logger:        logger,
 		state:         &atomic.Pointer[proto.State]{},
 		machineStatus: &atomic.Pointer[Status]{},
+		queue:         queue.New(txCh, 1),
 		TxChan:        txCh,
 	}
 
@@ -135,7 +167,10 @@ func New(ctx context.Context, port *serial.Port, logger *zap.Logger) *Server {
 }
 
 func (s *Server) Close() error {
-	s.listenCancel()
+	if s.listenCancel != nil {
+		s.listenCancel()
+	}
+	s.queue.Close()
 	return s.port.Close()
 }
 
@@ -188,7 +223,7 @@ func Round(x float32, unit float64) float32 {
 }
 
 func (s *Server) Move(ctx context.Context, req *proto.MoveRequest) (*proto.Response, error) {
-	err := s.do(goToMsg(req), true, func(state *proto.State) bool {
+	err := s.do(ctx, goToMsg(req), func(state *proto.State) bool {
 		if req.X != nil {
 			if state.Position.X != Round(req.GetX(), .01) {
 				return false
@@ -229,57 +264,131 @@ var (
 )
 
 func (s *Server) UpdateStatus(status StatusUpdate) {
-	if _, ok := status.(*Ack); ok {
-		s.cts.Store(true)
-		return
-	}
-	if _, ok := status.(*Processing); ok {
-		return
-	}
-
-	if upd, ok := status.(*Status); ok {
-		// s.logger.Debug("Received status update", zap.Any("status", upd))
+	switch upd := status.(type) {
+	case Resend:
+		s.resend.Store(true)
+	case *Ack:
+		var err error
+		if s.resend.Swap(false) {
+			// the ok after a resend request does not mean the line was run
+			err = errResend
+		}
+		if !s.queue.Resolve(err) {
+			s.logger.Debug("Received ok with no command outstanding")
+		}
+	case *Processing:
+	case *Started:
+		s.logger.Info("Marlin started")
+		// a restart clears alarms and turns temperature reports off
+		s.alarm.Store(nil)
+		s.resend.Store(false)
+		s.reporting.Store(false)
+		s.setState(func(state *proto.State) {
+			state.Alarm = nil
+		})
+	case Fault:
+		if upd.Recoverable() {
+			s.logger.Warn("Line rejected", zap.String("error", string(upd)))
+			s.resend.Store(true)
+			return
+		}
+		s.logger.Error("Received error", zap.String("error", string(upd)))
+		if alarm := faultAlarm(upd, s.alarm.Load()); alarm != nil {
+			s.alarm.Store(alarm)
+			s.setState(func(state *proto.State) {
+				state.Alarm = alarm
+			})
+		}
+		// Marlin drops what it was doing, so nothing outstanding will be answered
+		s.queue.Flush(grpcstatus.Error(codes.Aborted, string(upd)))
+	case *Temperature:
+		s.setState(func(state *proto.State) {
+			state.Temperatures = &proto.Temperatures{
+				Hotend: heaterToProto(upd.Hotend),
+				Bed:    heaterToProto(upd.Bed),
+			}
+		})
+	case *Status:
 		s.machineStatus.Store(upd)
-		newState := &proto.State{
-			Position: &proto.Position{
+		s.setState(func(state *proto.State) {
+			state.Position = &proto.Position{
 				X: upd.Position.X,
 				Y: upd.Position.Y,
 				Z: upd.Position.Z,
 				E: upd.Position.E,
-			},
-		}
-		s.state.Store(newState)
+			}
+		})
 	}
 }
 
+// RunHeartbeat asks for the position while no command is outstanding; Marlin
+// answers one command at a time, so asking during a long move would only
+// queue behind it. It first turns on temperature reports, which Marlin sends
+// by itself.
 func (s *Server) RunHeartbeat(ctx context.Context) {
 	ticker := time.NewTicker(300 * time.Millisecond)
-	errCount := 3
+	defer ticker.Stop()
+	missed := 0
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			errCount = 3
-			if !s.cts.Load() {
-				errCount--
-				if errCount == 0 {
-					s.logger.Fatal("Failed to receive ack")
-				}
+			// a halted Marlin does not answer until it is reset
+			if s.queue.Len() > 0 || s.disconnected.Load() || s.alarm.Load() != nil {
+				missed = 0
 				continue
 			}
-			s.cts.Store(false)
-			// s.logger.Debug("Sending heartbeat")
-			s.TxChan <- []byte(HeartbeatMsg)
+			msg := HeartbeatMsg
+			reporting := s.reporting.Load()
+			if !reporting {
+				msg = fmt.Sprintf("M155 S%d\n", TemperatureInterval)
+			}
+			hbCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+			err := s.queue.Do(hbCtx, []byte(msg))
+			cancel()
+			switch {
+			case err == nil:
+				if !reporting {
+					s.reporting.Store(true)
+				}
+				missed = 0
+			case ctx.Err() != nil:
+				return
+			default:
+				if missed++; missed == 3 {
+					s.logger.Fatal("Failed to receive ack", zap.Error(err))
+				}
+			}
 		}
 	}
 }
 
+// connection fails whatever was waiting on Marlin when the port is lost.
+// Marlin restarts when the port is reopened.
+func (s *Server) connection(e serial.Event) {
+	switch e.State {
+	case serial.Disconnected:
+		s.logger.Error("Serial port lost", zap.String("port", e.Port), zap.Error(e.Err))
+		s.disconnected.Store(true)
+		s.reporting.Store(false)
+		s.queue.Flush(errDisconnected)
+	case serial.Connected:
+		s.logger.Info("Serial port reconnected", zap.String("port", e.Port))
+		s.resend.Store(false)
+		s.disconnected.Store(false)
+	}
+	s.changed.Notify()
+}
+
 func (s *Server) Listen(ctx context.Context) error {
+	defer s.queue.Close()
 	for {
 		select {
 		case <-ctx.Done():
 			return nil
+		case e := <-s.port.Events():
+			s.connection(e)
 		case msg := <-s.rxChan:
 			bb, err := io.ReadAll(msg)
 			if err != nil {
@@ -290,11 +399,9 @@ func (s *Server) Listen(ctx context.Context) error {
 			parser := NewParser(buf)
 			upd, err := parser.Parse()
 			if err != nil {
-				// write back to the buffer so that we can try again
 				s.logger.Error("Failed to parse message", zap.Error(err))
 			} else {
 				s.UpdateStatus(upd)
-
 			}
 		}
 	}
@@ -0,0 +1,193 @@
+//go:build linux
+
+package marlin_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/jt05610/petri/comm/serial"
+	"github.com/jt05610/petri/marlin"
+	proto "github.com/jt05610/petri/marlin/proto/v1"
+	"github.com/jt05610/petri/marlin/sim"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func start(t *testing.T) (context.Context, *sim.Simulator, *marlin.Server) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	t.Cleanup(cancel)
+	dev := sim.New()
+	dev.Speedup = 20
+	pty, err := serial.Simulate(ctx, dev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := serial.OpenPort(pty.Name, 115200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := marlin.New(ctx, port, zap.NewNop())
+	t.Cleanup(func() {
+		_ = s.Close()
+	})
+	go func() {
+		_ = s.Listen(ctx)
+	}()
+	go s.RunHeartbeat(ctx)
+	return ctx, dev, s
+}
+
+func float(f float32) *float32 {
+	return &f
+}
+
+func TestServer_Move(t *testing.T) {
+	ctx, dev, s := start(t)
+	if _, err := s.Home(ctx, &proto.HomeRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Move(ctx, &proto.MoveRequest{X: float(20), Y: float(5), Speed: float(3000)}); err != nil {
+		t.Fatal(err)
+	}
+	if pos := dev.Position(); pos != [4]float64{20, 5, 0, 0} {
+		t.Fatalf("expected to end at 20, 5, 0, got %v", pos)
+	}
+}
+
+func TestServer_Kill(t *testing.T) {
+	ctx, dev, s := start(t)
+	if _, err := s.Home(ctx, &proto.HomeRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error)
+	go func() {
+		_, err := s.Move(ctx, &proto.MoveRequest{X: float(100), Speed: float(100)})
+		done <- err
+	}()
+	time.Sleep(200 * time.Millisecond)
+	dev.Kill("Thermal Runaway, system stopped! Heater_ID: 0")
+	if err := <-done; status.Code(err) != codes.Aborted {
+		t.Fatalf("expected the move to be aborted, got %v", err)
+	}
+	if _, err := s.Move(ctx, &proto.MoveRequest{X: float(5)}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected moves to be locked out, got %v", err)
+	}
+}
+
+func TestServer_Temperature(t *testing.T) {
+	ctx, dev, s := start(t)
+	resp, err := s.SetHotendTemperature(ctx, &proto.SetTemperatureRequest{Target: 60, Wait: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hotend, _ := dev.Temperatures(); hotend < 59 {
+		t.Fatalf("expected to wait for the hotend to warm up, got %v", hotend)
+	}
+	if target := resp.GetState().GetTemperatures().GetHotend().GetTarget(); target != 60 {
+		t.Fatalf("expected reports to carry the target, got %v", target)
+	}
+	if _, err := s.SetBedTemperature(ctx, &proto.SetTemperatureRequest{Target: 40}); err != nil {
+		t.Fatal(err)
+	}
+	// the bed's new target arrives with the next automatic report
+	streamCtx, cancel := context.WithCancel(ctx)
+	stream := &stateStream{ctx: streamCtx, cancel: cancel}
+	if err := s.StateStream(&proto.StateStreamRequest{}, stream); err != nil {
+		t.Fatal(err)
+	}
+	if target := stream.state.GetTemperatures().GetBed().GetTarget(); target != 40 {
+		t.Fatalf("expected the bed to be heating to 40, got %v", stream.state.GetTemperatures())
+	}
+	if _, err := s.SetBedTemperature(ctx, &proto.SetTemperatureRequest{Target: -1}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected a negative target to be rejected, got %v", err)
+	}
+}
+
+// stateStream keeps the first state sent and ends the stream.
+type stateStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	cancel context.CancelFunc
+	state  *proto.State
+}
+
+func (s *stateStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *stateStream) Send(resp *proto.StateStreamResponse) error {
+	s.state = resp.State
+	s.cancel()
+	return nil
+}
+
+type programStream struct {
+	grpc.ServerStream
+	ctx   context.Context
+	reqs  chan *proto.StreamProgramRequest
+	resps chan *proto.StreamProgramResponse
+}
+
+func (p *programStream) Context() context.Context {
+	return p.ctx
+}
+
+func (p *programStream) Send(resp *proto.StreamProgramResponse) error {
+	p.resps <- resp
+	return nil
+}
+
+func (p *programStream) Recv() (*proto.StreamProgramRequest, error) {
+	select {
+	case req, ok := <-p.reqs:
+		if !ok {
+			return nil, io.EOF
+		}
+		return req, nil
+	case <-p.ctx.Done():
+		return nil, p.ctx.Err()
+	}
+}
+
+func TestServer_StreamProgram(t *testing.T) {
+	ctx, dev, s := start(t)
+	var gcode strings.Builder
+	gcode.WriteString("G90\nG1 F6000\n")
+	for i := 1; i <= 20; i++ {
+		fmt.Fprintf(&gcode, "G1 X%d E%d\n", i, i)
+	}
+	stream := &programStream{
+		ctx:   ctx,
+		reqs:  make(chan *proto.StreamProgramRequest, 1),
+		resps: make(chan *proto.StreamProgramResponse, 100),
+	}
+	stream.reqs <- &proto.StreamProgramRequest{
+		Request: &proto.StreamProgramRequest_Program{Program: &proto.Program{Name: "test", Gcode: gcode.String()}},
+	}
+	// lines garbled on the way are sent again
+	dev.Garble(2)
+	if err := s.StreamProgram(stream); err != nil {
+		t.Fatal(err)
+	}
+	close(stream.resps)
+	var last *proto.StreamProgramResponse
+	for resp := range stream.resps {
+		last = resp
+	}
+	if last.Event != proto.ProgramEvent_ProgramEvent_Completed || last.Done != 22 {
+		t.Fatalf("expected the program to complete, got %v", last)
+	}
+	if _, err := s.Move(ctx, &proto.MoveRequest{X: float(20)}); err != nil {
+		t.Fatal(err)
+	}
+	if pos := dev.Position(); pos[0] != 20 || pos[3] != 20 {
+		t.Fatalf("expected every line to run once, got %v", pos)
+	}
+}
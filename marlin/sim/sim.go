@@ -0,0 +1,523 @@
+// Package sim simulates a Marlin printer board so that the server and the
+// devices built on it can be tested without hardware. Run it behind a
+// pseudo-terminal with serial.Simulate.
+package sim
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"github.com/jt05610/petri/comm/queue"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// PlannerSize is how many moves Marlin buffers. Moves are acknowledged
+	// once planned, and wait while the planner is full.
+	PlannerSize = 16
+	// StepsPerMM converts positions to the step counts M114 reports.
+	StepsPerMM = 80
+	// HomingFeed is the homing speed in mm/min.
+	HomingFeed = 3000
+	// BusyInterval is how often Marlin reports that it is still busy with a
+	// long command.
+	BusyInterval = 2 * time.Second
+	// Tick is how often motion is updated.
+	Tick    = 10 * time.Millisecond
+	Version = "2.1.2.1"
+	// Ambient is the temperature heaters cool down to, in °C.
+	Ambient = 25
+	// HeatRate is how fast heaters warm up and cool down, in °C/s.
+	HeatRate = 2
+	// TempWindow is how close to its target a heater must get for M109 and
+	// M190 to stop waiting, in °C.
+	TempWindow = 1
+)
+
+type heater struct {
+	actual float64
+	target float64
+}
+
+// warm moves the heater dt seconds closer to its target, or to Ambient when
+// it is off.
+func (h *heater) warm(dt float64) {
+	goal := h.target
+	if goal == 0 {
+		goal = Ambient
+	}
+	step := HeatRate * dt
+	switch {
+	case h.actual+step < goal:
+		h.actual += step
+	case h.actual-step > goal:
+		h.actual -= step
+	default:
+		h.actual = goal
+	}
+}
+
+type move struct {
+	target [4]float64
+	// feed in mm/min
+	feed float64
+}
+
+// Simulator is a simulated Marlin board.
+type Simulator struct {
+	// Speedup runs motion, dwells and homing this many times faster than
+	// real time.
+	Speedup float64
+	// Travel is the size of each axis. Moves are clamped to it, as Marlin's
+	// software endstops do.
+	Travel [3]float64
+
+	writeMu sync.Mutex
+	w       io.Writer
+	changed queue.Signal
+
+	mu        sync.Mutex
+	pos       [4]float64
+	planned   [4]float64
+	plan      []*move
+	absolute  bool
+	absoluteE bool
+	feed      float64
+	fan       int
+	hotend    heater
+	bed       heater
+	report    float64 // M155 interval in seconds
+	elapsed   float64 // since the last report
+	lastLine  int
+	garble    int
+	killed    bool
+}
+
+func New() *Simulator {
+	return &Simulator{
+		Speedup:   1,
+		Travel:    [3]float64{200, 200, 200},
+		absolute:  true,
+		absoluteE: true,
+		feed:      1500,
+		hotend:    heater{actual: Ambient},
+		bed:       heater{actual: Ambient},
+	}
+}
+
+// Temperatures returns the hotend's and the bed's temperatures.
+func (s *Simulator) Temperatures() (hotend, bed float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.hotend.actual, s.bed.actual
+}
+
+// temperatures formats a temperature report. Callers hold s.mu.
+func (s *Simulator) temperatures() string {
+	return fmt.Sprintf(" T:%.2f /%.2f B:%.2f /%.2f @:0 B@:0",
+		s.hotend.actual, s.hotend.target, s.bed.actual, s.bed.target)
+}
+
+// Position returns where the axes are, in X, Y, Z, E order.
+func (s *Simulator) Position() [4]float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pos
+}
+
+// Garble makes the next n numbered lines arrive with a bad checksum, as if
+// they were corrupted on the way.
+func (s *Simulator) Garble(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.garble = n
+}
+
+// Kill halts the board as if it had detected a fault, such as a thermal
+// runaway. It stops answering until it is restarted.
+func (s *Simulator) Kill(reason string) {
+	s.mu.Lock()
+	s.killed = true
+	s.plan = nil
+	s.mu.Unlock()
+	s.changed.Notify()
+	s.writeln("Error:"+reason, "Error:Printer halted. kill() called!")
+}
+
+func (s *Simulator) writeln(lines ...string) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	for _, l := range lines {
+		_, _ = io.WriteString(s.w, l+"\n")
+	}
+}
+
+// Serve runs the board, reading from and writing to rw.
+func (s *Simulator) Serve(ctx context.Context, rw io.ReadWriter) error {
+	s.w = rw
+	s.writeln("start", "echo:Marlin "+Version)
+	go s.run(ctx)
+	scanner := bufio.NewScanner(rw)
+	for scanner.Scan() {
+		s.mu.Lock()
+		killed := s.killed
+		s.mu.Unlock()
+		if killed {
+			continue
+		}
+		s.handle(ctx, scanner.Text())
+	}
+	return scanner.Err()
+}
+
+// run moves the axes along the planned moves.
+func (s *Simulator) run(ctx context.Context) {
+	ticker := time.NewTicker(Tick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		dt := Tick.Seconds() * s.Speedup
+		report := ""
+		s.mu.Lock()
+		s.step(dt)
+		s.hotend.warm(dt)
+		s.bed.warm(dt)
+		if s.elapsed += dt; s.report > 0 && s.elapsed >= s.report && !s.killed {
+			s.elapsed = 0
+			report = s.temperatures()
+		}
+		s.mu.Unlock()
+		s.changed.Notify()
+		if report != "" {
+			s.writeln(report)
+		}
+	}
+}
+
+// step advances motion by dt seconds. Callers hold s.mu.
+func (s *Simulator) step(dt float64) {
+	if len(s.plan) == 0 {
+		return
+	}
+	m := s.plan[0]
+	dist := 0.0
+	for i := range s.pos {
+		dist += (m.target[i] - s.pos[i]) * (m.target[i] - s.pos[i])
+	}
+	dist = math.Sqrt(dist)
+	travel := m.feed / 60 * dt
+	if travel >= dist {
+		s.pos = m.target
+		s.plan = s.plan[1:]
+		return
+	}
+	for i := range s.pos {
+		s.pos[i] += (m.target[i] - s.pos[i]) * travel / dist
+	}
+}
+
+// busy waits until done reports true, telling the host that Marlin is busy
+// every BusyInterval so it does not time out.
+func (s *Simulator) busy(ctx context.Context, done func() bool) {
+	ticker := time.NewTicker(BusyInterval)
+	defer ticker.Stop()
+	for {
+		wait := s.changed.Wait()
+		s.mu.Lock()
+		ok := done() || s.killed
+		s.mu.Unlock()
+		if ok {
+			return
+		}
+		select {
+		case <-wait:
+		case <-ticker.C:
+			s.writeln("echo:busy: processing")
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// heat waits until h is within TempWindow of its target, reporting the
+// temperatures every second as Marlin does. Like M109 S, it does not wait
+// for a heater to cool down.
+func (s *Simulator) heat(ctx context.Context, h *heater) {
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / s.Speedup))
+	defer ticker.Stop()
+	for {
+		wait := s.changed.Wait()
+		s.mu.Lock()
+		done := h.actual >= h.target-TempWindow || s.killed
+		report := s.temperatures()
+		s.mu.Unlock()
+		if done {
+			return
+		}
+		select {
+		case <-wait:
+		case <-ticker.C:
+			s.writeln(report + " W:?")
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *Simulator) sync(ctx context.Context) {
+	s.busy(ctx, func() bool {
+		return len(s.plan) == 0
+	})
+}
+
+func (s *Simulator) sleep(ctx context.Context, d time.Duration) {
+	deadline := time.After(time.Duration(float64(d) / s.Speedup))
+	s.busy(ctx, func() bool {
+		select {
+		case <-deadline:
+			return true
+		default:
+			return false
+		}
+	})
+}
+
+func checksum(line string) int {
+	var sum byte
+	for i := 0; i < len(line); i++ {
+		sum ^= line[i]
+	}
+	return int(sum)
+}
+
+// reject asks the host to send the line after the last good one again.
+func (s *Simulator) reject(reason string) {
+	s.mu.Lock()
+	last := s.lastLine
+	s.mu.Unlock()
+	s.writeln(fmt.Sprintf("Error:%s, Last Line: %d", reason, last), fmt.Sprintf("Resend: %d", last+1), "ok")
+}
+
+// handle checks a line's number and checksum, if it has them, then runs it.
+func (s *Simulator) handle(ctx context.Context, text string) {
+	if i := strings.IndexByte(text, ';'); i >= 0 {
+		text = text[:i]
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	if text[0] == 'N' {
+		body, sum, found := strings.Cut(text, "*")
+		if !found {
+			s.reject("No Checksum with line number")
+			return
+		}
+		want, err := strconv.Atoi(strings.TrimSpace(sum))
+		s.mu.Lock()
+		garbled := s.garble > 0
+		if garbled {
+			s.garble--
+		}
+		s.mu.Unlock()
+		if err != nil || garbled || checksum(body) != want {
+			s.reject("checksum mismatch")
+			return
+		}
+		num, cmd, _ := strings.Cut(body[1:], " ")
+		n, err := strconv.Atoi(num)
+		if err != nil {
+			s.reject("Line Number is not Last Line Number+1")
+			return
+		}
+		cmd = strings.TrimSpace(cmd)
+		s.mu.Lock()
+		expected := n == s.lastLine+1 || strings.HasPrefix(cmd, "M110")
+		if expected {
+			s.lastLine = n
+		}
+		s.mu.Unlock()
+		if !expected {
+			s.reject("Line Number is not Last Line Number+1")
+			return
+		}
+		text = cmd
+	}
+	out := s.exec(ctx, text)
+	s.mu.Lock()
+	killed := s.killed
+	s.mu.Unlock()
+	if !killed {
+		s.writeln(append(out, "ok")...)
+	}
+}
+
+type word struct {
+	letter byte
+	value  float64
+	given  bool
+}
+
+func parseWords(fields []string) map[byte]word {
+	ret := make(map[byte]word)
+	for _, f := range fields {
+		f = strings.ToUpper(f)
+		v, err := strconv.ParseFloat(f[1:], 64)
+		ret[f[0]] = word{letter: f[0], value: v, given: err == nil}
+	}
+	return ret
+}
+
+var axes = "XYZE"
+
+func (s *Simulator) exec(ctx context.Context, text string) []string {
+	fields := strings.Fields(text)
+	cmd := strings.ToUpper(fields[0])
+	words := parseWords(fields[1:])
+	switch cmd {
+	case "G0", "G1":
+		s.mu.Lock()
+		if f, found := words['F']; found && f.given {
+			s.feed = f.value
+		}
+		m := &move{target: s.planned, feed: s.feed}
+		for i := range axes {
+			w, found := words[axes[i]]
+			if !found || !w.given {
+				continue
+			}
+			relative := !s.absolute || (i == 3 && !s.absoluteE)
+			if relative {
+				m.target[i] += w.value
+			} else {
+				m.target[i] = w.value
+			}
+			if i < 3 {
+				m.target[i] = math.Max(0, math.Min(s.Travel[i], m.target[i]))
+			}
+		}
+		s.mu.Unlock()
+		s.busy(ctx, func() bool {
+			return len(s.plan) < PlannerSize
+		})
+		s.mu.Lock()
+		s.plan = append(s.plan, m)
+		s.planned = m.target
+		s.mu.Unlock()
+		s.changed.Notify()
+	case "G4":
+		s.sync(ctx)
+		ms := words['P'].value + words['S'].value*1000
+		s.sleep(ctx, time.Duration(ms*float64(time.Millisecond)))
+	case "G28":
+		s.sync(ctx)
+		home := make([]int, 0, 3)
+		for i := 0; i < 3; i++ {
+			if _, found := words[axes[i]]; found {
+				home = append(home, i)
+			}
+		}
+		if len(home) == 0 {
+			home = []int{0, 1, 2}
+		}
+		s.mu.Lock()
+		far := 0.0
+		for _, i := range home {
+			far = math.Max(far, s.pos[i])
+		}
+		s.mu.Unlock()
+		s.sleep(ctx, time.Duration(far/HomingFeed*60*float64(time.Second)))
+		s.mu.Lock()
+		for _, i := range home {
+			s.pos[i] = 0
+		}
+		s.planned = s.pos
+		s.mu.Unlock()
+		s.changed.Notify()
+	case "G90", "G91":
+		s.mu.Lock()
+		s.absolute = cmd == "G90"
+		s.absoluteE = s.absolute
+		s.mu.Unlock()
+	case "M82", "M83":
+		s.mu.Lock()
+		s.absoluteE = cmd == "M82"
+		s.mu.Unlock()
+	case "G92":
+		s.sync(ctx)
+		s.mu.Lock()
+		for i := range axes {
+			if w, found := words[axes[i]]; found && w.given {
+				s.pos[i] = w.value
+			}
+		}
+		s.planned = s.pos
+		s.mu.Unlock()
+	case "M400":
+		s.sync(ctx)
+	case "M106":
+		s.mu.Lock()
+		s.fan = 255
+		if w, found := words['S']; found && w.given {
+			s.fan = int(w.value)
+		}
+		s.mu.Unlock()
+	case "M107":
+		s.mu.Lock()
+		s.fan = 0
+		s.mu.Unlock()
+	case "M104", "M109", "M140", "M190":
+		h := &s.hotend
+		if cmd == "M140" || cmd == "M190" {
+			h = &s.bed
+		}
+		s.mu.Lock()
+		if w, found := words['S']; found && w.given {
+			h.target = w.value
+		}
+		s.mu.Unlock()
+		if cmd == "M109" || cmd == "M190" {
+			s.heat(ctx, h)
+		}
+	case "M155":
+		s.mu.Lock()
+		s.report = words['S'].value
+		s.elapsed = 0
+		s.mu.Unlock()
+	case "M110":
+		if w, found := words['N']; found && w.given {
+			s.mu.Lock()
+			s.lastLine = int(w.value)
+			s.mu.Unlock()
+		}
+	case "M114":
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return []string{fmt.Sprintf("X:%.2f Y:%.2f Z:%.2f E:%.2f Count X:%d Y:%d Z:%d",
+			s.planned[0], s.planned[1], s.planned[2], s.planned[3],
+			int(s.pos[0]*StepsPerMM), int(s.pos[1]*StepsPerMM), int(s.pos[2]*StepsPerMM))}
+	case "M115":
+		return []string{"FIRMWARE_NAME:Marlin " + Version + " PROTOCOL_VERSION:1.0 MACHINE_TYPE:petri EXTRUDER_COUNT:1"}
+	case "M410":
+		s.mu.Lock()
+		s.plan = nil
+		s.planned = s.pos
+		s.mu.Unlock()
+		s.changed.Notify()
+	case "M112":
+		s.Kill("Emergency stop")
+		return nil
+	default:
+		return []string{fmt.Sprintf("echo:Unknown command: %q", text)}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package marlin
+
+import (
+	"context"
+	"fmt"
+	proto "github.com/jt05610/petri/marlin/proto/v1"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+	"strconv"
+	"strings"
+)
+
+// TemperatureInterval is how often, in seconds, Marlin is asked to report
+// its temperatures with M155.
+var TemperatureInterval = 1
+
+// Heater is a heater's temperature and target in °C.
+type Heater struct {
+	Actual float32
+	Target float32
+}
+
+// Temperature is a temperature report such as
+//
+//	T:25.00 /0.00 B:24.91 /60.00 @:0 B@:0
+//
+// which Marlin sends every TemperatureInterval once M155 is on, and while it
+// waits for a heater.
+type Temperature struct {
+	Hotend *Heater
+	Bed    *Heater
+}
+
+func (t *Temperature) IsStatusUpdate() {}
+
+func parseTemperature(line string) (*Temperature, error) {
+	ret := new(Temperature)
+	var last *Heater
+	for _, f := range strings.Fields(line) {
+		if target, found := strings.CutPrefix(f, "/"); found {
+			if last == nil {
+				continue
+			}
+			v, err := strconv.ParseFloat(target, 32)
+			if err != nil {
+				return nil, fmt.Errorf("expected target temperature, got %q", f)
+			}
+			last.Target = float32(v)
+			continue
+		}
+		name, actual, found := strings.Cut(f, ":")
+		if !found {
+			continue
+		}
+		var heater **Heater
+		switch name {
+		case "T", "T0":
+			heater = &ret.Hotend
+		case "B":
+			heater = &ret.Bed
+		default:
+			// other heaters, power (@) and time left waiting (W)
+			last = nil
+			continue
+		}
+		v, err := strconv.ParseFloat(actual, 32)
+		if err != nil {
+			return nil, fmt.Errorf("expected temperature, got %q", f)
+		}
+		*heater = &Heater{Actual: float32(v)}
+		last = *heater
+	}
+	if ret.Hotend == nil && ret.Bed == nil {
+		return nil, fmt.Errorf("no temperatures in %q", line)
+	}
+	return ret, nil
+}
+
+func heaterToProto(h *Heater) *proto.Heater {
+	if h == nil {
+		return nil
+	}
+	return &proto.Heater{Actual: h.Actual, Target: h.Target}
+}
+
+// faultAlarm returns the alarm a fault raises, or nil if it does not halt
+// Marlin. Marlin follows a thermal fault with "Printer halted", which keeps
+// the more specific alarm in current.
+func faultAlarm(f Fault, current *proto.Alarm) *proto.Alarm {
+	msg := string(f)
+	code := proto.AlarmCode_AlarmCode_None
+	switch {
+	case strings.Contains(msg, "Thermal Runaway"):
+		code = proto.AlarmCode_AlarmCode_ThermalRunaway
+	case strings.Contains(msg, "MINTEMP"):
+		code = proto.AlarmCode_AlarmCode_MinTemp
+	case strings.Contains(msg, "MAXTEMP"):
+		code = proto.AlarmCode_AlarmCode_MaxTemp
+	case strings.Contains(msg, "Heating failed"):
+		code = proto.AlarmCode_AlarmCode_HeatingFailed
+	case strings.Contains(msg, "Printer halted"):
+		if current != nil {
+			return nil
+		}
+		code = proto.AlarmCode_AlarmCode_Halted
+	default:
+		return nil
+	}
+	ret := &proto.Alarm{Code: code, Message: msg}
+	if _, id, found := strings.Cut(msg, "Heater_ID:"); found {
+		id = strings.TrimSpace(id)
+		ret.Heater = &id
+	}
+	return ret
+}
+
+func alarmError(a *proto.Alarm) error {
+	return grpcstatus.Errorf(codes.Aborted, "%s: %s", a.Code, a.Message)
+}
+
+func (s *Server) setTemperature(ctx context.Context, set, wait string, req *proto.SetTemperatureRequest) (*proto.Response, error) {
+	if req.Target < 0 {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "target %.1f is below 0", req.Target)
+	}
+	// waiting holds up every later command until the heater is warm
+	cmd := set
+	if req.Wait {
+		cmd = wait
+	}
+	if err := s.do(ctx, []byte(fmt.Sprintf("%s S%.1f\n", cmd, req.Target)), nil); err != nil {
+		return nil, err
+	}
+	return &proto.Response{
+		Message:  "ok",
+		Response: &proto.Response_State{State: s.currentState()},
+	}, nil
+}
+
+func (s *Server) SetHotendTemperature(ctx context.Context, req *proto.SetTemperatureRequest) (*proto.Response, error) {
+	return s.setTemperature(ctx, "M104", "M109", req)
+}
+
+func (s *Server) SetBedTemperature(ctx context.Context, req *proto.SetTemperatureRequest) (*proto.Response, error) {
+	return s.setTemperature(ctx, "M140", "M190", req)
+}
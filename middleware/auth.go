@@ -1,13 +1,191 @@
 package middleware
 
 import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jt05610/petri/datastore"
 	"log"
 	"net/http"
+	"strings"
+	"time"
 )
 
-func RequireUser(next http.HandlerFunc) http.HandlerFunc {
+var (
+	ErrUnauthenticated = errors.New("unauthenticated")
+	ErrForbidden       = errors.New("forbidden")
+	ErrInvalidToken    = errors.New("invalid token")
+	ErrExpiredToken    = errors.New("token expired")
+)
+
+// Identity is the authenticated user making a request.
+type Identity struct {
+	User string         `json:"sub"`
+	Role datastore.Role `json:"role"`
+}
+
+func (i *Identity) IsAdmin() bool {
+	return i != nil && i.Role == datastore.AdminRole
+}
+
+type identityKey struct{}
+
+func WithIdentity(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// IdentityFrom returns the identity RequireUser put on the context, or nil if
+// the request was not authenticated.
+func IdentityFrom(ctx context.Context) *Identity {
+	id, _ := ctx.Value(identityKey{}).(*Identity)
+	return id
+}
+
+// RequireRole returns an error unless the identity on ctx has the given role.
+// Admins pass every check.
+func RequireRole(ctx context.Context, role datastore.Role) error {
+	id := IdentityFrom(ctx)
+	if id == nil {
+		return ErrUnauthenticated
+	}
+	if id.IsAdmin() || id.Role == role {
+		return nil
+	}
+	return fmt.Errorf("%w: %s requires the %s role", ErrForbidden, id.User, role)
+}
+
+// Authenticator checks a user's password and returns their role.
+type Authenticator interface {
+	Authenticate(ctx context.Context, name, password string) (datastore.Role, error)
+}
+
+type claims struct {
+	Identity
+	Expires int64 `json:"exp"`
+}
+
+var tokenHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// Tokens issues and verifies HS256 signed JWTs carrying an Identity.
+type Tokens struct {
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+func NewTokens(secret []byte, ttl time.Duration) *Tokens {
+	return &Tokens{
+		secret: secret,
+		ttl:    ttl,
+		now:    time.Now,
+	}
+}
+
+func (t *Tokens) sign(payload string) string {
+	mac := hmac.New(sha256.New, t.secret)
+	mac.Write([]byte(tokenHeader + "." + payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (t *Tokens) Issue(id *Identity) (string, error) {
+	bytes, err := json.Marshal(&claims{
+		Identity: *id,
+		Expires:  t.now().Add(t.ttl).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(bytes)
+	return tokenHeader + "." + payload + "." + t.sign(payload), nil
+}
+
+func (t *Tokens) Verify(token string) (*Identity, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != tokenHeader {
+		return nil, ErrInvalidToken
+	}
+	if !hmac.Equal([]byte(parts[2]), []byte(t.sign(parts[1]))) {
+		return nil, ErrInvalidToken
+	}
+	bytes, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	var c claims
+	if err := json.Unmarshal(bytes, &c); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if t.now().Unix() >= c.Expires {
+		return nil, ErrExpiredToken
+	}
+	return &c.Identity, nil
+}
+
+func bearer(r *http.Request) string {
+	h := r.Header.Get("Authorization")
+	if token, ok := strings.CutPrefix(h, "Bearer "); ok {
+		return token
+	}
+	return ""
+}
+
+// RequireUser rejects requests without a valid bearer token and passes the
+// identity in the token to next through the request context.
+func (t *Tokens) RequireUser(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		log.Println("handling middleware RequireUser")
+		token := bearer(r)
+		if token == "" {
+			http.Error(w, "missing bearer token", http.StatusUnauthorized)
+			return
+		}
+		id, err := t.Verify(token)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		next(w, r.WithContext(WithIdentity(r.Context(), id)))
+	}
+}
 
+type loginRequest struct {
+	User     string `json:"user"`
+	Password string `json:"password"`
+}
+
+type loginResponse struct {
+	Token string         `json:"token"`
+	Role  datastore.Role `json:"role"`
+}
+
+// Login exchanges a user name and password posted as JSON for a token.
+func (t *Tokens) Login(auth Authenticator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Add("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var req loginRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		role, err := auth.Authenticate(r.Context(), req.User, req.Password)
+		if err != nil {
+			log.Printf("login failed for %s: %v", req.User, err)
+			http.Error(w, "invalid user or password", http.StatusUnauthorized)
+			return
+		}
+		token, err := t.Issue(&Identity{User: req.User, Role: role})
+		if err != nil {
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(&loginResponse{Token: token, Role: role})
 	}
 }
@@ -0,0 +1,102 @@
+package middleware_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/jt05610/petri/datastore"
+	"github.com/jt05610/petri/middleware"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type passwords map[string]string
+
+func (p passwords) Authenticate(_ context.Context, name, password string) (datastore.Role, error) {
+	if p[name] != password {
+		return "", errors.New("bad password")
+	}
+	if name == "admin" {
+		return datastore.AdminRole, nil
+	}
+	return datastore.UserRole, nil
+}
+
+func TestTokens(t *testing.T) {
+	tokens := middleware.NewTokens([]byte("secret"), time.Minute)
+	token, err := tokens.Issue(&middleware.Identity{User: "alice", Role: datastore.UserRole})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := tokens.Verify(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.User != "alice" || id.Role != datastore.UserRole {
+		t.Fatalf("unexpected identity %+v", id)
+	}
+	if _, err := middleware.NewTokens([]byte("other"), time.Minute).Verify(token); !errors.Is(err, middleware.ErrInvalidToken) {
+		t.Fatalf("expected ErrInvalidToken, got %v", err)
+	}
+	expired, err := middleware.NewTokens([]byte("secret"), -time.Minute).Issue(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tokens.Verify(expired); !errors.Is(err, middleware.ErrExpiredToken) {
+		t.Fatalf("expected ErrExpiredToken, got %v", err)
+	}
+}
+
+func TestRequireUser(t *testing.T) {
+	tokens := middleware.NewTokens([]byte("secret"), time.Minute)
+	users := passwords{"admin": "pw", "alice": "pw"}
+	var seen *middleware.Identity
+	h := tokens.RequireUser(func(w http.ResponseWriter, r *http.Request) {
+		seen = middleware.IdentityFrom(r.Context())
+		if err := middleware.RequireRole(r.Context(), datastore.AdminRole); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+		}
+	})
+
+	rec := httptest.NewRecorder()
+	h(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without a token, got %d", rec.Code)
+	}
+
+	login := func(user string) string {
+		body, _ := json.Marshal(map[string]string{"user": user, "password": "pw"})
+		rec := httptest.NewRecorder()
+		tokens.Login(users)(rec, httptest.NewRequest(http.MethodPost, "/login", bytes.NewReader(body)))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("login failed: %d", rec.Code)
+		}
+		var res struct{ Token string }
+		if err := json.NewDecoder(rec.Body).Decode(&res); err != nil {
+			t.Fatal(err)
+		}
+		return res.Token
+	}
+
+	for _, tc := range []struct {
+		user string
+		code int
+	}{
+		{"alice", http.StatusForbidden},
+		{"admin", http.StatusOK},
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+login(tc.user))
+		rec := httptest.NewRecorder()
+		h(rec, req)
+		if rec.Code != tc.code {
+			t.Fatalf("%s: expected %d, got %d", tc.user, tc.code, rec.Code)
+		}
+		if seen == nil || seen.User != tc.user {
+			t.Fatalf("expected identity %s, got %+v", tc.user, seen)
+		}
+	}
+}
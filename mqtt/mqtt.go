@@ -0,0 +1,463 @@
+// Package mqtt carries commands and events over an MQTT broker for devices
+// that cannot speak AMQP. Routing keys become topics under a prefix that plays
+// the part of the AMQP exchange: pump.commands.dispense is published on
+// <prefix>/pump/commands/dispense with QoS 1, and the patterns * and # become
+// the MQTT wildcards + and #.
+//
+// MQTT 3.1.1 has no message properties, so every payload is a JSON envelope:
+//
+//	{
+//	  "key": "pump.events.dispensed",
+//	  "headers": {"x-event-id": "..."},
+//	  "binary": {"x-marking": "<base64>"},
+//	  "message_id": "...",
+//	  "correlation_id": "...",
+//	  "reply_to": "<prefix>/reply/...",
+//	  "body": {"volume": 5}
+//	}
+//
+// Bodies that are not JSON are sent base64 encoded in "raw" instead of "body".
+package mqtt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	paho "github.com/eclipse/paho.mqtt.golang"
+	"github.com/google/uuid"
+	"github.com/jt05610/petri/transport"
+	"go.uber.org/zap"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var _ transport.Transport = (*Transport)(nil)
+
+// QoS is the quality of service every message is published and subscribed
+// with: at least once, matching publisher confirms and acks on AMQP.
+const QoS = 1
+
+// DefaultDisconnectQuiesce is how long Close waits, in milliseconds, for work
+// in flight before disconnecting.
+const DefaultDisconnectQuiesce = 250
+
+// Topology describes the topics a Transport uses.
+type Topology struct {
+	// Prefix roots every topic, as the exchange does for AMQP.
+	Prefix string
+	// ClientID names the session. When set the broker keeps the session's
+	// subscriptions and undelivered messages while the client is away; when
+	// empty a random ID is used and the session is clean.
+	ClientID string
+}
+
+// Topic is the topic a routing key is published on.
+func Topic(prefix, key string) string {
+	path := strings.ReplaceAll(key, ".", "/")
+	if prefix == "" {
+		return path
+	}
+	return prefix + "/" + path
+}
+
+// Filter is the topic filter for a routing key pattern.
+func Filter(prefix, pattern string) string {
+	words := strings.Split(pattern, ".")
+	for i, w := range words {
+		if w == "*" {
+			words[i] = "+"
+		}
+	}
+	return Topic(prefix, strings.Join(words, "."))
+}
+
+// Key is the routing key a topic was published for.
+func Key(prefix, topic string) string {
+	if prefix != "" {
+		topic = strings.TrimPrefix(topic, prefix+"/")
+	}
+	return strings.ReplaceAll(topic, "/", ".")
+}
+
+// ReplyTopic is the root of the topics subscriptions are sent replies on.
+func ReplyTopic(prefix string) string {
+	return Topic(prefix, "reply")
+}
+
+// DeadLetterTopic is the root of the topics poison messages are republished
+// under, followed by their routing key.
+func DeadLetterTopic(prefix string) string {
+	return Topic(prefix, "dead")
+}
+
+// envelope carries what AMQP keeps in message properties.
+type envelope struct {
+	Key           string            `json:"key,omitempty"`
+	Headers       map[string]string `json:"headers,omitempty"`
+	Binary        map[string][]byte `json:"binary,omitempty"`
+	MessageID     string            `json:"message_id,omitempty"`
+	CorrelationID string            `json:"correlation_id,omitempty"`
+	ReplyTo       string            `json:"reply_to,omitempty"`
+	Body          json.RawMessage   `json:"body,omitempty"`
+	Raw           []byte            `json:"raw,omitempty"`
+}
+
+func encode(msg *transport.Message) ([]byte, error) {
+	e := &envelope{
+		Key:           msg.Key,
+		MessageID:     msg.MessageID,
+		CorrelationID: msg.CorrelationID,
+		ReplyTo:       msg.ReplyTo,
+	}
+	for k, v := range msg.Headers {
+		switch v := v.(type) {
+		case []byte:
+			if e.Binary == nil {
+				e.Binary = make(map[string][]byte)
+			}
+			e.Binary[k] = v
+		default:
+			if e.Headers == nil {
+				e.Headers = make(map[string]string)
+			}
+			e.Headers[k] = fmt.Sprint(v)
+		}
+	}
+	if json.Valid(msg.Body) {
+		e.Body = msg.Body
+	} else {
+		e.Raw = msg.Body
+	}
+	return json.Marshal(e)
+}
+
+func decode(prefix, topic string, payload []byte) (*transport.Message, error) {
+	var e envelope
+	if err := json.Unmarshal(payload, &e); err != nil {
+		return nil, err
+	}
+	msg := &transport.Message{
+		Key:           e.Key,
+		Headers:       make(map[string]interface{}, len(e.Headers)+len(e.Binary)),
+		Body:          e.Raw,
+		MessageID:     e.MessageID,
+		CorrelationID: e.CorrelationID,
+		ReplyTo:       e.ReplyTo,
+	}
+	if msg.Key == "" {
+		msg.Key = Key(prefix, topic)
+	}
+	if len(e.Body) > 0 {
+		msg.Body = e.Body
+	}
+	for k, v := range e.Headers {
+		msg.Headers[k] = v
+	}
+	for k, v := range e.Binary {
+		msg.Headers[k] = v
+	}
+	return msg, nil
+}
+
+// Transport carries messages over one MQTT connection. All of its
+// subscriptions share the connection and are restored when it reconnects.
+type Transport struct {
+	client   paho.Client
+	id       string
+	topology *Topology
+	logger   *zap.Logger
+	mu       sync.Mutex
+	subs     map[*subscription]struct{}
+	next     int
+}
+
+// Connect dials the broker at uri, such as tcp://localhost:1883, and keeps the
+// connection open until Close is called.
+func Connect(uri string, topology *Topology, logger *zap.Logger) (*Transport, error) {
+	t := &Transport{
+		topology: topology,
+		logger:   logger,
+		subs:     make(map[*subscription]struct{}),
+	}
+	t.id = topology.ClientID
+	if t.id == "" {
+		t.id = "petri-" + uuid.NewString()
+	}
+	opts := paho.NewClientOptions().
+		AddBroker(uri).
+		SetClientID(t.id).
+		SetCleanSession(topology.ClientID == "").
+		SetAutoReconnect(true).
+		SetAutoAckDisabled(true).
+		SetOrderMatters(true).
+		SetDefaultPublishHandler(t.dispatch).
+		SetOnConnectHandler(t.resubscribe).
+		SetConnectionLostHandler(func(_ paho.Client, err error) {
+			logger.Warn("Lost connection to broker", zap.Error(err))
+		})
+	t.client = paho.NewClient(opts)
+	tok := t.client.Connect()
+	tok.Wait()
+	if err := tok.Error(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func wait(ctx context.Context, tok paho.Token) error {
+	select {
+	case <-tok.Done():
+		return tok.Error()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (t *Transport) publish(ctx context.Context, topic string, msg *transport.Message) error {
+	payload, err := encode(msg)
+	if err != nil {
+		return err
+	}
+	return wait(ctx, t.client.Publish(topic, QoS, false, payload))
+}
+
+func (t *Transport) Publish(ctx context.Context, msg *transport.Message) error {
+	return t.publish(ctx, Topic(t.topology.Prefix, msg.Key), msg)
+}
+
+// Send publishes msg on the reply topic at address.
+func (t *Transport) Send(ctx context.Context, address string, msg *transport.Message) error {
+	return t.publish(ctx, address, msg)
+}
+
+// delivery is shared by the copies of a message handed to overlapping
+// subscriptions. The broker is acked once every copy is settled.
+type delivery struct {
+	pm      paho.Message
+	pending atomic.Int32
+}
+
+func (d *delivery) settle() {
+	if d.pending.Add(-1) == 0 {
+		d.pm.Ack()
+	}
+}
+
+type acknowledger struct {
+	d       *delivery
+	sub     *subscription
+	msg     *transport.Message
+	settled atomic.Bool
+}
+
+func (a *acknowledger) Ack() error {
+	if a.settled.CompareAndSwap(false, true) {
+		a.d.settle()
+	}
+	return nil
+}
+
+// Nack puts the message back at the head of its subscription when requeue is
+// set. Otherwise it is republished under the dead letter topic if the queue
+// keeps dead letters, and dropped if not.
+func (a *acknowledger) Nack(requeue bool) error {
+	if requeue {
+		a.sub.push(a.msg, true)
+		return nil
+	}
+	if !a.settled.CompareAndSwap(false, true) {
+		return nil
+	}
+	defer a.d.settle()
+	if !a.sub.deadLetter {
+		return nil
+	}
+	t := a.sub.t
+	return t.publish(context.Background(), DeadLetterTopic(t.topology.Prefix)+"/"+strings.ReplaceAll(a.msg.Key, ".", "/"), a.msg)
+}
+
+// dispatch hands a message from the broker to every subscription that wants
+// it. Messages on a reply topic only go to the subscription that owns it.
+func (t *Transport) dispatch(_ paho.Client, pm paho.Message) {
+	msg, err := decode(t.topology.Prefix, pm.Topic(), pm.Payload())
+	if err != nil {
+		t.logger.Error("Dropping undecodable message", zap.String("topic", pm.Topic()), zap.Error(err))
+		pm.Ack()
+		return
+	}
+	t.mu.Lock()
+	targets := make([]*subscription, 0, 1)
+	for sub := range t.subs {
+		if sub.wants(pm.Topic(), msg.Key) {
+			targets = append(targets, sub)
+		}
+	}
+	t.mu.Unlock()
+	if len(targets) == 0 {
+		pm.Ack()
+		return
+	}
+	d := &delivery{pm: pm}
+	d.pending.Store(int32(len(targets)))
+	for _, sub := range targets {
+		cp := *msg
+		a := &acknowledger{d: d, sub: sub, msg: &cp}
+		cp.Acknowledger = a
+		sub.push(&cp, false)
+	}
+}
+
+func (t *Transport) filters(sub *subscription) map[string]byte {
+	ret := map[string]byte{sub.address: QoS}
+	for _, key := range sub.keys {
+		ret[Filter(t.topology.Prefix, key)] = QoS
+	}
+	return ret
+}
+
+func (t *Transport) resubscribe(c paho.Client) {
+	t.mu.Lock()
+	filters := make(map[string]byte)
+	for sub := range t.subs {
+		for f, qos := range t.filters(sub) {
+			filters[f] = qos
+		}
+	}
+	t.mu.Unlock()
+	if len(filters) == 0 {
+		return
+	}
+	tok := c.SubscribeMultiple(filters, nil)
+	tok.Wait()
+	if err := tok.Error(); err != nil {
+		t.logger.Error("Failed to resubscribe", zap.Error(err))
+	}
+}
+
+type subscription struct {
+	t          *Transport
+	address    string
+	keys       []string
+	deadLetter bool
+	mu         sync.Mutex
+	items      []*transport.Message
+	// wake is closed and replaced whenever a message is queued.
+	wake chan struct{}
+	msgs chan *transport.Message
+}
+
+func (s *subscription) Address() string {
+	return s.address
+}
+
+func (s *subscription) Messages() <-chan *transport.Message {
+	return s.msgs
+}
+
+func (s *subscription) wants(topic, key string) bool {
+	if strings.HasPrefix(topic, ReplyTopic(s.t.topology.Prefix)+"/") {
+		return topic == s.address
+	}
+	for _, k := range s.keys {
+		if transport.Match(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *subscription) push(m *transport.Message, front bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if front {
+		s.items = append([]*transport.Message{m}, s.items...)
+	} else {
+		s.items = append(s.items, m)
+	}
+	close(s.wake)
+	s.wake = make(chan struct{})
+}
+
+func (s *subscription) consume(ctx context.Context) {
+	defer close(s.msgs)
+	defer s.t.unsubscribe(s)
+	for {
+		s.mu.Lock()
+		if len(s.items) == 0 {
+			wake := s.wake
+			s.mu.Unlock()
+			select {
+			case <-ctx.Done():
+				return
+			case <-wake:
+				continue
+			}
+		}
+		m := s.items[0]
+		s.items = s.items[1:]
+		s.mu.Unlock()
+		select {
+		case s.msgs <- m:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Subscribe subscribes to the queue's keys and a reply topic of its own until
+// ctx is cancelled. Messages left unacked when ctx is cancelled are sent again
+// by the broker when the session resumes, if it is kept.
+func (t *Transport) Subscribe(ctx context.Context, q *transport.Queue) (transport.Subscription, error) {
+	t.mu.Lock()
+	t.next++
+	sub := &subscription{
+		t:          t,
+		address:    fmt.Sprintf("%s/%s.%d", ReplyTopic(t.topology.Prefix), t.id, t.next),
+		keys:       q.Keys,
+		deadLetter: q.DeadLetter,
+		wake:       make(chan struct{}),
+		msgs:       make(chan *transport.Message),
+	}
+	t.subs[sub] = struct{}{}
+	t.mu.Unlock()
+	if err := wait(ctx, t.client.SubscribeMultiple(t.filters(sub), nil)); err != nil {
+		t.unsubscribe(sub)
+		return nil, err
+	}
+	go sub.consume(ctx)
+	return sub, nil
+}
+
+// unsubscribe drops the subscription and the filters no other subscription
+// still needs.
+func (t *Transport) unsubscribe(sub *subscription) {
+	t.mu.Lock()
+	delete(t.subs, sub)
+	inUse := make(map[string]bool)
+	for other := range t.subs {
+		for f := range t.filters(other) {
+			inUse[f] = true
+		}
+	}
+	t.mu.Unlock()
+	unused := make([]string, 0)
+	for f := range t.filters(sub) {
+		if !inUse[f] {
+			unused = append(unused, f)
+		}
+	}
+	if len(unused) == 0 || !t.client.IsConnectionOpen() {
+		return
+	}
+	tok := t.client.Unsubscribe(unused...)
+	if !tok.WaitTimeout(DefaultDisconnectQuiesce*time.Millisecond) || tok.Error() != nil {
+		t.logger.Debug("Failed to unsubscribe", zap.Strings("filters", unused), zap.Error(tok.Error()))
+	}
+}
+
+func (t *Transport) Close() error {
+	t.client.Disconnect(DefaultDisconnectQuiesce)
+	return nil
+}
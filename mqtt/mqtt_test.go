@@ -0,0 +1,177 @@
+package mqtt_test
+
+import (
+	"context"
+	"errors"
+	"github.com/jt05610/petri"
+	"github.com/jt05610/petri/amqp/client"
+	"github.com/jt05610/petri/amqp/server"
+	"github.com/jt05610/petri/control"
+	"github.com/jt05610/petri/labeled"
+	"github.com/jt05610/petri/marked"
+	"github.com/jt05610/petri/mqtt"
+	"github.com/jt05610/petri/transport"
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/hooks/auth"
+	"github.com/mochi-mqtt/server/v2/listeners"
+	"go.uber.org/zap"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestTopic(t *testing.T) {
+	if got := mqtt.Topic("lab", "pump.commands.dispense"); got != "lab/pump/commands/dispense" {
+		t.Errorf("unexpected topic %s", got)
+	}
+	if got := mqtt.Topic("lab", "devices"); got != "lab/devices" {
+		t.Errorf("unexpected topic %s", got)
+	}
+	if got := mqtt.Filter("lab", "*.events.*"); got != "lab/+/events/+" {
+		t.Errorf("unexpected filter %s", got)
+	}
+	if got := mqtt.Filter("lab", "pump.#"); got != "lab/pump/#" {
+		t.Errorf("unexpected filter %s", got)
+	}
+	if got := mqtt.Key("lab", "lab/pump/device/pump-device"); got != "pump.device.pump-device" {
+		t.Errorf("unexpected key %s", got)
+	}
+}
+
+// broker starts an embedded MQTT broker and returns its URI.
+func broker(t *testing.T) string {
+	t.Helper()
+	s := mochi.New(&mochi.Options{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))})
+	if err := s.AddHook(new(auth.AllowHook), nil); err != nil {
+		t.Fatal(err)
+	}
+	tcp := listeners.NewTCP(listeners.Config{ID: "test", Address: "127.0.0.1:0"})
+	if err := s.AddListener(tcp); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Serve(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = s.Close()
+	})
+	return "tcp://" + tcp.Address()
+}
+
+func connect(t *testing.T, uri string) *mqtt.Transport {
+	t.Helper()
+	tr, err := mqtt.Connect(uri, &mqtt.Topology{Prefix: "lab"}, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = tr.Close()
+	})
+	return tr
+}
+
+// valve is a device that opens, or fails when asked to close.
+func valve(ctx context.Context, t *testing.T, tr transport.Transport) {
+	t.Helper()
+	closed, open := petri.NewPlace("closed", 1), petri.NewPlace("open", 1)
+	openT, closeT := petri.NewTransition("open"), petri.NewTransition("close")
+	net := petri.NewNet("valve").
+		WithPlaces(closed, open).
+		WithTransitions(openT, closeT).
+		WithArcs(
+			petri.NewArc(closed, openT, "", nil),
+			petri.NewArc(openT, open, "", nil),
+			petri.NewArc(open, closeT, "", nil),
+			petri.NewArc(closeT, closed, "", nil),
+		)
+	ln := labeled.New(marked.New(net, marked.Marking{1, 0}))
+	ln.Events = []*labeled.Event{{ID: "open-id", Name: "open"}, {ID: "close-id", Name: "close"}}
+	handlers := control.Handlers{
+		"open": func(_ context.Context, ev *labeled.Event) (*labeled.Event, error) {
+			return &labeled.Event{Name: ev.Name, Data: map[string]interface{}{"position": 1.0}}, nil
+		},
+		"close": func(context.Context, *labeled.Event) (*labeled.Event, error) {
+			return nil, errors.New("stuck")
+		},
+	}
+	srv, err := server.New(ln, tr, "valve-device", "valve-1", map[string]*petri.Transition{"open": openT, "close": closeT}, handlers, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		_ = srv.Listen(ctx)
+	}()
+}
+
+func call(ctx context.Context, c *client.Controller, name string) (*control.Event, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	return c.Call(ctx, &control.Command{
+		Event: &labeled.Event{ID: name + "-id", Name: name, Data: map[string]interface{}{}},
+		To:    "valve-1",
+	})
+}
+
+func check(ctx context.Context, t *testing.T, c *client.Controller) {
+	t.Helper()
+	// the device subscribes asynchronously, so retry until it is listening
+	var ev *control.Event
+	var err error
+	for i := 0; i < 5; i++ {
+		ev, err = call(ctx, c, "open")
+		if err == nil || !errors.Is(err, context.DeadlineExceeded) {
+			break
+		}
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ev.From != "valve-1" || ev.ID != "open-id" || ev.Data["position"] != 1.0 || ev.Marking == nil {
+		t.Fatalf("unexpected answer %+v", ev)
+	}
+	_, err = call(ctx, c, "close")
+	var cErr *control.Error
+	if !errors.As(err, &cErr) || cErr.Kind != control.HandlerFailed || cErr.Message != "stuck" {
+		t.Fatalf("expected a handler failure, got %v", err)
+	}
+}
+
+func TestTransport(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	uri := broker(t)
+	valve(ctx, t, connect(t, uri))
+	c := client.NewController(zap.NewNop(), connect(t, uri))
+	defer c.Close()
+	if err := c.Listen(ctx); err != nil {
+		t.Fatal(err)
+	}
+	check(ctx, t, c)
+}
+
+func TestBridge(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	uri := broker(t)
+	valve(ctx, t, connect(t, uri))
+
+	// the in-process broker stands in for the AMQP side
+	local := transport.NewBroker()
+	defer func() {
+		_ = local.Close()
+	}()
+	remote := connect(t, uri)
+	go func() {
+		_ = transport.Bridge(ctx, local, remote, &transport.Queue{Name: "bridge.down", Keys: transport.Downstream})
+	}()
+	go func() {
+		_ = transport.Bridge(ctx, remote, local, &transport.Queue{Name: "bridge.up", Keys: transport.Upstream})
+	}()
+	c := client.NewController(zap.NewNop(), local)
+	defer c.Close()
+	if err := c.Listen(ctx); err != nil {
+		t.Fatal(err)
+	}
+	check(ctx, t, c)
+}
@@ -2,6 +2,7 @@ package petri
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"io"
 )
@@ -81,13 +82,13 @@ func (m Marking) PlaceTokens(place *Place, tokens ...*Token[interface{}]) error
 
 // Net struct
 type Net struct {
-	ID           string
-	Name         string
-	TokenSchemas []*TokenSchema
-	Places       []*Place
-	Transitions  []*Transition
-	Arcs         []*Arc
-	Nets         []*Net
+	ID           string         `json:"_id"`
+	Name         string         `json:"name,omitempty"`
+	TokenSchemas []*TokenSchema `json:"tokenSchemas,omitempty"`
+	Places       []*Place       `json:"places,omitempty"`
+	Transitions  []*Transition  `json:"transitions,omitempty"`
+	Arcs         []*Arc         `json:"arcs,omitempty"`
+	Nets         []*Net         `json:"nets,omitempty"`
 	inputs       map[string][]*Arc
 	outputs      map[string][]*Arc
 }
@@ -96,14 +97,67 @@ func (p *Net) PostInit() error {
 	return nil
 }
 
+func placeIDs(places []*Place) []*Place {
+	ids := make([]*Place, len(places))
+	for i, p := range places {
+		ids[i] = &Place{ID: p.ID}
+	}
+	return ids
+}
+
+func transitionIDs(transitions []*Transition) []*Transition {
+	ids := make([]*Transition, len(transitions))
+	for i, t := range transitions {
+		ids[i] = &Transition{ID: t.ID}
+	}
+	return ids
+}
+
+func arcIDs(arcs []*Arc) []Document {
+	ids := make([]Document, len(arcs))
+	for i, a := range arcs {
+		ids[i] = Document{"_id": a.ID}
+	}
+	return ids
+}
+
+func netIDs(nets []*Net) []Document {
+	ids := make([]Document, len(nets))
+	for i, n := range nets {
+		ids[i] = Document{"_id": n.ID}
+	}
+	return ids
+}
+
 func (p *Net) Document() Document {
-	//TODO implement me
-	panic("implement me")
+	return Document{
+		"_id":          p.ID,
+		"name":         p.Name,
+		"tokenSchemas": acceptedTokenIDs(p.TokenSchemas),
+		"places":       placeIDs(p.Places),
+		"transitions":  transitionIDs(p.Transitions),
+		"arcs":         arcIDs(p.Arcs),
+		"nets":         netIDs(p.Nets),
+	}
 }
 
+// From loads the net from a document such as the one Document returns. Like
+// Document, it leaves the places, transitions, arcs and nets as references
+// holding only their IDs.
 func (p *Net) From(doc Document) error {
-	//TODO implement me
-	panic("implement me")
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	ret := Net{
+		inputs:  make(map[string][]*Arc),
+		outputs: make(map[string][]*Arc),
+	}
+	if err := json.Unmarshal(b, &ret); err != nil {
+		return err
+	}
+	*p = ret
+	return p.PostInit()
 }
 
 func (p *Net) NewMarking() Marking {
@@ -315,8 +369,14 @@ func (p *Net) Update(update Update) error {
 	return nil
 }
 
+// Identifier is the net's ID, which its document is stored under, like the
+// places and transitions it holds. Nets built with NewNet have no ID until
+// they are stored, so those fall back to their name.
 func (p *Net) Identifier() string {
-	return p.Name
+	if p.ID == "" {
+		return p.Name
+	}
+	return p.ID
 }
 
 func (p *Net) String() string {
@@ -463,8 +523,11 @@ type NetInput struct {
 }
 
 func (n *NetInput) Object() Object {
-	//TODO implement me
-	panic("implement me")
+	net := LoadNet(n.Places, n.Transitions, n.Arcs)
+	net.ID = ID()
+	net.Name = n.Name
+	net.TokenSchemas = n.TokenSchemas
+	return net
 }
 
 func (n *NetInput) Kind() Kind {
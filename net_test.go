@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/jt05610/petri"
+	"testing"
 )
 
 // ExampleNet demonstrates how to create a simple net, initialize the marking, and process the marking with the net
@@ -12,7 +13,7 @@ func ExampleNet() {
 	// describe the schema of the tokens involved in the system
 
 	// we need to pay a coin to get a cookie
-	coinSchema := petri.TokenSchema[interface{}]{
+	coinSchema := petri.TokenSchema{
 		Name: "Coin",
 		Type: petri.Obj,
 		Properties: map[string]petri.Properties{
@@ -29,7 +30,7 @@ func ExampleNet() {
 	}
 
 	// we get a cookie in return
-	cookieSchema := petri.TokenSchema[interface{}]{
+	cookieSchema := petri.TokenSchema{
 		Name: "Cookie",
 		Type: petri.Obj,
 		Properties: map[string]petri.Properties{
@@ -50,7 +51,7 @@ func ExampleNet() {
 	// signalPlace is a place that is used to signal that a cookie is ready to be dispensed
 	signalPlace := petri.NewPlace("Signal Place", 1, signalSchema)
 
-	countSchema := petri.TokenSchema[interface{}]{
+	countSchema := petri.TokenSchema{
 		Name: "Count",
 		Type: petri.Int,
 	}
@@ -80,7 +81,7 @@ func ExampleNet() {
 			"Currency": coin.Currency,
 			"Diameter": coin.Diameter,
 		}, nil
-	}))
+	}), "", &coinSchema, &coinSchema)
 	checkStorage := petri.NewTransition("Check Coin", "Coin.Currency == \"EUR\" && Coin.Value == 1.0 && Count > 0")
 	returnCoin := petri.NewTransition("Return Coin", "!(Coin.Currency == \"EUR\" && Coin.Value == 1.0 && Count > 0)")
 	getCookie := petri.NewTransition("Get Cookie")
@@ -92,9 +93,8 @@ func ExampleNet() {
 		result := &Cookie{
 			Flavor: flavor,
 		}
-		fmt.Sprintln("Got cookie", result)
 		return result, nil
-	}))
+	}), "", &cookieSchema, &cookieSchema)
 
 	machine := petri.NewNet(
 		"CookieMachine",
@@ -198,11 +198,33 @@ func ExampleNet() {
 
 	// Output:
 	// Initial marking:
-	// map[Cash Box:[] Coin Slot:[Coin(map[Currency:EUR Diameter:23.25 Value:1])] Compartment:[] Counter:[Count(5)] Signal Place:[] Storage:[Cookie(map[Flavor:Chocolate Chip]) Cookie(map[Flavor:Chocolate Chip]) Cookie(map[Flavor:Chocolate Chip]) Cookie(map[Flavor:Chocolate Chip]) Cookie(map[Flavor:Chocolate Chip])]]
-	//
-	// Intermediate marking:
-	// map[Cash Box:[Coin(map[Currency:EUR Diameter:23.25 Value:1])] Coin Slot:[] Compartment:[] Counter:[Count(4)] Signal Place:[Signal(<nil>)] Storage:[Cookie(map[Flavor:Chocolate Chip]) Cookie(map[Flavor:Chocolate Chip]) Cookie(map[Flavor:Chocolate Chip]) Cookie(map[Flavor:Chocolate Chip]) Cookie(map[Flavor:Chocolate Chip])]]
-	//
-	// Final marking:
-	// map[Cash Box:[Coin(map[Currency:EUR Diameter:23.25 Value:1])] Coin Slot:[] Compartment:[Cookie(map[Flavor:Chocolate Chip])] Counter:[Count(4)] Signal Place:[] Storage:[Cookie(map[Flavor:Chocolate Chip]) Cookie(map[Flavor:Chocolate Chip]) Cookie(map[Flavor:Chocolate Chip]) Cookie(map[Flavor:Chocolate Chip])]]
+	// map[Cash Box:[] Coin Slot:[] Compartment:[] Counter:[Count(5)] Signal Place:[] Storage:[Cookie(map[Flavor:Chocolate Chip]) Cookie(map[Flavor:Chocolate Chip]) Cookie(map[Flavor:Chocolate Chip]) Cookie(map[Flavor:Chocolate Chip]) Cookie(map[Flavor:Chocolate Chip])]]
+	// map[Cash Box:[Coin(map[Currency:EUR Diameter:23.25 Value:1])] Coin Slot:[] Compartment:[] Counter:[Count(4)] Signal Place:[] Storage:[Cookie(map[Flavor:Chocolate Chip]) Cookie(map[Flavor:Chocolate Chip]) Cookie(map[Flavor:Chocolate Chip]) Cookie(map[Flavor:Chocolate Chip])]]
+	// map[Cash Box:[Coin(map[Currency:EUR Diameter:23.25 Value:1]) Coin(map[Currency:EUR Diameter:23.25 Value:1])] Coin Slot:[] Compartment:[] Counter:[Count(3)] Signal Place:[] Storage:[Cookie(map[Flavor:Chocolate Chip]) Cookie(map[Flavor:Chocolate Chip]) Cookie(map[Flavor:Chocolate Chip])]]
+	// map[Cash Box:[Coin(map[Currency:EUR Diameter:23.25 Value:1]) Coin(map[Currency:EUR Diameter:23.25 Value:1]) Coin(map[Currency:EUR Diameter:23.25 Value:1])] Coin Slot:[] Compartment:[] Counter:[Count(2)] Signal Place:[] Storage:[Cookie(map[Flavor:Chocolate Chip]) Cookie(map[Flavor:Chocolate Chip])]]
+	// map[Cash Box:[Coin(map[Currency:EUR Diameter:23.25 Value:1]) Coin(map[Currency:EUR Diameter:23.25 Value:1]) Coin(map[Currency:EUR Diameter:23.25 Value:1]) Coin(map[Currency:EUR Diameter:23.25 Value:1])] Coin Slot:[] Compartment:[] Counter:[Count(1)] Signal Place:[] Storage:[Cookie(map[Flavor:Chocolate Chip])]]
+	// map[Cash Box:[Coin(map[Currency:EUR Diameter:23.25 Value:1]) Coin(map[Currency:EUR Diameter:23.25 Value:1]) Coin(map[Currency:EUR Diameter:23.25 Value:1]) Coin(map[Currency:EUR Diameter:23.25 Value:1]) Coin(map[Currency:EUR Diameter:23.25 Value:1])] Coin Slot:[] Compartment:[] Counter:[Count(0)] Signal Place:[] Storage:[]]
+}
+
+func TestNet_From(t *testing.T) {
+	p := petri.NewPlace("p", 1)
+	tr := petri.NewTransition("t")
+	net := petri.NewNet("net").WithPlaces(p).WithTransitions(tr).WithArcs(petri.NewArc(p, tr, "", nil))
+	net.ID = "net-id"
+	got := new(petri.Net)
+	if err := got.From(net.Document()); err != nil {
+		t.Fatal(err)
+	}
+	if got.Identifier() != "net-id" || got.Name != "net" {
+		t.Errorf("got net %s named %q", got.Identifier(), got.Name)
+	}
+	if len(got.Places) != 1 || got.Places[0].ID != p.ID {
+		t.Errorf("got places %v, want only %s", got.Places, p.ID)
+	}
+	if len(got.Transitions) != 1 || got.Transitions[0].ID != tr.ID {
+		t.Errorf("got transitions %v, want only %s", got.Transitions, tr.ID)
+	}
+	if len(got.Arcs) != 1 || got.Arcs[0].ID != net.Arcs[0].ID {
+		t.Errorf("got %d arcs, want only %s", len(got.Arcs), net.Arcs[0].ID)
+	}
 }
@@ -20,4 +20,26 @@ const (
 	NetObject
 	TokenObject
 	EventObject
+	NetVersionObject
 )
+
+func (k Kind) String() string {
+	switch k {
+	case PlaceObject:
+		return "place"
+	case TransitionObject:
+		return "transition"
+	case ArcObject:
+		return "arc"
+	case NetObject:
+		return "net"
+	case TokenObject:
+		return "token"
+	case EventObject:
+		return "event"
+	case NetVersionObject:
+		return "netVersion"
+	default:
+		return "unknown"
+	}
+}
@@ -8,28 +8,28 @@ import (
 func ExampleAdd() {
 	n1 := &petri.Net{
 		Places: []*petri.Place{
-			{Name: "a"},
-			{Name: "c"},
+			{ID: "a", Name: "a"},
+			{ID: "c", Name: "c"},
 		},
 		Transitions: []*petri.Transition{
-			{Name: "b"},
+			{ID: "b", Name: "b"},
 		},
 		Arcs: []*petri.Arc{
-			{Src: &petri.Place{Name: "a"}, Dest: &petri.Transition{Name: "b"}},
-			{Src: &petri.Transition{Name: "b"}, Dest: &petri.Place{Name: "c"}},
+			{Src: &petri.Place{ID: "a", Name: "a"}, Dest: &petri.Transition{ID: "b", Name: "b"}},
+			{Src: &petri.Transition{ID: "b", Name: "b"}, Dest: &petri.Place{ID: "c", Name: "c"}},
 		},
 	}
 	n2 := &petri.Net{
 		Places: []*petri.Place{
-			{Name: "d"},
-			{Name: "c"},
+			{ID: "d", Name: "d"},
+			{ID: "c", Name: "c"},
 		},
 		Transitions: []*petri.Transition{
-			{Name: "b"},
+			{ID: "b", Name: "b"},
 		},
 		Arcs: []*petri.Arc{
-			{Src: &petri.Place{Name: "d"}, Dest: &petri.Transition{Name: "b"}},
-			{Src: &petri.Transition{Name: "b"}, Dest: &petri.Place{Name: "c"}},
+			{Src: &petri.Place{ID: "d", Name: "d"}, Dest: &petri.Transition{ID: "b", Name: "b"}},
+			{Src: &petri.Transition{ID: "b", Name: "b"}, Dest: &petri.Place{ID: "c", Name: "c"}},
 		},
 	}
 	combined := petri.Add(n1, n2)
@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.28.1
-// 	protoc        v4.23.4
+// 	protoc-gen-go v1.31.0
+// 	protoc        (unknown)
 // source: v1/modbus.proto
 
 package modbus
@@ -702,6 +702,245 @@ func (x *WriteSingleRegisterResponse) GetValue() uint32 {
 	return 0
 }
 
+// Function Code 15 (Write Multiple Coils)
+type WriteMultipleCoilsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UnitId       uint32 `protobuf:"varint,1,opt,name=unitId,proto3" json:"unitId,omitempty"`
+	StartAddress uint32 `protobuf:"varint,2,opt,name=startAddress,proto3" json:"startAddress,omitempty"`
+	Data         []bool `protobuf:"varint,3,rep,packed,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *WriteMultipleCoilsRequest) Reset() {
+	*x = WriteMultipleCoilsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_modbus_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WriteMultipleCoilsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteMultipleCoilsRequest) ProtoMessage() {}
+
+func (x *WriteMultipleCoilsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_modbus_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteMultipleCoilsRequest.ProtoReflect.Descriptor instead.
+func (*WriteMultipleCoilsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_modbus_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *WriteMultipleCoilsRequest) GetUnitId() uint32 {
+	if x != nil {
+		return x.UnitId
+	}
+	return 0
+}
+
+func (x *WriteMultipleCoilsRequest) GetStartAddress() uint32 {
+	if x != nil {
+		return x.StartAddress
+	}
+	return 0
+}
+
+func (x *WriteMultipleCoilsRequest) GetData() []bool {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type WriteMultipleCoilsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StartAddress uint32 `protobuf:"varint,1,opt,name=startAddress,proto3" json:"startAddress,omitempty"`
+	Quantity     uint32 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+}
+
+func (x *WriteMultipleCoilsResponse) Reset() {
+	*x = WriteMultipleCoilsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_modbus_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WriteMultipleCoilsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteMultipleCoilsResponse) ProtoMessage() {}
+
+func (x *WriteMultipleCoilsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_modbus_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteMultipleCoilsResponse.ProtoReflect.Descriptor instead.
+func (*WriteMultipleCoilsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_modbus_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *WriteMultipleCoilsResponse) GetStartAddress() uint32 {
+	if x != nil {
+		return x.StartAddress
+	}
+	return 0
+}
+
+func (x *WriteMultipleCoilsResponse) GetQuantity() uint32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// Function Code 16 (Write Multiple Registers)
+type WriteMultipleRegistersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UnitId       uint32 `protobuf:"varint,1,opt,name=unitId,proto3" json:"unitId,omitempty"`
+	StartAddress uint32 `protobuf:"varint,2,opt,name=startAddress,proto3" json:"startAddress,omitempty"`
+	// big-endian, two bytes per register
+	Data []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *WriteMultipleRegistersRequest) Reset() {
+	*x = WriteMultipleRegistersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_modbus_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WriteMultipleRegistersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteMultipleRegistersRequest) ProtoMessage() {}
+
+func (x *WriteMultipleRegistersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_modbus_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteMultipleRegistersRequest.ProtoReflect.Descriptor instead.
+func (*WriteMultipleRegistersRequest) Descriptor() ([]byte, []int) {
+	return file_v1_modbus_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *WriteMultipleRegistersRequest) GetUnitId() uint32 {
+	if x != nil {
+		return x.UnitId
+	}
+	return 0
+}
+
+func (x *WriteMultipleRegistersRequest) GetStartAddress() uint32 {
+	if x != nil {
+		return x.StartAddress
+	}
+	return 0
+}
+
+func (x *WriteMultipleRegistersRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type WriteMultipleRegistersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StartAddress uint32 `protobuf:"varint,1,opt,name=startAddress,proto3" json:"startAddress,omitempty"`
+	Quantity     uint32 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+}
+
+func (x *WriteMultipleRegistersResponse) Reset() {
+	*x = WriteMultipleRegistersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_modbus_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WriteMultipleRegistersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteMultipleRegistersResponse) ProtoMessage() {}
+
+func (x *WriteMultipleRegistersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_modbus_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteMultipleRegistersResponse.ProtoReflect.Descriptor instead.
+func (*WriteMultipleRegistersResponse) Descriptor() ([]byte, []int) {
+	return file_v1_modbus_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *WriteMultipleRegistersResponse) GetStartAddress() uint32 {
+	if x != nil {
+		return x.StartAddress
+	}
+	return 0
+}
+
+func (x *WriteMultipleRegistersResponse) GetQuantity() uint32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
 type InputRegister struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -716,7 +955,7 @@ type InputRegister struct {
 func (x *InputRegister) Reset() {
 	*x = InputRegister{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_modbus_proto_msgTypes[12]
+		mi := &file_v1_modbus_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -729,7 +968,7 @@ func (x *InputRegister) String() string {
 func (*InputRegister) ProtoMessage() {}
 
 func (x *InputRegister) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_modbus_proto_msgTypes[12]
+	mi := &file_v1_modbus_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -742,7 +981,7 @@ func (x *InputRegister) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InputRegister.ProtoReflect.Descriptor instead.
 func (*InputRegister) Descriptor() ([]byte, []int) {
-	return file_v1_modbus_proto_rawDescGZIP(), []int{12}
+	return file_v1_modbus_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *InputRegister) GetRegisterMapId() string {
@@ -787,7 +1026,7 @@ type HoldingRegister struct {
 func (x *HoldingRegister) Reset() {
 	*x = HoldingRegister{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_modbus_proto_msgTypes[13]
+		mi := &file_v1_modbus_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -800,7 +1039,7 @@ func (x *HoldingRegister) String() string {
 func (*HoldingRegister) ProtoMessage() {}
 
 func (x *HoldingRegister) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_modbus_proto_msgTypes[13]
+	mi := &file_v1_modbus_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -813,7 +1052,7 @@ func (x *HoldingRegister) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HoldingRegister.ProtoReflect.Descriptor instead.
 func (*HoldingRegister) Descriptor() ([]byte, []int) {
-	return file_v1_modbus_proto_rawDescGZIP(), []int{13}
+	return file_v1_modbus_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *HoldingRegister) GetRegisterMapId() string {
@@ -857,7 +1096,7 @@ type Coil struct {
 func (x *Coil) Reset() {
 	*x = Coil{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_modbus_proto_msgTypes[14]
+		mi := &file_v1_modbus_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -870,7 +1109,7 @@ func (x *Coil) String() string {
 func (*Coil) ProtoMessage() {}
 
 func (x *Coil) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_modbus_proto_msgTypes[14]
+	mi := &file_v1_modbus_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -883,7 +1122,7 @@ func (x *Coil) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Coil.ProtoReflect.Descriptor instead.
 func (*Coil) Descriptor() ([]byte, []int) {
-	return file_v1_modbus_proto_rawDescGZIP(), []int{14}
+	return file_v1_modbus_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *Coil) GetRegisterMapId() string {
@@ -920,7 +1159,7 @@ type DiscreteInput struct {
 func (x *DiscreteInput) Reset() {
 	*x = DiscreteInput{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_modbus_proto_msgTypes[15]
+		mi := &file_v1_modbus_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -933,7 +1172,7 @@ func (x *DiscreteInput) String() string {
 func (*DiscreteInput) ProtoMessage() {}
 
 func (x *DiscreteInput) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_modbus_proto_msgTypes[15]
+	mi := &file_v1_modbus_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -946,7 +1185,7 @@ func (x *DiscreteInput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DiscreteInput.ProtoReflect.Descriptor instead.
 func (*DiscreteInput) Descriptor() ([]byte, []int) {
-	return file_v1_modbus_proto_rawDescGZIP(), []int{15}
+	return file_v1_modbus_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *DiscreteInput) GetRegisterMapId() string {
@@ -985,7 +1224,7 @@ type RegisterMap struct {
 func (x *RegisterMap) Reset() {
 	*x = RegisterMap{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_modbus_proto_msgTypes[16]
+		mi := &file_v1_modbus_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -998,7 +1237,7 @@ func (x *RegisterMap) String() string {
 func (*RegisterMap) ProtoMessage() {}
 
 func (x *RegisterMap) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_modbus_proto_msgTypes[16]
+	mi := &file_v1_modbus_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1011,7 +1250,7 @@ func (x *RegisterMap) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterMap.ProtoReflect.Descriptor instead.
 func (*RegisterMap) Descriptor() ([]byte, []int) {
-	return file_v1_modbus_proto_rawDescGZIP(), []int{16}
+	return file_v1_modbus_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *RegisterMap) GetId() string {
@@ -1116,88 +1355,126 @@ var file_v1_modbus_proto_rawDesc = []byte{
 	0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64,
 	0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72,
 	0x65, 0x73, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x79, 0x0a, 0x0d, 0x49, 0x6e, 0x70,
-	0x75, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x0f, 0x72, 0x65,
-	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x4d, 0x61, 0x70,
-	0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
-	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
-	0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04,
-	0x73, 0x69, 0x7a, 0x65, 0x22, 0x7b, 0x0a, 0x0f, 0x48, 0x6f, 0x6c, 0x64, 0x69, 0x6e, 0x67, 0x52,
-	0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x0f, 0x72, 0x65, 0x67, 0x69, 0x73,
+	0x28, 0x0d, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x6b, 0x0a, 0x19, 0x57, 0x72, 0x69,
+	0x74, 0x65, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x65, 0x43, 0x6f, 0x69, 0x6c, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x6e, 0x69, 0x74, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x75, 0x6e, 0x69, 0x74, 0x49, 0x64, 0x12, 0x22,
+	0x0a, 0x0c, 0x73, 0x74, 0x61, 0x72, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x73, 0x74, 0x61, 0x72, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x03, 0x28, 0x08,
+	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x5c, 0x0a, 0x1a, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4d,
+	0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x65, 0x43, 0x6f, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x72, 0x74, 0x41, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75, 0x61, 0x6e,
+	0x74, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x71, 0x75, 0x61, 0x6e,
+	0x74, 0x69, 0x74, 0x79, 0x22, 0x6f, 0x0a, 0x1d, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4d, 0x75, 0x6c,
+	0x74, 0x69, 0x70, 0x6c, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x6e, 0x69, 0x74, 0x49, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x75, 0x6e, 0x69, 0x74, 0x49, 0x64, 0x12, 0x22, 0x0a,
+	0x0c, 0x73, 0x74, 0x61, 0x72, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0c, 0x73, 0x74, 0x61, 0x72, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x60, 0x0a, 0x1e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4d, 0x75,
+	0x6c, 0x74, 0x69, 0x70, 0x6c, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x71,
+	0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x71,
+	0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x22, 0x79, 0x0a, 0x0d, 0x49, 0x6e, 0x70, 0x75, 0x74,
+	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x0f, 0x72, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x4d, 0x61, 0x70, 0x49, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x73, 0x69,
+	0x7a, 0x65, 0x22, 0x7b, 0x0a, 0x0f, 0x48, 0x6f, 0x6c, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x0f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65,
+	0x72, 0x5f, 0x6d, 0x61, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x4d, 0x61, 0x70, 0x49, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x22,
+	0x5c, 0x0a, 0x04, 0x43, 0x6f, 0x69, 0x6c, 0x12, 0x26, 0x0a, 0x0f, 0x72, 0x65, 0x67, 0x69, 0x73,
 	0x74, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
 	0x52, 0x0d, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x4d, 0x61, 0x70, 0x49, 0x64, 0x12,
 	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
 	0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a,
-	0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x73, 0x69, 0x7a,
-	0x65, 0x22, 0x5c, 0x0a, 0x04, 0x43, 0x6f, 0x69, 0x6c, 0x12, 0x26, 0x0a, 0x0f, 0x72, 0x65, 0x67,
-	0x69, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x4d, 0x61, 0x70, 0x49,
-	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22,
-	0x65, 0x0a, 0x0d, 0x44, 0x69, 0x73, 0x63, 0x72, 0x65, 0x74, 0x65, 0x49, 0x6e, 0x70, 0x75, 0x74,
-	0x12, 0x26, 0x0a, 0x0f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x70,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x67, 0x69, 0x73,
-	0x74, 0x65, 0x72, 0x4d, 0x61, 0x70, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07,
-	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x61,
-	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x87, 0x02, 0x0a, 0x0b, 0x52, 0x65, 0x67, 0x69, 0x73,
-	0x74, 0x65, 0x72, 0x4d, 0x61, 0x70, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x3e, 0x0a, 0x0f, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x5f,
-	0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x15, 0x2e, 0x6d, 0x6f, 0x64, 0x62, 0x75, 0x73, 0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x65,
-	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x0e, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x65, 0x67,
-	0x69, 0x73, 0x74, 0x65, 0x72, 0x73, 0x12, 0x44, 0x0a, 0x11, 0x68, 0x6f, 0x6c, 0x64, 0x69, 0x6e,
-	0x67, 0x5f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x17, 0x2e, 0x6d, 0x6f, 0x64, 0x62, 0x75, 0x73, 0x2e, 0x48, 0x6f, 0x6c, 0x64, 0x69,
-	0x6e, 0x67, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x10, 0x68, 0x6f, 0x6c, 0x64,
-	0x69, 0x6e, 0x67, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x73, 0x12, 0x22, 0x0a, 0x05,
-	0x63, 0x6f, 0x69, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x6d, 0x6f,
-	0x64, 0x62, 0x75, 0x73, 0x2e, 0x43, 0x6f, 0x69, 0x6c, 0x52, 0x05, 0x63, 0x6f, 0x69, 0x6c, 0x73,
-	0x12, 0x3e, 0x0a, 0x0f, 0x64, 0x69, 0x73, 0x63, 0x72, 0x65, 0x74, 0x65, 0x5f, 0x69, 0x6e, 0x70,
-	0x75, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x6f, 0x64, 0x62,
-	0x75, 0x73, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x72, 0x65, 0x74, 0x65, 0x49, 0x6e, 0x70, 0x75, 0x74,
-	0x52, 0x0e, 0x64, 0x69, 0x73, 0x63, 0x72, 0x65, 0x74, 0x65, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x73,
-	0x32, 0xa7, 0x04, 0x0a, 0x06, 0x4d, 0x6f, 0x64, 0x62, 0x75, 0x73, 0x12, 0x42, 0x0a, 0x09, 0x52,
-	0x65, 0x61, 0x64, 0x43, 0x6f, 0x69, 0x6c, 0x73, 0x12, 0x18, 0x2e, 0x6d, 0x6f, 0x64, 0x62, 0x75,
-	0x73, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x43, 0x6f, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x19, 0x2e, 0x6d, 0x6f, 0x64, 0x62, 0x75, 0x73, 0x2e, 0x52, 0x65, 0x61, 0x64,
-	0x43, 0x6f, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x5d, 0x0a, 0x12, 0x52, 0x65, 0x61, 0x64, 0x44, 0x69, 0x73, 0x63, 0x72, 0x65, 0x74, 0x65, 0x49,
-	0x6e, 0x70, 0x75, 0x74, 0x73, 0x12, 0x21, 0x2e, 0x6d, 0x6f, 0x64, 0x62, 0x75, 0x73, 0x2e, 0x52,
-	0x65, 0x61, 0x64, 0x44, 0x69, 0x73, 0x63, 0x72, 0x65, 0x74, 0x65, 0x49, 0x6e, 0x70, 0x75, 0x74,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x6d, 0x6f, 0x64, 0x62, 0x75,
-	0x73, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x44, 0x69, 0x73, 0x63, 0x72, 0x65, 0x74, 0x65, 0x49, 0x6e,
-	0x70, 0x75, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x63,
-	0x0a, 0x14, 0x52, 0x65, 0x61, 0x64, 0x48, 0x6f, 0x6c, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x67,
-	0x69, 0x73, 0x74, 0x65, 0x72, 0x73, 0x12, 0x23, 0x2e, 0x6d, 0x6f, 0x64, 0x62, 0x75, 0x73, 0x2e,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x65, 0x0a,
+	0x0d, 0x44, 0x69, 0x73, 0x63, 0x72, 0x65, 0x74, 0x65, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x26,
+	0x0a, 0x0f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x70, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65,
+	0x72, 0x4d, 0x61, 0x70, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x22, 0x87, 0x02, 0x0a, 0x0b, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65,
+	0x72, 0x4d, 0x61, 0x70, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x3e, 0x0a, 0x0f, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x5f, 0x72, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x6d, 0x6f, 0x64, 0x62, 0x75, 0x73, 0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x65, 0x72, 0x52, 0x0e, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x65, 0x72, 0x73, 0x12, 0x44, 0x0a, 0x11, 0x68, 0x6f, 0x6c, 0x64, 0x69, 0x6e, 0x67, 0x5f,
+	0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x6d, 0x6f, 0x64, 0x62, 0x75, 0x73, 0x2e, 0x48, 0x6f, 0x6c, 0x64, 0x69, 0x6e, 0x67,
+	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x10, 0x68, 0x6f, 0x6c, 0x64, 0x69, 0x6e,
+	0x67, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x73, 0x12, 0x22, 0x0a, 0x05, 0x63, 0x6f,
+	0x69, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x6d, 0x6f, 0x64, 0x62,
+	0x75, 0x73, 0x2e, 0x43, 0x6f, 0x69, 0x6c, 0x52, 0x05, 0x63, 0x6f, 0x69, 0x6c, 0x73, 0x12, 0x3e,
+	0x0a, 0x0f, 0x64, 0x69, 0x73, 0x63, 0x72, 0x65, 0x74, 0x65, 0x5f, 0x69, 0x6e, 0x70, 0x75, 0x74,
+	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x6f, 0x64, 0x62, 0x75, 0x73,
+	0x2e, 0x44, 0x69, 0x73, 0x63, 0x72, 0x65, 0x74, 0x65, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x0e,
+	0x64, 0x69, 0x73, 0x63, 0x72, 0x65, 0x74, 0x65, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x32, 0xf1,
+	0x05, 0x0a, 0x06, 0x4d, 0x6f, 0x64, 0x62, 0x75, 0x73, 0x12, 0x42, 0x0a, 0x09, 0x52, 0x65, 0x61,
+	0x64, 0x43, 0x6f, 0x69, 0x6c, 0x73, 0x12, 0x18, 0x2e, 0x6d, 0x6f, 0x64, 0x62, 0x75, 0x73, 0x2e,
+	0x52, 0x65, 0x61, 0x64, 0x43, 0x6f, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x19, 0x2e, 0x6d, 0x6f, 0x64, 0x62, 0x75, 0x73, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x43, 0x6f,
+	0x69, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5d, 0x0a,
+	0x12, 0x52, 0x65, 0x61, 0x64, 0x44, 0x69, 0x73, 0x63, 0x72, 0x65, 0x74, 0x65, 0x49, 0x6e, 0x70,
+	0x75, 0x74, 0x73, 0x12, 0x21, 0x2e, 0x6d, 0x6f, 0x64, 0x62, 0x75, 0x73, 0x2e, 0x52, 0x65, 0x61,
+	0x64, 0x44, 0x69, 0x73, 0x63, 0x72, 0x65, 0x74, 0x65, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x6d, 0x6f, 0x64, 0x62, 0x75, 0x73, 0x2e,
+	0x52, 0x65, 0x61, 0x64, 0x44, 0x69, 0x73, 0x63, 0x72, 0x65, 0x74, 0x65, 0x49, 0x6e, 0x70, 0x75,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x63, 0x0a, 0x14,
 	0x52, 0x65, 0x61, 0x64, 0x48, 0x6f, 0x6c, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x67, 0x69, 0x73,
-	0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x6d, 0x6f,
-	0x64, 0x62, 0x75, 0x73, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x48, 0x6f, 0x6c, 0x64, 0x69, 0x6e, 0x67,
-	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x12, 0x5d, 0x0a, 0x12, 0x52, 0x65, 0x61, 0x64, 0x49, 0x6e, 0x70, 0x75, 0x74,
-	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x73, 0x12, 0x21, 0x2e, 0x6d, 0x6f, 0x64, 0x62,
-	0x75, 0x73, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x65, 0x67, 0x69,
-	0x73, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x6d,
-	0x6f, 0x64, 0x62, 0x75, 0x73, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52,
+	0x74, 0x65, 0x72, 0x73, 0x12, 0x23, 0x2e, 0x6d, 0x6f, 0x64, 0x62, 0x75, 0x73, 0x2e, 0x52, 0x65,
+	0x61, 0x64, 0x48, 0x6f, 0x6c, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65,
+	0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x6d, 0x6f, 0x64, 0x62,
+	0x75, 0x73, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x48, 0x6f, 0x6c, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x5d, 0x0a, 0x12, 0x52, 0x65, 0x61, 0x64, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x73, 0x12, 0x21, 0x2e, 0x6d, 0x6f, 0x64, 0x62, 0x75, 0x73,
+	0x2e, 0x52, 0x65, 0x61, 0x64, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x6d, 0x6f, 0x64,
+	0x62, 0x75, 0x73, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x54, 0x0a, 0x0f, 0x57, 0x72, 0x69, 0x74, 0x65, 0x53, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x43,
+	0x6f, 0x69, 0x6c, 0x12, 0x1e, 0x2e, 0x6d, 0x6f, 0x64, 0x62, 0x75, 0x73, 0x2e, 0x57, 0x72, 0x69,
+	0x74, 0x65, 0x53, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x43, 0x6f, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x6d, 0x6f, 0x64, 0x62, 0x75, 0x73, 0x2e, 0x57, 0x72, 0x69,
+	0x74, 0x65, 0x53, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x43, 0x6f, 0x69, 0x6c, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x60, 0x0a, 0x13, 0x57, 0x72, 0x69, 0x74, 0x65, 0x53,
+	0x69, 0x6e, 0x67, 0x6c, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x12, 0x22, 0x2e,
+	0x6d, 0x6f, 0x64, 0x62, 0x75, 0x73, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x53, 0x69, 0x6e, 0x67,
+	0x6c, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x23, 0x2e, 0x6d, 0x6f, 0x64, 0x62, 0x75, 0x73, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65,
+	0x53, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5d, 0x0a, 0x12, 0x57, 0x72, 0x69, 0x74,
+	0x65, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x65, 0x43, 0x6f, 0x69, 0x6c, 0x73, 0x12, 0x21,
+	0x2e, 0x6d, 0x6f, 0x64, 0x62, 0x75, 0x73, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4d, 0x75, 0x6c,
+	0x74, 0x69, 0x70, 0x6c, 0x65, 0x43, 0x6f, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x22, 0x2e, 0x6d, 0x6f, 0x64, 0x62, 0x75, 0x73, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65,
+	0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x65, 0x43, 0x6f, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x69, 0x0a, 0x16, 0x57, 0x72, 0x69, 0x74, 0x65,
+	0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
+	0x73, 0x12, 0x25, 0x2e, 0x6d, 0x6f, 0x64, 0x62, 0x75, 0x73, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65,
+	0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x6d, 0x6f, 0x64, 0x62, 0x75,
+	0x73, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x65, 0x52,
 	0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x12, 0x54, 0x0a, 0x0f, 0x57, 0x72, 0x69, 0x74, 0x65, 0x53, 0x69, 0x6e, 0x67, 0x6c,
-	0x65, 0x43, 0x6f, 0x69, 0x6c, 0x12, 0x1e, 0x2e, 0x6d, 0x6f, 0x64, 0x62, 0x75, 0x73, 0x2e, 0x57,
-	0x72, 0x69, 0x74, 0x65, 0x53, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x43, 0x6f, 0x69, 0x6c, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x6d, 0x6f, 0x64, 0x62, 0x75, 0x73, 0x2e, 0x57,
-	0x72, 0x69, 0x74, 0x65, 0x53, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x43, 0x6f, 0x69, 0x6c, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x60, 0x0a, 0x13, 0x57, 0x72, 0x69, 0x74,
-	0x65, 0x53, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x12,
-	0x22, 0x2e, 0x6d, 0x6f, 0x64, 0x62, 0x75, 0x73, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x53, 0x69,
-	0x6e, 0x67, 0x6c, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x6d, 0x6f, 0x64, 0x62, 0x75, 0x73, 0x2e, 0x57, 0x72, 0x69,
-	0x74, 0x65, 0x53, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x11, 0x5a, 0x0f, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2f, 0x76, 0x31, 0x2f, 0x6d, 0x6f, 0x64, 0x62, 0x75, 0x73, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x22, 0x00, 0x42, 0x11, 0x5a, 0x0f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x76, 0x31, 0x2f, 0x6d,
+	0x6f, 0x64, 0x62, 0x75, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1212,45 +1489,53 @@ func file_v1_modbus_proto_rawDescGZIP() []byte {
 	return file_v1_modbus_proto_rawDescData
 }
 
-var file_v1_modbus_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_v1_modbus_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_v1_modbus_proto_goTypes = []interface{}{
-	(*ReadCoilsRequest)(nil),             // 0: modbus.ReadCoilsRequest
-	(*ReadCoilsResponse)(nil),            // 1: modbus.ReadCoilsResponse
-	(*ReadDiscreteInputsRequest)(nil),    // 2: modbus.ReadDiscreteInputsRequest
-	(*ReadDiscreteInputsResponse)(nil),   // 3: modbus.ReadDiscreteInputsResponse
-	(*ReadHoldingRegistersRequest)(nil),  // 4: modbus.ReadHoldingRegistersRequest
-	(*ReadHoldingRegistersResponse)(nil), // 5: modbus.ReadHoldingRegistersResponse
-	(*ReadInputRegistersRequest)(nil),    // 6: modbus.ReadInputRegistersRequest
-	(*ReadInputRegistersResponse)(nil),   // 7: modbus.ReadInputRegistersResponse
-	(*WriteSingleCoilRequest)(nil),       // 8: modbus.WriteSingleCoilRequest
-	(*WriteSingleCoilResponse)(nil),      // 9: modbus.WriteSingleCoilResponse
-	(*WriteSingleRegisterRequest)(nil),   // 10: modbus.WriteSingleRegisterRequest
-	(*WriteSingleRegisterResponse)(nil),  // 11: modbus.WriteSingleRegisterResponse
-	(*InputRegister)(nil),                // 12: modbus.InputRegister
-	(*HoldingRegister)(nil),              // 13: modbus.HoldingRegister
-	(*Coil)(nil),                         // 14: modbus.Coil
-	(*DiscreteInput)(nil),                // 15: modbus.DiscreteInput
-	(*RegisterMap)(nil),                  // 16: modbus.RegisterMap
+	(*ReadCoilsRequest)(nil),               // 0: modbus.ReadCoilsRequest
+	(*ReadCoilsResponse)(nil),              // 1: modbus.ReadCoilsResponse
+	(*ReadDiscreteInputsRequest)(nil),      // 2: modbus.ReadDiscreteInputsRequest
+	(*ReadDiscreteInputsResponse)(nil),     // 3: modbus.ReadDiscreteInputsResponse
+	(*ReadHoldingRegistersRequest)(nil),    // 4: modbus.ReadHoldingRegistersRequest
+	(*ReadHoldingRegistersResponse)(nil),   // 5: modbus.ReadHoldingRegistersResponse
+	(*ReadInputRegistersRequest)(nil),      // 6: modbus.ReadInputRegistersRequest
+	(*ReadInputRegistersResponse)(nil),     // 7: modbus.ReadInputRegistersResponse
+	(*WriteSingleCoilRequest)(nil),         // 8: modbus.WriteSingleCoilRequest
+	(*WriteSingleCoilResponse)(nil),        // 9: modbus.WriteSingleCoilResponse
+	(*WriteSingleRegisterRequest)(nil),     // 10: modbus.WriteSingleRegisterRequest
+	(*WriteSingleRegisterResponse)(nil),    // 11: modbus.WriteSingleRegisterResponse
+	(*WriteMultipleCoilsRequest)(nil),      // 12: modbus.WriteMultipleCoilsRequest
+	(*WriteMultipleCoilsResponse)(nil),     // 13: modbus.WriteMultipleCoilsResponse
+	(*WriteMultipleRegistersRequest)(nil),  // 14: modbus.WriteMultipleRegistersRequest
+	(*WriteMultipleRegistersResponse)(nil), // 15: modbus.WriteMultipleRegistersResponse
+	(*InputRegister)(nil),                  // 16: modbus.InputRegister
+	(*HoldingRegister)(nil),                // 17: modbus.HoldingRegister
+	(*Coil)(nil),                           // 18: modbus.Coil
+	(*DiscreteInput)(nil),                  // 19: modbus.DiscreteInput
+	(*RegisterMap)(nil),                    // 20: modbus.RegisterMap
 }
 var file_v1_modbus_proto_depIdxs = []int32{
-	12, // 0: modbus.RegisterMap.input_registers:type_name -> modbus.InputRegister
-	13, // 1: modbus.RegisterMap.holding_registers:type_name -> modbus.HoldingRegister
-	14, // 2: modbus.RegisterMap.coils:type_name -> modbus.Coil
-	15, // 3: modbus.RegisterMap.discrete_inputs:type_name -> modbus.DiscreteInput
+	16, // 0: modbus.RegisterMap.input_registers:type_name -> modbus.InputRegister
+	17, // 1: modbus.RegisterMap.holding_registers:type_name -> modbus.HoldingRegister
+	18, // 2: modbus.RegisterMap.coils:type_name -> modbus.Coil
+	19, // 3: modbus.RegisterMap.discrete_inputs:type_name -> modbus.DiscreteInput
 	0,  // 4: modbus.Modbus.ReadCoils:input_type -> modbus.ReadCoilsRequest
 	2,  // 5: modbus.Modbus.ReadDiscreteInputs:input_type -> modbus.ReadDiscreteInputsRequest
 	4,  // 6: modbus.Modbus.ReadHoldingRegisters:input_type -> modbus.ReadHoldingRegistersRequest
 	6,  // 7: modbus.Modbus.ReadInputRegisters:input_type -> modbus.ReadInputRegistersRequest
 	8,  // 8: modbus.Modbus.WriteSingleCoil:input_type -> modbus.WriteSingleCoilRequest
 	10, // 9: modbus.Modbus.WriteSingleRegister:input_type -> modbus.WriteSingleRegisterRequest
-	1,  // 10: modbus.Modbus.ReadCoils:output_type -> modbus.ReadCoilsResponse
-	3,  // 11: modbus.Modbus.ReadDiscreteInputs:output_type -> modbus.ReadDiscreteInputsResponse
-	5,  // 12: modbus.Modbus.ReadHoldingRegisters:output_type -> modbus.ReadHoldingRegistersResponse
-	7,  // 13: modbus.Modbus.ReadInputRegisters:output_type -> modbus.ReadInputRegistersResponse
-	9,  // 14: modbus.Modbus.WriteSingleCoil:output_type -> modbus.WriteSingleCoilResponse
-	11, // 15: modbus.Modbus.WriteSingleRegister:output_type -> modbus.WriteSingleRegisterResponse
-	10, // [10:16] is the sub-list for method output_type
-	4,  // [4:10] is the sub-list for method input_type
+	12, // 10: modbus.Modbus.WriteMultipleCoils:input_type -> modbus.WriteMultipleCoilsRequest
+	14, // 11: modbus.Modbus.WriteMultipleRegisters:input_type -> modbus.WriteMultipleRegistersRequest
+	1,  // 12: modbus.Modbus.ReadCoils:output_type -> modbus.ReadCoilsResponse
+	3,  // 13: modbus.Modbus.ReadDiscreteInputs:output_type -> modbus.ReadDiscreteInputsResponse
+	5,  // 14: modbus.Modbus.ReadHoldingRegisters:output_type -> modbus.ReadHoldingRegistersResponse
+	7,  // 15: modbus.Modbus.ReadInputRegisters:output_type -> modbus.ReadInputRegistersResponse
+	9,  // 16: modbus.Modbus.WriteSingleCoil:output_type -> modbus.WriteSingleCoilResponse
+	11, // 17: modbus.Modbus.WriteSingleRegister:output_type -> modbus.WriteSingleRegisterResponse
+	13, // 18: modbus.Modbus.WriteMultipleCoils:output_type -> modbus.WriteMultipleCoilsResponse
+	15, // 19: modbus.Modbus.WriteMultipleRegisters:output_type -> modbus.WriteMultipleRegistersResponse
+	12, // [12:20] is the sub-list for method output_type
+	4,  // [4:12] is the sub-list for method input_type
 	4,  // [4:4] is the sub-list for extension type_name
 	4,  // [4:4] is the sub-list for extension extendee
 	0,  // [0:4] is the sub-list for field type_name
@@ -1407,7 +1692,7 @@ func file_v1_modbus_proto_init() {
 			}
 		}
 		file_v1_modbus_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*InputRegister); i {
+			switch v := v.(*WriteMultipleCoilsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1419,7 +1704,7 @@ func file_v1_modbus_proto_init() {
 			}
 		}
 		file_v1_modbus_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HoldingRegister); i {
+			switch v := v.(*WriteMultipleCoilsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1431,7 +1716,7 @@ func file_v1_modbus_proto_init() {
 			}
 		}
 		file_v1_modbus_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Coil); i {
+			switch v := v.(*WriteMultipleRegistersRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1443,7 +1728,7 @@ func file_v1_modbus_proto_init() {
 			}
 		}
 		file_v1_modbus_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DiscreteInput); i {
+			switch v := v.(*WriteMultipleRegistersResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1455,6 +1740,54 @@ func file_v1_modbus_proto_init() {
 			}
 		}
 		file_v1_modbus_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InputRegister); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_modbus_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HoldingRegister); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_modbus_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Coil); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_modbus_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DiscreteInput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_modbus_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RegisterMap); i {
 			case 0:
 				return &v.state
@@ -1473,7 +1806,7 @@ func file_v1_modbus_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_v1_modbus_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   17,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
-// - protoc-gen-go-grpc v1.2.0
-// - protoc             v4.23.4
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
 // source: v1/modbus.proto
 
 package modbus
@@ -18,6 +18,17 @@ import (
 // Requires gRPC-Go v1.32.0 or later.
 const _ = grpc.SupportPackageIsVersion7
 
+const (
+	Modbus_ReadCoils_FullMethodName              = "/modbus.Modbus/ReadCoils"
+	Modbus_ReadDiscreteInputs_FullMethodName     = "/modbus.Modbus/ReadDiscreteInputs"
+	Modbus_ReadHoldingRegisters_FullMethodName   = "/modbus.Modbus/ReadHoldingRegisters"
+	Modbus_ReadInputRegisters_FullMethodName     = "/modbus.Modbus/ReadInputRegisters"
+	Modbus_WriteSingleCoil_FullMethodName        = "/modbus.Modbus/WriteSingleCoil"
+	Modbus_WriteSingleRegister_FullMethodName    = "/modbus.Modbus/WriteSingleRegister"
+	Modbus_WriteMultipleCoils_FullMethodName     = "/modbus.Modbus/WriteMultipleCoils"
+	Modbus_WriteMultipleRegisters_FullMethodName = "/modbus.Modbus/WriteMultipleRegisters"
+)
+
 // ModbusClient is the client API for Modbus service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
@@ -28,6 +39,8 @@ type ModbusClient interface {
 	ReadInputRegisters(ctx context.Context, in *ReadInputRegistersRequest, opts ...grpc.CallOption) (*ReadInputRegistersResponse, error)
 	WriteSingleCoil(ctx context.Context, in *WriteSingleCoilRequest, opts ...grpc.CallOption) (*WriteSingleCoilResponse, error)
 	WriteSingleRegister(ctx context.Context, in *WriteSingleRegisterRequest, opts ...grpc.CallOption) (*WriteSingleRegisterResponse, error)
+	WriteMultipleCoils(ctx context.Context, in *WriteMultipleCoilsRequest, opts ...grpc.CallOption) (*WriteMultipleCoilsResponse, error)
+	WriteMultipleRegisters(ctx context.Context, in *WriteMultipleRegistersRequest, opts ...grpc.CallOption) (*WriteMultipleRegistersResponse, error)
 }
 
 type modbusClient struct {
@@ -40,7 +53,7 @@ func NewModbusClient(cc grpc.ClientConnInterface) ModbusClient {
 
 func (c *modbusClient) ReadCoils(ctx context.Context, in *ReadCoilsRequest, opts ...grpc.CallOption) (*ReadCoilsResponse, error) {
 	out := new(ReadCoilsResponse)
-	err := c.cc.Invoke(ctx, "/modbus.Modbus/ReadCoils", in, out, opts...)
+	err := c.cc.Invoke(ctx, Modbus_ReadCoils_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -49,7 +62,7 @@ func (c *modbusClient) ReadCoils(ctx context.Context, in *ReadCoilsRequest, opts
 
 func (c *modbusClient) ReadDiscreteInputs(ctx context.Context, in *ReadDiscreteInputsRequest, opts ...grpc.CallOption) (*ReadDiscreteInputsResponse, error) {
 	out := new(ReadDiscreteInputsResponse)
-	err := c.cc.Invoke(ctx, "/modbus.Modbus/ReadDiscreteInputs", in, out, opts...)
+	err := c.cc.Invoke(ctx, Modbus_ReadDiscreteInputs_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -58,7 +71,7 @@ func (c *modbusClient) ReadDiscreteInputs(ctx context.Context, in *ReadDiscreteI
 
 func (c *modbusClient) ReadHoldingRegisters(ctx context.Context, in *ReadHoldingRegistersRequest, opts ...grpc.CallOption) (*ReadHoldingRegistersResponse, error) {
 	out := new(ReadHoldingRegistersResponse)
-	err := c.cc.Invoke(ctx, "/modbus.Modbus/ReadHoldingRegisters", in, out, opts...)
+	err := c.cc.Invoke(ctx, Modbus_ReadHoldingRegisters_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -67,7 +80,7 @@ func (c *modbusClient) ReadHoldingRegisters(ctx context.Context, in *ReadHolding
 
 func (c *modbusClient) ReadInputRegisters(ctx context.Context, in *ReadInputRegistersRequest, opts ...grpc.CallOption) (*ReadInputRegistersResponse, error) {
 	out := new(ReadInputRegistersResponse)
-	err := c.cc.Invoke(ctx, "/modbus.Modbus/ReadInputRegisters", in, out, opts...)
+	err := c.cc.Invoke(ctx, Modbus_ReadInputRegisters_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -76,7 +89,7 @@ func (c *modbusClient) ReadInputRegisters(ctx context.Context, in *ReadInputRegi
 
 func (c *modbusClient) WriteSingleCoil(ctx context.Context, in *WriteSingleCoilRequest, opts ...grpc.CallOption) (*WriteSingleCoilResponse, error) {
 	out := new(WriteSingleCoilResponse)
-	err := c.cc.Invoke(ctx, "/modbus.Modbus/WriteSingleCoil", in, out, opts...)
+	err := c.cc.Invoke(ctx, Modbus_WriteSingleCoil_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -85,7 +98,25 @@ func (c *modbusClient) WriteSingleCoil(ctx context.Context, in *WriteSingleCoilR
 
 func (c *modbusClient) WriteSingleRegister(ctx context.Context, in *WriteSingleRegisterRequest, opts ...grpc.CallOption) (*WriteSingleRegisterResponse, error) {
 	out := new(WriteSingleRegisterResponse)
-	err := c.cc.Invoke(ctx, "/modbus.Modbus/WriteSingleRegister", in, out, opts...)
+	err := c.cc.Invoke(ctx, Modbus_WriteSingleRegister_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *modbusClient) WriteMultipleCoils(ctx context.Context, in *WriteMultipleCoilsRequest, opts ...grpc.CallOption) (*WriteMultipleCoilsResponse, error) {
+	out := new(WriteMultipleCoilsResponse)
+	err := c.cc.Invoke(ctx, Modbus_WriteMultipleCoils_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *modbusClient) WriteMultipleRegisters(ctx context.Context, in *WriteMultipleRegistersRequest, opts ...grpc.CallOption) (*WriteMultipleRegistersResponse, error) {
+	out := new(WriteMultipleRegistersResponse)
+	err := c.cc.Invoke(ctx, Modbus_WriteMultipleRegisters_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -102,6 +133,8 @@ type ModbusServer interface {
 	ReadInputRegisters(context.Context, *ReadInputRegistersRequest) (*ReadInputRegistersResponse, error)
 	WriteSingleCoil(context.Context, *WriteSingleCoilRequest) (*WriteSingleCoilResponse, error)
 	WriteSingleRegister(context.Context, *WriteSingleRegisterRequest) (*WriteSingleRegisterResponse, error)
+	WriteMultipleCoils(context.Context, *WriteMultipleCoilsRequest) (*WriteMultipleCoilsResponse, error)
+	WriteMultipleRegisters(context.Context, *WriteMultipleRegistersRequest) (*WriteMultipleRegistersResponse, error)
 	mustEmbedUnimplementedModbusServer()
 }
 
@@ -127,6 +160,12 @@ func (UnimplementedModbusServer) WriteSingleCoil(context.Context, *WriteSingleCo
 func (UnimplementedModbusServer) WriteSingleRegister(context.Context, *WriteSingleRegisterRequest) (*WriteSingleRegisterResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method WriteSingleRegister not implemented")
 }
+func (UnimplementedModbusServer) WriteMultipleCoils(context.Context, *WriteMultipleCoilsRequest) (*WriteMultipleCoilsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WriteMultipleCoils not implemented")
+}
+func (UnimplementedModbusServer) WriteMultipleRegisters(context.Context, *WriteMultipleRegistersRequest) (*WriteMultipleRegistersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WriteMultipleRegisters not implemented")
+}
 func (UnimplementedModbusServer) mustEmbedUnimplementedModbusServer() {}
 
 // UnsafeModbusServer may be embedded to opt out of forward compatibility for this service.
@@ -150,7 +189,7 @@ func _Modbus_ReadCoils_Handler(srv interface{}, ctx context.Context, dec func(in
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/modbus.Modbus/ReadCoils",
+		FullMethod: Modbus_ReadCoils_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(ModbusServer).ReadCoils(ctx, req.(*ReadCoilsRequest))
@@ -168,7 +207,7 @@ func _Modbus_ReadDiscreteInputs_Handler(srv interface{}, ctx context.Context, de
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/modbus.Modbus/ReadDiscreteInputs",
+		FullMethod: Modbus_ReadDiscreteInputs_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(ModbusServer).ReadDiscreteInputs(ctx, req.(*ReadDiscreteInputsRequest))
@@ -186,7 +225,7 @@ func _Modbus_ReadHoldingRegisters_Handler(srv interface{}, ctx context.Context,
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/modbus.Modbus/ReadHoldingRegisters",
+		FullMethod: Modbus_ReadHoldingRegisters_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(ModbusServer).ReadHoldingRegisters(ctx, req.(*ReadHoldingRegistersRequest))
@@ -204,7 +243,7 @@ func _Modbus_ReadInputRegisters_Handler(srv interface{}, ctx context.Context, de
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/modbus.Modbus/ReadInputRegisters",
+		FullMethod: Modbus_ReadInputRegisters_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(ModbusServer).ReadInputRegisters(ctx, req.(*ReadInputRegistersRequest))
@@ -222,7 +261,7 @@ func _Modbus_WriteSingleCoil_Handler(srv interface{}, ctx context.Context, dec f
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/modbus.Modbus/WriteSingleCoil",
+		FullMethod: Modbus_WriteSingleCoil_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(ModbusServer).WriteSingleCoil(ctx, req.(*WriteSingleCoilRequest))
@@ -240,7 +279,7 @@ func _Modbus_WriteSingleRegister_Handler(srv interface{}, ctx context.Context, d
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/modbus.Modbus/WriteSingleRegister",
+		FullMethod: Modbus_WriteSingleRegister_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(ModbusServer).WriteSingleRegister(ctx, req.(*WriteSingleRegisterRequest))
@@ -248,6 +287,42 @@ func _Modbus_WriteSingleRegister_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Modbus_WriteMultipleCoils_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteMultipleCoilsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ModbusServer).WriteMultipleCoils(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Modbus_WriteMultipleCoils_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ModbusServer).WriteMultipleCoils(ctx, req.(*WriteMultipleCoilsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Modbus_WriteMultipleRegisters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteMultipleRegistersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ModbusServer).WriteMultipleRegisters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Modbus_WriteMultipleRegisters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ModbusServer).WriteMultipleRegisters(ctx, req.(*WriteMultipleRegistersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Modbus_ServiceDesc is the grpc.ServiceDesc for Modbus service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -279,6 +354,14 @@ var Modbus_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "WriteSingleRegister",
 			Handler:    _Modbus_WriteSingleRegister_Handler,
 		},
+		{
+			MethodName: "WriteMultipleCoils",
+			Handler:    _Modbus_WriteMultipleCoils_Handler,
+		},
+		{
+			MethodName: "WriteMultipleRegisters",
+			Handler:    _Modbus_WriteMultipleRegisters_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "v1/modbus.proto",
@@ -0,0 +1,47 @@
+package resolver
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.42
+
+import (
+	"context"
+
+	"github.com/jt05610/petri/access"
+)
+
+// Share is the resolver for the share field.
+func (r *mutationResolver) Share(ctx context.Context, input access.Grant) (*access.Grant, error) {
+	if err := r.access.Share(ctx, &input); err != nil {
+		return nil, err
+	}
+	return &input, nil
+}
+
+// Unshare is the resolver for the unshare field.
+func (r *mutationResolver) Unshare(ctx context.Context, input access.Grant) (*access.Grant, error) {
+	if err := r.access.Unshare(ctx, &input); err != nil {
+		return nil, err
+	}
+	return &input, nil
+}
+
+// Owner is the resolver for the owner field.
+func (r *queryResolver) Owner(ctx context.Context, resource access.Resource, id string) (*string, error) {
+	if err := r.access.Check(ctx, resource, id, access.Read); err != nil {
+		return nil, err
+	}
+	owner, err := r.access.Store.Owner(ctx, resource, id)
+	if err != nil || owner == "" {
+		return nil, err
+	}
+	return &owner, nil
+}
+
+// Grants is the resolver for the grants field.
+func (r *queryResolver) Grants(ctx context.Context, resource access.Resource, id string) ([]*access.Grant, error) {
+	if err := r.access.Check(ctx, resource, id, access.Read); err != nil {
+		return nil, err
+	}
+	return r.access.Store.Grants(ctx, resource, id)
+}
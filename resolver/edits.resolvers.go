@@ -0,0 +1,40 @@
+package resolver
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.42
+
+import (
+	"context"
+
+	"github.com/jt05610/petri"
+	"github.com/jt05610/petri/access"
+	"github.com/jt05610/petri/graph/generated"
+)
+
+// OutputSchema is the resolver for the outputSchema field.
+func (r *arcEditInputResolver) OutputSchema(ctx context.Context, obj *petri.ArcEditInput, data *string) error {
+	if data == nil {
+		obj.OutputSchema = nil
+		return nil
+	}
+	t, err := r.tokenSchema.Get(ctx, *data)
+	if err != nil {
+		return err
+	}
+	obj.OutputSchema = t
+	return nil
+}
+
+// ApplyNetEdit is the resolver for the applyNetEdit field.
+func (r *mutationResolver) ApplyNetEdit(ctx context.Context, netID string, input petri.NetEdit) (*petri.Net, error) {
+	if err := r.access.Check(ctx, access.NetResource, netID, access.Edit); err != nil {
+		return nil, err
+	}
+	return r.editor.ApplyNetEdit(ctx, netID, &input)
+}
+
+// ArcEditInput returns generated.ArcEditInputResolver implementation.
+func (r *Resolver) ArcEditInput() generated.ArcEditInputResolver { return &arcEditInputResolver{r} }
+
+type arcEditInputResolver struct{ *Resolver }
@@ -5,6 +5,7 @@ package resolver
 import (
 	"context"
 	"github.com/jt05610/petri"
+	"github.com/jt05610/petri/access"
 	"github.com/jt05610/petri/amqp/client"
 	"github.com/jt05610/petri/control"
 )
@@ -17,6 +18,9 @@ type Resolver struct {
 	eventSchema  petri.Service[*petri.EventSchema, *petri.EventInput, *petri.EventFilter, *petri.EventUpdate]
 	arcs         petri.Service[*petri.Arc, *petri.ArcInput, *petri.ArcFilter, *petri.ArcUpdate]
 	nets         petri.Service[*petri.Net, *petri.NetInput, *petri.NetFilter, *petri.NetUpdate]
+	editor       petri.NetEditor
+	versions     petri.NetVersioner
+	access       *access.Checker
 	dataCh       chan *control.Event
 	seenEvents   map[string]int
 	recordCtx    context.Context
@@ -32,14 +36,20 @@ func NewResolver(
 	arcs petri.Service[*petri.Arc, *petri.ArcInput, *petri.ArcFilter, *petri.ArcUpdate],
 	nets petri.Service[*petri.Net, *petri.NetInput, *petri.NetFilter, *petri.NetUpdate],
 	eventSchema petri.Service[*petri.EventSchema, *petri.EventInput, *petri.EventFilter, *petri.EventUpdate],
+	editor petri.NetEditor,
+	versions petri.NetVersioner,
+	checker *access.Checker,
 ) *Resolver {
 	return &Resolver{
 		tokenSchema: tokenSchema,
-		places:      places,
-		transitions: transitions,
-		arcs:        arcs,
-		nets:        nets,
+		places:      access.Wrap(places, checker, access.PlaceResource),
+		transitions: access.Wrap(transitions, checker, access.TransitionResource),
+		arcs:        access.Wrap(arcs, checker, access.ArcResource),
+		nets:        access.Wrap(nets, checker, access.NetResource),
 		eventSchema: eventSchema,
+		editor:      editor,
+		versions:    versions,
+		access:      checker,
 		dataCh:      make(chan *control.Event),
 		seenEvents:  make(map[string]int),
 	}
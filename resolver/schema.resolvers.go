@@ -9,7 +9,9 @@ import (
 	"fmt"
 
 	"github.com/jt05610/petri"
+	"github.com/jt05610/petri/datastore"
 	"github.com/jt05610/petri/graph/generated"
+	"github.com/jt05610/petri/middleware"
 	"github.com/jt05610/petri/resolver/model"
 )
 
@@ -40,7 +42,15 @@ func (r *mutationResolver) ResumeSession(ctx context.Context, sessionID string)
 
 // CreateNet is the resolver for the createNet field.
 func (r *mutationResolver) CreateNet(ctx context.Context, input petri.NetInput) (*petri.Net, error) {
-	return r.nets.Add(ctx, &input)
+	n, err := r.nets.Add(ctx, &input)
+	if err != nil {
+		return nil, err
+	}
+	_, err = r.versions.Commit(ctx, n.ID, "created")
+	if err != nil {
+		return nil, err
+	}
+	return n, nil
 }
 
 // AddTokenSchemaToNet is the resolver for the addTokenSchemaToNet field.
@@ -274,6 +284,9 @@ func (r *mutationResolver) UpdateNet(ctx context.Context, id string, input petri
 
 // DeleteNet is the resolver for the deleteNet field.
 func (r *mutationResolver) DeleteNet(ctx context.Context, netID string) (*petri.Net, error) {
+	if err := middleware.RequireRole(ctx, datastore.AdminRole); err != nil {
+		return nil, err
+	}
 	return r.nets.Remove(ctx, netID)
 }
 
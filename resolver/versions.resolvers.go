@@ -0,0 +1,90 @@
+package resolver
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.42
+
+import (
+	"context"
+	"time"
+
+	"github.com/jt05610/petri"
+	"github.com/jt05610/petri/access"
+	"github.com/jt05610/petri/graph/generated"
+)
+
+// Kind is the resolver for the kind field.
+func (r *changeResolver) Kind(ctx context.Context, obj *petri.Change) (string, error) {
+	return obj.Kind.String(), nil
+}
+
+// Kind is the resolver for the kind field.
+func (r *expressionChangeResolver) Kind(ctx context.Context, obj *petri.ExpressionChange) (string, error) {
+	return obj.Kind.String(), nil
+}
+
+// CommitNet is the resolver for the commitNet field.
+func (r *mutationResolver) CommitNet(ctx context.Context, netID string, message *string) (*petri.NetVersion, error) {
+	if err := r.access.Check(ctx, access.NetResource, netID, access.Edit); err != nil {
+		return nil, err
+	}
+	msg := ""
+	if message != nil {
+		msg = *message
+	}
+	return r.versions.Commit(ctx, netID, msg)
+}
+
+// RevertNet is the resolver for the revertNet field.
+func (r *mutationResolver) RevertNet(ctx context.Context, netID string, versionID string) (*petri.Net, error) {
+	if err := r.access.Check(ctx, access.NetResource, netID, access.Edit); err != nil {
+		return nil, err
+	}
+	return r.versions.Revert(ctx, netID, versionID)
+}
+
+// CreatedAt is the resolver for the createdAt field.
+func (r *netVersionResolver) CreatedAt(ctx context.Context, obj *petri.NetVersion) (string, error) {
+	return obj.CreatedAt.Format(time.RFC3339Nano), nil
+}
+
+// NetHistory is the resolver for the netHistory field.
+func (r *queryResolver) NetHistory(ctx context.Context, netID string) ([]*petri.NetVersion, error) {
+	if err := r.access.Check(ctx, access.NetResource, netID, access.Read); err != nil {
+		return nil, err
+	}
+	return r.versions.History(ctx, netID)
+}
+
+// NetDiff is the resolver for the netDiff field.
+func (r *queryResolver) NetDiff(ctx context.Context, from string, to string) (*petri.NetDiff, error) {
+	a, err := r.versions.Version(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	b, err := r.versions.Version(ctx, to)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range []*petri.NetVersion{a, b} {
+		if err := r.access.Check(ctx, access.NetResource, v.NetID, access.Read); err != nil {
+			return nil, err
+		}
+	}
+	return petri.Diff(a.Net(), b.Net()), nil
+}
+
+// Change returns generated.ChangeResolver implementation.
+func (r *Resolver) Change() generated.ChangeResolver { return &changeResolver{r} }
+
+// ExpressionChange returns generated.ExpressionChangeResolver implementation.
+func (r *Resolver) ExpressionChange() generated.ExpressionChangeResolver {
+	return &expressionChangeResolver{r}
+}
+
+// NetVersion returns generated.NetVersionResolver implementation.
+func (r *Resolver) NetVersion() generated.NetVersionResolver { return &netVersionResolver{r} }
+
+type changeResolver struct{ *Resolver }
+type expressionChangeResolver struct{ *Resolver }
+type netVersionResolver struct{ *Resolver }
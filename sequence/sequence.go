@@ -49,13 +49,16 @@ func (a *Action) ApplyParameters(params map[string]interface{}) error {
 				return labeled.ErrMissingParameter(p.Field, a.Event)
 			}
 			if v, found := vm["value"]; found {
-				p.Value, ok = v.(string)
-				if !ok {
-					floatVal, ok := v.(json.Number)
-					if !ok {
-						return labeled.ErrMissingParameter(p.Field, a.Event)
-					}
-					p.Value = floatVal.String()
+				switch v := v.(type) {
+				case string:
+					p.Value = v
+				case json.Number:
+					p.Value = v.String()
+				case float64:
+					// parameters restored from a checkpoint lose json.Number
+					p.Value = strconv.FormatFloat(v, 'f', -1, 64)
+				default:
+					return labeled.ErrMissingParameter(p.Field, a.Event)
 				}
 				a.setParams++
 			} else {
@@ -101,6 +104,9 @@ type Sequence struct {
 	CurrentStep    int
 	Running        bool
 	Steps          []*Step
+	// NetVersionID pins the sequence to the version of the net it was started
+	// with so later edits to the net do not affect a running session.
+	NetVersionID string
 }
 
 func (s *Sequence) ApplyNet(net *marked.Net) error {
@@ -0,0 +1,87 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jt05610/petri/access"
+	"github.com/jt05610/petri/datastore"
+)
+
+var _ access.Store = (*Store)(nil)
+
+func (s *Store) SetOwner(ctx context.Context, res access.Resource, id, user string) error {
+	_, err := s.admin.ExecContext(ctx,
+		"INSERT INTO owners (resource, id, user) VALUES (?, ?, ?) ON CONFLICT (resource, id) DO UPDATE SET user = excluded.user",
+		string(res), id, user,
+	)
+	return err
+}
+
+func (s *Store) Owner(ctx context.Context, res access.Resource, id string) (string, error) {
+	var user string
+	err := s.admin.QueryRowContext(ctx, "SELECT user FROM owners WHERE resource = ? AND id = ?", string(res), id).Scan(&user)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return user, err
+}
+
+func (s *Store) Grant(ctx context.Context, g *access.Grant) error {
+	found, err := s.exists(ctx, "users", "name", g.User)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("user %s: %w", g.User, datastore.ErrNotFound)
+	}
+	_, err = s.admin.ExecContext(ctx,
+		"INSERT OR IGNORE INTO grants (resource, id, user, permission) VALUES (?, ?, ?, ?)",
+		string(g.Resource), g.ID, g.User, string(g.Permission),
+	)
+	return err
+}
+
+func (s *Store) Revoke(ctx context.Context, g *access.Grant) error {
+	res, err := s.admin.ExecContext(ctx,
+		"DELETE FROM grants WHERE resource = ? AND id = ? AND user = ? AND permission = ?",
+		string(g.Resource), g.ID, g.User, string(g.Permission),
+	)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%s grant for %s on %s: %w", g.Permission, g.User, g.ID, datastore.ErrNotFound)
+	}
+	return nil
+}
+
+func (s *Store) Grants(ctx context.Context, res access.Resource, id string) ([]*access.Grant, error) {
+	rows, err := s.admin.QueryContext(ctx, "SELECT user, permission FROM grants WHERE resource = ? AND id = ? ORDER BY user, permission", string(res), id)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	ret := make([]*access.Grant, 0)
+	for rows.Next() {
+		g := &access.Grant{Resource: res, ID: id}
+		var perm string
+		if err := rows.Scan(&g.User, &perm); err != nil {
+			return ret, err
+		}
+		g.Permission = access.Permission(perm)
+		ret = append(ret, g)
+	}
+	return ret, rows.Err()
+}
+
+func (s *Store) Forget(ctx context.Context, res access.Resource, id string) error {
+	if _, err := s.admin.ExecContext(ctx, "DELETE FROM grants WHERE resource = ? AND id = ?", string(res), id); err != nil {
+		return err
+	}
+	_, err := s.admin.ExecContext(ctx, "DELETE FROM owners WHERE resource = ? AND id = ?", string(res), id)
+	return err
+}
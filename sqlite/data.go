@@ -0,0 +1,320 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jt05610/petri/datastore"
+	"strings"
+	"time"
+)
+
+var _ datastore.DataStore = (*DB)(nil)
+
+const dataSchema = `
+CREATE TABLE IF NOT EXISTS runs (
+	id             TEXT PRIMARY KEY,
+	session_id     TEXT NOT NULL,
+	sequence_id    TEXT NOT NULL,
+	net_id         TEXT NOT NULL,
+	net_version_id TEXT NOT NULL,
+	state          TEXT NOT NULL,
+	started_at     INTEGER NOT NULL,
+	ended_at       INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS runs_session ON runs (session_id, started_at);
+CREATE TABLE IF NOT EXISTS events (
+	id          INTEGER PRIMARY KEY AUTOINCREMENT,
+	run_id      TEXT NOT NULL,
+	session_id  TEXT NOT NULL,
+	step        INTEGER NOT NULL,
+	device_id   TEXT NOT NULL,
+	instance_id TEXT NOT NULL,
+	event       TEXT NOT NULL,
+	data        TEXT,
+	marking     TEXT,
+	ts          INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS events_session ON events (session_id, ts);
+CREATE INDEX IF NOT EXISTS events_device ON events (device_id, ts);
+CREATE TABLE IF NOT EXISTS telemetry (
+	id          INTEGER PRIMARY KEY AUTOINCREMENT,
+	session_id  TEXT NOT NULL,
+	device_id   TEXT NOT NULL,
+	instance_id TEXT NOT NULL,
+	name        TEXT NOT NULL,
+	value       TEXT,
+	ts          INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS telemetry_session ON telemetry (session_id, ts);
+CREATE INDEX IF NOT EXISTS telemetry_device ON telemetry (device_id, ts);
+`
+
+// DB is a datastore.DataStore backed by a single sqlite file. Times are stored
+// as unix nanoseconds so range queries compare integers.
+type DB struct {
+	db *sql.DB
+}
+
+func (d *DB) Close() error {
+	return d.db.Close()
+}
+
+func unix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+func fromUnix(n int64) time.Time {
+	if n == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, n)
+}
+
+func (d *DB) AddRun(ctx context.Context, run *datastore.Run) error {
+	if run.State == "" {
+		run.State = datastore.RunRunning
+	}
+	if run.StartedAt.IsZero() {
+		run.StartedAt = time.Now()
+	}
+	_, err := d.db.ExecContext(ctx,
+		"INSERT INTO runs (id, session_id, sequence_id, net_id, net_version_id, state, started_at, ended_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		run.ID, run.SessionID, run.SequenceID, run.NetID, run.NetVersionID, string(run.State), unix(run.StartedAt), unix(run.EndedAt),
+	)
+	return err
+}
+
+func (d *DB) FinishRun(ctx context.Context, id string, state datastore.RunState, at time.Time) error {
+	res, err := d.db.ExecContext(ctx, "UPDATE runs SET state = ?, ended_at = ? WHERE id = ?", string(state), unix(at), id)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("run %s: %w", id, datastore.ErrNotFound)
+	}
+	return nil
+}
+
+const runColumns = "id, session_id, sequence_id, net_id, net_version_id, state, started_at, ended_at"
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRun(row scanner) (*datastore.Run, error) {
+	var r datastore.Run
+	var state string
+	var started, ended int64
+	err := row.Scan(&r.ID, &r.SessionID, &r.SequenceID, &r.NetID, &r.NetVersionID, &state, &started, &ended)
+	if err != nil {
+		return nil, err
+	}
+	r.State = datastore.RunState(state)
+	r.StartedAt = fromUnix(started)
+	r.EndedAt = fromUnix(ended)
+	return &r, nil
+}
+
+func (d *DB) Run(ctx context.Context, id string) (*datastore.Run, error) {
+	r, err := scanRun(d.db.QueryRowContext(ctx, "SELECT "+runColumns+" FROM runs WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("run %s: %w", id, datastore.ErrNotFound)
+	}
+	return r, err
+}
+
+func (d *DB) Runs(ctx context.Context, sessionID string) ([]*datastore.Run, error) {
+	rows, err := d.db.QueryContext(ctx, "SELECT "+runColumns+" FROM runs WHERE session_id = ? ORDER BY started_at", sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	ret := make([]*datastore.Run, 0)
+	for rows.Next() {
+		r, err := scanRun(rows)
+		if err != nil {
+			return ret, err
+		}
+		ret = append(ret, r)
+	}
+	return ret, rows.Err()
+}
+
+func marshal(v interface{}) (string, error) {
+	bytes, err := json.Marshal(v)
+	return string(bytes), err
+}
+
+func unmarshal(s sql.NullString, into interface{}) error {
+	if !s.Valid || s.String == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(s.String), into)
+}
+
+// insertAll runs one insert per item inside a single transaction so that a
+// batch is either stored completely or not at all.
+func insertAll[T any](ctx context.Context, db *sql.DB, query string, items []T, args func(T) ([]interface{}, error)) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+	for _, item := range items {
+		a, err := args(item)
+		if err != nil {
+			return errors.Join(err, tx.Rollback())
+		}
+		if _, err := stmt.ExecContext(ctx, a...); err != nil {
+			return errors.Join(err, tx.Rollback())
+		}
+	}
+	return tx.Commit()
+}
+
+func (d *DB) AppendEvents(ctx context.Context, events ...*datastore.StepEvent) error {
+	return insertAll(ctx, d.db,
+		"INSERT INTO events (run_id, session_id, step, device_id, instance_id, event, data, marking, ts) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		events,
+		func(e *datastore.StepEvent) ([]interface{}, error) {
+			data, err := marshal(e.Data)
+			if err != nil {
+				return nil, err
+			}
+			marking, err := marshal(e.Marking)
+			if err != nil {
+				return nil, err
+			}
+			if e.Timestamp.IsZero() {
+				e.Timestamp = time.Now()
+			}
+			return []interface{}{e.RunID, e.SessionID, e.Step, e.DeviceID, e.InstanceID, e.Event, data, marking, unix(e.Timestamp)}, nil
+		},
+	)
+}
+
+// where builds the WHERE and LIMIT clauses for q. Tables without a run_id
+// column pass runs as false and ignore q.RunID.
+func where(q *datastore.Query, runs bool) (string, []interface{}) {
+	if q == nil {
+		return "", nil
+	}
+	clauses := make([]string, 0)
+	args := make([]interface{}, 0)
+	add := func(clause string, arg interface{}) {
+		clauses = append(clauses, clause)
+		args = append(args, arg)
+	}
+	if q.SessionID != "" {
+		add("session_id = ?", q.SessionID)
+	}
+	if runs && q.RunID != "" {
+		add("run_id = ?", q.RunID)
+	}
+	if q.DeviceID != "" {
+		add("device_id = ?", q.DeviceID)
+	}
+	if !q.From.IsZero() {
+		add("ts >= ?", unix(q.From))
+	}
+	if !q.To.IsZero() {
+		add("ts < ?", unix(q.To))
+	}
+	ret := ""
+	if len(clauses) > 0 {
+		ret = " WHERE " + strings.Join(clauses, " AND ")
+	}
+	ret += " ORDER BY ts, id"
+	if q.Limit > 0 {
+		ret += " LIMIT ?"
+		args = append(args, q.Limit)
+	}
+	return ret, args
+}
+
+func (d *DB) Events(ctx context.Context, q *datastore.Query) ([]*datastore.StepEvent, error) {
+	clause, args := where(q, true)
+	rows, err := d.db.QueryContext(ctx, "SELECT run_id, session_id, step, device_id, instance_id, event, data, marking, ts FROM events"+clause, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	ret := make([]*datastore.StepEvent, 0)
+	for rows.Next() {
+		var e datastore.StepEvent
+		var data, marking sql.NullString
+		var ts int64
+		err := rows.Scan(&e.RunID, &e.SessionID, &e.Step, &e.DeviceID, &e.InstanceID, &e.Event, &data, &marking, &ts)
+		if err != nil {
+			return ret, err
+		}
+		if err := unmarshal(data, &e.Data); err != nil {
+			return ret, err
+		}
+		if err := unmarshal(marking, &e.Marking); err != nil {
+			return ret, err
+		}
+		e.Timestamp = fromUnix(ts)
+		ret = append(ret, &e)
+	}
+	return ret, rows.Err()
+}
+
+func (d *DB) AppendTelemetry(ctx context.Context, telemetry ...*datastore.Telemetry) error {
+	return insertAll(ctx, d.db,
+		"INSERT INTO telemetry (session_id, device_id, instance_id, name, value, ts) VALUES (?, ?, ?, ?, ?, ?)",
+		telemetry,
+		func(t *datastore.Telemetry) ([]interface{}, error) {
+			value, err := marshal(t.Value)
+			if err != nil {
+				return nil, err
+			}
+			if t.Timestamp.IsZero() {
+				t.Timestamp = time.Now()
+			}
+			return []interface{}{t.SessionID, t.DeviceID, t.InstanceID, t.Name, value, unix(t.Timestamp)}, nil
+		},
+	)
+}
+
+func (d *DB) Telemetry(ctx context.Context, q *datastore.Query) ([]*datastore.Telemetry, error) {
+	clause, args := where(q, false)
+	rows, err := d.db.QueryContext(ctx, "SELECT session_id, device_id, instance_id, name, value, ts FROM telemetry"+clause, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	ret := make([]*datastore.Telemetry, 0)
+	for rows.Next() {
+		var t datastore.Telemetry
+		var value sql.NullString
+		var ts int64
+		if err := rows.Scan(&t.SessionID, &t.DeviceID, &t.InstanceID, &t.Name, &value, &ts); err != nil {
+			return ret, err
+		}
+		if err := unmarshal(value, &t.Value); err != nil {
+			return ret, err
+		}
+		t.Timestamp = fromUnix(ts)
+		ret = append(ret, &t)
+	}
+	return ret, rows.Err()
+}
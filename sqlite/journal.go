@@ -0,0 +1,75 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jt05610/petri/datastore"
+	"time"
+)
+
+const journalSchema = `
+CREATE TABLE IF NOT EXISTS checkpoints (
+	session_id TEXT PRIMARY KEY,
+	state      TEXT NOT NULL,
+	checkpoint TEXT NOT NULL,
+	updated_at INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS checkpoints_state ON checkpoints (state, updated_at);
+`
+
+// SaveCheckpoint replaces the session's checkpoint. The whole checkpoint is
+// written in one statement, so a crash leaves either the old one or the new
+// one.
+func (s *Store) SaveCheckpoint(ctx context.Context, cp *datastore.Checkpoint) error {
+	if cp.UpdatedAt.IsZero() {
+		cp.UpdatedAt = time.Now()
+	}
+	data, err := marshal(cp)
+	if err != nil {
+		return err
+	}
+	_, err = s.admin.ExecContext(ctx,
+		"INSERT INTO checkpoints (session_id, state, checkpoint, updated_at) VALUES (?, ?, ?, ?) "+
+			"ON CONFLICT (session_id) DO UPDATE SET state = excluded.state, checkpoint = excluded.checkpoint, updated_at = excluded.updated_at",
+		cp.SessionID, cp.State, data, unix(cp.UpdatedAt),
+	)
+	return err
+}
+
+func scanCheckpoint(row scanner) (*datastore.Checkpoint, error) {
+	var data sql.NullString
+	if err := row.Scan(&data); err != nil {
+		return nil, err
+	}
+	cp := new(datastore.Checkpoint)
+	return cp, unmarshal(data, cp)
+}
+
+func (s *Store) Checkpoint(ctx context.Context, sessionID string) (*datastore.Checkpoint, error) {
+	cp, err := scanCheckpoint(s.admin.QueryRowContext(ctx, "SELECT checkpoint FROM checkpoints WHERE session_id = ?", sessionID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("checkpoint for %s: %w", sessionID, datastore.ErrNotFound)
+	}
+	return cp, err
+}
+
+func (s *Store) Interrupted(ctx context.Context) ([]*datastore.Checkpoint, error) {
+	rows, err := s.admin.QueryContext(ctx, "SELECT checkpoint FROM checkpoints WHERE state IN ('running', 'paused', 'stopping') ORDER BY updated_at")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	ret := make([]*datastore.Checkpoint, 0)
+	for rows.Next() {
+		cp, err := scanCheckpoint(rows)
+		if err != nil {
+			return ret, err
+		}
+		ret = append(ret, cp)
+	}
+	return ret, rows.Err()
+}
@@ -0,0 +1,292 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jt05610/petri/datastore"
+	_ "github.com/mattn/go-sqlite3"
+	"golang.org/x/crypto/bcrypt"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+var (
+	_ datastore.Initializer   = (*Store)(nil)
+	_ datastore.User          = (*Store)(nil)
+	_ datastore.Administrator = (*Store)(nil)
+	_ datastore.Loader        = (*Store)(nil)
+	_ datastore.Journal       = (*Store)(nil)
+	_ datastore.NetVersions   = (*Store)(nil)
+)
+
+var validName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_\-]*$`)
+
+const adminSchema = `
+CREATE TABLE IF NOT EXISTS users (
+	name TEXT PRIMARY KEY,
+	hash BLOB NOT NULL,
+	role TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS dbs (
+	name TEXT PRIMARY KEY
+);
+CREATE TABLE IF NOT EXISTS db_users (
+	db   TEXT NOT NULL,
+	user TEXT NOT NULL,
+	role TEXT NOT NULL,
+	PRIMARY KEY (db, user)
+);
+CREATE TABLE IF NOT EXISTS owners (
+	resource TEXT NOT NULL,
+	id       TEXT NOT NULL,
+	user     TEXT NOT NULL,
+	PRIMARY KEY (resource, id)
+);
+CREATE TABLE IF NOT EXISTS grants (
+	resource   TEXT NOT NULL,
+	id         TEXT NOT NULL,
+	user       TEXT NOT NULL,
+	permission TEXT NOT NULL,
+	PRIMARY KEY (resource, id, user, permission)
+);
+`
+
+// Store keeps each named database in its own file under a directory. Users,
+// their password hashes, their roles on each database and who owns or has been
+// granted access to each net, device and sequence live in admin.db in the same
+// directory.
+type Store struct {
+	dir   string
+	admin *sql.DB
+}
+
+func open(path string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite3", "file:"+path+"?_busy_timeout=5000&_journal_mode=WAL")
+	if err != nil {
+		return nil, err
+	}
+	// sqlite allows a single writer, so serialize access through one connection
+	db.SetMaxOpenConns(1)
+	return db, nil
+}
+
+// Open returns a Store rooted at dir, creating the directory and the admin
+// database if they do not exist.
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	admin, err := open(filepath.Join(dir, "admin.db"))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := admin.Exec(adminSchema + journalSchema + versionSchema); err != nil {
+		_ = admin.Close()
+		return nil, err
+	}
+	return &Store{dir: dir, admin: admin}, nil
+}
+
+func (s *Store) Close() error {
+	return s.admin.Close()
+}
+
+func (s *Store) path(name string) string {
+	return filepath.Join(s.dir, name+".db")
+}
+
+func checkName(name string) error {
+	if !validName.MatchString(name) || name == "admin" {
+		return fmt.Errorf("invalid database name %q", name)
+	}
+	return nil
+}
+
+func (s *Store) exists(ctx context.Context, table, column, value string) (bool, error) {
+	var n int
+	err := s.admin.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+table+" WHERE "+column+" = ?", value).Scan(&n)
+	return n > 0, err
+}
+
+func (s *Store) CreateDb(ctx context.Context, name string) error {
+	if err := checkName(name); err != nil {
+		return err
+	}
+	found, err := s.exists(ctx, "dbs", "name", name)
+	if err != nil {
+		return err
+	}
+	if found {
+		return fmt.Errorf("database %s: %w", name, datastore.ErrExists)
+	}
+	db, err := open(s.path(name))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	if _, err := db.ExecContext(ctx, dataSchema); err != nil {
+		return err
+	}
+	_, err = s.admin.ExecContext(ctx, "INSERT INTO dbs (name) VALUES (?)", name)
+	return err
+}
+
+func (s *Store) DeleteDb(ctx context.Context, name string) error {
+	res, err := s.admin.ExecContext(ctx, "DELETE FROM dbs WHERE name = ?", name)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("database %s: %w", name, datastore.ErrNotFound)
+	}
+	if _, err := s.admin.ExecContext(ctx, "DELETE FROM db_users WHERE db = ?", name); err != nil {
+		return err
+	}
+	var errs []error
+	for _, suffix := range []string{"", "-wal", "-shm"} {
+		if err := os.Remove(s.path(name) + suffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *Store) CreateUser(ctx context.Context, name, password string, role datastore.Role) error {
+	if !role.Valid() {
+		return fmt.Errorf("%w: %s", datastore.ErrInvalidRole, role)
+	}
+	found, err := s.exists(ctx, "users", "name", name)
+	if err != nil {
+		return err
+	}
+	if found {
+		return fmt.Errorf("user %s: %w", name, datastore.ErrExists)
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	_, err = s.admin.ExecContext(ctx, "INSERT INTO users (name, hash, role) VALUES (?, ?, ?)", name, hash, string(role))
+	return err
+}
+
+func (s *Store) DeleteUser(ctx context.Context, name string) error {
+	res, err := s.admin.ExecContext(ctx, "DELETE FROM users WHERE name = ?", name)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("user %s: %w", name, datastore.ErrNotFound)
+	}
+	for _, table := range []string{"db_users", "grants"} {
+		if _, err := s.admin.ExecContext(ctx, "DELETE FROM "+table+" WHERE user = ?", name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Authenticate checks the password of the named user and returns their role.
+func (s *Store) Authenticate(ctx context.Context, name, password string) (datastore.Role, error) {
+	var hash []byte
+	var role string
+	err := s.admin.QueryRowContext(ctx, "SELECT hash, role FROM users WHERE name = ?", name).Scan(&hash, &role)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("user %s: %w", name, datastore.ErrNotFound)
+	}
+	if err != nil {
+		return "", err
+	}
+	if err := bcrypt.CompareHashAndPassword(hash, []byte(password)); err != nil {
+		return "", err
+	}
+	return datastore.Role(role), nil
+}
+
+func (s *Store) checkMember(ctx context.Context, db, user string) error {
+	found, err := s.exists(ctx, "dbs", "name", db)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("database %s: %w", db, datastore.ErrNotFound)
+	}
+	found, err = s.exists(ctx, "users", "name", user)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("user %s: %w", user, datastore.ErrNotFound)
+	}
+	return nil
+}
+
+func (s *Store) AddUserToDb(ctx context.Context, db, user string, role datastore.Role) error {
+	if !role.Valid() {
+		return fmt.Errorf("%w: %s", datastore.ErrInvalidRole, role)
+	}
+	if err := s.checkMember(ctx, db, user); err != nil {
+		return err
+	}
+	_, err := s.admin.ExecContext(ctx, "INSERT INTO db_users (db, user, role) VALUES (?, ?, ?)", db, user, string(role))
+	return err
+}
+
+func (s *Store) RemoveUserFromDb(ctx context.Context, db, user string) error {
+	res, err := s.admin.ExecContext(ctx, "DELETE FROM db_users WHERE db = ? AND user = ?", db, user)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("user %s on %s: %w", user, db, datastore.ErrNotFound)
+	}
+	return nil
+}
+
+func (s *Store) ChangeUserDbRole(ctx context.Context, db, user string, role datastore.Role) error {
+	if !role.Valid() {
+		return fmt.Errorf("%w: %s", datastore.ErrInvalidRole, role)
+	}
+	res, err := s.admin.ExecContext(ctx, "UPDATE db_users SET role = ? WHERE db = ? AND user = ?", string(role), db, user)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("user %s on %s: %w", user, db, datastore.ErrNotFound)
+	}
+	return nil
+}
+
+// DbRole returns the role the user has on the database.
+func (s *Store) DbRole(ctx context.Context, db, user string) (datastore.Role, error) {
+	var role string
+	err := s.admin.QueryRowContext(ctx, "SELECT role FROM db_users WHERE db = ? AND user = ?", db, user).Scan(&role)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("user %s on %s: %w", user, db, datastore.ErrNotFound)
+	}
+	return datastore.Role(role), err
+}
+
+func (s *Store) Load(ctx context.Context, name string) (datastore.DataStore, error) {
+	found, err := s.exists(ctx, "dbs", "name", name)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("database %s: %w", name, datastore.ErrNotFound)
+	}
+	db, err := open(s.path(name))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.ExecContext(ctx, dataSchema); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return &DB{db: db}, nil
+}
@@ -0,0 +1,262 @@
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"github.com/jt05610/petri"
+	"github.com/jt05610/petri/datastore"
+	"github.com/jt05610/petri/sqlite"
+	"testing"
+	"time"
+)
+
+func TestStore_Admin(t *testing.T) {
+	ctx := context.Background()
+	s, err := sqlite.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = s.Close()
+	}()
+	if err := s.CreateDb(ctx, "lab"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.CreateDb(ctx, "lab"); !errors.Is(err, datastore.ErrExists) {
+		t.Fatalf("expected ErrExists, got %v", err)
+	}
+	if err := s.CreateUser(ctx, "alice", "secret", datastore.UserRole); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.CreateUser(ctx, "bob", "secret", "owner"); !errors.Is(err, datastore.ErrInvalidRole) {
+		t.Fatalf("expected ErrInvalidRole, got %v", err)
+	}
+	role, err := s.Authenticate(ctx, "alice", "secret")
+	if err != nil || role != datastore.UserRole {
+		t.Fatalf("expected user role, got %q %v", role, err)
+	}
+	if _, err := s.Authenticate(ctx, "alice", "wrong"); err == nil {
+		t.Fatal("expected wrong password to fail")
+	}
+	if err := s.AddUserToDb(ctx, "lab", "alice", datastore.UserRole); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.ChangeUserDbRole(ctx, "lab", "alice", datastore.AdminRole); err != nil {
+		t.Fatal(err)
+	}
+	if role, err := s.DbRole(ctx, "lab", "alice"); err != nil || role != datastore.AdminRole {
+		t.Fatalf("expected admin role, got %q %v", role, err)
+	}
+	if err := s.RemoveUserFromDb(ctx, "lab", "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddUserToDb(ctx, "missing", "alice", datastore.UserRole); !errors.Is(err, datastore.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if err := s.DeleteUser(ctx, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DeleteDb(ctx, "lab"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Load(ctx, "lab"); !errors.Is(err, datastore.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestDB(t *testing.T) {
+	ctx := context.Background()
+	s, err := sqlite.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = s.Close()
+	}()
+	if err := s.CreateDb(ctx, "lab"); err != nil {
+		t.Fatal(err)
+	}
+	db, err := s.Load(ctx, "lab")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	start := time.Unix(1700000000, 0)
+	run := &datastore.Run{ID: "run", SessionID: "session", SequenceID: "seq", NetID: "net", NetVersionID: "v1", StartedAt: start}
+	if err := db.AddRun(ctx, run); err != nil {
+		t.Fatal(err)
+	}
+	events := make([]*datastore.StepEvent, 0)
+	for i := 0; i < 4; i++ {
+		dev := "pump"
+		if i%2 == 1 {
+			dev = "valve"
+		}
+		events = append(events, &datastore.StepEvent{
+			RunID:     "run",
+			SessionID: "session",
+			Step:      i,
+			DeviceID:  dev,
+			Event:     "moved",
+			Data:      map[string]interface{}{"volume": float64(i)},
+			Marking:   map[string]int{"p": i},
+			Timestamp: start.Add(time.Duration(i) * time.Second),
+		})
+	}
+	if err := db.AppendEvents(ctx, events...); err != nil {
+		t.Fatal(err)
+	}
+	got, err := db.Events(ctx, &datastore.Query{SessionID: "session", DeviceID: "pump"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[1].Step != 2 || got[1].Data["volume"] != float64(2) || got[1].Marking["p"] != 2 {
+		t.Fatalf("unexpected events %+v", got)
+	}
+	got, err = db.Events(ctx, &datastore.Query{From: start.Add(time.Second), To: start.Add(3 * time.Second)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Step != 1 {
+		t.Fatalf("expected steps 1 and 2, got %+v", got)
+	}
+	if err := db.AppendTelemetry(ctx, &datastore.Telemetry{SessionID: "session", DeviceID: "heater", Name: "temperature", Value: map[string]interface{}{"celsius": 37.5}, Timestamp: start}); err != nil {
+		t.Fatal(err)
+	}
+	tel, err := db.Telemetry(ctx, &datastore.Query{DeviceID: "heater", Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tel) != 1 || tel[0].Value["celsius"] != 37.5 || !tel[0].Timestamp.Equal(start) {
+		t.Fatalf("unexpected telemetry %+v", tel)
+	}
+	if err := db.FinishRun(ctx, "run", datastore.RunCompleted, start.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	runs, err := db.Runs(ctx, "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 1 || runs[0].State != datastore.RunCompleted || runs[0].NetVersionID != "v1" {
+		t.Fatalf("unexpected runs %+v", runs)
+	}
+	if err := db.FinishRun(ctx, "missing", datastore.RunFailed, time.Now()); !errors.Is(err, datastore.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestStore_Journal(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s, err := sqlite.Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Unix(1700000000, 0)
+	for i, state := range []string{"completed", "running", "paused"} {
+		cp := &datastore.Checkpoint{
+			SessionID: "session-" + state,
+			State:     state,
+			Step:      i,
+			Routes:    map[string]string{"pump": "pump-1"},
+			Markings:  map[string]map[string]int{"pump": {"idle": 1}},
+			UpdatedAt: start.Add(time.Duration(i) * time.Second),
+		}
+		if err := s.SaveCheckpoint(ctx, cp); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cp := &datastore.Checkpoint{
+		SessionID:   "session-running",
+		State:       "running",
+		Step:        3,
+		Outstanding: &datastore.Outstanding{DeviceID: "pump", InstanceID: "pump-1", Event: "fill"},
+		Events:      []*datastore.StepEvent{{SessionID: "session-running", Step: 2, Event: "empty"}},
+		UpdatedAt:   start.Add(time.Minute),
+	}
+	if err := s.SaveCheckpoint(ctx, cp); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// reopen to check the checkpoints survive a restart
+	s, err = sqlite.Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = s.Close()
+	}()
+	got, err := s.Interrupted(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].SessionID != "session-paused" || got[1].SessionID != "session-running" {
+		t.Fatalf("expected the paused then the running session, got %+v", got)
+	}
+	if got[1].Step != 3 || got[1].Outstanding.Event != "fill" || len(got[1].Events) != 1 {
+		t.Fatalf("expected the latest checkpoint, got %+v", got[1])
+	}
+	if _, err := s.Checkpoint(ctx, "missing"); !errors.Is(err, datastore.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestStore_NetVersions(t *testing.T) {
+	ctx := context.Background()
+	s, err := sqlite.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = s.Close()
+	}()
+	if _, err := s.LatestNetVersion(ctx, "net"); !errors.Is(err, datastore.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	idle, busy := petri.NewPlace("idle", 1), petri.NewPlace("busy", 1)
+	start := petri.NewTransition("start")
+	n := petri.NewNet("pump").
+		WithPlaces(idle, busy).
+		WithTransitions(start).
+		WithArcs(petri.NewArc(idle, start, "", nil), petri.NewArc(start, busy, "", nil))
+	n.ID = "net"
+	first := &datastore.NetVersion{
+		NetVersion: (&petri.NetVersionInput{Net: n}).Object().(*petri.NetVersion),
+		Marking:    map[string]int{idle.ID: 1},
+	}
+	if err := s.AddNetVersion(ctx, first); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddNetVersion(ctx, first); !errors.Is(err, datastore.ErrExists) {
+		t.Fatalf("expected ErrExists, got %v", err)
+	}
+	second := &datastore.NetVersion{
+		NetVersion: (&petri.NetVersionInput{Net: n, Parent: first.NetVersion}).Object().(*petri.NetVersion),
+		Marking:    map[string]int{busy.ID: 1},
+	}
+	if err := s.AddNetVersion(ctx, second); err != nil {
+		t.Fatal(err)
+	}
+	latest, err := s.LatestNetVersion(ctx, "net")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest.ID != second.ID || latest.Parent != first.ID || latest.Marking[busy.ID] != 1 {
+		t.Fatalf("expected the second version, got %+v", latest)
+	}
+	got, err := s.NetVersion(ctx, first.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := petri.Diff(n, got.Net()); !d.Empty() {
+		t.Fatalf("expected the stored net to match, got %+v", d)
+	}
+	if _, err := s.NetVersion(ctx, "missing"); !errors.Is(err, datastore.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jt05610/petri"
+	"github.com/jt05610/petri/datastore"
+	"time"
+)
+
+const versionSchema = `
+CREATE TABLE IF NOT EXISTS net_versions (
+	id         TEXT PRIMARY KEY,
+	net_id     TEXT NOT NULL,
+	version    INTEGER NOT NULL,
+	snapshot   TEXT NOT NULL,
+	marking    TEXT,
+	created_at INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS net_versions_net ON net_versions (net_id, version);
+`
+
+// AddNetVersion stores a version. Versions are immutable, so adding one with
+// an ID that is already stored fails with datastore.ErrExists.
+func (s *Store) AddNetVersion(ctx context.Context, v *datastore.NetVersion) error {
+	if v.CreatedAt.IsZero() {
+		v.CreatedAt = time.Now()
+	}
+	snapshot, err := marshal(v.Document())
+	if err != nil {
+		return err
+	}
+	marking, err := marshal(v.Marking)
+	if err != nil {
+		return err
+	}
+	res, err := s.admin.ExecContext(ctx,
+		"INSERT INTO net_versions (id, net_id, version, snapshot, marking, created_at) VALUES (?, ?, ?, ?, ?, ?) ON CONFLICT (id) DO NOTHING",
+		v.ID, v.NetID, v.Version, snapshot, marking, unix(v.CreatedAt),
+	)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("net version %s: %w", v.ID, datastore.ErrExists)
+	}
+	return nil
+}
+
+func scanNetVersion(row scanner) (*datastore.NetVersion, error) {
+	var snapshot, marking sql.NullString
+	if err := row.Scan(&snapshot, &marking); err != nil {
+		return nil, err
+	}
+	v := &datastore.NetVersion{NetVersion: new(petri.NetVersion)}
+	if err := unmarshal(snapshot, v.NetVersion); err != nil {
+		return nil, err
+	}
+	if err := v.PostInit(); err != nil {
+		return nil, err
+	}
+	return v, unmarshal(marking, &v.Marking)
+}
+
+func (s *Store) NetVersion(ctx context.Context, id string) (*datastore.NetVersion, error) {
+	v, err := scanNetVersion(s.admin.QueryRowContext(ctx, "SELECT snapshot, marking FROM net_versions WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("net version %s: %w", id, datastore.ErrNotFound)
+	}
+	return v, err
+}
+
+func (s *Store) LatestNetVersion(ctx context.Context, netID string) (*datastore.NetVersion, error) {
+	v, err := scanNetVersion(s.admin.QueryRowContext(ctx, "SELECT snapshot, marking FROM net_versions WHERE net_id = ? ORDER BY version DESC LIMIT 1", netID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("versions of net %s: %w", netID, datastore.ErrNotFound)
+	}
+	return v, err
+}
@@ -6,7 +6,7 @@ import (
 )
 
 func TestToken_New(t *testing.T) {
-	coin := petri.TokenSchema[interface{}]{
+	coin := petri.TokenSchema{
 		Name: "Coin",
 		Type: petri.Float,
 	}
@@ -16,14 +16,12 @@ func TestToken_New(t *testing.T) {
 		t.Error(err)
 	}
 	if penny == nil {
-		t.Error("penny is nil")
+		t.Fatal("penny is nil")
 	}
-	shouldntWork, err := coin.NewToken("hello")
-
-	if err == nil {
-		t.Error("shouldntWork is valid")
+	if penny.Schema != &coin {
+		t.Error("penny does not have the coin schema")
 	}
-	if shouldntWork != nil {
-		t.Error("shouldntWork is not nil")
+	if penny.Value != 0.01 {
+		t.Errorf("penny is worth %v, not 0.01", penny.Value)
 	}
 }
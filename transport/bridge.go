@@ -0,0 +1,63 @@
+package transport
+
+import (
+	"context"
+)
+
+// BridgedHeader marks messages a Bridge has forwarded so that a pair of
+// bridges running in opposite directions never send a message back.
+const BridgedHeader = "x-bridged"
+
+var (
+	// Downstream are the patterns of messages the controller sends to devices.
+	Downstream = []string{"*.commands.*", "*.state.get", "devices"}
+	// Upstream are the patterns of messages devices send to the controller.
+	Upstream = []string{"*.events.*", "*.errors.*", "*.state.current", "*.device.*"}
+)
+
+// Bridge publishes everything q receives on from to to until ctx is
+// cancelled. A message is acked once it has been published on the other side
+// and requeued if that fails. Reply-to addresses only mean something on the
+// transport that issued them, so they are dropped: answers cross back as
+// ordinary events and are matched to their calls by correlation ID.
+func Bridge(ctx context.Context, from, to Transport, q *Queue) error {
+	sub, err := from.Subscribe(ctx, q)
+	if err != nil {
+		return err
+	}
+	for m := range sub.Messages() {
+		if _, bridged := m.Headers[BridgedHeader]; bridged {
+			if err := m.Ack(); err != nil {
+				return err
+			}
+			continue
+		}
+		fwd := &Message{
+			Key:           m.Key,
+			Headers:       make(map[string]interface{}, len(m.Headers)+1),
+			Body:          m.Body,
+			MessageID:     m.MessageID,
+			CorrelationID: m.CorrelationID,
+		}
+		for k, v := range m.Headers {
+			fwd.Headers[k] = v
+		}
+		fwd.Headers[BridgedHeader] = "true"
+		if err := to.Publish(ctx, fwd); err != nil {
+			if nErr := m.Nack(true); nErr != nil {
+				return nErr
+			}
+			if ctx.Err() != nil {
+				continue
+			}
+			return err
+		}
+		if err := m.Ack(); err != nil {
+			return err
+		}
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	return ErrClosed
+}
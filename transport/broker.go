@@ -0,0 +1,257 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+var _ Transport = (*Broker)(nil)
+
+// Broker is an in-process Transport. A controller and device servers that
+// share a Broker talk to each other as they would through a real broker, so a
+// whole sequence can be run inside one test.
+type Broker struct {
+	mu     sync.Mutex
+	queues map[string]*queue
+	dead   []*Message
+	next   int
+	once   sync.Once
+	closed chan struct{}
+}
+
+type queue struct {
+	name       string
+	private    bool
+	deadLetter bool
+	keys       []string
+	items      []*Message
+	// wake is closed and replaced whenever a message is queued.
+	wake chan struct{}
+}
+
+func (q *queue) bind(keys []string) {
+	for _, key := range keys {
+		found := false
+		for _, k := range q.keys {
+			if k == key {
+				found = true
+				break
+			}
+		}
+		if !found {
+			q.keys = append(q.keys, key)
+		}
+	}
+}
+
+func (q *queue) matches(key string) bool {
+	for _, k := range q.keys {
+		if Match(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (q *queue) push(m *Message, front bool) {
+	if front {
+		q.items = append([]*Message{m}, q.items...)
+	} else {
+		q.items = append(q.items, m)
+	}
+	close(q.wake)
+	q.wake = make(chan struct{})
+}
+
+func NewBroker() *Broker {
+	return &Broker{
+		queues: make(map[string]*queue),
+		closed: make(chan struct{}),
+	}
+}
+
+type delivery struct {
+	b       *Broker
+	q       *queue
+	m       *Message
+	settled bool
+}
+
+func (d *delivery) Ack() error {
+	d.b.mu.Lock()
+	defer d.b.mu.Unlock()
+	d.settled = true
+	return nil
+}
+
+func (d *delivery) Nack(requeue bool) error {
+	d.b.mu.Lock()
+	defer d.b.mu.Unlock()
+	if d.settled {
+		return nil
+	}
+	d.settled = true
+	if requeue {
+		d.q.push(d.m, true)
+		return nil
+	}
+	if d.q.deadLetter {
+		d.b.dead = append(d.b.dead, d.m)
+	}
+	return nil
+}
+
+// enqueue gives q its own copy of msg. Callers hold b.mu.
+func (b *Broker) enqueue(q *queue, msg *Message) {
+	cp := *msg
+	cp.Headers = make(map[string]interface{}, len(msg.Headers))
+	for k, v := range msg.Headers {
+		cp.Headers[k] = v
+	}
+	cp.Acknowledger = &delivery{b: b, q: q, m: &cp}
+	q.push(&cp, false)
+}
+
+func (b *Broker) isClosed() bool {
+	select {
+	case <-b.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+func (b *Broker) Publish(ctx context.Context, msg *Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.isClosed() {
+		return ErrClosed
+	}
+	for _, q := range b.queues {
+		if q.matches(msg.Key) {
+			b.enqueue(q, msg)
+		}
+	}
+	return nil
+}
+
+// Send delivers msg to the queue at address. Like a broker's default exchange
+// it drops messages for queues that do not exist.
+func (b *Broker) Send(ctx context.Context, address string, msg *Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.isClosed() {
+		return ErrClosed
+	}
+	if q, found := b.queues[address]; found {
+		b.enqueue(q, msg)
+	}
+	return nil
+}
+
+type subscription struct {
+	address string
+	msgs    chan *Message
+}
+
+func (s *subscription) Address() string {
+	return s.address
+}
+
+func (s *subscription) Messages() <-chan *Message {
+	return s.msgs
+}
+
+// Subscribe binds the queue, creating it if needed. Messages still queued when
+// ctx is cancelled stay on a named queue for the next subscriber.
+func (b *Broker) Subscribe(ctx context.Context, qd *Queue) (Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.isClosed() {
+		return nil, ErrClosed
+	}
+	name := qd.Name
+	if name == "" {
+		b.next++
+		name = fmt.Sprintf("inproc.%d", b.next)
+	}
+	q, found := b.queues[name]
+	if !found {
+		q = &queue{
+			name:    name,
+			private: qd.Name == "",
+			wake:    make(chan struct{}),
+		}
+		b.queues[name] = q
+	}
+	q.deadLetter = q.deadLetter || qd.DeadLetter
+	q.bind(qd.Keys)
+	sub := &subscription{address: name, msgs: make(chan *Message)}
+	go b.consume(ctx, q, sub.msgs)
+	return sub, nil
+}
+
+func (b *Broker) consume(ctx context.Context, q *queue, out chan<- *Message) {
+	defer close(out)
+	defer func() {
+		if q.private {
+			b.mu.Lock()
+			delete(b.queues, q.name)
+			b.mu.Unlock()
+		}
+	}()
+	for {
+		b.mu.Lock()
+		if len(q.items) == 0 {
+			wake := q.wake
+			b.mu.Unlock()
+			select {
+			case <-ctx.Done():
+				return
+			case <-b.closed:
+				return
+			case <-wake:
+				continue
+			}
+		}
+		m := q.items[0]
+		q.items = q.items[1:]
+		m.Acknowledger.(*delivery).settled = false
+		b.mu.Unlock()
+		select {
+		case out <- m:
+		case <-ctx.Done():
+			b.mu.Lock()
+			q.push(m, true)
+			b.mu.Unlock()
+			return
+		case <-b.closed:
+			return
+		}
+	}
+}
+
+// DeadLetters returns the messages nacked without requeue from queues that
+// keep them.
+func (b *Broker) DeadLetters() []*Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	ret := make([]*Message, len(b.dead))
+	copy(ret, b.dead)
+	return ret
+}
+
+// Close stops every subscription. Messages still queued are discarded.
+func (b *Broker) Close() error {
+	b.once.Do(func() {
+		close(b.closed)
+	})
+	return nil
+}
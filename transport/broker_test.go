@@ -0,0 +1,105 @@
+package transport_test
+
+import (
+	"context"
+	"github.com/jt05610/petri/transport"
+	"testing"
+	"time"
+)
+
+func TestMatch(t *testing.T) {
+	for _, tc := range []struct {
+		pattern, key string
+		want         bool
+	}{
+		{"*.events.*", "pump.events.dispensed", true},
+		{"*.events.*", "pump.commands.dispense", false},
+		{"*.events.*", "pump.events", false},
+		{"*.events.*", "pump.events.a.b", false},
+		{"pump.#", "pump", true},
+		{"pump.#", "pump.events.dispensed", true},
+		{"#.dispensed", "pump.events.dispensed", true},
+		{"#", "devices", true},
+		{"devices", "devices", true},
+		{"devices", "device", false},
+	} {
+		if got := transport.Match(tc.pattern, tc.key); got != tc.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", tc.pattern, tc.key, got, tc.want)
+		}
+	}
+}
+
+func receive(t *testing.T, sub transport.Subscription) *transport.Message {
+	t.Helper()
+	select {
+	case m := <-sub.Messages():
+		return m
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for a message")
+		return nil
+	}
+}
+
+func TestBroker(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	b := transport.NewBroker()
+	defer func() {
+		_ = b.Close()
+	}()
+	events, err := b.Subscribe(ctx, &transport.Queue{Keys: []string{"*.events.*"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	devCtx, devCancel := context.WithCancel(ctx)
+	commands, err := b.Subscribe(devCtx, &transport.Queue{Name: "pump", Keys: []string{"pump.commands.*"}, DeadLetter: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"pump.commands.dispense", "pump.events.dispensed", "valve.commands.open"} {
+		if err := b.Publish(ctx, &transport.Message{Key: key}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if m := receive(t, events); m.Key != "pump.events.dispensed" {
+		t.Fatalf("unexpected event %s", m.Key)
+	}
+	m := receive(t, commands)
+	if m.Key != "pump.commands.dispense" {
+		t.Fatalf("unexpected command %s", m.Key)
+	}
+	if err := m.Nack(true); err != nil {
+		t.Fatal(err)
+	}
+	m = receive(t, commands)
+	if m.Key != "pump.commands.dispense" {
+		t.Fatalf("expected the command to be redelivered, got %s", m.Key)
+	}
+	if err := m.Nack(false); err != nil {
+		t.Fatal(err)
+	}
+	if dead := b.DeadLetters(); len(dead) != 1 || dead[0].Key != "pump.commands.dispense" {
+		t.Fatalf("expected the command to be dead-lettered, got %v", dead)
+	}
+
+	devCancel()
+	for range commands.Messages() {
+	}
+	if err := b.Publish(ctx, &transport.Message{Key: "pump.commands.prime"}); err != nil {
+		t.Fatal(err)
+	}
+	commands, err = b.Subscribe(ctx, &transport.Queue{Name: "pump"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := receive(t, commands); m.Key != "pump.commands.prime" {
+		t.Fatalf("expected the named queue to keep its messages, got %s", m.Key)
+	}
+
+	if err := b.Send(ctx, events.Address(), &transport.Message{Key: "pump.state.current", CorrelationID: "corr"}); err != nil {
+		t.Fatal(err)
+	}
+	if m := receive(t, events); m.Key != "pump.state.current" || m.CorrelationID != "corr" {
+		t.Fatalf("unexpected reply %+v", m)
+	}
+}
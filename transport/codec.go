@@ -0,0 +1,131 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/jt05610/petri/control"
+	"github.com/jt05610/petri/labeled"
+	"strings"
+)
+
+var ErrInvalidRoutingKey = errors.New("invalid routing key")
+
+// MarkingHeader carries the device's marking as JSON bytes.
+const MarkingHeader = "x-marking"
+
+type CommandService struct{}
+
+func (a *CommandService) Load(_ context.Context, data *Message) (*control.Command, error) {
+	sk := strings.Split(data.Key, ".")
+	if len(sk) != 3 {
+		return nil, ErrInvalidRoutingKey
+	}
+	to := sk[0]
+	topic := sk[1]
+	command := sk[2]
+	id, _ := data.Headers["x-event-id"].(string)
+	res := &control.Command{
+		To:    to,
+		Topic: topic,
+		Event: &labeled.Event{
+			ID:   id,
+			Name: command,
+		},
+		CorrelationID: data.CorrelationID,
+		ReplyTo:       data.ReplyTo,
+	}
+	if len(data.Body) == 0 {
+		return res, nil
+	}
+	return res, json.Unmarshal(data.Body, &res.Event.Data)
+}
+
+// Flush encodes an event. The caller sets the routing key.
+func (a *CommandService) Flush(_ context.Context, event *labeled.Event, mark ...control.Marking) (*Message, error) {
+	bytes, err := json.Marshal(&event.Data)
+	if err != nil {
+		return nil, err
+	}
+	headers := map[string]interface{}{
+		"x-event-name": event.Name,
+		"x-event-id":   event.ID,
+	}
+	if len(mark) > 0 {
+		m := mark[0]
+		mbytes, err := json.Marshal(&m)
+		if err != nil {
+			return nil, err
+		}
+		headers[MarkingHeader] = mbytes
+	}
+	return &Message{
+		Body:    bytes,
+		Headers: headers,
+	}, nil
+}
+
+// FlushCommand encodes a command with the correlation ID and reply-to address
+// the device answers with.
+func (a *CommandService) FlushCommand(ctx context.Context, cmd *control.Command) (*Message, error) {
+	m, err := a.Flush(ctx, cmd.Event)
+	if err != nil {
+		return nil, err
+	}
+	m.Key = cmd.RoutingKey()
+	m.MessageID = cmd.CorrelationID
+	m.CorrelationID = cmd.CorrelationID
+	m.ReplyTo = cmd.ReplyTo
+	return m, nil
+}
+
+// FlushError encodes a failed command. The error travels in headers so the
+// body keeps the data of the command that failed.
+func (a *CommandService) FlushError(ctx context.Context, e *control.Error, event *labeled.Event, mark ...control.Marking) (*Message, error) {
+	m, err := a.Flush(ctx, event, mark...)
+	if err != nil {
+		return nil, err
+	}
+	m.Headers["x-error-kind"] = string(e.Kind)
+	m.Headers["x-error"] = e.Message
+	return m, nil
+}
+
+type EventService struct{}
+
+func (a *EventService) Load(_ context.Context, data *Message) (*control.Event, error) {
+	sk := strings.Split(data.Key, ".")
+	if len(sk) != 3 {
+		return nil, ErrInvalidRoutingKey
+	}
+	from := sk[0]
+	topic := sk[1]
+	event := sk[2]
+	id, _ := data.Headers["x-event-id"].(string)
+	res := &control.Event{
+		From:  from,
+		Topic: topic,
+		Event: &labeled.Event{
+			Name: event,
+			ID:   id,
+		},
+		CorrelationID: data.CorrelationID,
+	}
+	if mark, ok := data.Headers[MarkingHeader].([]byte); ok {
+		if err := json.Unmarshal(mark, &res.Marking); err != nil {
+			return nil, err
+		}
+	}
+	if kind, ok := data.Headers["x-error-kind"].(string); ok {
+		msg, _ := data.Headers["x-error"].(string)
+		res.Error = &control.Error{
+			Kind:    control.ErrorKind(kind),
+			Command: event,
+			Message: msg,
+		}
+	}
+	if len(data.Body) == 0 {
+		return res, nil
+	}
+	return res, json.Unmarshal(data.Body, &res.Data)
+}
@@ -0,0 +1,64 @@
+package transport_test
+
+import (
+	"context"
+	"errors"
+	"github.com/jt05610/petri/control"
+	"github.com/jt05610/petri/labeled"
+	"github.com/jt05610/petri/transport"
+	"testing"
+)
+
+func TestCommandService_FlushError(t *testing.T) {
+	ctx := context.Background()
+	cmd := &transport.CommandService{}
+	event := &labeled.Event{ID: "ev", Name: "dispense", Data: map[string]interface{}{"volume": 5.0}}
+	cErr := &control.Error{Kind: control.HandlerFailed, Command: "dispense", Message: "stalled"}
+	m, err := cmd.FlushError(ctx, cErr, event, control.Marking{"idle": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ev := &control.Event{Event: event, From: "pump", Error: cErr}
+	m.Key = ev.RoutingKey()
+	got, err := (&transport.EventService{}).Load(ctx, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Topic != "errors" || got.Error == nil || got.Error.Kind != control.HandlerFailed || got.Error.Message != "stalled" {
+		t.Fatalf("unexpected error event %+v", got)
+	}
+	if got.ID != "ev" || got.Data["volume"] != 5.0 || got.Marking["idle"] != 1 {
+		t.Fatalf("expected command data and marking to round trip, got %+v", got)
+	}
+}
+
+func TestCommandService_Load_InvalidRoutingKey(t *testing.T) {
+	_, err := (&transport.CommandService{}).Load(context.Background(), &transport.Message{Key: "pump.commands"})
+	if !errors.Is(err, transport.ErrInvalidRoutingKey) {
+		t.Fatalf("expected ErrInvalidRoutingKey, got %v", err)
+	}
+}
+
+func TestCommandService_FlushCommand(t *testing.T) {
+	ctx := context.Background()
+	cmd := &control.Command{
+		Event:         &labeled.Event{ID: "ev", Name: "dispense"},
+		To:            "pump",
+		CorrelationID: "corr",
+		ReplyTo:       "controller",
+	}
+	m, err := (&transport.CommandService{}).FlushCommand(ctx, cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Key != "pump.commands.dispense" || m.MessageID != "corr" {
+		t.Fatalf("unexpected message %+v", m)
+	}
+	got, err := (&transport.CommandService{}).Load(ctx, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.CorrelationID != "corr" || got.ReplyTo != "controller" || got.To != "pump" {
+		t.Fatalf("unexpected command %+v", got)
+	}
+}
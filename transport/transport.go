@@ -0,0 +1,105 @@
+// Package transport carries commands and events between the controller and
+// device servers. Messages are published to a topic exchange under a dotted
+// routing key such as pump.commands.dispense and delivered to every queue bound
+// with a matching pattern. A pattern word of * matches exactly one word and #
+// matches zero or more.
+package transport
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+var ErrClosed = errors.New("transport closed")
+
+// Acknowledger settles a delivered message.
+type Acknowledger interface {
+	Ack() error
+	// Nack rejects the message. It is put back on its queue when requeue is
+	// set and dead-lettered otherwise.
+	Nack(requeue bool) error
+}
+
+type Message struct {
+	// Key is the topic routing key.
+	Key     string
+	Headers map[string]interface{}
+	Body    []byte
+	// MessageID identifies the message across redeliveries.
+	MessageID     string
+	CorrelationID string
+	// ReplyTo is the address of the queue an answer should be sent to.
+	ReplyTo string
+	// Acknowledger is set on delivered messages.
+	Acknowledger
+}
+
+func (m *Message) Ack() error {
+	if m.Acknowledger == nil {
+		return nil
+	}
+	return m.Acknowledger.Ack()
+}
+
+func (m *Message) Nack(requeue bool) error {
+	if m.Acknowledger == nil {
+		return nil
+	}
+	return m.Acknowledger.Nack(requeue)
+}
+
+// Queue describes what a subscription receives.
+type Queue struct {
+	// Name identifies a queue that outlives its subscription so messages sent
+	// while nobody is listening wait for the next one. When empty the queue is
+	// private to the subscription and removed with it.
+	Name string
+	// Keys are the patterns the queue is bound with.
+	Keys []string
+	// DeadLetter keeps messages nacked without requeue for inspection instead
+	// of dropping them.
+	DeadLetter bool
+}
+
+type Subscription interface {
+	// Address is where messages meant only for this subscription are sent.
+	// It can change if the transport reconnects, so read it when it is used.
+	Address() string
+	// Messages delivers until the subscription's context is cancelled and is
+	// then closed. Every message must be acked or nacked.
+	Messages() <-chan *Message
+}
+
+type Transport interface {
+	// Publish routes msg to every queue bound to a pattern matching its key.
+	Publish(ctx context.Context, msg *Message) error
+	// Send delivers msg straight to the queue at address, keeping its key.
+	Send(ctx context.Context, address string, msg *Message) error
+	// Subscribe starts receiving from q until ctx is cancelled.
+	Subscribe(ctx context.Context, q *Queue) (Subscription, error)
+	Close() error
+}
+
+// Match reports whether key matches the binding pattern.
+func Match(pattern, key string) bool {
+	return match(strings.Split(pattern, "."), strings.Split(key, "."))
+}
+
+func match(pattern, key []string) bool {
+	if len(pattern) == 0 {
+		return len(key) == 0
+	}
+	switch pattern[0] {
+	case "#":
+		for i := 0; i <= len(key); i++ {
+			if match(pattern[1:], key[i:]) {
+				return true
+			}
+		}
+		return false
+	case "*":
+		return len(key) > 0 && match(pattern[1:], key[1:])
+	}
+	return len(key) > 0 && pattern[0] == key[0] && match(pattern[1:], key[1:])
+}
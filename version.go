@@ -0,0 +1,162 @@
+package petri
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+)
+
+var ErrImmutable = errors.New("object is immutable")
+
+var _ Object = (*NetVersion)(nil)
+var _ Input = (*NetVersionInput)(nil)
+var _ Update = (*NetVersionUpdate)(nil)
+var _ Filter = (*NetVersionFilter)(nil)
+
+// NetVersion is an immutable snapshot of a net. Each version points at the
+// version it was made from so the history of a net forms a chain.
+type NetVersion struct {
+	ID           string         `json:"_id"`
+	NetID        string         `json:"netID"`
+	Parent       string         `json:"parent,omitempty"`
+	Version      int            `json:"version"`
+	Message      string         `json:"message,omitempty"`
+	CreatedAt    time.Time      `json:"createdAt"`
+	Name         string         `json:"name"`
+	TokenSchemas []*TokenSchema `json:"tokenSchemas,omitempty"`
+	Places       []*Place       `json:"places,omitempty"`
+	Transitions  []*Transition  `json:"transitions,omitempty"`
+	Arcs         []*Arc         `json:"arcs,omitempty"`
+}
+
+func (v *NetVersion) PostInit() error {
+	for _, a := range v.Arcs {
+		if a.SrcMeta == nil || a.DestMeta == nil {
+			return ErrInvalidNet
+		}
+		if err := a.PostInit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *NetVersion) Kind() Kind { return NetVersionObject }
+
+func (v *NetVersion) Identifier() string { return v.ID }
+
+func (v *NetVersion) String() string { return v.Name }
+
+func (v *NetVersion) Update(Update) error {
+	return ErrImmutable
+}
+
+func (v *NetVersion) Document() Document {
+	arcs := make([]Document, len(v.Arcs))
+	for i, a := range v.Arcs {
+		arcs[i] = a.Document()
+	}
+	return Document{
+		"_id":          v.ID,
+		"netID":        v.NetID,
+		"parent":       v.Parent,
+		"version":      v.Version,
+		"message":      v.Message,
+		"createdAt":    v.CreatedAt,
+		"name":         v.Name,
+		"tokenSchemas": v.TokenSchemas,
+		"places":       v.Places,
+		"transitions":  v.Transitions,
+		"arcs":         arcs,
+	}
+}
+
+// Net rebuilds the net the version was taken of, with each arc joined to the
+// places and transitions of the snapshot.
+func (v *NetVersion) Net() *Net {
+	nodes := make(map[string]Node)
+	for _, p := range v.Places {
+		nodes[p.ID] = p
+	}
+	for _, t := range v.Transitions {
+		nodes[t.ID] = t
+	}
+	arcs := make([]*Arc, len(v.Arcs))
+	for i, a := range v.Arcs {
+		arc := *a
+		if n, ok := nodes[a.Src.Identifier()]; ok {
+			arc.Src = n
+		}
+		if n, ok := nodes[a.Dest.Identifier()]; ok {
+			arc.Dest = n
+		}
+		arcs[i] = &arc
+	}
+	n := LoadNet(v.Places, v.Transitions, arcs)
+	n.ID = v.NetID
+	n.Name = v.Name
+	n.TokenSchemas = v.TokenSchemas
+	return n
+}
+
+type NetVersionInput struct {
+	Net     *Net
+	Parent  *NetVersion
+	Message string
+}
+
+func (v *NetVersionInput) Object() Object {
+	ret := &NetVersion{
+		ID:           ID(),
+		NetID:        v.Net.ID,
+		Version:      1,
+		Message:      v.Message,
+		CreatedAt:    time.Now(),
+		Name:         v.Net.Name,
+		TokenSchemas: v.Net.TokenSchemas,
+		Places:       v.Net.Places,
+		Transitions:  v.Net.Transitions,
+		Arcs:         v.Net.Arcs,
+	}
+	if v.Parent != nil {
+		ret.Parent = v.Parent.ID
+		ret.Version = v.Parent.Version + 1
+	}
+	return ret
+}
+
+func (v *NetVersionInput) Kind() Kind { return NetVersionObject }
+
+// NetVersionUpdate only exists so versions can be kept in a Service; versions
+// reject every update.
+type NetVersionUpdate struct{}
+
+type NetVersionFilter struct {
+	ID    *StringSelector `json:"_id,omitempty"`
+	NetID *StringSelector `json:"netID,omitempty"`
+}
+
+// SortVersions orders versions from oldest to newest.
+func SortVersions(versions []*NetVersion) {
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Version < versions[j].Version
+	})
+}
+
+// NetVersioner keeps the history of a net.
+type NetVersioner interface {
+	// Commit records the current state of the net as a new version.
+	Commit(ctx context.Context, netID string, message string) (*NetVersion, error)
+	// History lists the versions of the net from oldest to newest.
+	History(ctx context.Context, netID string) ([]*NetVersion, error)
+	// Version returns a single version.
+	Version(ctx context.Context, id string) (*NetVersion, error)
+	// Revert makes the net match the given version again and records that as
+	// a new version.
+	Revert(ctx context.Context, netID string, versionID string) (*Net, error)
+}
+
+func (v *NetVersionInput) IsInput()   {}
+func (v *NetVersionUpdate) IsUpdate() {}
+func (v *NetVersionFilter) IsFilter() {}